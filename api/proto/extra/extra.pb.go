@@ -23,16 +23,19 @@ const (
 type WebSocketsMessageType int32
 
 const (
-	WebSocketsMessageType_DownloadIso WebSocketsMessageType = 0
+	WebSocketsMessageType_DownloadIso       WebSocketsMessageType = 0
+	WebSocketsMessageType_MigrationProgress WebSocketsMessageType = 1 //json, see slave/virsh MigrationProgress
 )
 
 // Enum value maps for WebSocketsMessageType.
 var (
 	WebSocketsMessageType_name = map[int32]string{
 		0: "DownloadIso",
+		1: "MigrationProgress",
 	}
 	WebSocketsMessageType_value = map[string]int32{
-		"DownloadIso": 0,
+		"DownloadIso":       0,
+		"MigrationProgress": 1,
 	}
 )
 
@@ -313,6 +316,85 @@ func (x *UpdateRequest) GetRestartAfter() bool {
 	return false
 }
 
+type SlaveEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MachineName string `protobuf:"bytes,1,opt,name=machineName,proto3" json:"machineName,omitempty"`
+	Kind        string `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`       //nfs_remount_failed
+	Subject     string `protobuf:"bytes,3,opt,name=subject,proto3" json:"subject,omitempty"` //what it is about, the mount target for nfs
+	Message     string `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	Resolved    bool   `protobuf:"varint,5,opt,name=resolved,proto3" json:"resolved,omitempty"` //the problem went away
+}
+
+func (x *SlaveEvent) Reset() {
+	*x = SlaveEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_extra_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SlaveEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SlaveEvent) ProtoMessage() {}
+
+func (x *SlaveEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_extra_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SlaveEvent.ProtoReflect.Descriptor instead.
+func (*SlaveEvent) Descriptor() ([]byte, []int) {
+	return file_extra_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *SlaveEvent) GetMachineName() string {
+	if x != nil {
+		return x.MachineName
+	}
+	return ""
+}
+
+func (x *SlaveEvent) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *SlaveEvent) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+func (x *SlaveEvent) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *SlaveEvent) GetResolved() bool {
+	if x != nil {
+		return x.Resolved
+	}
+	return false
+}
+
 var File_extra_proto protoreflect.FileDescriptor
 
 var file_extra_proto_rawDesc = []byte{
@@ -335,25 +417,39 @@ var file_extra_proto_rawDesc = []byte{
 	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
 	0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x22, 0x0a, 0x0c, 0x72, 0x65, 0x73,
 	0x74, 0x61, 0x72, 0x74, 0x41, 0x66, 0x74, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52,
-	0x0c, 0x72, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x41, 0x66, 0x74, 0x65, 0x72, 0x2a, 0x28, 0x0a,
-	0x15, 0x57, 0x65, 0x62, 0x53, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x4d, 0x65, 0x73, 0x73, 0x61,
-	0x67, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0f, 0x0a, 0x0b, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f,
-	0x61, 0x64, 0x49, 0x73, 0x6f, 0x10, 0x00, 0x32, 0xb6, 0x01, 0x0a, 0x0c, 0x45, 0x78, 0x74, 0x72,
-	0x61, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x3d, 0x0a, 0x14, 0x53, 0x65, 0x6e, 0x64,
-	0x57, 0x65, 0x62, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
-	0x12, 0x17, 0x2e, 0x65, 0x78, 0x74, 0x72, 0x61, 0x2e, 0x57, 0x65, 0x62, 0x73, 0x6f, 0x63, 0x6b,
-	0x65, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x1a, 0x0c, 0x2e, 0x65, 0x78, 0x74, 0x72,
-	0x61, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x32, 0x0a, 0x0f, 0x43, 0x68, 0x65, 0x63, 0x6b,
-	0x46, 0x6f, 0x72, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x73, 0x12, 0x0c, 0x2e, 0x65, 0x78, 0x74,
-	0x72, 0x61, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x11, 0x2e, 0x65, 0x78, 0x74, 0x72, 0x61,
-	0x2e, 0x41, 0x6c, 0x6c, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x73, 0x12, 0x33, 0x0a, 0x0d, 0x50,
-	0x65, 0x72, 0x66, 0x6f, 0x72, 0x6d, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x14, 0x2e, 0x65,
-	0x78, 0x74, 0x72, 0x61, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x0c, 0x2e, 0x65, 0x78, 0x74, 0x72, 0x61, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79,
-	0x42, 0x33, 0x5a, 0x31, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x4d,
-	0x61, 0x72, 0x75, 0x71, 0x65, 0x73, 0x2f, 0x35, 0x31, 0x32, 0x53, 0x76, 0x4d, 0x61, 0x6e, 0x2f,
-	0x61, 0x70, 0x69, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x65, 0x78, 0x74, 0x72, 0x61, 0x3b,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x0c, 0x72, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x41, 0x66, 0x74, 0x65, 0x72, 0x22, 0x92, 0x01,
+	0x0a, 0x0a, 0x53, 0x6c, 0x61, 0x76, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x20, 0x0a, 0x0b,
+	0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0b, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x12,
+	0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6b, 0x69,
+	0x6e, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x73, 0x75, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x12, 0x18, 0x0a, 0x07,
+	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x73, 0x6f, 0x6c, 0x76,
+	0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x72, 0x65, 0x73, 0x6f, 0x6c, 0x76,
+	0x65, 0x64, 0x2a, 0x3f, 0x0a, 0x15, 0x57, 0x65, 0x62, 0x53, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x73,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0f, 0x0a, 0x0b, 0x44,
+	0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x49, 0x73, 0x6f, 0x10, 0x00, 0x12, 0x15, 0x0a, 0x11,
+	0x4d, 0x69, 0x67, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73,
+	0x73, 0x10, 0x01, 0x32, 0xe6, 0x01, 0x0a, 0x0c, 0x45, 0x78, 0x74, 0x72, 0x61, 0x53, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x12, 0x3d, 0x0a, 0x14, 0x53, 0x65, 0x6e, 0x64, 0x57, 0x65, 0x62, 0x73,
+	0x6f, 0x63, 0x6b, 0x65, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x17, 0x2e, 0x65,
+	0x78, 0x74, 0x72, 0x61, 0x2e, 0x57, 0x65, 0x62, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x4d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x1a, 0x0c, 0x2e, 0x65, 0x78, 0x74, 0x72, 0x61, 0x2e, 0x45, 0x6d,
+	0x70, 0x74, 0x79, 0x12, 0x2e, 0x0a, 0x0b, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x45, 0x76, 0x65,
+	0x6e, 0x74, 0x12, 0x11, 0x2e, 0x65, 0x78, 0x74, 0x72, 0x61, 0x2e, 0x53, 0x6c, 0x61, 0x76, 0x65,
+	0x45, 0x76, 0x65, 0x6e, 0x74, 0x1a, 0x0c, 0x2e, 0x65, 0x78, 0x74, 0x72, 0x61, 0x2e, 0x45, 0x6d,
+	0x70, 0x74, 0x79, 0x12, 0x32, 0x0a, 0x0f, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x46, 0x6f, 0x72, 0x55,
+	0x70, 0x64, 0x61, 0x74, 0x65, 0x73, 0x12, 0x0c, 0x2e, 0x65, 0x78, 0x74, 0x72, 0x61, 0x2e, 0x45,
+	0x6d, 0x70, 0x74, 0x79, 0x1a, 0x11, 0x2e, 0x65, 0x78, 0x74, 0x72, 0x61, 0x2e, 0x41, 0x6c, 0x6c,
+	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x73, 0x12, 0x33, 0x0a, 0x0d, 0x50, 0x65, 0x72, 0x66, 0x6f,
+	0x72, 0x6d, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x14, 0x2e, 0x65, 0x78, 0x74, 0x72, 0x61,
+	0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0c,
+	0x2e, 0x65, 0x78, 0x74, 0x72, 0x61, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x42, 0x33, 0x5a, 0x31,
+	0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x4d, 0x61, 0x72, 0x75, 0x71,
+	0x65, 0x73, 0x2f, 0x35, 0x31, 0x32, 0x53, 0x76, 0x4d, 0x61, 0x6e, 0x2f, 0x61, 0x70, 0x69, 0x2f,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x65, 0x78, 0x74, 0x72, 0x61, 0x3b, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -369,7 +465,7 @@ func file_extra_proto_rawDescGZIP() []byte {
 }
 
 var file_extra_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_extra_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_extra_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
 var file_extra_proto_goTypes = []interface{}{
 	(WebSocketsMessageType)(0), // 0: extra.WebSocketsMessageType
 	(*WebsocketMessage)(nil),   // 1: extra.WebsocketMessage
@@ -377,18 +473,21 @@ var file_extra_proto_goTypes = []interface{}{
 	(*UpdateInfo)(nil),         // 3: extra.UpdateInfo
 	(*AllUpdates)(nil),         // 4: extra.AllUpdates
 	(*UpdateRequest)(nil),      // 5: extra.UpdateRequest
+	(*SlaveEvent)(nil),         // 6: extra.SlaveEvent
 }
 var file_extra_proto_depIdxs = []int32{
 	0, // 0: extra.WebsocketMessage.type:type_name -> extra.WebSocketsMessageType
 	3, // 1: extra.AllUpdates.updates:type_name -> extra.UpdateInfo
 	1, // 2: extra.ExtraService.SendWebsocketMessage:input_type -> extra.WebsocketMessage
-	2, // 3: extra.ExtraService.CheckForUpdates:input_type -> extra.Empty
-	5, // 4: extra.ExtraService.PerformUpdate:input_type -> extra.UpdateRequest
-	2, // 5: extra.ExtraService.SendWebsocketMessage:output_type -> extra.Empty
-	4, // 6: extra.ExtraService.CheckForUpdates:output_type -> extra.AllUpdates
-	2, // 7: extra.ExtraService.PerformUpdate:output_type -> extra.Empty
-	5, // [5:8] is the sub-list for method output_type
-	2, // [2:5] is the sub-list for method input_type
+	6, // 3: extra.ExtraService.ReportEvent:input_type -> extra.SlaveEvent
+	2, // 4: extra.ExtraService.CheckForUpdates:input_type -> extra.Empty
+	5, // 5: extra.ExtraService.PerformUpdate:input_type -> extra.UpdateRequest
+	2, // 6: extra.ExtraService.SendWebsocketMessage:output_type -> extra.Empty
+	2, // 7: extra.ExtraService.ReportEvent:output_type -> extra.Empty
+	4, // 8: extra.ExtraService.CheckForUpdates:output_type -> extra.AllUpdates
+	2, // 9: extra.ExtraService.PerformUpdate:output_type -> extra.Empty
+	6, // [6:10] is the sub-list for method output_type
+	2, // [2:6] is the sub-list for method input_type
 	2, // [2:2] is the sub-list for extension type_name
 	2, // [2:2] is the sub-list for extension extendee
 	0, // [0:2] is the sub-list for field type_name
@@ -460,6 +559,18 @@ func file_extra_proto_init() {
 				return nil
 			}
 		}
+		file_extra_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SlaveEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -467,7 +578,7 @@ func file_extra_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_extra_proto_rawDesc,
 			NumEnums:      1,
-			NumMessages:   5,
+			NumMessages:   6,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -20,6 +20,7 @@ const _ = grpc.SupportPackageIsVersion8
 
 const (
 	ExtraService_SendWebsocketMessage_FullMethodName = "/extra.ExtraService/SendWebsocketMessage"
+	ExtraService_ReportEvent_FullMethodName          = "/extra.ExtraService/ReportEvent"
 	ExtraService_CheckForUpdates_FullMethodName      = "/extra.ExtraService/CheckForUpdates"
 	ExtraService_PerformUpdate_FullMethodName        = "/extra.ExtraService/PerformUpdate"
 )
@@ -30,6 +31,8 @@ const (
 type ExtraServiceClient interface {
 	// master
 	SendWebsocketMessage(ctx context.Context, in *WebsocketMessage, opts ...grpc.CallOption) (*Empty, error)
+	// master, problems the slave finds on its own, they feed the alert rules
+	ReportEvent(ctx context.Context, in *SlaveEvent, opts ...grpc.CallOption) (*Empty, error)
 	// master
 	CheckForUpdates(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*AllUpdates, error)
 	PerformUpdate(ctx context.Context, in *UpdateRequest, opts ...grpc.CallOption) (*Empty, error)
@@ -53,6 +56,16 @@ func (c *extraServiceClient) SendWebsocketMessage(ctx context.Context, in *Webso
 	return out, nil
 }
 
+func (c *extraServiceClient) ReportEvent(ctx context.Context, in *SlaveEvent, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, ExtraService_ReportEvent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *extraServiceClient) CheckForUpdates(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*AllUpdates, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(AllUpdates)
@@ -79,6 +92,8 @@ func (c *extraServiceClient) PerformUpdate(ctx context.Context, in *UpdateReques
 type ExtraServiceServer interface {
 	// master
 	SendWebsocketMessage(context.Context, *WebsocketMessage) (*Empty, error)
+	// master, problems the slave finds on its own, they feed the alert rules
+	ReportEvent(context.Context, *SlaveEvent) (*Empty, error)
 	// master
 	CheckForUpdates(context.Context, *Empty) (*AllUpdates, error)
 	PerformUpdate(context.Context, *UpdateRequest) (*Empty, error)
@@ -92,6 +107,9 @@ type UnimplementedExtraServiceServer struct {
 func (UnimplementedExtraServiceServer) SendWebsocketMessage(context.Context, *WebsocketMessage) (*Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SendWebsocketMessage not implemented")
 }
+func (UnimplementedExtraServiceServer) ReportEvent(context.Context, *SlaveEvent) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReportEvent not implemented")
+}
 func (UnimplementedExtraServiceServer) CheckForUpdates(context.Context, *Empty) (*AllUpdates, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CheckForUpdates not implemented")
 }
@@ -129,6 +147,24 @@ func _ExtraService_SendWebsocketMessage_Handler(srv interface{}, ctx context.Con
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ExtraService_ReportEvent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SlaveEvent)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExtraServiceServer).ReportEvent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExtraService_ReportEvent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExtraServiceServer).ReportEvent(ctx, req.(*SlaveEvent))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ExtraService_CheckForUpdates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(Empty)
 	if err := dec(in); err != nil {
@@ -176,6 +212,10 @@ var ExtraService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SendWebsocketMessage",
 			Handler:    _ExtraService_SendWebsocketMessage_Handler,
 		},
+		{
+			MethodName: "ReportEvent",
+			Handler:    _ExtraService_ReportEvent_Handler,
+		},
 		{
 			MethodName: "CheckForUpdates",
 			Handler:    _ExtraService_CheckForUpdates_Handler,
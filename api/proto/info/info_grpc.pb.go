@@ -0,0 +1,518 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v3.19.6
+// source: info.proto
+
+package proto
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.62.0 or later.
+const _ = grpc.SupportPackageIsVersion8
+
+const (
+	SlaveInfoService_GetCPUInfo_FullMethodName       = "/info.SlaveInfoService/GetCPUInfo"
+	SlaveInfoService_GetMemInfo_FullMethodName       = "/info.SlaveInfoService/GetMemInfo"
+	SlaveInfoService_GetDiskInfo_FullMethodName      = "/info.SlaveInfoService/GetDiskInfo"
+	SlaveInfoService_GetNetworkInfo_FullMethodName   = "/info.SlaveInfoService/GetNetworkInfo"
+	SlaveInfoService_GetProcesses_FullMethodName     = "/info.SlaveInfoService/GetProcesses"
+	SlaveInfoService_GetMetrics_FullMethodName       = "/info.SlaveInfoService/GetMetrics"
+	SlaveInfoService_StreamMetrics_FullMethodName    = "/info.SlaveInfoService/StreamMetrics"
+	SlaveInfoService_GetServices_FullMethodName      = "/info.SlaveInfoService/GetServices"
+	SlaveInfoService_GetServiceStatus_FullMethodName = "/info.SlaveInfoService/GetServiceStatus"
+	SlaveInfoService_GetServiceLogs_FullMethodName   = "/info.SlaveInfoService/GetServiceLogs"
+	SlaveInfoService_ServiceAction_FullMethodName    = "/info.SlaveInfoService/ServiceAction"
+)
+
+// SlaveInfoServiceClient is the client API for SlaveInfoService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type SlaveInfoServiceClient interface {
+	GetCPUInfo(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*CpuInfo, error)
+	GetMemInfo(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*MemInfo, error)
+	GetDiskInfo(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*DisksInfo, error)
+	GetNetworkInfo(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*NetworkInfo, error)
+	GetProcesses(ctx context.Context, in *ProcessesRequest, opts ...grpc.CallOption) (*ProcessList, error)
+	GetMetrics(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*HostMetrics, error)
+	StreamMetrics(ctx context.Context, in *StreamMetricsRequest, opts ...grpc.CallOption) (SlaveInfoService_StreamMetricsClient, error)
+	GetServices(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ServiceList, error)
+	GetServiceStatus(ctx context.Context, in *ServiceRequest, opts ...grpc.CallOption) (*Service, error)
+	GetServiceLogs(ctx context.Context, in *ServiceLogsRequest, opts ...grpc.CallOption) (*ServiceLogs, error)
+	ServiceAction(ctx context.Context, in *ServiceActionRequest, opts ...grpc.CallOption) (*Empty, error)
+}
+
+type slaveInfoServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSlaveInfoServiceClient(cc grpc.ClientConnInterface) SlaveInfoServiceClient {
+	return &slaveInfoServiceClient{cc}
+}
+
+func (c *slaveInfoServiceClient) GetCPUInfo(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*CpuInfo, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CpuInfo)
+	err := c.cc.Invoke(ctx, SlaveInfoService_GetCPUInfo_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *slaveInfoServiceClient) GetMemInfo(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*MemInfo, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MemInfo)
+	err := c.cc.Invoke(ctx, SlaveInfoService_GetMemInfo_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *slaveInfoServiceClient) GetDiskInfo(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*DisksInfo, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DisksInfo)
+	err := c.cc.Invoke(ctx, SlaveInfoService_GetDiskInfo_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *slaveInfoServiceClient) GetNetworkInfo(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*NetworkInfo, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(NetworkInfo)
+	err := c.cc.Invoke(ctx, SlaveInfoService_GetNetworkInfo_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *slaveInfoServiceClient) GetProcesses(ctx context.Context, in *ProcessesRequest, opts ...grpc.CallOption) (*ProcessList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ProcessList)
+	err := c.cc.Invoke(ctx, SlaveInfoService_GetProcesses_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *slaveInfoServiceClient) GetMetrics(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*HostMetrics, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(HostMetrics)
+	err := c.cc.Invoke(ctx, SlaveInfoService_GetMetrics_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *slaveInfoServiceClient) StreamMetrics(ctx context.Context, in *StreamMetricsRequest, opts ...grpc.CallOption) (SlaveInfoService_StreamMetricsClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &SlaveInfoService_ServiceDesc.Streams[0], SlaveInfoService_StreamMetrics_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &slaveInfoServiceStreamMetricsClient{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type SlaveInfoService_StreamMetricsClient interface {
+	Recv() (*HostMetrics, error)
+	grpc.ClientStream
+}
+
+type slaveInfoServiceStreamMetricsClient struct {
+	grpc.ClientStream
+}
+
+func (x *slaveInfoServiceStreamMetricsClient) Recv() (*HostMetrics, error) {
+	m := new(HostMetrics)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *slaveInfoServiceClient) GetServices(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ServiceList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ServiceList)
+	err := c.cc.Invoke(ctx, SlaveInfoService_GetServices_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *slaveInfoServiceClient) GetServiceStatus(ctx context.Context, in *ServiceRequest, opts ...grpc.CallOption) (*Service, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Service)
+	err := c.cc.Invoke(ctx, SlaveInfoService_GetServiceStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *slaveInfoServiceClient) GetServiceLogs(ctx context.Context, in *ServiceLogsRequest, opts ...grpc.CallOption) (*ServiceLogs, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ServiceLogs)
+	err := c.cc.Invoke(ctx, SlaveInfoService_GetServiceLogs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *slaveInfoServiceClient) ServiceAction(ctx context.Context, in *ServiceActionRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, SlaveInfoService_ServiceAction_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SlaveInfoServiceServer is the server API for SlaveInfoService service.
+// All implementations must embed UnimplementedSlaveInfoServiceServer
+// for forward compatibility
+type SlaveInfoServiceServer interface {
+	GetCPUInfo(context.Context, *Empty) (*CpuInfo, error)
+	GetMemInfo(context.Context, *Empty) (*MemInfo, error)
+	GetDiskInfo(context.Context, *Empty) (*DisksInfo, error)
+	GetNetworkInfo(context.Context, *Empty) (*NetworkInfo, error)
+	GetProcesses(context.Context, *ProcessesRequest) (*ProcessList, error)
+	GetMetrics(context.Context, *Empty) (*HostMetrics, error)
+	StreamMetrics(*StreamMetricsRequest, SlaveInfoService_StreamMetricsServer) error
+	GetServices(context.Context, *Empty) (*ServiceList, error)
+	GetServiceStatus(context.Context, *ServiceRequest) (*Service, error)
+	GetServiceLogs(context.Context, *ServiceLogsRequest) (*ServiceLogs, error)
+	ServiceAction(context.Context, *ServiceActionRequest) (*Empty, error)
+	mustEmbedUnimplementedSlaveInfoServiceServer()
+}
+
+// UnimplementedSlaveInfoServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedSlaveInfoServiceServer struct {
+}
+
+func (UnimplementedSlaveInfoServiceServer) GetCPUInfo(context.Context, *Empty) (*CpuInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCPUInfo not implemented")
+}
+func (UnimplementedSlaveInfoServiceServer) GetMemInfo(context.Context, *Empty) (*MemInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMemInfo not implemented")
+}
+func (UnimplementedSlaveInfoServiceServer) GetDiskInfo(context.Context, *Empty) (*DisksInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDiskInfo not implemented")
+}
+func (UnimplementedSlaveInfoServiceServer) GetNetworkInfo(context.Context, *Empty) (*NetworkInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNetworkInfo not implemented")
+}
+func (UnimplementedSlaveInfoServiceServer) GetProcesses(context.Context, *ProcessesRequest) (*ProcessList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProcesses not implemented")
+}
+func (UnimplementedSlaveInfoServiceServer) GetMetrics(context.Context, *Empty) (*HostMetrics, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMetrics not implemented")
+}
+func (UnimplementedSlaveInfoServiceServer) StreamMetrics(*StreamMetricsRequest, SlaveInfoService_StreamMetricsServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamMetrics not implemented")
+}
+func (UnimplementedSlaveInfoServiceServer) GetServices(context.Context, *Empty) (*ServiceList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetServices not implemented")
+}
+func (UnimplementedSlaveInfoServiceServer) GetServiceStatus(context.Context, *ServiceRequest) (*Service, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetServiceStatus not implemented")
+}
+func (UnimplementedSlaveInfoServiceServer) GetServiceLogs(context.Context, *ServiceLogsRequest) (*ServiceLogs, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetServiceLogs not implemented")
+}
+func (UnimplementedSlaveInfoServiceServer) ServiceAction(context.Context, *ServiceActionRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ServiceAction not implemented")
+}
+func (UnimplementedSlaveInfoServiceServer) mustEmbedUnimplementedSlaveInfoServiceServer() {}
+
+// UnsafeSlaveInfoServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SlaveInfoServiceServer will
+// result in compilation errors.
+type UnsafeSlaveInfoServiceServer interface {
+	mustEmbedUnimplementedSlaveInfoServiceServer()
+}
+
+func RegisterSlaveInfoServiceServer(s grpc.ServiceRegistrar, srv SlaveInfoServiceServer) {
+	s.RegisterService(&SlaveInfoService_ServiceDesc, srv)
+}
+
+func _SlaveInfoService_GetCPUInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SlaveInfoServiceServer).GetCPUInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SlaveInfoService_GetCPUInfo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SlaveInfoServiceServer).GetCPUInfo(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SlaveInfoService_GetMemInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SlaveInfoServiceServer).GetMemInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SlaveInfoService_GetMemInfo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SlaveInfoServiceServer).GetMemInfo(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SlaveInfoService_GetDiskInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SlaveInfoServiceServer).GetDiskInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SlaveInfoService_GetDiskInfo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SlaveInfoServiceServer).GetDiskInfo(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SlaveInfoService_GetNetworkInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SlaveInfoServiceServer).GetNetworkInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SlaveInfoService_GetNetworkInfo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SlaveInfoServiceServer).GetNetworkInfo(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SlaveInfoService_GetProcesses_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProcessesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SlaveInfoServiceServer).GetProcesses(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SlaveInfoService_GetProcesses_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SlaveInfoServiceServer).GetProcesses(ctx, req.(*ProcessesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SlaveInfoService_GetMetrics_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SlaveInfoServiceServer).GetMetrics(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SlaveInfoService_GetMetrics_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SlaveInfoServiceServer).GetMetrics(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SlaveInfoService_StreamMetrics_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamMetricsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SlaveInfoServiceServer).StreamMetrics(m, &slaveInfoServiceStreamMetricsServer{ServerStream: stream})
+}
+
+type SlaveInfoService_StreamMetricsServer interface {
+	Send(*HostMetrics) error
+	grpc.ServerStream
+}
+
+type slaveInfoServiceStreamMetricsServer struct {
+	grpc.ServerStream
+}
+
+func (x *slaveInfoServiceStreamMetricsServer) Send(m *HostMetrics) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _SlaveInfoService_GetServices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SlaveInfoServiceServer).GetServices(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SlaveInfoService_GetServices_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SlaveInfoServiceServer).GetServices(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SlaveInfoService_GetServiceStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ServiceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SlaveInfoServiceServer).GetServiceStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SlaveInfoService_GetServiceStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SlaveInfoServiceServer).GetServiceStatus(ctx, req.(*ServiceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SlaveInfoService_GetServiceLogs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ServiceLogsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SlaveInfoServiceServer).GetServiceLogs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SlaveInfoService_GetServiceLogs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SlaveInfoServiceServer).GetServiceLogs(ctx, req.(*ServiceLogsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SlaveInfoService_ServiceAction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ServiceActionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SlaveInfoServiceServer).ServiceAction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SlaveInfoService_ServiceAction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SlaveInfoServiceServer).ServiceAction(ctx, req.(*ServiceActionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// SlaveInfoService_ServiceDesc is the grpc.ServiceDesc for SlaveInfoService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var SlaveInfoService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "info.SlaveInfoService",
+	HandlerType: (*SlaveInfoServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetCPUInfo",
+			Handler:    _SlaveInfoService_GetCPUInfo_Handler,
+		},
+		{
+			MethodName: "GetMemInfo",
+			Handler:    _SlaveInfoService_GetMemInfo_Handler,
+		},
+		{
+			MethodName: "GetDiskInfo",
+			Handler:    _SlaveInfoService_GetDiskInfo_Handler,
+		},
+		{
+			MethodName: "GetNetworkInfo",
+			Handler:    _SlaveInfoService_GetNetworkInfo_Handler,
+		},
+		{
+			MethodName: "GetProcesses",
+			Handler:    _SlaveInfoService_GetProcesses_Handler,
+		},
+		{
+			MethodName: "GetMetrics",
+			Handler:    _SlaveInfoService_GetMetrics_Handler,
+		},
+		{
+			MethodName: "GetServices",
+			Handler:    _SlaveInfoService_GetServices_Handler,
+		},
+		{
+			MethodName: "GetServiceStatus",
+			Handler:    _SlaveInfoService_GetServiceStatus_Handler,
+		},
+		{
+			MethodName: "GetServiceLogs",
+			Handler:    _SlaveInfoService_GetServiceLogs_Handler,
+		},
+		{
+			MethodName: "ServiceAction",
+			Handler:    _SlaveInfoService_ServiceAction_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamMetrics",
+			Handler:       _SlaveInfoService_StreamMetrics_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "info.proto",
+}
@@ -19,8 +19,10 @@ import (
 const _ = grpc.SupportPackageIsVersion8
 
 const (
-	ProtocolService_SetConnection_FullMethodName = "/protocol.ProtocolService/SetConnection"
-	ProtocolService_Notify_FullMethodName        = "/protocol.ProtocolService/Notify"
+	ProtocolService_SetConnection_FullMethodName    = "/protocol.ProtocolService/SetConnection"
+	ProtocolService_Notify_FullMethodName           = "/protocol.ProtocolService/Notify"
+	ProtocolService_Enroll_FullMethodName           = "/protocol.ProtocolService/Enroll"
+	ProtocolService_RenewCertificate_FullMethodName = "/protocol.ProtocolService/RenewCertificate"
 )
 
 // ProtocolServiceClient is the client API for ProtocolService service.
@@ -29,6 +31,10 @@ const (
 type ProtocolServiceClient interface {
 	SetConnection(ctx context.Context, in *SetConnectionRequest, opts ...grpc.CallOption) (*SetConnectionResponse, error)
 	Notify(ctx context.Context, in *NotifyRequest, opts ...grpc.CallOption) (*NotifyResponse, error)
+	// the only call allowed without a client certificate, trades a one-time token for one
+	Enroll(ctx context.Context, in *EnrollRequest, opts ...grpc.CallOption) (*EnrollResponse, error)
+	// new certificate for the slave calling, before the current one expires
+	RenewCertificate(ctx context.Context, in *RenewRequest, opts ...grpc.CallOption) (*EnrollResponse, error)
 }
 
 type protocolServiceClient struct {
@@ -59,12 +65,36 @@ func (c *protocolServiceClient) Notify(ctx context.Context, in *NotifyRequest, o
 	return out, nil
 }
 
+func (c *protocolServiceClient) Enroll(ctx context.Context, in *EnrollRequest, opts ...grpc.CallOption) (*EnrollResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EnrollResponse)
+	err := c.cc.Invoke(ctx, ProtocolService_Enroll_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *protocolServiceClient) RenewCertificate(ctx context.Context, in *RenewRequest, opts ...grpc.CallOption) (*EnrollResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EnrollResponse)
+	err := c.cc.Invoke(ctx, ProtocolService_RenewCertificate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ProtocolServiceServer is the server API for ProtocolService service.
 // All implementations must embed UnimplementedProtocolServiceServer
 // for forward compatibility
 type ProtocolServiceServer interface {
 	SetConnection(context.Context, *SetConnectionRequest) (*SetConnectionResponse, error)
 	Notify(context.Context, *NotifyRequest) (*NotifyResponse, error)
+	// the only call allowed without a client certificate, trades a one-time token for one
+	Enroll(context.Context, *EnrollRequest) (*EnrollResponse, error)
+	// new certificate for the slave calling, before the current one expires
+	RenewCertificate(context.Context, *RenewRequest) (*EnrollResponse, error)
 	mustEmbedUnimplementedProtocolServiceServer()
 }
 
@@ -78,6 +108,12 @@ func (UnimplementedProtocolServiceServer) SetConnection(context.Context, *SetCon
 func (UnimplementedProtocolServiceServer) Notify(context.Context, *NotifyRequest) (*NotifyResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Notify not implemented")
 }
+func (UnimplementedProtocolServiceServer) Enroll(context.Context, *EnrollRequest) (*EnrollResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Enroll not implemented")
+}
+func (UnimplementedProtocolServiceServer) RenewCertificate(context.Context, *RenewRequest) (*EnrollResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RenewCertificate not implemented")
+}
 func (UnimplementedProtocolServiceServer) mustEmbedUnimplementedProtocolServiceServer() {}
 
 // UnsafeProtocolServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -127,6 +163,42 @@ func _ProtocolService_Notify_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ProtocolService_Enroll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EnrollRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProtocolServiceServer).Enroll(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProtocolService_Enroll_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProtocolServiceServer).Enroll(ctx, req.(*EnrollRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProtocolService_RenewCertificate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenewRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProtocolServiceServer).RenewCertificate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProtocolService_RenewCertificate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProtocolServiceServer).RenewCertificate(ctx, req.(*RenewRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ProtocolService_ServiceDesc is the grpc.ServiceDesc for ProtocolService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -142,6 +214,14 @@ var ProtocolService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Notify",
 			Handler:    _ProtocolService_Notify_Handler,
 		},
+		{
+			MethodName: "Enroll",
+			Handler:    _ProtocolService_Enroll_Handler,
+		},
+		{
+			MethodName: "RenewCertificate",
+			Handler:    _ProtocolService_RenewCertificate_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "protocol.proto",
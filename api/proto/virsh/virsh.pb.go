@@ -178,15 +178,18 @@ type CreateVmRequest struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Name        string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Memory      int32  `protobuf:"varint,2,opt,name=memory,proto3" json:"memory,omitempty"`
-	Vcpu        int32  `protobuf:"varint,3,opt,name=vcpu,proto3" json:"vcpu,omitempty"`
-	DiskFolder  string `protobuf:"bytes,4,opt,name=disk_folder,json=diskFolder,proto3" json:"disk_folder,omitempty"`
-	DiskPath    string `protobuf:"bytes,5,opt,name=disk_path,json=diskPath,proto3" json:"disk_path,omitempty"`
-	DiskSizeGB  int32  `protobuf:"varint,6,opt,name=disk_sizeGB,json=diskSizeGB,proto3" json:"disk_sizeGB,omitempty"`
-	IsoPath     string `protobuf:"bytes,7,opt,name=iso_path,json=isoPath,proto3" json:"iso_path,omitempty"`
-	Network     string `protobuf:"bytes,8,opt,name=network,proto3" json:"network,omitempty"`
-	VncPassword string `protobuf:"bytes,9,opt,name=vnc_password,json=vncPassword,proto3" json:"vnc_password,omitempty"`
+	Name         string     `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Memory       int32      `protobuf:"varint,2,opt,name=memory,proto3" json:"memory,omitempty"`
+	Vcpu         int32      `protobuf:"varint,3,opt,name=vcpu,proto3" json:"vcpu,omitempty"`
+	DiskFolder   string     `protobuf:"bytes,4,opt,name=disk_folder,json=diskFolder,proto3" json:"disk_folder,omitempty"`
+	DiskPath     string     `protobuf:"bytes,5,opt,name=disk_path,json=diskPath,proto3" json:"disk_path,omitempty"`
+	DiskSizeGB   int32      `protobuf:"varint,6,opt,name=disk_sizeGB,json=diskSizeGB,proto3" json:"disk_sizeGB,omitempty"`
+	IsoPath      string     `protobuf:"bytes,7,opt,name=iso_path,json=isoPath,proto3" json:"iso_path,omitempty"`
+	Network      string     `protobuf:"bytes,8,opt,name=network,proto3" json:"network,omitempty"`
+	VncPassword  string     `protobuf:"bytes,9,opt,name=vnc_password,json=vncPassword,proto3" json:"vnc_password,omitempty"`
+	TemplatePath string     `protobuf:"bytes,10,opt,name=template_path,json=templatePath,proto3" json:"template_path,omitempty"` //golden qcow2, used as backing file instead of booting iso_path
+	CloudInit    *CloudInit `protobuf:"bytes,11,opt,name=cloud_init,json=cloudInit,proto3" json:"cloud_init,omitempty"`
+	Nics         []*VmNic   `protobuf:"bytes,12,rep,name=nics,proto3" json:"nics,omitempty"` //every nic of the vm, when empty a single virtio nic on network
 }
 
 func (x *CreateVmRequest) Reset() {
@@ -284,6 +287,91 @@ func (x *CreateVmRequest) GetVncPassword() string {
 	return ""
 }
 
+func (x *CreateVmRequest) GetTemplatePath() string {
+	if x != nil {
+		return x.TemplatePath
+	}
+	return ""
+}
+
+func (x *CreateVmRequest) GetCloudInit() *CloudInit {
+	if x != nil {
+		return x.CloudInit
+	}
+	return nil
+}
+
+func (x *CreateVmRequest) GetNics() []*VmNic {
+	if x != nil {
+		return x.Nics
+	}
+	return nil
+}
+
+// NoCloud seed attached as a cdrom to vms created from a template
+type CloudInit struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Hostname string   `protobuf:"bytes,1,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	UserData string   `protobuf:"bytes,2,opt,name=user_data,json=userData,proto3" json:"user_data,omitempty"`
+	SshKeys  []string `protobuf:"bytes,3,rep,name=ssh_keys,json=sshKeys,proto3" json:"ssh_keys,omitempty"`
+}
+
+func (x *CloudInit) Reset() {
+	*x = CloudInit{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_virsh_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CloudInit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CloudInit) ProtoMessage() {}
+
+func (x *CloudInit) ProtoReflect() protoreflect.Message {
+	mi := &file_virsh_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CloudInit.ProtoReflect.Descriptor instead.
+func (*CloudInit) Descriptor() ([]byte, []int) {
+	return file_virsh_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CloudInit) GetHostname() string {
+	if x != nil {
+		return x.Hostname
+	}
+	return ""
+}
+
+func (x *CloudInit) GetUserData() string {
+	if x != nil {
+		return x.UserData
+	}
+	return ""
+}
+
+func (x *CloudInit) GetSshKeys() []string {
+	if x != nil {
+		return x.SshKeys
+	}
+	return nil
+}
+
 type OkResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -296,7 +384,7 @@ type OkResponse struct {
 func (x *OkResponse) Reset() {
 	*x = OkResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_virsh_proto_msgTypes[3]
+		mi := &file_virsh_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -309,7 +397,7 @@ func (x *OkResponse) String() string {
 func (*OkResponse) ProtoMessage() {}
 
 func (x *OkResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_virsh_proto_msgTypes[3]
+	mi := &file_virsh_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -322,7 +410,7 @@ func (x *OkResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use OkResponse.ProtoReflect.Descriptor instead.
 func (*OkResponse) Descriptor() ([]byte, []int) {
-	return file_virsh_proto_rawDescGZIP(), []int{3}
+	return file_virsh_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *OkResponse) GetOk() bool {
@@ -344,23 +432,27 @@ type Vm struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	MachineName          string   `protobuf:"bytes,1,opt,name=machineName,proto3" json:"machineName,omitempty"`
-	Name                 string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	State                VmState  `protobuf:"varint,3,opt,name=state,proto3,enum=virsh.VmState" json:"state,omitempty"`
-	NovncPort            string   `protobuf:"bytes,4,opt,name=novncPort,proto3" json:"novncPort,omitempty"`
-	CpuCount             int32    `protobuf:"varint,5,opt,name=cpuCount,proto3" json:"cpuCount,omitempty"`
-	MemoryMB             int32    `protobuf:"varint,6,opt,name=memoryMB,proto3" json:"memoryMB,omitempty"`
-	CurrentCpuUsage      int32    `protobuf:"varint,7,opt,name=currentCpuUsage,proto3" json:"currentCpuUsage,omitempty"`
-	CurrentMemoryUsageMB int32    `protobuf:"varint,8,opt,name=currentMemoryUsageMB,proto3" json:"currentMemoryUsageMB,omitempty"`
-	DiskSizeGB           int32    `protobuf:"varint,9,opt,name=diskSizeGB,proto3" json:"diskSizeGB,omitempty"`
-	DiskPath             string   `protobuf:"bytes,10,opt,name=diskPath,proto3" json:"diskPath,omitempty"`
-	Ip                   []string `protobuf:"bytes,12,rep,name=ip,proto3" json:"ip,omitempty"`
+	MachineName          string    `protobuf:"bytes,1,opt,name=machineName,proto3" json:"machineName,omitempty"`
+	Name                 string    `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	State                VmState   `protobuf:"varint,3,opt,name=state,proto3,enum=virsh.VmState" json:"state,omitempty"`
+	NovncPort            string    `protobuf:"bytes,4,opt,name=novncPort,proto3" json:"novncPort,omitempty"`
+	CpuCount             int32     `protobuf:"varint,5,opt,name=cpuCount,proto3" json:"cpuCount,omitempty"`
+	MemoryMB             int32     `protobuf:"varint,6,opt,name=memoryMB,proto3" json:"memoryMB,omitempty"`
+	CurrentCpuUsage      int32     `protobuf:"varint,7,opt,name=currentCpuUsage,proto3" json:"currentCpuUsage,omitempty"`
+	CurrentMemoryUsageMB int32     `protobuf:"varint,8,opt,name=currentMemoryUsageMB,proto3" json:"currentMemoryUsageMB,omitempty"`
+	DiskSizeGB           int32     `protobuf:"varint,9,opt,name=diskSizeGB,proto3" json:"diskSizeGB,omitempty"`
+	DiskPath             string    `protobuf:"bytes,10,opt,name=diskPath,proto3" json:"diskPath,omitempty"`
+	Ip                   []string  `protobuf:"bytes,12,rep,name=ip,proto3" json:"ip,omitempty"`
+	Disks                []*VmDisk `protobuf:"bytes,13,rep,name=disks,proto3" json:"disks,omitempty"`          //every disk, the primary one included
+	Nics                 []*VmNic  `protobuf:"bytes,14,rep,name=nics,proto3" json:"nics,omitempty"`            //ip above is every address of these together
+	CpuTimeNs            uint64    `protobuf:"varint,15,opt,name=cpuTimeNs,proto3" json:"cpuTimeNs,omitempty"` //cumulative cpu time of every vcpu, only while running
+	CpuTimeAt            int64     `protobuf:"varint,16,opt,name=cpuTimeAt,proto3" json:"cpuTimeAt,omitempty"` //unix ms on the slave when cpuTimeNs was read
 }
 
 func (x *Vm) Reset() {
 	*x = Vm{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_virsh_proto_msgTypes[4]
+		mi := &file_virsh_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -373,7 +465,7 @@ func (x *Vm) String() string {
 func (*Vm) ProtoMessage() {}
 
 func (x *Vm) ProtoReflect() protoreflect.Message {
-	mi := &file_virsh_proto_msgTypes[4]
+	mi := &file_virsh_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -386,7 +478,7 @@ func (x *Vm) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Vm.ProtoReflect.Descriptor instead.
 func (*Vm) Descriptor() ([]byte, []int) {
-	return file_virsh_proto_rawDescGZIP(), []int{4}
+	return file_virsh_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *Vm) GetMachineName() string {
@@ -466,31 +558,63 @@ func (x *Vm) GetIp() []string {
 	return nil
 }
 
-type GetVmByNameRequest struct {
+func (x *Vm) GetDisks() []*VmDisk {
+	if x != nil {
+		return x.Disks
+	}
+	return nil
+}
+
+func (x *Vm) GetNics() []*VmNic {
+	if x != nil {
+		return x.Nics
+	}
+	return nil
+}
+
+func (x *Vm) GetCpuTimeNs() uint64 {
+	if x != nil {
+		return x.CpuTimeNs
+	}
+	return 0
+}
+
+func (x *Vm) GetCpuTimeAt() int64 {
+	if x != nil {
+		return x.CpuTimeAt
+	}
+	return 0
+}
+
+type VmNic struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Mac     string   `protobuf:"bytes,1,opt,name=mac,proto3" json:"mac,omitempty"` //random 52:54:00 address when empty
+	Network string   `protobuf:"bytes,2,opt,name=network,proto3" json:"network,omitempty"`
+	Model   string   `protobuf:"bytes,3,opt,name=model,proto3" json:"model,omitempty"`   //virtio when empty
+	Device  string   `protobuf:"bytes,4,opt,name=device,proto3" json:"device,omitempty"` //host side tap device, only while running
+	Ip      []string `protobuf:"bytes,5,rep,name=ip,proto3" json:"ip,omitempty"`
 }
 
-func (x *GetVmByNameRequest) Reset() {
-	*x = GetVmByNameRequest{}
+func (x *VmNic) Reset() {
+	*x = VmNic{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_virsh_proto_msgTypes[5]
+		mi := &file_virsh_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *GetVmByNameRequest) String() string {
+func (x *VmNic) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetVmByNameRequest) ProtoMessage() {}
+func (*VmNic) ProtoMessage() {}
 
-func (x *GetVmByNameRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_virsh_proto_msgTypes[5]
+func (x *VmNic) ProtoReflect() protoreflect.Message {
+	mi := &file_virsh_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -501,43 +625,72 @@ func (x *GetVmByNameRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetVmByNameRequest.ProtoReflect.Descriptor instead.
-func (*GetVmByNameRequest) Descriptor() ([]byte, []int) {
-	return file_virsh_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use VmNic.ProtoReflect.Descriptor instead.
+func (*VmNic) Descriptor() ([]byte, []int) {
+	return file_virsh_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *GetVmByNameRequest) GetName() string {
+func (x *VmNic) GetMac() string {
 	if x != nil {
-		return x.Name
+		return x.Mac
 	}
 	return ""
 }
 
-type GetAllVmsResponse struct {
+func (x *VmNic) GetNetwork() string {
+	if x != nil {
+		return x.Network
+	}
+	return ""
+}
+
+func (x *VmNic) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *VmNic) GetDevice() string {
+	if x != nil {
+		return x.Device
+	}
+	return ""
+}
+
+func (x *VmNic) GetIp() []string {
+	if x != nil {
+		return x.Ip
+	}
+	return nil
+}
+
+type VmNicRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Vms []*Vm `protobuf:"bytes,1,rep,name=vms,proto3" json:"vms,omitempty"`
+	VmName string `protobuf:"bytes,1,opt,name=vmName,proto3" json:"vmName,omitempty"`
+	Nic    *VmNic `protobuf:"bytes,2,opt,name=nic,proto3" json:"nic,omitempty"` //detach only needs the mac
 }
 
-func (x *GetAllVmsResponse) Reset() {
-	*x = GetAllVmsResponse{}
+func (x *VmNicRequest) Reset() {
+	*x = VmNicRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_virsh_proto_msgTypes[6]
+		mi := &file_virsh_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *GetAllVmsResponse) String() string {
+func (x *VmNicRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetAllVmsResponse) ProtoMessage() {}
+func (*VmNicRequest) ProtoMessage() {}
 
-func (x *GetAllVmsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_virsh_proto_msgTypes[6]
+func (x *VmNicRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_virsh_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -548,44 +701,54 @@ func (x *GetAllVmsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetAllVmsResponse.ProtoReflect.Descriptor instead.
-func (*GetAllVmsResponse) Descriptor() ([]byte, []int) {
-	return file_virsh_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use VmNicRequest.ProtoReflect.Descriptor instead.
+func (*VmNicRequest) Descriptor() ([]byte, []int) {
+	return file_virsh_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *GetAllVmsResponse) GetVms() []*Vm {
+func (x *VmNicRequest) GetVmName() string {
 	if x != nil {
-		return x.Vms
+		return x.VmName
+	}
+	return ""
+}
+
+func (x *VmNicRequest) GetNic() *VmNic {
+	if x != nil {
+		return x.Nic
 	}
 	return nil
 }
 
-type CreateVmLiveRequest struct {
+type VmDisk struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Vm     *CreateVmRequest `protobuf:"bytes,1,opt,name=vm,proto3" json:"vm,omitempty"`
-	CpuXml string           `protobuf:"bytes,2,opt,name=cpuXml,proto3" json:"cpuXml,omitempty"`
+	Target string `protobuf:"bytes,1,opt,name=target,proto3" json:"target,omitempty"` //vda, vdb...
+	Path   string `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	SizeGB int32  `protobuf:"varint,3,opt,name=sizeGB,proto3" json:"sizeGB,omitempty"`
+	Bus    string `protobuf:"bytes,4,opt,name=bus,proto3" json:"bus,omitempty"`
+	Owned  bool   `protobuf:"varint,5,opt,name=owned,proto3" json:"owned,omitempty"` //created for this vm, removed with it
 }
 
-func (x *CreateVmLiveRequest) Reset() {
-	*x = CreateVmLiveRequest{}
+func (x *VmDisk) Reset() {
+	*x = VmDisk{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_virsh_proto_msgTypes[7]
+		mi := &file_virsh_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *CreateVmLiveRequest) String() string {
+func (x *VmDisk) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateVmLiveRequest) ProtoMessage() {}
+func (*VmDisk) ProtoMessage() {}
 
-func (x *CreateVmLiveRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_virsh_proto_msgTypes[7]
+func (x *VmDisk) ProtoReflect() protoreflect.Message {
+	mi := &file_virsh_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -596,52 +759,73 @@ func (x *CreateVmLiveRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateVmLiveRequest.ProtoReflect.Descriptor instead.
-func (*CreateVmLiveRequest) Descriptor() ([]byte, []int) {
-	return file_virsh_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use VmDisk.ProtoReflect.Descriptor instead.
+func (*VmDisk) Descriptor() ([]byte, []int) {
+	return file_virsh_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *CreateVmLiveRequest) GetVm() *CreateVmRequest {
+func (x *VmDisk) GetTarget() string {
 	if x != nil {
-		return x.Vm
+		return x.Target
 	}
-	return nil
+	return ""
 }
 
-func (x *CreateVmLiveRequest) GetCpuXml() string {
+func (x *VmDisk) GetPath() string {
 	if x != nil {
-		return x.CpuXml
+		return x.Path
 	}
 	return ""
 }
 
-type MigrateVmRequest struct {
+func (x *VmDisk) GetSizeGB() int32 {
+	if x != nil {
+		return x.SizeGB
+	}
+	return 0
+}
+
+func (x *VmDisk) GetBus() string {
+	if x != nil {
+		return x.Bus
+	}
+	return ""
+}
+
+func (x *VmDisk) GetOwned() bool {
+	if x != nil {
+		return x.Owned
+	}
+	return false
+}
+
+type MoveVmStorageResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Name    string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	SlaveIp string `protobuf:"bytes,3,opt,name=slaveIp,proto3" json:"slaveIp,omitempty"`
-	Live    bool   `protobuf:"varint,4,opt,name=live,proto3" json:"live,omitempty"`
+	Moved        []*VmDisk `protobuf:"bytes,1,rep,name=moved,proto3" json:"moved,omitempty"`                //disks now in diskFolder, path is the new file
+	PrimaryMoved bool      `protobuf:"varint,2,opt,name=primaryMoved,proto3" json:"primaryMoved,omitempty"` //the boot disk moved, its backing chain is flattened
+	Error        string    `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`                //a later step failed, what is in moved stays on the new share
 }
 
-func (x *MigrateVmRequest) Reset() {
-	*x = MigrateVmRequest{}
+func (x *MoveVmStorageResponse) Reset() {
+	*x = MoveVmStorageResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_virsh_proto_msgTypes[8]
+		mi := &file_virsh_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *MigrateVmRequest) String() string {
+func (x *MoveVmStorageResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*MigrateVmRequest) ProtoMessage() {}
+func (*MoveVmStorageResponse) ProtoMessage() {}
 
-func (x *MigrateVmRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_virsh_proto_msgTypes[8]
+func (x *MoveVmStorageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_virsh_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -652,57 +836,58 @@ func (x *MigrateVmRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use MigrateVmRequest.ProtoReflect.Descriptor instead.
-func (*MigrateVmRequest) Descriptor() ([]byte, []int) {
-	return file_virsh_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use MoveVmStorageResponse.ProtoReflect.Descriptor instead.
+func (*MoveVmStorageResponse) Descriptor() ([]byte, []int) {
+	return file_virsh_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *MigrateVmRequest) GetName() string {
+func (x *MoveVmStorageResponse) GetMoved() []*VmDisk {
 	if x != nil {
-		return x.Name
+		return x.Moved
 	}
-	return ""
+	return nil
 }
 
-func (x *MigrateVmRequest) GetSlaveIp() string {
+func (x *MoveVmStorageResponse) GetPrimaryMoved() bool {
 	if x != nil {
-		return x.SlaveIp
+		return x.PrimaryMoved
 	}
-	return ""
+	return false
 }
 
-func (x *MigrateVmRequest) GetLive() bool {
+func (x *MoveVmStorageResponse) GetError() string {
 	if x != nil {
-		return x.Live
+		return x.Error
 	}
-	return false
+	return ""
 }
 
-type CPUXMLResponse struct {
+type BackingFileRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	CpuXML string `protobuf:"bytes,1,opt,name=cpuXML,proto3" json:"cpuXML,omitempty"`
+	Path  string   `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	Files []string `protobuf:"bytes,2,rep,name=files,proto3" json:"files,omitempty"` //trashed disks, no domain points to them anymore
 }
 
-func (x *CPUXMLResponse) Reset() {
-	*x = CPUXMLResponse{}
+func (x *BackingFileRequest) Reset() {
+	*x = BackingFileRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_virsh_proto_msgTypes[9]
+		mi := &file_virsh_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *CPUXMLResponse) String() string {
+func (x *BackingFileRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CPUXMLResponse) ProtoMessage() {}
+func (*BackingFileRequest) ProtoMessage() {}
 
-func (x *CPUXMLResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_virsh_proto_msgTypes[9]
+func (x *BackingFileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_virsh_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -713,104 +898,2391 @@ func (x *CPUXMLResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CPUXMLResponse.ProtoReflect.Descriptor instead.
-func (*CPUXMLResponse) Descriptor() ([]byte, []int) {
-	return file_virsh_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use BackingFileRequest.ProtoReflect.Descriptor instead.
+func (*BackingFileRequest) Descriptor() ([]byte, []int) {
+	return file_virsh_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *CPUXMLResponse) GetCpuXML() string {
+func (x *BackingFileRequest) GetPath() string {
 	if x != nil {
-		return x.CpuXML
+		return x.Path
 	}
 	return ""
 }
 
-var File_virsh_proto protoreflect.FileDescriptor
+func (x *BackingFileRequest) GetFiles() []string {
+	if x != nil {
+		return x.Files
+	}
+	return nil
+}
 
-var file_virsh_proto_rawDesc = []byte{
-	0x0a, 0x0b, 0x76, 0x69, 0x72, 0x73, 0x68, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x05, 0x76,
-	0x69, 0x72, 0x73, 0x68, 0x22, 0x07, 0x0a, 0x05, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x34, 0x0a,
-	0x16, 0x47, 0x65, 0x74, 0x43, 0x70, 0x75, 0x46, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x65, 0x61, 0x74, 0x75,
-	0x72, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x66, 0x65, 0x61, 0x74, 0x75,
-	0x72, 0x65, 0x73, 0x22, 0x88, 0x02, 0x0a, 0x0f, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x56, 0x6d,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x6d,
-	0x65, 0x6d, 0x6f, 0x72, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x6d, 0x65, 0x6d,
-	0x6f, 0x72, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x76, 0x63, 0x70, 0x75, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x05, 0x52, 0x04, 0x76, 0x63, 0x70, 0x75, 0x12, 0x1f, 0x0a, 0x0b, 0x64, 0x69, 0x73, 0x6b, 0x5f,
-	0x66, 0x6f, 0x6c, 0x64, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x64, 0x69,
-	0x73, 0x6b, 0x46, 0x6f, 0x6c, 0x64, 0x65, 0x72, 0x12, 0x1b, 0x0a, 0x09, 0x64, 0x69, 0x73, 0x6b,
-	0x5f, 0x70, 0x61, 0x74, 0x68, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x64, 0x69, 0x73,
-	0x6b, 0x50, 0x61, 0x74, 0x68, 0x12, 0x1f, 0x0a, 0x0b, 0x64, 0x69, 0x73, 0x6b, 0x5f, 0x73, 0x69,
-	0x7a, 0x65, 0x47, 0x42, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x64, 0x69, 0x73, 0x6b,
-	0x53, 0x69, 0x7a, 0x65, 0x47, 0x42, 0x12, 0x19, 0x0a, 0x08, 0x69, 0x73, 0x6f, 0x5f, 0x70, 0x61,
-	0x74, 0x68, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x69, 0x73, 0x6f, 0x50, 0x61, 0x74,
-	0x68, 0x12, 0x18, 0x0a, 0x07, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x18, 0x08, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x07, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x12, 0x21, 0x0a, 0x0c, 0x76,
+type BackingFileUsers struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	VmNames []string `protobuf:"bytes,1,rep,name=vmNames,proto3" json:"vmNames,omitempty"`
+	Files   []string `protobuf:"bytes,2,rep,name=files,proto3" json:"files,omitempty"` //the request files that have path in their backing chain
+}
+
+func (x *BackingFileUsers) Reset() {
+	*x = BackingFileUsers{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_virsh_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BackingFileUsers) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BackingFileUsers) ProtoMessage() {}
+
+func (x *BackingFileUsers) ProtoReflect() protoreflect.Message {
+	mi := &file_virsh_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BackingFileUsers.ProtoReflect.Descriptor instead.
+func (*BackingFileUsers) Descriptor() ([]byte, []int) {
+	return file_virsh_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *BackingFileUsers) GetVmNames() []string {
+	if x != nil {
+		return x.VmNames
+	}
+	return nil
+}
+
+func (x *BackingFileUsers) GetFiles() []string {
+	if x != nil {
+		return x.Files
+	}
+	return nil
+}
+
+type VmDiskRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	VmName     string `protobuf:"bytes,1,opt,name=vmName,proto3" json:"vmName,omitempty"`
+	DiskPath   string `protobuf:"bytes,2,opt,name=diskPath,proto3" json:"diskPath,omitempty"` //attach: existing file, add: optional, defaults to diskFolder/vmName-target.qcow2
+	DiskFolder string `protobuf:"bytes,3,opt,name=diskFolder,proto3" json:"diskFolder,omitempty"`
+	SizeGB     int32  `protobuf:"varint,4,opt,name=sizeGB,proto3" json:"sizeGB,omitempty"`
+	Target     string `protobuf:"bytes,5,opt,name=target,proto3" json:"target,omitempty"`          //next free vdX when empty on add/attach
+	DeleteFile bool   `protobuf:"varint,6,opt,name=deleteFile,proto3" json:"deleteFile,omitempty"` //detach: also delete the file when the vm owns it
+}
+
+func (x *VmDiskRequest) Reset() {
+	*x = VmDiskRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_virsh_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VmDiskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VmDiskRequest) ProtoMessage() {}
+
+func (x *VmDiskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_virsh_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VmDiskRequest.ProtoReflect.Descriptor instead.
+func (*VmDiskRequest) Descriptor() ([]byte, []int) {
+	return file_virsh_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *VmDiskRequest) GetVmName() string {
+	if x != nil {
+		return x.VmName
+	}
+	return ""
+}
+
+func (x *VmDiskRequest) GetDiskPath() string {
+	if x != nil {
+		return x.DiskPath
+	}
+	return ""
+}
+
+func (x *VmDiskRequest) GetDiskFolder() string {
+	if x != nil {
+		return x.DiskFolder
+	}
+	return ""
+}
+
+func (x *VmDiskRequest) GetSizeGB() int32 {
+	if x != nil {
+		return x.SizeGB
+	}
+	return 0
+}
+
+func (x *VmDiskRequest) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *VmDiskRequest) GetDeleteFile() bool {
+	if x != nil {
+		return x.DeleteFile
+	}
+	return false
+}
+
+type GetVmByNameRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *GetVmByNameRequest) Reset() {
+	*x = GetVmByNameRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_virsh_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetVmByNameRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetVmByNameRequest) ProtoMessage() {}
+
+func (x *GetVmByNameRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_virsh_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetVmByNameRequest.ProtoReflect.Descriptor instead.
+func (*GetVmByNameRequest) Descriptor() ([]byte, []int) {
+	return file_virsh_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetVmByNameRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type GetAllVmsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Vms []*Vm `protobuf:"bytes,1,rep,name=vms,proto3" json:"vms,omitempty"`
+}
+
+func (x *GetAllVmsResponse) Reset() {
+	*x = GetAllVmsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_virsh_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetAllVmsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAllVmsResponse) ProtoMessage() {}
+
+func (x *GetAllVmsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_virsh_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAllVmsResponse.ProtoReflect.Descriptor instead.
+func (*GetAllVmsResponse) Descriptor() ([]byte, []int) {
+	return file_virsh_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetAllVmsResponse) GetVms() []*Vm {
+	if x != nil {
+		return x.Vms
+	}
+	return nil
+}
+
+type CreateVmLiveRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Vm     *CreateVmRequest `protobuf:"bytes,1,opt,name=vm,proto3" json:"vm,omitempty"`
+	CpuXml string           `protobuf:"bytes,2,opt,name=cpuXml,proto3" json:"cpuXml,omitempty"`
+}
+
+func (x *CreateVmLiveRequest) Reset() {
+	*x = CreateVmLiveRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_virsh_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateVmLiveRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateVmLiveRequest) ProtoMessage() {}
+
+func (x *CreateVmLiveRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_virsh_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateVmLiveRequest.ProtoReflect.Descriptor instead.
+func (*CreateVmLiveRequest) Descriptor() ([]byte, []int) {
+	return file_virsh_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *CreateVmLiveRequest) GetVm() *CreateVmRequest {
+	if x != nil {
+		return x.Vm
+	}
+	return nil
+}
+
+func (x *CreateVmLiveRequest) GetCpuXml() string {
+	if x != nil {
+		return x.CpuXml
+	}
+	return ""
+}
+
+type MigrateVmRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name          string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	SlaveIp       string `protobuf:"bytes,3,opt,name=slaveIp,proto3" json:"slaveIp,omitempty"`
+	Live          bool   `protobuf:"varint,4,opt,name=live,proto3" json:"live,omitempty"`
+	BandwidthMiB  uint64 `protobuf:"varint,5,opt,name=bandwidthMiB,proto3" json:"bandwidthMiB,omitempty"`   //MiB/s, 0 is unlimited
+	MaxDowntimeMs uint64 `protobuf:"varint,6,opt,name=maxDowntimeMs,proto3" json:"maxDowntimeMs,omitempty"` //0 keeps the hypervisor default
+}
+
+func (x *MigrateVmRequest) Reset() {
+	*x = MigrateVmRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_virsh_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MigrateVmRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MigrateVmRequest) ProtoMessage() {}
+
+func (x *MigrateVmRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_virsh_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MigrateVmRequest.ProtoReflect.Descriptor instead.
+func (*MigrateVmRequest) Descriptor() ([]byte, []int) {
+	return file_virsh_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *MigrateVmRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *MigrateVmRequest) GetSlaveIp() string {
+	if x != nil {
+		return x.SlaveIp
+	}
+	return ""
+}
+
+func (x *MigrateVmRequest) GetLive() bool {
+	if x != nil {
+		return x.Live
+	}
+	return false
+}
+
+func (x *MigrateVmRequest) GetBandwidthMiB() uint64 {
+	if x != nil {
+		return x.BandwidthMiB
+	}
+	return 0
+}
+
+func (x *MigrateVmRequest) GetMaxDowntimeMs() uint64 {
+	if x != nil {
+		return x.MaxDowntimeMs
+	}
+	return 0
+}
+
+type CPUXMLResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CpuXML string `protobuf:"bytes,1,opt,name=cpuXML,proto3" json:"cpuXML,omitempty"`
+}
+
+func (x *CPUXMLResponse) Reset() {
+	*x = CPUXMLResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_virsh_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CPUXMLResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CPUXMLResponse) ProtoMessage() {}
+
+func (x *CPUXMLResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_virsh_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CPUXMLResponse.ProtoReflect.Descriptor instead.
+func (*CPUXMLResponse) Descriptor() ([]byte, []int) {
+	return file_virsh_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *CPUXMLResponse) GetCpuXML() string {
+	if x != nil {
+		return x.CpuXML
+	}
+	return ""
+}
+
+type Snapshot struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	VmName      string `protobuf:"bytes,1,opt,name=vmName,proto3" json:"vmName,omitempty"`
+	Name        string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description string `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	External    bool   `protobuf:"varint,4,opt,name=external,proto3" json:"external,omitempty"` //disk-only snapshot with qcow2 overlays instead of internal
+	State       string `protobuf:"bytes,5,opt,name=state,proto3" json:"state,omitempty"`        //domain state when the snapshot was taken
+	Parent      string `protobuf:"bytes,6,opt,name=parent,proto3" json:"parent,omitempty"`
+	CreatedAt   int64  `protobuf:"varint,7,opt,name=createdAt,proto3" json:"createdAt,omitempty"` //unix seconds
+	Current     bool   `protobuf:"varint,8,opt,name=current,proto3" json:"current,omitempty"`
+	Xml         string `protobuf:"bytes,9,opt,name=xml,proto3" json:"xml,omitempty"` //snapshot xml, used to redefine the metadata on another slave
+}
+
+func (x *Snapshot) Reset() {
+	*x = Snapshot{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_virsh_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Snapshot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Snapshot) ProtoMessage() {}
+
+func (x *Snapshot) ProtoReflect() protoreflect.Message {
+	mi := &file_virsh_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Snapshot.ProtoReflect.Descriptor instead.
+func (*Snapshot) Descriptor() ([]byte, []int) {
+	return file_virsh_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *Snapshot) GetVmName() string {
+	if x != nil {
+		return x.VmName
+	}
+	return ""
+}
+
+func (x *Snapshot) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Snapshot) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Snapshot) GetExternal() bool {
+	if x != nil {
+		return x.External
+	}
+	return false
+}
+
+func (x *Snapshot) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *Snapshot) GetParent() string {
+	if x != nil {
+		return x.Parent
+	}
+	return ""
+}
+
+func (x *Snapshot) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *Snapshot) GetCurrent() bool {
+	if x != nil {
+		return x.Current
+	}
+	return false
+}
+
+func (x *Snapshot) GetXml() string {
+	if x != nil {
+		return x.Xml
+	}
+	return ""
+}
+
+type CreateSnapshotRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	VmName      string `protobuf:"bytes,1,opt,name=vmName,proto3" json:"vmName,omitempty"`
+	Name        string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description string `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	External    bool   `protobuf:"varint,4,opt,name=external,proto3" json:"external,omitempty"`
+}
+
+func (x *CreateSnapshotRequest) Reset() {
+	*x = CreateSnapshotRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_virsh_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateSnapshotRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSnapshotRequest) ProtoMessage() {}
+
+func (x *CreateSnapshotRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_virsh_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSnapshotRequest.ProtoReflect.Descriptor instead.
+func (*CreateSnapshotRequest) Descriptor() ([]byte, []int) {
+	return file_virsh_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *CreateSnapshotRequest) GetVmName() string {
+	if x != nil {
+		return x.VmName
+	}
+	return ""
+}
+
+func (x *CreateSnapshotRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateSnapshotRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CreateSnapshotRequest) GetExternal() bool {
+	if x != nil {
+		return x.External
+	}
+	return false
+}
+
+type SnapshotRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	VmName       string `protobuf:"bytes,1,opt,name=vmName,proto3" json:"vmName,omitempty"`
+	Name         string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	MetadataOnly bool   `protobuf:"varint,3,opt,name=metadataOnly,proto3" json:"metadataOnly,omitempty"` //delete only, keeps the snapshot data in the disks
+}
+
+func (x *SnapshotRequest) Reset() {
+	*x = SnapshotRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_virsh_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SnapshotRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SnapshotRequest) ProtoMessage() {}
+
+func (x *SnapshotRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_virsh_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SnapshotRequest.ProtoReflect.Descriptor instead.
+func (*SnapshotRequest) Descriptor() ([]byte, []int) {
+	return file_virsh_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *SnapshotRequest) GetVmName() string {
+	if x != nil {
+		return x.VmName
+	}
+	return ""
+}
+
+func (x *SnapshotRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SnapshotRequest) GetMetadataOnly() bool {
+	if x != nil {
+		return x.MetadataOnly
+	}
+	return false
+}
+
+type ListSnapshotsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Snapshots []*Snapshot `protobuf:"bytes,1,rep,name=snapshots,proto3" json:"snapshots,omitempty"`
+}
+
+func (x *ListSnapshotsResponse) Reset() {
+	*x = ListSnapshotsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_virsh_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListSnapshotsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSnapshotsResponse) ProtoMessage() {}
+
+func (x *ListSnapshotsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_virsh_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSnapshotsResponse.ProtoReflect.Descriptor instead.
+func (*ListSnapshotsResponse) Descriptor() ([]byte, []int) {
+	return file_virsh_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ListSnapshotsResponse) GetSnapshots() []*Snapshot {
+	if x != nil {
+		return x.Snapshots
+	}
+	return nil
+}
+
+type CloneVmRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SourceName string `protobuf:"bytes,1,opt,name=sourceName,proto3" json:"sourceName,omitempty"`
+	Name       string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	DiskFolder string `protobuf:"bytes,3,opt,name=diskFolder,proto3" json:"diskFolder,omitempty"`
+	DiskPath   string `protobuf:"bytes,4,opt,name=diskPath,proto3" json:"diskPath,omitempty"`
+	Linked     bool   `protobuf:"varint,5,opt,name=linked,proto3" json:"linked,omitempty"` //qcow2 overlay backed by the source disk instead of a full copy
+	Start      bool   `protobuf:"varint,6,opt,name=start,proto3" json:"start,omitempty"`
+}
+
+func (x *CloneVmRequest) Reset() {
+	*x = CloneVmRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_virsh_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CloneVmRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CloneVmRequest) ProtoMessage() {}
+
+func (x *CloneVmRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_virsh_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CloneVmRequest.ProtoReflect.Descriptor instead.
+func (*CloneVmRequest) Descriptor() ([]byte, []int) {
+	return file_virsh_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *CloneVmRequest) GetSourceName() string {
+	if x != nil {
+		return x.SourceName
+	}
+	return ""
+}
+
+func (x *CloneVmRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CloneVmRequest) GetDiskFolder() string {
+	if x != nil {
+		return x.DiskFolder
+	}
+	return ""
+}
+
+func (x *CloneVmRequest) GetDiskPath() string {
+	if x != nil {
+		return x.DiskPath
+	}
+	return ""
+}
+
+func (x *CloneVmRequest) GetLinked() bool {
+	if x != nil {
+		return x.Linked
+	}
+	return false
+}
+
+func (x *CloneVmRequest) GetStart() bool {
+	if x != nil {
+		return x.Start
+	}
+	return false
+}
+
+type BackupDisk struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Target string `protobuf:"bytes,1,opt,name=target,proto3" json:"target,omitempty"` //vda, vdb...
+	Path   string `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`     //file of the backup
+}
+
+func (x *BackupDisk) Reset() {
+	*x = BackupDisk{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_virsh_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BackupDisk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BackupDisk) ProtoMessage() {}
+
+func (x *BackupDisk) ProtoReflect() protoreflect.Message {
+	mi := &file_virsh_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BackupDisk.ProtoReflect.Descriptor instead.
+func (*BackupDisk) Descriptor() ([]byte, []int) {
+	return file_virsh_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *BackupDisk) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *BackupDisk) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+type BackupVmRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	VmName      string        `protobuf:"bytes,1,opt,name=vmName,proto3" json:"vmName,omitempty"`
+	Folder      string        `protobuf:"bytes,2,opt,name=folder,proto3" json:"folder,omitempty"`           //backup folder of the vm, files are name-target.qcow2 and name.xml
+	Name        string        `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`               //unique per vm, also the name of the checkpoint
+	Parent      string        `protobuf:"bytes,4,opt,name=parent,proto3" json:"parent,omitempty"`           //checkpoint of the previous backup, empty for a full backup
+	ParentDisks []*BackupDisk `protobuf:"bytes,5,rep,name=parentDisks,proto3" json:"parentDisks,omitempty"` //files of the previous backup, the incremental files are rebased on them
+}
+
+func (x *BackupVmRequest) Reset() {
+	*x = BackupVmRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_virsh_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BackupVmRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BackupVmRequest) ProtoMessage() {}
+
+func (x *BackupVmRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_virsh_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BackupVmRequest.ProtoReflect.Descriptor instead.
+func (*BackupVmRequest) Descriptor() ([]byte, []int) {
+	return file_virsh_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *BackupVmRequest) GetVmName() string {
+	if x != nil {
+		return x.VmName
+	}
+	return ""
+}
+
+func (x *BackupVmRequest) GetFolder() string {
+	if x != nil {
+		return x.Folder
+	}
+	return ""
+}
+
+func (x *BackupVmRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *BackupVmRequest) GetParent() string {
+	if x != nil {
+		return x.Parent
+	}
+	return ""
+}
+
+func (x *BackupVmRequest) GetParentDisks() []*BackupDisk {
+	if x != nil {
+		return x.ParentDisks
+	}
+	return nil
+}
+
+type BackupVmResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Incremental bool          `protobuf:"varint,1,opt,name=incremental,proto3" json:"incremental,omitempty"` //false when a full backup was done instead (no parent or parent checkpoint gone)
+	Checkpoint  bool          `protobuf:"varint,2,opt,name=checkpoint,proto3" json:"checkpoint,omitempty"`   //a checkpoint was created, the next backup can be incremental on top of this one
+	XmlPath     string        `protobuf:"bytes,3,opt,name=xmlPath,proto3" json:"xmlPath,omitempty"`
+	Disks       []*BackupDisk `protobuf:"bytes,4,rep,name=disks,proto3" json:"disks,omitempty"`
+	SizeBytes   int64         `protobuf:"varint,5,opt,name=sizeBytes,proto3" json:"sizeBytes,omitempty"` //space the new files use
+}
+
+func (x *BackupVmResponse) Reset() {
+	*x = BackupVmResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_virsh_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BackupVmResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BackupVmResponse) ProtoMessage() {}
+
+func (x *BackupVmResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_virsh_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BackupVmResponse.ProtoReflect.Descriptor instead.
+func (*BackupVmResponse) Descriptor() ([]byte, []int) {
+	return file_virsh_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *BackupVmResponse) GetIncremental() bool {
+	if x != nil {
+		return x.Incremental
+	}
+	return false
+}
+
+func (x *BackupVmResponse) GetCheckpoint() bool {
+	if x != nil {
+		return x.Checkpoint
+	}
+	return false
+}
+
+func (x *BackupVmResponse) GetXmlPath() string {
+	if x != nil {
+		return x.XmlPath
+	}
+	return ""
+}
+
+func (x *BackupVmResponse) GetDisks() []*BackupDisk {
+	if x != nil {
+		return x.Disks
+	}
+	return nil
+}
+
+func (x *BackupVmResponse) GetSizeBytes() int64 {
+	if x != nil {
+		return x.SizeBytes
+	}
+	return 0
+}
+
+type RestoreBackupRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name       string        `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`       //new vm
+	XmlPath    string        `protobuf:"bytes,2,opt,name=xmlPath,proto3" json:"xmlPath,omitempty"` //domain xml saved with the backup
+	Disks      []*BackupDisk `protobuf:"bytes,3,rep,name=disks,proto3" json:"disks,omitempty"`     //newest file of every disk, the backing chain is flattened
+	DiskFolder string        `protobuf:"bytes,4,opt,name=diskFolder,proto3" json:"diskFolder,omitempty"`
+	DiskPath   string        `protobuf:"bytes,5,opt,name=diskPath,proto3" json:"diskPath,omitempty"` //boot disk of the new vm, extra disks go next to it
+}
+
+func (x *RestoreBackupRequest) Reset() {
+	*x = RestoreBackupRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_virsh_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RestoreBackupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreBackupRequest) ProtoMessage() {}
+
+func (x *RestoreBackupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_virsh_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreBackupRequest.ProtoReflect.Descriptor instead.
+func (*RestoreBackupRequest) Descriptor() ([]byte, []int) {
+	return file_virsh_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *RestoreBackupRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RestoreBackupRequest) GetXmlPath() string {
+	if x != nil {
+		return x.XmlPath
+	}
+	return ""
+}
+
+func (x *RestoreBackupRequest) GetDisks() []*BackupDisk {
+	if x != nil {
+		return x.Disks
+	}
+	return nil
+}
+
+func (x *RestoreBackupRequest) GetDiskFolder() string {
+	if x != nil {
+		return x.DiskFolder
+	}
+	return ""
+}
+
+func (x *RestoreBackupRequest) GetDiskPath() string {
+	if x != nil {
+		return x.DiskPath
+	}
+	return ""
+}
+
+type DeleteBackupRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	VmName     string   `protobuf:"bytes,1,opt,name=vmName,proto3" json:"vmName,omitempty"`
+	Checkpoint string   `protobuf:"bytes,2,opt,name=checkpoint,proto3" json:"checkpoint,omitempty"` //removed from the vm when it is on this slave
+	Files      []string `protobuf:"bytes,3,rep,name=files,proto3" json:"files,omitempty"`
+}
+
+func (x *DeleteBackupRequest) Reset() {
+	*x = DeleteBackupRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_virsh_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteBackupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteBackupRequest) ProtoMessage() {}
+
+func (x *DeleteBackupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_virsh_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteBackupRequest.ProtoReflect.Descriptor instead.
+func (*DeleteBackupRequest) Descriptor() ([]byte, []int) {
+	return file_virsh_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *DeleteBackupRequest) GetVmName() string {
+	if x != nil {
+		return x.VmName
+	}
+	return ""
+}
+
+func (x *DeleteBackupRequest) GetCheckpoint() string {
+	if x != nil {
+		return x.Checkpoint
+	}
+	return ""
+}
+
+func (x *DeleteBackupRequest) GetFiles() []string {
+	if x != nil {
+		return x.Files
+	}
+	return nil
+}
+
+type TrashVmRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name      string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	TrashName string `protobuf:"bytes,2,opt,name=trashName,proto3" json:"trashName,omitempty"` //folder inside share/.trash the files go to
+}
+
+func (x *TrashVmRequest) Reset() {
+	*x = TrashVmRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_virsh_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TrashVmRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrashVmRequest) ProtoMessage() {}
+
+func (x *TrashVmRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_virsh_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TrashVmRequest.ProtoReflect.Descriptor instead.
+func (*TrashVmRequest) Descriptor() ([]byte, []int) {
+	return file_virsh_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *TrashVmRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *TrashVmRequest) GetTrashName() string {
+	if x != nil {
+		return x.TrashName
+	}
+	return ""
+}
+
+type TrashFile struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Original string `protobuf:"bytes,1,opt,name=original,proto3" json:"original,omitempty"`
+	Trashed  string `protobuf:"bytes,2,opt,name=trashed,proto3" json:"trashed,omitempty"`
+}
+
+func (x *TrashFile) Reset() {
+	*x = TrashFile{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_virsh_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TrashFile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrashFile) ProtoMessage() {}
+
+func (x *TrashFile) ProtoReflect() protoreflect.Message {
+	mi := &file_virsh_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TrashFile.ProtoReflect.Descriptor instead.
+func (*TrashFile) Descriptor() ([]byte, []int) {
+	return file_virsh_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *TrashFile) GetOriginal() string {
+	if x != nil {
+		return x.Original
+	}
+	return ""
+}
+
+func (x *TrashFile) GetTrashed() string {
+	if x != nil {
+		return x.Trashed
+	}
+	return ""
+}
+
+type TrashedVm struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name  string       `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Xml   string       `protobuf:"bytes,2,opt,name=xml,proto3" json:"xml,omitempty"` //definition of the vm when it was trashed
+	Files []*TrashFile `protobuf:"bytes,3,rep,name=files,proto3" json:"files,omitempty"`
+}
+
+func (x *TrashedVm) Reset() {
+	*x = TrashedVm{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_virsh_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TrashedVm) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrashedVm) ProtoMessage() {}
+
+func (x *TrashedVm) ProtoReflect() protoreflect.Message {
+	mi := &file_virsh_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TrashedVm.ProtoReflect.Descriptor instead.
+func (*TrashedVm) Descriptor() ([]byte, []int) {
+	return file_virsh_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *TrashedVm) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *TrashedVm) GetXml() string {
+	if x != nil {
+		return x.Xml
+	}
+	return ""
+}
+
+func (x *TrashedVm) GetFiles() []*TrashFile {
+	if x != nil {
+		return x.Files
+	}
+	return nil
+}
+
+type DefineSnapshotRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	VmName  string `protobuf:"bytes,1,opt,name=vmName,proto3" json:"vmName,omitempty"`
+	Xml     string `protobuf:"bytes,2,opt,name=xml,proto3" json:"xml,omitempty"`
+	Current bool   `protobuf:"varint,3,opt,name=current,proto3" json:"current,omitempty"`
+}
+
+func (x *DefineSnapshotRequest) Reset() {
+	*x = DefineSnapshotRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_virsh_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DefineSnapshotRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DefineSnapshotRequest) ProtoMessage() {}
+
+func (x *DefineSnapshotRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_virsh_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DefineSnapshotRequest.ProtoReflect.Descriptor instead.
+func (*DefineSnapshotRequest) Descriptor() ([]byte, []int) {
+	return file_virsh_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *DefineSnapshotRequest) GetVmName() string {
+	if x != nil {
+		return x.VmName
+	}
+	return ""
+}
+
+func (x *DefineSnapshotRequest) GetXml() string {
+	if x != nil {
+		return x.Xml
+	}
+	return ""
+}
+
+func (x *DefineSnapshotRequest) GetCurrent() bool {
+	if x != nil {
+		return x.Current
+	}
+	return false
+}
+
+type HaVmRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	VmName   string `protobuf:"bytes,1,opt,name=vmName,proto3" json:"vmName,omitempty"`
+	DiskPath string `protobuf:"bytes,2,opt,name=diskPath,proto3" json:"diskPath,omitempty"` //recover: lease and domain xml live next to it
+	Enabled  bool   `protobuf:"varint,3,opt,name=enabled,proto3" json:"enabled,omitempty"`
+}
+
+func (x *HaVmRequest) Reset() {
+	*x = HaVmRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_virsh_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HaVmRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HaVmRequest) ProtoMessage() {}
+
+func (x *HaVmRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_virsh_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HaVmRequest.ProtoReflect.Descriptor instead.
+func (*HaVmRequest) Descriptor() ([]byte, []int) {
+	return file_virsh_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *HaVmRequest) GetVmName() string {
+	if x != nil {
+		return x.VmName
+	}
+	return ""
+}
+
+func (x *HaVmRequest) GetDiskPath() string {
+	if x != nil {
+		return x.DiskPath
+	}
+	return ""
+}
+
+func (x *HaVmRequest) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+// what the qemu guest agent reports, empty when the agent is not running
+type GuestInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	VmName        string             `protobuf:"bytes,1,opt,name=vmName,proto3" json:"vmName,omitempty"`
+	Hostname      string             `protobuf:"bytes,2,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	OsName        string             `protobuf:"bytes,3,opt,name=osName,proto3" json:"osName,omitempty"`
+	OsVersion     string             `protobuf:"bytes,4,opt,name=osVersion,proto3" json:"osVersion,omitempty"`
+	Kernel        string             `protobuf:"bytes,5,opt,name=kernel,proto3" json:"kernel,omitempty"`
+	Timezone      string             `protobuf:"bytes,6,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	UptimeSeconds int64              `protobuf:"varint,7,opt,name=uptimeSeconds,proto3" json:"uptimeSeconds,omitempty"` //0 when the guest has no /proc/uptime
+	Interfaces    []*GuestInterface  `protobuf:"bytes,8,rep,name=interfaces,proto3" json:"interfaces,omitempty"`
+	Filesystems   []*GuestFilesystem `protobuf:"bytes,9,rep,name=filesystems,proto3" json:"filesystems,omitempty"`
+	Users         []string           `protobuf:"bytes,10,rep,name=users,proto3" json:"users,omitempty"` //logged in
+}
+
+func (x *GuestInfo) Reset() {
+	*x = GuestInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_virsh_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GuestInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GuestInfo) ProtoMessage() {}
+
+func (x *GuestInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_virsh_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GuestInfo.ProtoReflect.Descriptor instead.
+func (*GuestInfo) Descriptor() ([]byte, []int) {
+	return file_virsh_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *GuestInfo) GetVmName() string {
+	if x != nil {
+		return x.VmName
+	}
+	return ""
+}
+
+func (x *GuestInfo) GetHostname() string {
+	if x != nil {
+		return x.Hostname
+	}
+	return ""
+}
+
+func (x *GuestInfo) GetOsName() string {
+	if x != nil {
+		return x.OsName
+	}
+	return ""
+}
+
+func (x *GuestInfo) GetOsVersion() string {
+	if x != nil {
+		return x.OsVersion
+	}
+	return ""
+}
+
+func (x *GuestInfo) GetKernel() string {
+	if x != nil {
+		return x.Kernel
+	}
+	return ""
+}
+
+func (x *GuestInfo) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+func (x *GuestInfo) GetUptimeSeconds() int64 {
+	if x != nil {
+		return x.UptimeSeconds
+	}
+	return 0
+}
+
+func (x *GuestInfo) GetInterfaces() []*GuestInterface {
+	if x != nil {
+		return x.Interfaces
+	}
+	return nil
+}
+
+func (x *GuestInfo) GetFilesystems() []*GuestFilesystem {
+	if x != nil {
+		return x.Filesystems
+	}
+	return nil
+}
+
+func (x *GuestInfo) GetUsers() []string {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+type GuestInterface struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Mac  string   `protobuf:"bytes,2,opt,name=mac,proto3" json:"mac,omitempty"`
+	Ips  []string `protobuf:"bytes,3,rep,name=ips,proto3" json:"ips,omitempty"` //address/prefix
+}
+
+func (x *GuestInterface) Reset() {
+	*x = GuestInterface{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_virsh_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GuestInterface) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GuestInterface) ProtoMessage() {}
+
+func (x *GuestInterface) ProtoReflect() protoreflect.Message {
+	mi := &file_virsh_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GuestInterface.ProtoReflect.Descriptor instead.
+func (*GuestInterface) Descriptor() ([]byte, []int) {
+	return file_virsh_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *GuestInterface) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *GuestInterface) GetMac() string {
+	if x != nil {
+		return x.Mac
+	}
+	return ""
+}
+
+func (x *GuestInterface) GetIps() []string {
+	if x != nil {
+		return x.Ips
+	}
+	return nil
+}
+
+type GuestFilesystem struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Mountpoint string `protobuf:"bytes,1,opt,name=mountpoint,proto3" json:"mountpoint,omitempty"`
+	Name       string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Type       string `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	TotalBytes uint64 `protobuf:"varint,4,opt,name=totalBytes,proto3" json:"totalBytes,omitempty"`
+	UsedBytes  uint64 `protobuf:"varint,5,opt,name=usedBytes,proto3" json:"usedBytes,omitempty"`
+}
+
+func (x *GuestFilesystem) Reset() {
+	*x = GuestFilesystem{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_virsh_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GuestFilesystem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GuestFilesystem) ProtoMessage() {}
+
+func (x *GuestFilesystem) ProtoReflect() protoreflect.Message {
+	mi := &file_virsh_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GuestFilesystem.ProtoReflect.Descriptor instead.
+func (*GuestFilesystem) Descriptor() ([]byte, []int) {
+	return file_virsh_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *GuestFilesystem) GetMountpoint() string {
+	if x != nil {
+		return x.Mountpoint
+	}
+	return ""
+}
+
+func (x *GuestFilesystem) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *GuestFilesystem) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *GuestFilesystem) GetTotalBytes() uint64 {
+	if x != nil {
+		return x.TotalBytes
+	}
+	return 0
+}
+
+func (x *GuestFilesystem) GetUsedBytes() uint64 {
+	if x != nil {
+		return x.UsedBytes
+	}
+	return 0
+}
+
+type GuestExecRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	VmName         string   `protobuf:"bytes,1,opt,name=vmName,proto3" json:"vmName,omitempty"`
+	Path           string   `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"` //absolute path of the program inside the guest
+	Args           []string `protobuf:"bytes,3,rep,name=args,proto3" json:"args,omitempty"`
+	Input          string   `protobuf:"bytes,4,opt,name=input,proto3" json:"input,omitempty"` //stdin
+	TimeoutSeconds int32    `protobuf:"varint,5,opt,name=timeoutSeconds,proto3" json:"timeoutSeconds,omitempty"`
+}
+
+func (x *GuestExecRequest) Reset() {
+	*x = GuestExecRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_virsh_proto_msgTypes[36]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GuestExecRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GuestExecRequest) ProtoMessage() {}
+
+func (x *GuestExecRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_virsh_proto_msgTypes[36]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GuestExecRequest.ProtoReflect.Descriptor instead.
+func (*GuestExecRequest) Descriptor() ([]byte, []int) {
+	return file_virsh_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *GuestExecRequest) GetVmName() string {
+	if x != nil {
+		return x.VmName
+	}
+	return ""
+}
+
+func (x *GuestExecRequest) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *GuestExecRequest) GetArgs() []string {
+	if x != nil {
+		return x.Args
+	}
+	return nil
+}
+
+func (x *GuestExecRequest) GetInput() string {
+	if x != nil {
+		return x.Input
+	}
+	return ""
+}
+
+func (x *GuestExecRequest) GetTimeoutSeconds() int32 {
+	if x != nil {
+		return x.TimeoutSeconds
+	}
+	return 0
+}
+
+type GuestExecResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ExitCode  int32  `protobuf:"varint,1,opt,name=exitCode,proto3" json:"exitCode,omitempty"`
+	Stdout    string `protobuf:"bytes,2,opt,name=stdout,proto3" json:"stdout,omitempty"`
+	Stderr    string `protobuf:"bytes,3,opt,name=stderr,proto3" json:"stderr,omitempty"`
+	Truncated bool   `protobuf:"varint,4,opt,name=truncated,proto3" json:"truncated,omitempty"` //the agent cut the output
+}
+
+func (x *GuestExecResponse) Reset() {
+	*x = GuestExecResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_virsh_proto_msgTypes[37]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GuestExecResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GuestExecResponse) ProtoMessage() {}
+
+func (x *GuestExecResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_virsh_proto_msgTypes[37]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GuestExecResponse.ProtoReflect.Descriptor instead.
+func (*GuestExecResponse) Descriptor() ([]byte, []int) {
+	return file_virsh_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *GuestExecResponse) GetExitCode() int32 {
+	if x != nil {
+		return x.ExitCode
+	}
+	return 0
+}
+
+func (x *GuestExecResponse) GetStdout() string {
+	if x != nil {
+		return x.Stdout
+	}
+	return ""
+}
+
+func (x *GuestExecResponse) GetStderr() string {
+	if x != nil {
+		return x.Stderr
+	}
+	return ""
+}
+
+func (x *GuestExecResponse) GetTruncated() bool {
+	if x != nil {
+		return x.Truncated
+	}
+	return false
+}
+
+// bytes of the vm serial console, the first message to the slave only names the vm
+type ConsoleData struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	VmName string `protobuf:"bytes,1,opt,name=vmName,proto3" json:"vmName,omitempty"`
+	Data   []byte `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (x *ConsoleData) Reset() {
+	*x = ConsoleData{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_virsh_proto_msgTypes[38]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ConsoleData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConsoleData) ProtoMessage() {}
+
+func (x *ConsoleData) ProtoReflect() protoreflect.Message {
+	mi := &file_virsh_proto_msgTypes[38]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConsoleData.ProtoReflect.Descriptor instead.
+func (*ConsoleData) Descriptor() ([]byte, []int) {
+	return file_virsh_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *ConsoleData) GetVmName() string {
+	if x != nil {
+		return x.VmName
+	}
+	return ""
+}
+
+func (x *ConsoleData) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+// what the placement scheduler needs to pick a slave
+type HostResources struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MemoryTotalMB     int64   `protobuf:"varint,1,opt,name=memoryTotalMB,proto3" json:"memoryTotalMB,omitempty"`
+	MemoryFreeMB      int64   `protobuf:"varint,2,opt,name=memoryFreeMB,proto3" json:"memoryFreeMB,omitempty"` //free + buffers + cache
+	Cpus              int32   `protobuf:"varint,3,opt,name=cpus,proto3" json:"cpus,omitempty"`
+	AllocatedVcpus    int32   `protobuf:"varint,4,opt,name=allocatedVcpus,proto3" json:"allocatedVcpus,omitempty"`       //running vms
+	AllocatedMemoryMB int64   `protobuf:"varint,5,opt,name=allocatedMemoryMB,proto3" json:"allocatedMemoryMB,omitempty"` //running vms
+	CpuLoad           float64 `protobuf:"fixed64,6,opt,name=cpuLoad,proto3" json:"cpuLoad,omitempty"`                    //0 to 1, whole host
+	RunningVms        int32   `protobuf:"varint,7,opt,name=runningVms,proto3" json:"runningVms,omitempty"`
+}
+
+func (x *HostResources) Reset() {
+	*x = HostResources{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_virsh_proto_msgTypes[39]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HostResources) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HostResources) ProtoMessage() {}
+
+func (x *HostResources) ProtoReflect() protoreflect.Message {
+	mi := &file_virsh_proto_msgTypes[39]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HostResources.ProtoReflect.Descriptor instead.
+func (*HostResources) Descriptor() ([]byte, []int) {
+	return file_virsh_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *HostResources) GetMemoryTotalMB() int64 {
+	if x != nil {
+		return x.MemoryTotalMB
+	}
+	return 0
+}
+
+func (x *HostResources) GetMemoryFreeMB() int64 {
+	if x != nil {
+		return x.MemoryFreeMB
+	}
+	return 0
+}
+
+func (x *HostResources) GetCpus() int32 {
+	if x != nil {
+		return x.Cpus
+	}
+	return 0
+}
+
+func (x *HostResources) GetAllocatedVcpus() int32 {
+	if x != nil {
+		return x.AllocatedVcpus
+	}
+	return 0
+}
+
+func (x *HostResources) GetAllocatedMemoryMB() int64 {
+	if x != nil {
+		return x.AllocatedMemoryMB
+	}
+	return 0
+}
+
+func (x *HostResources) GetCpuLoad() float64 {
+	if x != nil {
+		return x.CpuLoad
+	}
+	return 0
+}
+
+func (x *HostResources) GetRunningVms() int32 {
+	if x != nil {
+		return x.RunningVms
+	}
+	return 0
+}
+
+var File_virsh_proto protoreflect.FileDescriptor
+
+var file_virsh_proto_rawDesc = []byte{
+	0x0a, 0x0b, 0x76, 0x69, 0x72, 0x73, 0x68, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x05, 0x76,
+	0x69, 0x72, 0x73, 0x68, 0x22, 0x07, 0x0a, 0x05, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x34, 0x0a,
+	0x16, 0x47, 0x65, 0x74, 0x43, 0x70, 0x75, 0x46, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x65, 0x61, 0x74, 0x75,
+	0x72, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x66, 0x65, 0x61, 0x74, 0x75,
+	0x72, 0x65, 0x73, 0x22, 0x80, 0x03, 0x0a, 0x0f, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x56, 0x6d,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x6d,
+	0x65, 0x6d, 0x6f, 0x72, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x6d, 0x65, 0x6d,
+	0x6f, 0x72, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x76, 0x63, 0x70, 0x75, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x04, 0x76, 0x63, 0x70, 0x75, 0x12, 0x1f, 0x0a, 0x0b, 0x64, 0x69, 0x73, 0x6b, 0x5f,
+	0x66, 0x6f, 0x6c, 0x64, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x64, 0x69,
+	0x73, 0x6b, 0x46, 0x6f, 0x6c, 0x64, 0x65, 0x72, 0x12, 0x1b, 0x0a, 0x09, 0x64, 0x69, 0x73, 0x6b,
+	0x5f, 0x70, 0x61, 0x74, 0x68, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x64, 0x69, 0x73,
+	0x6b, 0x50, 0x61, 0x74, 0x68, 0x12, 0x1f, 0x0a, 0x0b, 0x64, 0x69, 0x73, 0x6b, 0x5f, 0x73, 0x69,
+	0x7a, 0x65, 0x47, 0x42, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x64, 0x69, 0x73, 0x6b,
+	0x53, 0x69, 0x7a, 0x65, 0x47, 0x42, 0x12, 0x19, 0x0a, 0x08, 0x69, 0x73, 0x6f, 0x5f, 0x70, 0x61,
+	0x74, 0x68, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x69, 0x73, 0x6f, 0x50, 0x61, 0x74,
+	0x68, 0x12, 0x18, 0x0a, 0x07, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x12, 0x21, 0x0a, 0x0c, 0x76,
 	0x6e, 0x63, 0x5f, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x18, 0x09, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x0b, 0x76, 0x6e, 0x63, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x22, 0x36,
-	0x0a, 0x0a, 0x4f, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02,
-	0x6f, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x02, 0x6f, 0x6b, 0x12, 0x18, 0x0a, 0x07,
-	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d,
-	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0xe0, 0x02, 0x0a, 0x02, 0x56, 0x6d, 0x12, 0x20, 0x0a,
-	0x0b, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x0b, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12,
-	0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e,
-	0x61, 0x6d, 0x65, 0x12, 0x24, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x0e, 0x32, 0x0e, 0x2e, 0x76, 0x69, 0x72, 0x73, 0x68, 0x2e, 0x56, 0x6d, 0x53, 0x74, 0x61,
-	0x74, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x6e, 0x6f, 0x76,
-	0x6e, 0x63, 0x50, 0x6f, 0x72, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6e, 0x6f,
-	0x76, 0x6e, 0x63, 0x50, 0x6f, 0x72, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x70, 0x75, 0x43, 0x6f,
-	0x75, 0x6e, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x63, 0x70, 0x75, 0x43, 0x6f,
-	0x75, 0x6e, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x4d, 0x42, 0x18,
-	0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x4d, 0x42, 0x12,
-	0x28, 0x0a, 0x0f, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x43, 0x70, 0x75, 0x55, 0x73, 0x61,
-	0x67, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0f, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e,
-	0x74, 0x43, 0x70, 0x75, 0x55, 0x73, 0x61, 0x67, 0x65, 0x12, 0x32, 0x0a, 0x14, 0x63, 0x75, 0x72,
-	0x72, 0x65, 0x6e, 0x74, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x55, 0x73, 0x61, 0x67, 0x65, 0x4d,
-	0x42, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05, 0x52, 0x14, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74,
-	0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x55, 0x73, 0x61, 0x67, 0x65, 0x4d, 0x42, 0x12, 0x1e, 0x0a,
-	0x0a, 0x64, 0x69, 0x73, 0x6b, 0x53, 0x69, 0x7a, 0x65, 0x47, 0x42, 0x18, 0x09, 0x20, 0x01, 0x28,
-	0x05, 0x52, 0x0a, 0x64, 0x69, 0x73, 0x6b, 0x53, 0x69, 0x7a, 0x65, 0x47, 0x42, 0x12, 0x1a, 0x0a,
-	0x08, 0x64, 0x69, 0x73, 0x6b, 0x50, 0x61, 0x74, 0x68, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x08, 0x64, 0x69, 0x73, 0x6b, 0x50, 0x61, 0x74, 0x68, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x70, 0x18,
-	0x0c, 0x20, 0x03, 0x28, 0x09, 0x52, 0x02, 0x69, 0x70, 0x22, 0x28, 0x0a, 0x12, 0x47, 0x65, 0x74,
-	0x56, 0x6d, 0x42, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
-	0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e,
-	0x61, 0x6d, 0x65, 0x22, 0x30, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x41, 0x6c, 0x6c, 0x56, 0x6d, 0x73,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1b, 0x0a, 0x03, 0x76, 0x6d, 0x73, 0x18,
-	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x09, 0x2e, 0x76, 0x69, 0x72, 0x73, 0x68, 0x2e, 0x56, 0x6d,
-	0x52, 0x03, 0x76, 0x6d, 0x73, 0x22, 0x55, 0x0a, 0x13, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x56,
-	0x6d, 0x4c, 0x69, 0x76, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x26, 0x0a, 0x02,
-	0x76, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x76, 0x69, 0x72, 0x73, 0x68,
-	0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x56, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x52, 0x02, 0x76, 0x6d, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x70, 0x75, 0x58, 0x6d, 0x6c, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x70, 0x75, 0x58, 0x6d, 0x6c, 0x22, 0x54, 0x0a, 0x10,
+	0x09, 0x52, 0x0b, 0x76, 0x6e, 0x63, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x12, 0x23,
+	0x0a, 0x0d, 0x74, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x18,
+	0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x74, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x50,
+	0x61, 0x74, 0x68, 0x12, 0x2f, 0x0a, 0x0a, 0x63, 0x6c, 0x6f, 0x75, 0x64, 0x5f, 0x69, 0x6e, 0x69,
+	0x74, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x76, 0x69, 0x72, 0x73, 0x68, 0x2e,
+	0x43, 0x6c, 0x6f, 0x75, 0x64, 0x49, 0x6e, 0x69, 0x74, 0x52, 0x09, 0x63, 0x6c, 0x6f, 0x75, 0x64,
+	0x49, 0x6e, 0x69, 0x74, 0x12, 0x20, 0x0a, 0x04, 0x6e, 0x69, 0x63, 0x73, 0x18, 0x0c, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x76, 0x69, 0x72, 0x73, 0x68, 0x2e, 0x56, 0x6d, 0x4e, 0x69, 0x63,
+	0x52, 0x04, 0x6e, 0x69, 0x63, 0x73, 0x22, 0x5f, 0x0a, 0x09, 0x43, 0x6c, 0x6f, 0x75, 0x64, 0x49,
+	0x6e, 0x69, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x68, 0x6f, 0x73, 0x74, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x68, 0x6f, 0x73, 0x74, 0x6e, 0x61, 0x6d, 0x65, 0x12,
+	0x1b, 0x0a, 0x09, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x44, 0x61, 0x74, 0x61, 0x12, 0x19, 0x0a, 0x08,
+	0x73, 0x73, 0x68, 0x5f, 0x6b, 0x65, 0x79, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07,
+	0x73, 0x73, 0x68, 0x4b, 0x65, 0x79, 0x73, 0x22, 0x36, 0x0a, 0x0a, 0x4f, 0x6b, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x6f, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x02, 0x6f, 0x6b, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22,
+	0xe3, 0x03, 0x0a, 0x02, 0x56, 0x6d, 0x12, 0x20, 0x0a, 0x0b, 0x6d, 0x61, 0x63, 0x68, 0x69, 0x6e,
+	0x65, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x6d, 0x61, 0x63,
+	0x68, 0x69, 0x6e, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x24, 0x0a, 0x05,
+	0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0e, 0x2e, 0x76, 0x69,
+	0x72, 0x73, 0x68, 0x2e, 0x56, 0x6d, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61,
+	0x74, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x6e, 0x6f, 0x76, 0x6e, 0x63, 0x50, 0x6f, 0x72, 0x74, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6e, 0x6f, 0x76, 0x6e, 0x63, 0x50, 0x6f, 0x72, 0x74,
+	0x12, 0x1a, 0x0a, 0x08, 0x63, 0x70, 0x75, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x08, 0x63, 0x70, 0x75, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1a, 0x0a, 0x08,
+	0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x4d, 0x42, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08,
+	0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x4d, 0x42, 0x12, 0x28, 0x0a, 0x0f, 0x63, 0x75, 0x72, 0x72,
+	0x65, 0x6e, 0x74, 0x43, 0x70, 0x75, 0x55, 0x73, 0x61, 0x67, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x0f, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x43, 0x70, 0x75, 0x55, 0x73, 0x61,
+	0x67, 0x65, 0x12, 0x32, 0x0a, 0x14, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x4d, 0x65, 0x6d,
+	0x6f, 0x72, 0x79, 0x55, 0x73, 0x61, 0x67, 0x65, 0x4d, 0x42, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x14, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x55,
+	0x73, 0x61, 0x67, 0x65, 0x4d, 0x42, 0x12, 0x1e, 0x0a, 0x0a, 0x64, 0x69, 0x73, 0x6b, 0x53, 0x69,
+	0x7a, 0x65, 0x47, 0x42, 0x18, 0x09, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x64, 0x69, 0x73, 0x6b,
+	0x53, 0x69, 0x7a, 0x65, 0x47, 0x42, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x69, 0x73, 0x6b, 0x50, 0x61,
+	0x74, 0x68, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x64, 0x69, 0x73, 0x6b, 0x50, 0x61,
+	0x74, 0x68, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x70, 0x18, 0x0c, 0x20, 0x03, 0x28, 0x09, 0x52, 0x02,
+	0x69, 0x70, 0x12, 0x23, 0x0a, 0x05, 0x64, 0x69, 0x73, 0x6b, 0x73, 0x18, 0x0d, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x0d, 0x2e, 0x76, 0x69, 0x72, 0x73, 0x68, 0x2e, 0x56, 0x6d, 0x44, 0x69, 0x73, 0x6b,
+	0x52, 0x05, 0x64, 0x69, 0x73, 0x6b, 0x73, 0x12, 0x20, 0x0a, 0x04, 0x6e, 0x69, 0x63, 0x73, 0x18,
+	0x0e, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x76, 0x69, 0x72, 0x73, 0x68, 0x2e, 0x56, 0x6d,
+	0x4e, 0x69, 0x63, 0x52, 0x04, 0x6e, 0x69, 0x63, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x70, 0x75,
+	0x54, 0x69, 0x6d, 0x65, 0x4e, 0x73, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x63, 0x70,
+	0x75, 0x54, 0x69, 0x6d, 0x65, 0x4e, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x70, 0x75, 0x54, 0x69,
+	0x6d, 0x65, 0x41, 0x74, 0x18, 0x10, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x63, 0x70, 0x75, 0x54,
+	0x69, 0x6d, 0x65, 0x41, 0x74, 0x22, 0x71, 0x0a, 0x05, 0x56, 0x6d, 0x4e, 0x69, 0x63, 0x12, 0x10,
+	0x0a, 0x03, 0x6d, 0x61, 0x63, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6d, 0x61, 0x63,
+	0x12, 0x18, 0x0a, 0x07, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x12, 0x14, 0x0a, 0x05, 0x6d, 0x6f,
+	0x64, 0x65, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6d, 0x6f, 0x64, 0x65, 0x6c,
+	0x12, 0x16, 0x0a, 0x06, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x70, 0x18, 0x05,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x02, 0x69, 0x70, 0x22, 0x46, 0x0a, 0x0c, 0x56, 0x6d, 0x4e, 0x69,
+	0x63, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x6d, 0x4e, 0x61,
+	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x76, 0x6d, 0x4e, 0x61, 0x6d, 0x65,
+	0x12, 0x1e, 0x0a, 0x03, 0x6e, 0x69, 0x63, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e,
+	0x76, 0x69, 0x72, 0x73, 0x68, 0x2e, 0x56, 0x6d, 0x4e, 0x69, 0x63, 0x52, 0x03, 0x6e, 0x69, 0x63,
+	0x22, 0x74, 0x0a, 0x06, 0x56, 0x6d, 0x44, 0x69, 0x73, 0x6b, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x61,
+	0x72, 0x67, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x61, 0x72, 0x67,
+	0x65, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x69, 0x7a, 0x65, 0x47, 0x42,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x73, 0x69, 0x7a, 0x65, 0x47, 0x42, 0x12, 0x10,
+	0x0a, 0x03, 0x62, 0x75, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x62, 0x75, 0x73,
+	0x12, 0x14, 0x0a, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x05, 0x6f, 0x77, 0x6e, 0x65, 0x64, 0x22, 0x76, 0x0a, 0x15, 0x4d, 0x6f, 0x76, 0x65, 0x56, 0x6d,
+	0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x23, 0x0a, 0x05, 0x6d, 0x6f, 0x76, 0x65, 0x64, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d,
+	0x2e, 0x76, 0x69, 0x72, 0x73, 0x68, 0x2e, 0x56, 0x6d, 0x44, 0x69, 0x73, 0x6b, 0x52, 0x05, 0x6d,
+	0x6f, 0x76, 0x65, 0x64, 0x12, 0x22, 0x0a, 0x0c, 0x70, 0x72, 0x69, 0x6d, 0x61, 0x72, 0x79, 0x4d,
+	0x6f, 0x76, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x70, 0x72, 0x69, 0x6d,
+	0x61, 0x72, 0x79, 0x4d, 0x6f, 0x76, 0x65, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f,
+	0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0x3e,
+	0x0a, 0x12, 0x42, 0x61, 0x63, 0x6b, 0x69, 0x6e, 0x67, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x69, 0x6c, 0x65,
+	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x22, 0x42,
+	0x0a, 0x10, 0x42, 0x61, 0x63, 0x6b, 0x69, 0x6e, 0x67, 0x46, 0x69, 0x6c, 0x65, 0x55, 0x73, 0x65,
+	0x72, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x6d, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x07, 0x76, 0x6d, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x12, 0x14, 0x0a, 0x05,
+	0x66, 0x69, 0x6c, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x66, 0x69, 0x6c,
+	0x65, 0x73, 0x22, 0xb3, 0x01, 0x0a, 0x0d, 0x56, 0x6d, 0x44, 0x69, 0x73, 0x6b, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x6d, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x76, 0x6d, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08,
+	0x64, 0x69, 0x73, 0x6b, 0x50, 0x61, 0x74, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x64, 0x69, 0x73, 0x6b, 0x50, 0x61, 0x74, 0x68, 0x12, 0x1e, 0x0a, 0x0a, 0x64, 0x69, 0x73, 0x6b,
+	0x46, 0x6f, 0x6c, 0x64, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x64, 0x69,
+	0x73, 0x6b, 0x46, 0x6f, 0x6c, 0x64, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x69, 0x7a, 0x65,
+	0x47, 0x42, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x73, 0x69, 0x7a, 0x65, 0x47, 0x42,
+	0x12, 0x16, 0x0a, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x64, 0x65, 0x6c, 0x65,
+	0x74, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x64, 0x65,
+	0x6c, 0x65, 0x74, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x22, 0x28, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x56,
+	0x6d, 0x42, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12,
+	0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x22, 0x30, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x41, 0x6c, 0x6c, 0x56, 0x6d, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1b, 0x0a, 0x03, 0x76, 0x6d, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x09, 0x2e, 0x76, 0x69, 0x72, 0x73, 0x68, 0x2e, 0x56, 0x6d, 0x52,
+	0x03, 0x76, 0x6d, 0x73, 0x22, 0x55, 0x0a, 0x13, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x56, 0x6d,
+	0x4c, 0x69, 0x76, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x26, 0x0a, 0x02, 0x76,
+	0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x76, 0x69, 0x72, 0x73, 0x68, 0x2e,
+	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x56, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52,
+	0x02, 0x76, 0x6d, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x70, 0x75, 0x58, 0x6d, 0x6c, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x70, 0x75, 0x58, 0x6d, 0x6c, 0x22, 0x9e, 0x01, 0x0a, 0x10,
 	0x4d, 0x69, 0x67, 0x72, 0x61, 0x74, 0x65, 0x56, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
 	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
 	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x6c, 0x61, 0x76, 0x65, 0x49, 0x70, 0x18,
 	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x73, 0x6c, 0x61, 0x76, 0x65, 0x49, 0x70, 0x12, 0x12,
 	0x0a, 0x04, 0x6c, 0x69, 0x76, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x6c, 0x69,
-	0x76, 0x65, 0x22, 0x28, 0x0a, 0x0e, 0x43, 0x50, 0x55, 0x58, 0x4d, 0x4c, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x70, 0x75, 0x58, 0x4d, 0x4c, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x70, 0x75, 0x58, 0x4d, 0x4c, 0x2a, 0x82, 0x01, 0x0a,
-	0x07, 0x56, 0x6d, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4b, 0x4e,
-	0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x0b, 0x0a, 0x07, 0x52, 0x55, 0x4e, 0x4e, 0x49, 0x4e, 0x47,
-	0x10, 0x01, 0x12, 0x0b, 0x0a, 0x07, 0x42, 0x4c, 0x4f, 0x43, 0x4b, 0x45, 0x44, 0x10, 0x02, 0x12,
-	0x0a, 0x0a, 0x06, 0x50, 0x41, 0x55, 0x53, 0x45, 0x44, 0x10, 0x03, 0x12, 0x0c, 0x0a, 0x08, 0x53,
-	0x48, 0x55, 0x54, 0x44, 0x4f, 0x57, 0x4e, 0x10, 0x04, 0x12, 0x0b, 0x0a, 0x07, 0x53, 0x48, 0x55,
-	0x54, 0x4f, 0x46, 0x46, 0x10, 0x05, 0x12, 0x0b, 0x0a, 0x07, 0x43, 0x52, 0x41, 0x53, 0x48, 0x45,
-	0x44, 0x10, 0x06, 0x12, 0x0f, 0x0a, 0x0b, 0x50, 0x4d, 0x53, 0x55, 0x53, 0x50, 0x45, 0x4e, 0x44,
-	0x45, 0x44, 0x10, 0x07, 0x12, 0x0b, 0x0a, 0x07, 0x4e, 0x4f, 0x53, 0x54, 0x41, 0x54, 0x45, 0x10,
-	0x08, 0x32, 0xad, 0x06, 0x0a, 0x11, 0x53, 0x6c, 0x61, 0x76, 0x65, 0x56, 0x69, 0x72, 0x73, 0x68,
-	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x3d, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x43, 0x70,
-	0x75, 0x46, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x12, 0x0c, 0x2e, 0x76, 0x69, 0x72, 0x73,
-	0x68, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x1d, 0x2e, 0x76, 0x69, 0x72, 0x73, 0x68, 0x2e,
-	0x47, 0x65, 0x74, 0x43, 0x70, 0x75, 0x46, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x30, 0x0a, 0x09, 0x47, 0x65, 0x74, 0x43, 0x50, 0x55,
-	0x58, 0x4d, 0x4c, 0x12, 0x0c, 0x2e, 0x76, 0x69, 0x72, 0x73, 0x68, 0x2e, 0x45, 0x6d, 0x70, 0x74,
-	0x79, 0x1a, 0x15, 0x2e, 0x76, 0x69, 0x72, 0x73, 0x68, 0x2e, 0x43, 0x50, 0x55, 0x58, 0x4d, 0x4c,
+	0x76, 0x65, 0x12, 0x22, 0x0a, 0x0c, 0x62, 0x61, 0x6e, 0x64, 0x77, 0x69, 0x64, 0x74, 0x68, 0x4d,
+	0x69, 0x42, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x62, 0x61, 0x6e, 0x64, 0x77, 0x69,
+	0x64, 0x74, 0x68, 0x4d, 0x69, 0x42, 0x12, 0x24, 0x0a, 0x0d, 0x6d, 0x61, 0x78, 0x44, 0x6f, 0x77,
+	0x6e, 0x74, 0x69, 0x6d, 0x65, 0x4d, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0d, 0x6d,
+	0x61, 0x78, 0x44, 0x6f, 0x77, 0x6e, 0x74, 0x69, 0x6d, 0x65, 0x4d, 0x73, 0x22, 0x28, 0x0a, 0x0e,
+	0x43, 0x50, 0x55, 0x58, 0x4d, 0x4c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16,
+	0x0a, 0x06, 0x63, 0x70, 0x75, 0x58, 0x4d, 0x4c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x63, 0x70, 0x75, 0x58, 0x4d, 0x4c, 0x22, 0xec, 0x01, 0x0a, 0x08, 0x53, 0x6e, 0x61, 0x70, 0x73,
+	0x68, 0x6f, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x6d, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x76, 0x6d, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12,
+	0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x65, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x08, 0x65, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x12, 0x14, 0x0a,
+	0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x73, 0x74,
+	0x61, 0x74, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x63,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09,
+	0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x75, 0x72,
+	0x72, 0x65, 0x6e, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x63, 0x75, 0x72, 0x72,
+	0x65, 0x6e, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x78, 0x6d, 0x6c, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x78, 0x6d, 0x6c, 0x22, 0x81, 0x01, 0x0a, 0x15, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x16, 0x0a, 0x06, 0x76, 0x6d, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x76, 0x6d, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64,
+	0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a,
+	0x08, 0x65, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x08, 0x65, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x22, 0x61, 0x0a, 0x0f, 0x53, 0x6e, 0x61,
+	0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06,
+	0x76, 0x6d, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x76, 0x6d,
+	0x4e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x22, 0x0a, 0x0c, 0x6d, 0x65, 0x74, 0x61,
+	0x64, 0x61, 0x74, 0x61, 0x4f, 0x6e, 0x6c, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c,
+	0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x4f, 0x6e, 0x6c, 0x79, 0x22, 0x46, 0x0a, 0x15,
+	0x4c, 0x69, 0x73, 0x74, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2d, 0x0a, 0x09, 0x73, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f,
+	0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x76, 0x69, 0x72, 0x73, 0x68,
+	0x2e, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x09, 0x73, 0x6e, 0x61, 0x70, 0x73,
+	0x68, 0x6f, 0x74, 0x73, 0x22, 0xae, 0x01, 0x0a, 0x0e, 0x43, 0x6c, 0x6f, 0x6e, 0x65, 0x56, 0x6d,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1e, 0x0a, 0x0a, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x73, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x64,
+	0x69, 0x73, 0x6b, 0x46, 0x6f, 0x6c, 0x64, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0a, 0x64, 0x69, 0x73, 0x6b, 0x46, 0x6f, 0x6c, 0x64, 0x65, 0x72, 0x12, 0x1a, 0x0a, 0x08, 0x64,
+	0x69, 0x73, 0x6b, 0x50, 0x61, 0x74, 0x68, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x64,
+	0x69, 0x73, 0x6b, 0x50, 0x61, 0x74, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x69, 0x6e, 0x6b, 0x65,
+	0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x64, 0x12,
+	0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05,
+	0x73, 0x74, 0x61, 0x72, 0x74, 0x22, 0x38, 0x0a, 0x0a, 0x42, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x44,
+	0x69, 0x73, 0x6b, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x70,
+	0x61, 0x74, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x22,
+	0xa2, 0x01, 0x0a, 0x0f, 0x42, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x56, 0x6d, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x6d, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x76, 0x6d, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x66,
+	0x6f, 0x6c, 0x64, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x66, 0x6f, 0x6c,
+	0x64, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x61, 0x72, 0x65, 0x6e,
+	0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x12,
+	0x33, 0x0a, 0x0b, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x44, 0x69, 0x73, 0x6b, 0x73, 0x18, 0x05,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x76, 0x69, 0x72, 0x73, 0x68, 0x2e, 0x42, 0x61, 0x63,
+	0x6b, 0x75, 0x70, 0x44, 0x69, 0x73, 0x6b, 0x52, 0x0b, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x44,
+	0x69, 0x73, 0x6b, 0x73, 0x22, 0xb5, 0x01, 0x0a, 0x10, 0x42, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x56,
+	0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x69, 0x6e, 0x63,
+	0x72, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b,
+	0x69, 0x6e, 0x63, 0x72, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x61, 0x6c, 0x12, 0x1e, 0x0a, 0x0a, 0x63,
+	0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x0a, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x78,
+	0x6d, 0x6c, 0x50, 0x61, 0x74, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x78, 0x6d,
+	0x6c, 0x50, 0x61, 0x74, 0x68, 0x12, 0x27, 0x0a, 0x05, 0x64, 0x69, 0x73, 0x6b, 0x73, 0x18, 0x04,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x76, 0x69, 0x72, 0x73, 0x68, 0x2e, 0x42, 0x61, 0x63,
+	0x6b, 0x75, 0x70, 0x44, 0x69, 0x73, 0x6b, 0x52, 0x05, 0x64, 0x69, 0x73, 0x6b, 0x73, 0x12, 0x1c,
+	0x0a, 0x09, 0x73, 0x69, 0x7a, 0x65, 0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x09, 0x73, 0x69, 0x7a, 0x65, 0x42, 0x79, 0x74, 0x65, 0x73, 0x22, 0xa9, 0x01, 0x0a,
+	0x14, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x42, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x78, 0x6d, 0x6c,
+	0x50, 0x61, 0x74, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x78, 0x6d, 0x6c, 0x50,
+	0x61, 0x74, 0x68, 0x12, 0x27, 0x0a, 0x05, 0x64, 0x69, 0x73, 0x6b, 0x73, 0x18, 0x03, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x11, 0x2e, 0x76, 0x69, 0x72, 0x73, 0x68, 0x2e, 0x42, 0x61, 0x63, 0x6b, 0x75,
+	0x70, 0x44, 0x69, 0x73, 0x6b, 0x52, 0x05, 0x64, 0x69, 0x73, 0x6b, 0x73, 0x12, 0x1e, 0x0a, 0x0a,
+	0x64, 0x69, 0x73, 0x6b, 0x46, 0x6f, 0x6c, 0x64, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0a, 0x64, 0x69, 0x73, 0x6b, 0x46, 0x6f, 0x6c, 0x64, 0x65, 0x72, 0x12, 0x1a, 0x0a, 0x08,
+	0x64, 0x69, 0x73, 0x6b, 0x50, 0x61, 0x74, 0x68, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x64, 0x69, 0x73, 0x6b, 0x50, 0x61, 0x74, 0x68, 0x22, 0x63, 0x0a, 0x13, 0x44, 0x65, 0x6c, 0x65,
+	0x74, 0x65, 0x42, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x16, 0x0a, 0x06, 0x76, 0x6d, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x76, 0x6d, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x68, 0x65, 0x63, 0x6b,
+	0x70, 0x6f, 0x69, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x68, 0x65,
+	0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x69, 0x6c, 0x65, 0x73,
+	0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x22, 0x42, 0x0a,
+	0x0e, 0x54, 0x72, 0x61, 0x73, 0x68, 0x56, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x72, 0x61, 0x73, 0x68, 0x4e, 0x61, 0x6d, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x74, 0x72, 0x61, 0x73, 0x68, 0x4e, 0x61, 0x6d,
+	0x65, 0x22, 0x41, 0x0a, 0x09, 0x54, 0x72, 0x61, 0x73, 0x68, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x1a,
+	0x0a, 0x08, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x61, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x61, 0x6c, 0x12, 0x18, 0x0a, 0x07, 0x74, 0x72,
+	0x61, 0x73, 0x68, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x74, 0x72, 0x61,
+	0x73, 0x68, 0x65, 0x64, 0x22, 0x59, 0x0a, 0x09, 0x54, 0x72, 0x61, 0x73, 0x68, 0x65, 0x64, 0x56,
+	0x6d, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x78, 0x6d, 0x6c, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x78, 0x6d, 0x6c, 0x12, 0x26, 0x0a, 0x05, 0x66, 0x69, 0x6c, 0x65, 0x73,
+	0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x76, 0x69, 0x72, 0x73, 0x68, 0x2e, 0x54,
+	0x72, 0x61, 0x73, 0x68, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x05, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x22,
+	0x5b, 0x0a, 0x15, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x65, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f,
+	0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x6d, 0x4e, 0x61,
+	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x76, 0x6d, 0x4e, 0x61, 0x6d, 0x65,
+	0x12, 0x10, 0x0a, 0x03, 0x78, 0x6d, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x78,
+	0x6d, 0x6c, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x07, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x22, 0x5b, 0x0a, 0x0b,
+	0x48, 0x61, 0x56, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x76,
+	0x6d, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x76, 0x6d, 0x4e,
+	0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x69, 0x73, 0x6b, 0x50, 0x61, 0x74, 0x68, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x64, 0x69, 0x73, 0x6b, 0x50, 0x61, 0x74, 0x68, 0x12,
+	0x18, 0x0a, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x22, 0xd6, 0x02, 0x0a, 0x09, 0x47, 0x75,
+	0x65, 0x73, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x6d, 0x4e, 0x61, 0x6d,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x76, 0x6d, 0x4e, 0x61, 0x6d, 0x65, 0x12,
+	0x1a, 0x0a, 0x08, 0x68, 0x6f, 0x73, 0x74, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x68, 0x6f, 0x73, 0x74, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x6f,
+	0x73, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6f, 0x73, 0x4e,
+	0x61, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x6f, 0x73, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6f, 0x73, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x12, 0x16, 0x0a, 0x06, 0x6b, 0x65, 0x72, 0x6e, 0x65, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x6b, 0x65, 0x72, 0x6e, 0x65, 0x6c, 0x12, 0x1a, 0x0a, 0x08, 0x74, 0x69, 0x6d,
+	0x65, 0x7a, 0x6f, 0x6e, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x74, 0x69, 0x6d,
+	0x65, 0x7a, 0x6f, 0x6e, 0x65, 0x12, 0x24, 0x0a, 0x0d, 0x75, 0x70, 0x74, 0x69, 0x6d, 0x65, 0x53,
+	0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x75, 0x70,
+	0x74, 0x69, 0x6d, 0x65, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x12, 0x35, 0x0a, 0x0a, 0x69,
+	0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x15, 0x2e, 0x76, 0x69, 0x72, 0x73, 0x68, 0x2e, 0x47, 0x75, 0x65, 0x73, 0x74, 0x49, 0x6e, 0x74,
+	0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x52, 0x0a, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63,
+	0x65, 0x73, 0x12, 0x38, 0x0a, 0x0b, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d,
+	0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x76, 0x69, 0x72, 0x73, 0x68, 0x2e,
+	0x47, 0x75, 0x65, 0x73, 0x74, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x52,
+	0x0b, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x73, 0x12, 0x14, 0x0a, 0x05,
+	0x75, 0x73, 0x65, 0x72, 0x73, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x75, 0x73, 0x65,
+	0x72, 0x73, 0x22, 0x48, 0x0a, 0x0e, 0x47, 0x75, 0x65, 0x73, 0x74, 0x49, 0x6e, 0x74, 0x65, 0x72,
+	0x66, 0x61, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x61, 0x63, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6d, 0x61, 0x63, 0x12, 0x10, 0x0a, 0x03, 0x69, 0x70,
+	0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x03, 0x69, 0x70, 0x73, 0x22, 0x97, 0x01, 0x0a,
+	0x0f, 0x47, 0x75, 0x65, 0x73, 0x74, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d,
+	0x12, 0x1e, 0x0a, 0x0a, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x70, 0x6f, 0x69, 0x6e, 0x74,
+	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x74, 0x6f, 0x74, 0x61,
+	0x6c, 0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x74, 0x6f,
+	0x74, 0x61, 0x6c, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x75, 0x73, 0x65, 0x64,
+	0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x75, 0x73, 0x65,
+	0x64, 0x42, 0x79, 0x74, 0x65, 0x73, 0x22, 0x90, 0x01, 0x0a, 0x10, 0x47, 0x75, 0x65, 0x73, 0x74,
+	0x45, 0x78, 0x65, 0x63, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x76,
+	0x6d, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x76, 0x6d, 0x4e,
+	0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x12, 0x0a, 0x04, 0x61, 0x72, 0x67, 0x73, 0x18,
+	0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x61, 0x72, 0x67, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x69,
+	0x6e, 0x70, 0x75, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x69, 0x6e, 0x70, 0x75,
+	0x74, 0x12, 0x26, 0x0a, 0x0e, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x53, 0x65, 0x63, 0x6f,
+	0x6e, 0x64, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x74, 0x69, 0x6d, 0x65, 0x6f,
+	0x75, 0x74, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x22, 0x7d, 0x0a, 0x11, 0x47, 0x75, 0x65,
+	0x73, 0x74, 0x45, 0x78, 0x65, 0x63, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1a,
+	0x0a, 0x08, 0x65, 0x78, 0x69, 0x74, 0x43, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x08, 0x65, 0x78, 0x69, 0x74, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74,
+	0x64, 0x6f, 0x75, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x64, 0x6f,
+	0x75, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x64, 0x65, 0x72, 0x72, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x64, 0x65, 0x72, 0x72, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x72,
+	0x75, 0x6e, 0x63, 0x61, 0x74, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x74,
+	0x72, 0x75, 0x6e, 0x63, 0x61, 0x74, 0x65, 0x64, 0x22, 0x39, 0x0a, 0x0b, 0x43, 0x6f, 0x6e, 0x73,
+	0x6f, 0x6c, 0x65, 0x44, 0x61, 0x74, 0x61, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x6d, 0x4e, 0x61, 0x6d,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x76, 0x6d, 0x4e, 0x61, 0x6d, 0x65, 0x12,
+	0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64,
+	0x61, 0x74, 0x61, 0x22, 0xfd, 0x01, 0x0a, 0x0d, 0x48, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x73, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x73, 0x12, 0x24, 0x0a, 0x0d, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x54,
+	0x6f, 0x74, 0x61, 0x6c, 0x4d, 0x42, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x6d, 0x65,
+	0x6d, 0x6f, 0x72, 0x79, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x4d, 0x42, 0x12, 0x22, 0x0a, 0x0c, 0x6d,
+	0x65, 0x6d, 0x6f, 0x72, 0x79, 0x46, 0x72, 0x65, 0x65, 0x4d, 0x42, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x0c, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x46, 0x72, 0x65, 0x65, 0x4d, 0x42, 0x12,
+	0x12, 0x0a, 0x04, 0x63, 0x70, 0x75, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x63,
+	0x70, 0x75, 0x73, 0x12, 0x26, 0x0a, 0x0e, 0x61, 0x6c, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x65, 0x64,
+	0x56, 0x63, 0x70, 0x75, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x61, 0x6c, 0x6c,
+	0x6f, 0x63, 0x61, 0x74, 0x65, 0x64, 0x56, 0x63, 0x70, 0x75, 0x73, 0x12, 0x2c, 0x0a, 0x11, 0x61,
+	0x6c, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x65, 0x64, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x4d, 0x42,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x11, 0x61, 0x6c, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x65,
+	0x64, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x4d, 0x42, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x70, 0x75,
+	0x4c, 0x6f, 0x61, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x52, 0x07, 0x63, 0x70, 0x75, 0x4c,
+	0x6f, 0x61, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x72, 0x75, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x56, 0x6d,
+	0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x72, 0x75, 0x6e, 0x6e, 0x69, 0x6e, 0x67,
+	0x56, 0x6d, 0x73, 0x2a, 0x82, 0x01, 0x0a, 0x07, 0x56, 0x6d, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12,
+	0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x0b, 0x0a, 0x07,
+	0x52, 0x55, 0x4e, 0x4e, 0x49, 0x4e, 0x47, 0x10, 0x01, 0x12, 0x0b, 0x0a, 0x07, 0x42, 0x4c, 0x4f,
+	0x43, 0x4b, 0x45, 0x44, 0x10, 0x02, 0x12, 0x0a, 0x0a, 0x06, 0x50, 0x41, 0x55, 0x53, 0x45, 0x44,
+	0x10, 0x03, 0x12, 0x0c, 0x0a, 0x08, 0x53, 0x48, 0x55, 0x54, 0x44, 0x4f, 0x57, 0x4e, 0x10, 0x04,
+	0x12, 0x0b, 0x0a, 0x07, 0x53, 0x48, 0x55, 0x54, 0x4f, 0x46, 0x46, 0x10, 0x05, 0x12, 0x0b, 0x0a,
+	0x07, 0x43, 0x52, 0x41, 0x53, 0x48, 0x45, 0x44, 0x10, 0x06, 0x12, 0x0f, 0x0a, 0x0b, 0x50, 0x4d,
+	0x53, 0x55, 0x53, 0x50, 0x45, 0x4e, 0x44, 0x45, 0x44, 0x10, 0x07, 0x12, 0x0b, 0x0a, 0x07, 0x4e,
+	0x4f, 0x53, 0x54, 0x41, 0x54, 0x45, 0x10, 0x08, 0x32, 0x97, 0x15, 0x0a, 0x11, 0x53, 0x6c, 0x61,
+	0x76, 0x65, 0x56, 0x69, 0x72, 0x73, 0x68, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x3d,
+	0x0a, 0x0e, 0x47, 0x65, 0x74, 0x43, 0x70, 0x75, 0x46, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x73,
+	0x12, 0x0c, 0x2e, 0x76, 0x69, 0x72, 0x73, 0x68, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x1d,
+	0x2e, 0x76, 0x69, 0x72, 0x73, 0x68, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x70, 0x75, 0x46, 0x65, 0x61,
+	0x74, 0x75, 0x72, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x30, 0x0a,
+	0x09, 0x47, 0x65, 0x74, 0x43, 0x50, 0x55, 0x58, 0x4d, 0x4c, 0x12, 0x0c, 0x2e, 0x76, 0x69, 0x72,
+	0x73, 0x68, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x15, 0x2e, 0x76, 0x69, 0x72, 0x73, 0x68,
+	0x2e, 0x43, 0x50, 0x55, 0x58, 0x4d, 0x4c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x3f, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x56, 0x6d, 0x43, 0x50, 0x55, 0x58, 0x4d, 0x4c, 0x12, 0x19,
+	0x2e, 0x76, 0x69, 0x72, 0x73, 0x68, 0x2e, 0x47, 0x65, 0x74, 0x56, 0x6d, 0x42, 0x79, 0x4e, 0x61,
+	0x6d, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x76, 0x69, 0x72, 0x73,
+	0x68, 0x2e, 0x43, 0x50, 0x55, 0x58, 0x4d, 0x4c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x36, 0x0a, 0x0a, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x72, 0x65, 0x43, 0x50, 0x55, 0x12, 0x15,
+	0x2e, 0x76, 0x69, 0x72, 0x73, 0x68, 0x2e, 0x43, 0x50, 0x55, 0x58, 0x4d, 0x4c, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x1a, 0x11, 0x2e, 0x76, 0x69, 0x72, 0x73, 0x68, 0x2e, 0x4f, 0x6b,
 	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x35, 0x0a, 0x08, 0x43, 0x72, 0x65, 0x61,
 	0x74, 0x65, 0x56, 0x6d, 0x12, 0x16, 0x2e, 0x76, 0x69, 0x72, 0x73, 0x68, 0x2e, 0x43, 0x72, 0x65,
 	0x61, 0x74, 0x65, 0x56, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x76,
@@ -818,46 +3290,157 @@ var file_virsh_proto_rawDesc = []byte{
 	0x3d, 0x0a, 0x0c, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x76, 0x65, 0x56, 0x4d, 0x12,
 	0x1a, 0x2e, 0x76, 0x69, 0x72, 0x73, 0x68, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x56, 0x6d,
 	0x4c, 0x69, 0x76, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x76, 0x69,
-	0x72, 0x73, 0x68, 0x2e, 0x4f, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x37,
-	0x0a, 0x09, 0x4d, 0x69, 0x67, 0x72, 0x61, 0x74, 0x65, 0x56, 0x4d, 0x12, 0x17, 0x2e, 0x76, 0x69,
-	0x72, 0x73, 0x68, 0x2e, 0x4d, 0x69, 0x67, 0x72, 0x61, 0x74, 0x65, 0x56, 0x6d, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x76, 0x69, 0x72, 0x73, 0x68, 0x2e, 0x4f, 0x6b, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2a, 0x0a, 0x0a, 0x53, 0x68, 0x75, 0x74, 0x64,
-	0x6f, 0x77, 0x6e, 0x56, 0x4d, 0x12, 0x09, 0x2e, 0x76, 0x69, 0x72, 0x73, 0x68, 0x2e, 0x56, 0x6d,
+	0x72, 0x73, 0x68, 0x2e, 0x4f, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x33,
+	0x0a, 0x07, 0x43, 0x6c, 0x6f, 0x6e, 0x65, 0x56, 0x4d, 0x12, 0x15, 0x2e, 0x76, 0x69, 0x72, 0x73,
+	0x68, 0x2e, 0x43, 0x6c, 0x6f, 0x6e, 0x65, 0x56, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
 	0x1a, 0x11, 0x2e, 0x76, 0x69, 0x72, 0x73, 0x68, 0x2e, 0x4f, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x12, 0x2f, 0x0a, 0x0f, 0x46, 0x6f, 0x72, 0x63, 0x65, 0x53, 0x68, 0x75, 0x74,
-	0x64, 0x6f, 0x77, 0x6e, 0x56, 0x4d, 0x12, 0x09, 0x2e, 0x76, 0x69, 0x72, 0x73, 0x68, 0x2e, 0x56,
-	0x6d, 0x1a, 0x11, 0x2e, 0x76, 0x69, 0x72, 0x73, 0x68, 0x2e, 0x4f, 0x6b, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x27, 0x0a, 0x07, 0x53, 0x74, 0x61, 0x72, 0x74, 0x56, 0x4d, 0x12,
-	0x09, 0x2e, 0x76, 0x69, 0x72, 0x73, 0x68, 0x2e, 0x56, 0x6d, 0x1a, 0x11, 0x2e, 0x76, 0x69, 0x72,
-	0x73, 0x68, 0x2e, 0x4f, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x28, 0x0a,
-	0x08, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x56, 0x4d, 0x12, 0x09, 0x2e, 0x76, 0x69, 0x72, 0x73,
-	0x68, 0x2e, 0x56, 0x6d, 0x1a, 0x11, 0x2e, 0x76, 0x69, 0x72, 0x73, 0x68, 0x2e, 0x4f, 0x6b, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x29, 0x0a, 0x09, 0x52, 0x65, 0x73, 0x74, 0x61,
-	0x72, 0x74, 0x56, 0x4d, 0x12, 0x09, 0x2e, 0x76, 0x69, 0x72, 0x73, 0x68, 0x2e, 0x56, 0x6d, 0x1a,
-	0x11, 0x2e, 0x76, 0x69, 0x72, 0x73, 0x68, 0x2e, 0x4f, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x12, 0x27, 0x0a, 0x07, 0x50, 0x61, 0x75, 0x73, 0x65, 0x56, 0x4d, 0x12, 0x09, 0x2e,
+	0x6e, 0x73, 0x65, 0x12, 0x37, 0x0a, 0x09, 0x4d, 0x69, 0x67, 0x72, 0x61, 0x74, 0x65, 0x56, 0x4d,
+	0x12, 0x17, 0x2e, 0x76, 0x69, 0x72, 0x73, 0x68, 0x2e, 0x4d, 0x69, 0x67, 0x72, 0x61, 0x74, 0x65,
+	0x56, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x76, 0x69, 0x72, 0x73,
+	0x68, 0x2e, 0x4f, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3f, 0x0a, 0x0f,
+	0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x4d, 0x69, 0x67, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x19, 0x2e, 0x76, 0x69, 0x72, 0x73, 0x68, 0x2e, 0x47, 0x65, 0x74, 0x56, 0x6d, 0x42, 0x79, 0x4e,
+	0x61, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x76, 0x69, 0x72,
+	0x73, 0x68, 0x2e, 0x4f, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x40, 0x0a,
+	0x12, 0x53, 0x65, 0x74, 0x4d, 0x69, 0x67, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x69, 0x6d,
+	0x69, 0x74, 0x73, 0x12, 0x17, 0x2e, 0x76, 0x69, 0x72, 0x73, 0x68, 0x2e, 0x4d, 0x69, 0x67, 0x72,
+	0x61, 0x74, 0x65, 0x56, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x76,
+	0x69, 0x72, 0x73, 0x68, 0x2e, 0x4f, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x2a, 0x0a, 0x0a, 0x53, 0x68, 0x75, 0x74, 0x64, 0x6f, 0x77, 0x6e, 0x56, 0x4d, 0x12, 0x09, 0x2e,
 	0x76, 0x69, 0x72, 0x73, 0x68, 0x2e, 0x56, 0x6d, 0x1a, 0x11, 0x2e, 0x76, 0x69, 0x72, 0x73, 0x68,
-	0x2e, 0x4f, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x28, 0x0a, 0x08, 0x52,
-	0x65, 0x73, 0x75, 0x6d, 0x65, 0x56, 0x4d, 0x12, 0x09, 0x2e, 0x76, 0x69, 0x72, 0x73, 0x68, 0x2e,
+	0x2e, 0x4f, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2f, 0x0a, 0x0f, 0x46,
+	0x6f, 0x72, 0x63, 0x65, 0x53, 0x68, 0x75, 0x74, 0x64, 0x6f, 0x77, 0x6e, 0x56, 0x4d, 0x12, 0x09,
+	0x2e, 0x76, 0x69, 0x72, 0x73, 0x68, 0x2e, 0x56, 0x6d, 0x1a, 0x11, 0x2e, 0x76, 0x69, 0x72, 0x73,
+	0x68, 0x2e, 0x4f, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x27, 0x0a, 0x07,
+	0x53, 0x74, 0x61, 0x72, 0x74, 0x56, 0x4d, 0x12, 0x09, 0x2e, 0x76, 0x69, 0x72, 0x73, 0x68, 0x2e,
 	0x56, 0x6d, 0x1a, 0x11, 0x2e, 0x76, 0x69, 0x72, 0x73, 0x68, 0x2e, 0x4f, 0x6b, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x33, 0x0a, 0x09, 0x47, 0x65, 0x74, 0x41, 0x6c, 0x6c, 0x56,
-	0x6d, 0x73, 0x12, 0x0c, 0x2e, 0x76, 0x69, 0x72, 0x73, 0x68, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79,
-	0x1a, 0x18, 0x2e, 0x76, 0x69, 0x72, 0x73, 0x68, 0x2e, 0x47, 0x65, 0x74, 0x41, 0x6c, 0x6c, 0x56,
-	0x6d, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x33, 0x0a, 0x0b, 0x47, 0x65,
-	0x74, 0x56, 0x6d, 0x42, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x19, 0x2e, 0x76, 0x69, 0x72, 0x73,
-	0x68, 0x2e, 0x47, 0x65, 0x74, 0x56, 0x6d, 0x42, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x1a, 0x09, 0x2e, 0x76, 0x69, 0x72, 0x73, 0x68, 0x2e, 0x56, 0x6d, 0x12,
-	0x2f, 0x0a, 0x0f, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x49, 0x73, 0x6f, 0x46, 0x72, 0x6f, 0x6d,
-	0x56, 0x6d, 0x12, 0x09, 0x2e, 0x76, 0x69, 0x72, 0x73, 0x68, 0x2e, 0x56, 0x6d, 0x1a, 0x11, 0x2e,
-	0x76, 0x69, 0x72, 0x73, 0x68, 0x2e, 0x4f, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x2f, 0x0a, 0x0f, 0x45, 0x64, 0x69, 0x74, 0x56, 0x6d, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72,
-	0x63, 0x65, 0x73, 0x12, 0x09, 0x2e, 0x76, 0x69, 0x72, 0x73, 0x68, 0x2e, 0x56, 0x6d, 0x1a, 0x11,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x28, 0x0a, 0x08, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x56,
+	0x4d, 0x12, 0x09, 0x2e, 0x76, 0x69, 0x72, 0x73, 0x68, 0x2e, 0x56, 0x6d, 0x1a, 0x11, 0x2e, 0x76,
+	0x69, 0x72, 0x73, 0x68, 0x2e, 0x4f, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x32, 0x0a, 0x07, 0x54, 0x72, 0x61, 0x73, 0x68, 0x56, 0x4d, 0x12, 0x15, 0x2e, 0x76, 0x69, 0x72,
+	0x73, 0x68, 0x2e, 0x54, 0x72, 0x61, 0x73, 0x68, 0x56, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x10, 0x2e, 0x76, 0x69, 0x72, 0x73, 0x68, 0x2e, 0x54, 0x72, 0x61, 0x73, 0x68, 0x65,
+	0x64, 0x56, 0x6d, 0x12, 0x37, 0x0a, 0x10, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x54, 0x72,
+	0x61, 0x73, 0x68, 0x65, 0x64, 0x56, 0x4d, 0x12, 0x10, 0x2e, 0x76, 0x69, 0x72, 0x73, 0x68, 0x2e,
+	0x54, 0x72, 0x61, 0x73, 0x68, 0x65, 0x64, 0x56, 0x6d, 0x1a, 0x11, 0x2e, 0x76, 0x69, 0x72, 0x73,
+	0x68, 0x2e, 0x4f, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x35, 0x0a, 0x0e,
+	0x50, 0x75, 0x72, 0x67, 0x65, 0x54, 0x72, 0x61, 0x73, 0x68, 0x65, 0x64, 0x56, 0x4d, 0x12, 0x10,
+	0x2e, 0x76, 0x69, 0x72, 0x73, 0x68, 0x2e, 0x54, 0x72, 0x61, 0x73, 0x68, 0x65, 0x64, 0x56, 0x6d,
+	0x1a, 0x11, 0x2e, 0x76, 0x69, 0x72, 0x73, 0x68, 0x2e, 0x4f, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x29, 0x0a, 0x09, 0x52, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x56, 0x4d,
+	0x12, 0x09, 0x2e, 0x76, 0x69, 0x72, 0x73, 0x68, 0x2e, 0x56, 0x6d, 0x1a, 0x11, 0x2e, 0x76, 0x69,
+	0x72, 0x73, 0x68, 0x2e, 0x4f, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x27,
+	0x0a, 0x07, 0x50, 0x61, 0x75, 0x73, 0x65, 0x56, 0x4d, 0x12, 0x09, 0x2e, 0x76, 0x69, 0x72, 0x73,
+	0x68, 0x2e, 0x56, 0x6d, 0x1a, 0x11, 0x2e, 0x76, 0x69, 0x72, 0x73, 0x68, 0x2e, 0x4f, 0x6b, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x28, 0x0a, 0x08, 0x52, 0x65, 0x73, 0x75, 0x6d,
+	0x65, 0x56, 0x4d, 0x12, 0x09, 0x2e, 0x76, 0x69, 0x72, 0x73, 0x68, 0x2e, 0x56, 0x6d, 0x1a, 0x11,
 	0x2e, 0x76, 0x69, 0x72, 0x73, 0x68, 0x2e, 0x4f, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x42, 0x33, 0x5a, 0x31, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
-	0x4d, 0x61, 0x72, 0x75, 0x71, 0x65, 0x73, 0x2f, 0x35, 0x31, 0x32, 0x53, 0x76, 0x4d, 0x61, 0x6e,
-	0x2f, 0x61, 0x70, 0x69, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x76, 0x69, 0x72, 0x73, 0x68,
-	0x3b, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x65, 0x12, 0x33, 0x0a, 0x09, 0x47, 0x65, 0x74, 0x41, 0x6c, 0x6c, 0x56, 0x6d, 0x73, 0x12, 0x0c,
+	0x2e, 0x76, 0x69, 0x72, 0x73, 0x68, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x18, 0x2e, 0x76,
+	0x69, 0x72, 0x73, 0x68, 0x2e, 0x47, 0x65, 0x74, 0x41, 0x6c, 0x6c, 0x56, 0x6d, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x33, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x56, 0x6d, 0x42,
+	0x79, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x19, 0x2e, 0x76, 0x69, 0x72, 0x73, 0x68, 0x2e, 0x47, 0x65,
+	0x74, 0x56, 0x6d, 0x42, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x09, 0x2e, 0x76, 0x69, 0x72, 0x73, 0x68, 0x2e, 0x56, 0x6d, 0x12, 0x2f, 0x0a, 0x0f, 0x52,
+	0x65, 0x6d, 0x6f, 0x76, 0x65, 0x49, 0x73, 0x6f, 0x46, 0x72, 0x6f, 0x6d, 0x56, 0x6d, 0x12, 0x09,
+	0x2e, 0x76, 0x69, 0x72, 0x73, 0x68, 0x2e, 0x56, 0x6d, 0x1a, 0x11, 0x2e, 0x76, 0x69, 0x72, 0x73,
+	0x68, 0x2e, 0x4f, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2f, 0x0a, 0x0f,
+	0x45, 0x64, 0x69, 0x74, 0x56, 0x6d, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x12,
+	0x09, 0x2e, 0x76, 0x69, 0x72, 0x73, 0x68, 0x2e, 0x56, 0x6d, 0x1a, 0x11, 0x2e, 0x76, 0x69, 0x72,
+	0x73, 0x68, 0x2e, 0x4f, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3f, 0x0a,
+	0x0e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x12,
+	0x1c, 0x2e, 0x76, 0x69, 0x72, 0x73, 0x68, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x6e,
+	0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0f, 0x2e,
+	0x76, 0x69, 0x72, 0x73, 0x68, 0x2e, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x12, 0x48,
+	0x0a, 0x0d, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x73, 0x12,
+	0x19, 0x2e, 0x76, 0x69, 0x72, 0x73, 0x68, 0x2e, 0x47, 0x65, 0x74, 0x56, 0x6d, 0x42, 0x79, 0x4e,
+	0x61, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x76, 0x69, 0x72,
+	0x73, 0x68, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3b, 0x0a, 0x0e, 0x52, 0x65, 0x76, 0x65,
+	0x72, 0x74, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x12, 0x16, 0x2e, 0x76, 0x69, 0x72,
+	0x73, 0x68, 0x2e, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x11, 0x2e, 0x76, 0x69, 0x72, 0x73, 0x68, 0x2e, 0x4f, 0x6b, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3b, 0x0a, 0x0e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x53,
+	0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x12, 0x16, 0x2e, 0x76, 0x69, 0x72, 0x73, 0x68, 0x2e,
+	0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x11, 0x2e, 0x76, 0x69, 0x72, 0x73, 0x68, 0x2e, 0x4f, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x41, 0x0a, 0x0e, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x65, 0x53, 0x6e, 0x61, 0x70,
+	0x73, 0x68, 0x6f, 0x74, 0x12, 0x1c, 0x2e, 0x76, 0x69, 0x72, 0x73, 0x68, 0x2e, 0x44, 0x65, 0x66,
+	0x69, 0x6e, 0x65, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x11, 0x2e, 0x76, 0x69, 0x72, 0x73, 0x68, 0x2e, 0x4f, 0x6b, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2e, 0x0a, 0x07, 0x41, 0x64, 0x64, 0x44, 0x69, 0x73, 0x6b,
+	0x12, 0x14, 0x2e, 0x76, 0x69, 0x72, 0x73, 0x68, 0x2e, 0x56, 0x6d, 0x44, 0x69, 0x73, 0x6b, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0d, 0x2e, 0x76, 0x69, 0x72, 0x73, 0x68, 0x2e, 0x56,
+	0x6d, 0x44, 0x69, 0x73, 0x6b, 0x12, 0x31, 0x0a, 0x0a, 0x41, 0x74, 0x74, 0x61, 0x63, 0x68, 0x44,
+	0x69, 0x73, 0x6b, 0x12, 0x14, 0x2e, 0x76, 0x69, 0x72, 0x73, 0x68, 0x2e, 0x56, 0x6d, 0x44, 0x69,
+	0x73, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0d, 0x2e, 0x76, 0x69, 0x72, 0x73,
+	0x68, 0x2e, 0x56, 0x6d, 0x44, 0x69, 0x73, 0x6b, 0x12, 0x35, 0x0a, 0x0a, 0x44, 0x65, 0x74, 0x61,
+	0x63, 0x68, 0x44, 0x69, 0x73, 0x6b, 0x12, 0x14, 0x2e, 0x76, 0x69, 0x72, 0x73, 0x68, 0x2e, 0x56,
+	0x6d, 0x44, 0x69, 0x73, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x76,
+	0x69, 0x72, 0x73, 0x68, 0x2e, 0x4f, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x31, 0x0a, 0x0a, 0x52, 0x65, 0x73, 0x69, 0x7a, 0x65, 0x44, 0x69, 0x73, 0x6b, 0x12, 0x14, 0x2e,
+	0x76, 0x69, 0x72, 0x73, 0x68, 0x2e, 0x56, 0x6d, 0x44, 0x69, 0x73, 0x6b, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x0d, 0x2e, 0x76, 0x69, 0x72, 0x73, 0x68, 0x2e, 0x56, 0x6d, 0x44, 0x69,
+	0x73, 0x6b, 0x12, 0x43, 0x0a, 0x0d, 0x4d, 0x6f, 0x76, 0x65, 0x56, 0x6d, 0x53, 0x74, 0x6f, 0x72,
+	0x61, 0x67, 0x65, 0x12, 0x14, 0x2e, 0x76, 0x69, 0x72, 0x73, 0x68, 0x2e, 0x56, 0x6d, 0x44, 0x69,
+	0x73, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x76, 0x69, 0x72, 0x73,
+	0x68, 0x2e, 0x4d, 0x6f, 0x76, 0x65, 0x56, 0x6d, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x49, 0x0a, 0x13, 0x47, 0x65, 0x74, 0x42, 0x61,
+	0x63, 0x6b, 0x69, 0x6e, 0x67, 0x46, 0x69, 0x6c, 0x65, 0x55, 0x73, 0x65, 0x72, 0x73, 0x12, 0x19,
+	0x2e, 0x76, 0x69, 0x72, 0x73, 0x68, 0x2e, 0x42, 0x61, 0x63, 0x6b, 0x69, 0x6e, 0x67, 0x46, 0x69,
+	0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x76, 0x69, 0x72, 0x73,
+	0x68, 0x2e, 0x42, 0x61, 0x63, 0x6b, 0x69, 0x6e, 0x67, 0x46, 0x69, 0x6c, 0x65, 0x55, 0x73, 0x65,
+	0x72, 0x73, 0x12, 0x3b, 0x0a, 0x08, 0x42, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x56, 0x4d, 0x12, 0x16,
+	0x2e, 0x76, 0x69, 0x72, 0x73, 0x68, 0x2e, 0x42, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x56, 0x6d, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x76, 0x69, 0x72, 0x73, 0x68, 0x2e, 0x42,
+	0x61, 0x63, 0x6b, 0x75, 0x70, 0x56, 0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x3f, 0x0a, 0x0d, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x42, 0x61, 0x63, 0x6b, 0x75, 0x70,
+	0x12, 0x1b, 0x2e, 0x76, 0x69, 0x72, 0x73, 0x68, 0x2e, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65,
+	0x42, 0x61, 0x63, 0x6b, 0x75, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e,
+	0x76, 0x69, 0x72, 0x73, 0x68, 0x2e, 0x4f, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x3d, 0x0a, 0x0c, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x42, 0x61, 0x63, 0x6b, 0x75, 0x70,
+	0x12, 0x1a, 0x2e, 0x76, 0x69, 0x72, 0x73, 0x68, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x42,
+	0x61, 0x63, 0x6b, 0x75, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x76,
+	0x69, 0x72, 0x73, 0x68, 0x2e, 0x4f, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x2e, 0x0a, 0x09, 0x41, 0x74, 0x74, 0x61, 0x63, 0x68, 0x4e, 0x69, 0x63, 0x12, 0x13, 0x2e, 0x76,
+	0x69, 0x72, 0x73, 0x68, 0x2e, 0x56, 0x6d, 0x4e, 0x69, 0x63, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x0c, 0x2e, 0x76, 0x69, 0x72, 0x73, 0x68, 0x2e, 0x56, 0x6d, 0x4e, 0x69, 0x63, 0x12,
+	0x33, 0x0a, 0x09, 0x44, 0x65, 0x74, 0x61, 0x63, 0x68, 0x4e, 0x69, 0x63, 0x12, 0x13, 0x2e, 0x76,
+	0x69, 0x72, 0x73, 0x68, 0x2e, 0x56, 0x6d, 0x4e, 0x69, 0x63, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x11, 0x2e, 0x76, 0x69, 0x72, 0x73, 0x68, 0x2e, 0x4f, 0x6b, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3b, 0x0a, 0x0c, 0x47, 0x65, 0x74, 0x47, 0x75, 0x65, 0x73, 0x74,
+	0x49, 0x6e, 0x66, 0x6f, 0x12, 0x19, 0x2e, 0x76, 0x69, 0x72, 0x73, 0x68, 0x2e, 0x47, 0x65, 0x74,
+	0x56, 0x6d, 0x42, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x10, 0x2e, 0x76, 0x69, 0x72, 0x73, 0x68, 0x2e, 0x47, 0x75, 0x65, 0x73, 0x74, 0x49, 0x6e, 0x66,
+	0x6f, 0x12, 0x3e, 0x0a, 0x09, 0x47, 0x75, 0x65, 0x73, 0x74, 0x45, 0x78, 0x65, 0x63, 0x12, 0x17,
+	0x2e, 0x76, 0x69, 0x72, 0x73, 0x68, 0x2e, 0x47, 0x75, 0x65, 0x73, 0x74, 0x45, 0x78, 0x65, 0x63,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x76, 0x69, 0x72, 0x73, 0x68, 0x2e,
+	0x47, 0x75, 0x65, 0x73, 0x74, 0x45, 0x78, 0x65, 0x63, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x40, 0x0a, 0x10, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x47, 0x75, 0x65, 0x73, 0x74,
+	0x41, 0x67, 0x65, 0x6e, 0x74, 0x12, 0x19, 0x2e, 0x76, 0x69, 0x72, 0x73, 0x68, 0x2e, 0x47, 0x65,
+	0x74, 0x56, 0x6d, 0x42, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x11, 0x2e, 0x76, 0x69, 0x72, 0x73, 0x68, 0x2e, 0x4f, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x3b, 0x0a, 0x0d, 0x53, 0x65, 0x72, 0x69, 0x61, 0x6c, 0x43, 0x6f, 0x6e,
+	0x73, 0x6f, 0x6c, 0x65, 0x12, 0x12, 0x2e, 0x76, 0x69, 0x72, 0x73, 0x68, 0x2e, 0x43, 0x6f, 0x6e,
+	0x73, 0x6f, 0x6c, 0x65, 0x44, 0x61, 0x74, 0x61, 0x1a, 0x12, 0x2e, 0x76, 0x69, 0x72, 0x73, 0x68,
+	0x2e, 0x43, 0x6f, 0x6e, 0x73, 0x6f, 0x6c, 0x65, 0x44, 0x61, 0x74, 0x61, 0x28, 0x01, 0x30, 0x01,
+	0x12, 0x30, 0x0a, 0x07, 0x53, 0x65, 0x74, 0x56, 0x6d, 0x48, 0x41, 0x12, 0x12, 0x2e, 0x76, 0x69,
+	0x72, 0x73, 0x68, 0x2e, 0x48, 0x61, 0x56, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x11, 0x2e, 0x76, 0x69, 0x72, 0x73, 0x68, 0x2e, 0x4f, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x32, 0x0a, 0x09, 0x52, 0x65, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x56, 0x4d, 0x12,
+	0x12, 0x2e, 0x76, 0x69, 0x72, 0x73, 0x68, 0x2e, 0x48, 0x61, 0x56, 0x6d, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x76, 0x69, 0x72, 0x73, 0x68, 0x2e, 0x4f, 0x6b, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x32, 0x0a, 0x09, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73,
+	0x65, 0x56, 0x4d, 0x12, 0x12, 0x2e, 0x76, 0x69, 0x72, 0x73, 0x68, 0x2e, 0x48, 0x61, 0x56, 0x6d,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x76, 0x69, 0x72, 0x73, 0x68, 0x2e,
+	0x4f, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x36, 0x0a, 0x10, 0x47, 0x65,
+	0x74, 0x48, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x12, 0x0c,
+	0x2e, 0x76, 0x69, 0x72, 0x73, 0x68, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x14, 0x2e, 0x76,
+	0x69, 0x72, 0x73, 0x68, 0x2e, 0x48, 0x6f, 0x73, 0x74, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x73, 0x42, 0x33, 0x5a, 0x31, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d,
+	0x2f, 0x4d, 0x61, 0x72, 0x75, 0x71, 0x65, 0x73, 0x2f, 0x35, 0x31, 0x32, 0x53, 0x76, 0x4d, 0x61,
+	0x6e, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x76, 0x69, 0x72, 0x73,
+	0x68, 0x3b, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -873,61 +3456,168 @@ func file_virsh_proto_rawDescGZIP() []byte {
 }
 
 var file_virsh_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_virsh_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_virsh_proto_msgTypes = make([]protoimpl.MessageInfo, 40)
 var file_virsh_proto_goTypes = []interface{}{
 	(VmState)(0),                   // 0: virsh.VmState
 	(*Empty)(nil),                  // 1: virsh.Empty
 	(*GetCpuFeaturesResponse)(nil), // 2: virsh.GetCpuFeaturesResponse
 	(*CreateVmRequest)(nil),        // 3: virsh.CreateVmRequest
-	(*OkResponse)(nil),             // 4: virsh.OkResponse
-	(*Vm)(nil),                     // 5: virsh.Vm
-	(*GetVmByNameRequest)(nil),     // 6: virsh.GetVmByNameRequest
-	(*GetAllVmsResponse)(nil),      // 7: virsh.GetAllVmsResponse
-	(*CreateVmLiveRequest)(nil),    // 8: virsh.CreateVmLiveRequest
-	(*MigrateVmRequest)(nil),       // 9: virsh.MigrateVmRequest
-	(*CPUXMLResponse)(nil),         // 10: virsh.CPUXMLResponse
+	(*CloudInit)(nil),              // 4: virsh.CloudInit
+	(*OkResponse)(nil),             // 5: virsh.OkResponse
+	(*Vm)(nil),                     // 6: virsh.Vm
+	(*VmNic)(nil),                  // 7: virsh.VmNic
+	(*VmNicRequest)(nil),           // 8: virsh.VmNicRequest
+	(*VmDisk)(nil),                 // 9: virsh.VmDisk
+	(*MoveVmStorageResponse)(nil),  // 10: virsh.MoveVmStorageResponse
+	(*BackingFileRequest)(nil),     // 11: virsh.BackingFileRequest
+	(*BackingFileUsers)(nil),       // 12: virsh.BackingFileUsers
+	(*VmDiskRequest)(nil),          // 13: virsh.VmDiskRequest
+	(*GetVmByNameRequest)(nil),     // 14: virsh.GetVmByNameRequest
+	(*GetAllVmsResponse)(nil),      // 15: virsh.GetAllVmsResponse
+	(*CreateVmLiveRequest)(nil),    // 16: virsh.CreateVmLiveRequest
+	(*MigrateVmRequest)(nil),       // 17: virsh.MigrateVmRequest
+	(*CPUXMLResponse)(nil),         // 18: virsh.CPUXMLResponse
+	(*Snapshot)(nil),               // 19: virsh.Snapshot
+	(*CreateSnapshotRequest)(nil),  // 20: virsh.CreateSnapshotRequest
+	(*SnapshotRequest)(nil),        // 21: virsh.SnapshotRequest
+	(*ListSnapshotsResponse)(nil),  // 22: virsh.ListSnapshotsResponse
+	(*CloneVmRequest)(nil),         // 23: virsh.CloneVmRequest
+	(*BackupDisk)(nil),             // 24: virsh.BackupDisk
+	(*BackupVmRequest)(nil),        // 25: virsh.BackupVmRequest
+	(*BackupVmResponse)(nil),       // 26: virsh.BackupVmResponse
+	(*RestoreBackupRequest)(nil),   // 27: virsh.RestoreBackupRequest
+	(*DeleteBackupRequest)(nil),    // 28: virsh.DeleteBackupRequest
+	(*TrashVmRequest)(nil),         // 29: virsh.TrashVmRequest
+	(*TrashFile)(nil),              // 30: virsh.TrashFile
+	(*TrashedVm)(nil),              // 31: virsh.TrashedVm
+	(*DefineSnapshotRequest)(nil),  // 32: virsh.DefineSnapshotRequest
+	(*HaVmRequest)(nil),            // 33: virsh.HaVmRequest
+	(*GuestInfo)(nil),              // 34: virsh.GuestInfo
+	(*GuestInterface)(nil),         // 35: virsh.GuestInterface
+	(*GuestFilesystem)(nil),        // 36: virsh.GuestFilesystem
+	(*GuestExecRequest)(nil),       // 37: virsh.GuestExecRequest
+	(*GuestExecResponse)(nil),      // 38: virsh.GuestExecResponse
+	(*ConsoleData)(nil),            // 39: virsh.ConsoleData
+	(*HostResources)(nil),          // 40: virsh.HostResources
 }
 var file_virsh_proto_depIdxs = []int32{
-	0,  // 0: virsh.Vm.state:type_name -> virsh.VmState
-	5,  // 1: virsh.GetAllVmsResponse.vms:type_name -> virsh.Vm
-	3,  // 2: virsh.CreateVmLiveRequest.vm:type_name -> virsh.CreateVmRequest
-	1,  // 3: virsh.SlaveVirshService.GetCpuFeatures:input_type -> virsh.Empty
-	1,  // 4: virsh.SlaveVirshService.GetCPUXML:input_type -> virsh.Empty
-	3,  // 5: virsh.SlaveVirshService.CreateVm:input_type -> virsh.CreateVmRequest
-	8,  // 6: virsh.SlaveVirshService.CreateLiveVM:input_type -> virsh.CreateVmLiveRequest
-	9,  // 7: virsh.SlaveVirshService.MigrateVM:input_type -> virsh.MigrateVmRequest
-	5,  // 8: virsh.SlaveVirshService.ShutdownVM:input_type -> virsh.Vm
-	5,  // 9: virsh.SlaveVirshService.ForceShutdownVM:input_type -> virsh.Vm
-	5,  // 10: virsh.SlaveVirshService.StartVM:input_type -> virsh.Vm
-	5,  // 11: virsh.SlaveVirshService.RemoveVM:input_type -> virsh.Vm
-	5,  // 12: virsh.SlaveVirshService.RestartVM:input_type -> virsh.Vm
-	5,  // 13: virsh.SlaveVirshService.PauseVM:input_type -> virsh.Vm
-	5,  // 14: virsh.SlaveVirshService.ResumeVM:input_type -> virsh.Vm
-	1,  // 15: virsh.SlaveVirshService.GetAllVms:input_type -> virsh.Empty
-	6,  // 16: virsh.SlaveVirshService.GetVmByName:input_type -> virsh.GetVmByNameRequest
-	5,  // 17: virsh.SlaveVirshService.RemoveIsoFromVm:input_type -> virsh.Vm
-	5,  // 18: virsh.SlaveVirshService.EditVmResources:input_type -> virsh.Vm
-	2,  // 19: virsh.SlaveVirshService.GetCpuFeatures:output_type -> virsh.GetCpuFeaturesResponse
-	10, // 20: virsh.SlaveVirshService.GetCPUXML:output_type -> virsh.CPUXMLResponse
-	4,  // 21: virsh.SlaveVirshService.CreateVm:output_type -> virsh.OkResponse
-	4,  // 22: virsh.SlaveVirshService.CreateLiveVM:output_type -> virsh.OkResponse
-	4,  // 23: virsh.SlaveVirshService.MigrateVM:output_type -> virsh.OkResponse
-	4,  // 24: virsh.SlaveVirshService.ShutdownVM:output_type -> virsh.OkResponse
-	4,  // 25: virsh.SlaveVirshService.ForceShutdownVM:output_type -> virsh.OkResponse
-	4,  // 26: virsh.SlaveVirshService.StartVM:output_type -> virsh.OkResponse
-	4,  // 27: virsh.SlaveVirshService.RemoveVM:output_type -> virsh.OkResponse
-	4,  // 28: virsh.SlaveVirshService.RestartVM:output_type -> virsh.OkResponse
-	4,  // 29: virsh.SlaveVirshService.PauseVM:output_type -> virsh.OkResponse
-	4,  // 30: virsh.SlaveVirshService.ResumeVM:output_type -> virsh.OkResponse
-	7,  // 31: virsh.SlaveVirshService.GetAllVms:output_type -> virsh.GetAllVmsResponse
-	5,  // 32: virsh.SlaveVirshService.GetVmByName:output_type -> virsh.Vm
-	4,  // 33: virsh.SlaveVirshService.RemoveIsoFromVm:output_type -> virsh.OkResponse
-	4,  // 34: virsh.SlaveVirshService.EditVmResources:output_type -> virsh.OkResponse
-	19, // [19:35] is the sub-list for method output_type
-	3,  // [3:19] is the sub-list for method input_type
-	3,  // [3:3] is the sub-list for extension type_name
-	3,  // [3:3] is the sub-list for extension extendee
-	0,  // [0:3] is the sub-list for field type_name
+	4,  // 0: virsh.CreateVmRequest.cloud_init:type_name -> virsh.CloudInit
+	7,  // 1: virsh.CreateVmRequest.nics:type_name -> virsh.VmNic
+	0,  // 2: virsh.Vm.state:type_name -> virsh.VmState
+	9,  // 3: virsh.Vm.disks:type_name -> virsh.VmDisk
+	7,  // 4: virsh.Vm.nics:type_name -> virsh.VmNic
+	7,  // 5: virsh.VmNicRequest.nic:type_name -> virsh.VmNic
+	9,  // 6: virsh.MoveVmStorageResponse.moved:type_name -> virsh.VmDisk
+	6,  // 7: virsh.GetAllVmsResponse.vms:type_name -> virsh.Vm
+	3,  // 8: virsh.CreateVmLiveRequest.vm:type_name -> virsh.CreateVmRequest
+	19, // 9: virsh.ListSnapshotsResponse.snapshots:type_name -> virsh.Snapshot
+	24, // 10: virsh.BackupVmRequest.parentDisks:type_name -> virsh.BackupDisk
+	24, // 11: virsh.BackupVmResponse.disks:type_name -> virsh.BackupDisk
+	24, // 12: virsh.RestoreBackupRequest.disks:type_name -> virsh.BackupDisk
+	30, // 13: virsh.TrashedVm.files:type_name -> virsh.TrashFile
+	35, // 14: virsh.GuestInfo.interfaces:type_name -> virsh.GuestInterface
+	36, // 15: virsh.GuestInfo.filesystems:type_name -> virsh.GuestFilesystem
+	1,  // 16: virsh.SlaveVirshService.GetCpuFeatures:input_type -> virsh.Empty
+	1,  // 17: virsh.SlaveVirshService.GetCPUXML:input_type -> virsh.Empty
+	14, // 18: virsh.SlaveVirshService.GetVmCPUXML:input_type -> virsh.GetVmByNameRequest
+	18, // 19: virsh.SlaveVirshService.CompareCPU:input_type -> virsh.CPUXMLResponse
+	3,  // 20: virsh.SlaveVirshService.CreateVm:input_type -> virsh.CreateVmRequest
+	16, // 21: virsh.SlaveVirshService.CreateLiveVM:input_type -> virsh.CreateVmLiveRequest
+	23, // 22: virsh.SlaveVirshService.CloneVM:input_type -> virsh.CloneVmRequest
+	17, // 23: virsh.SlaveVirshService.MigrateVM:input_type -> virsh.MigrateVmRequest
+	14, // 24: virsh.SlaveVirshService.CancelMigration:input_type -> virsh.GetVmByNameRequest
+	17, // 25: virsh.SlaveVirshService.SetMigrationLimits:input_type -> virsh.MigrateVmRequest
+	6,  // 26: virsh.SlaveVirshService.ShutdownVM:input_type -> virsh.Vm
+	6,  // 27: virsh.SlaveVirshService.ForceShutdownVM:input_type -> virsh.Vm
+	6,  // 28: virsh.SlaveVirshService.StartVM:input_type -> virsh.Vm
+	6,  // 29: virsh.SlaveVirshService.RemoveVM:input_type -> virsh.Vm
+	29, // 30: virsh.SlaveVirshService.TrashVM:input_type -> virsh.TrashVmRequest
+	31, // 31: virsh.SlaveVirshService.RestoreTrashedVM:input_type -> virsh.TrashedVm
+	31, // 32: virsh.SlaveVirshService.PurgeTrashedVM:input_type -> virsh.TrashedVm
+	6,  // 33: virsh.SlaveVirshService.RestartVM:input_type -> virsh.Vm
+	6,  // 34: virsh.SlaveVirshService.PauseVM:input_type -> virsh.Vm
+	6,  // 35: virsh.SlaveVirshService.ResumeVM:input_type -> virsh.Vm
+	1,  // 36: virsh.SlaveVirshService.GetAllVms:input_type -> virsh.Empty
+	14, // 37: virsh.SlaveVirshService.GetVmByName:input_type -> virsh.GetVmByNameRequest
+	6,  // 38: virsh.SlaveVirshService.RemoveIsoFromVm:input_type -> virsh.Vm
+	6,  // 39: virsh.SlaveVirshService.EditVmResources:input_type -> virsh.Vm
+	20, // 40: virsh.SlaveVirshService.CreateSnapshot:input_type -> virsh.CreateSnapshotRequest
+	14, // 41: virsh.SlaveVirshService.ListSnapshots:input_type -> virsh.GetVmByNameRequest
+	21, // 42: virsh.SlaveVirshService.RevertSnapshot:input_type -> virsh.SnapshotRequest
+	21, // 43: virsh.SlaveVirshService.DeleteSnapshot:input_type -> virsh.SnapshotRequest
+	32, // 44: virsh.SlaveVirshService.DefineSnapshot:input_type -> virsh.DefineSnapshotRequest
+	13, // 45: virsh.SlaveVirshService.AddDisk:input_type -> virsh.VmDiskRequest
+	13, // 46: virsh.SlaveVirshService.AttachDisk:input_type -> virsh.VmDiskRequest
+	13, // 47: virsh.SlaveVirshService.DetachDisk:input_type -> virsh.VmDiskRequest
+	13, // 48: virsh.SlaveVirshService.ResizeDisk:input_type -> virsh.VmDiskRequest
+	13, // 49: virsh.SlaveVirshService.MoveVmStorage:input_type -> virsh.VmDiskRequest
+	11, // 50: virsh.SlaveVirshService.GetBackingFileUsers:input_type -> virsh.BackingFileRequest
+	25, // 51: virsh.SlaveVirshService.BackupVM:input_type -> virsh.BackupVmRequest
+	27, // 52: virsh.SlaveVirshService.RestoreBackup:input_type -> virsh.RestoreBackupRequest
+	28, // 53: virsh.SlaveVirshService.DeleteBackup:input_type -> virsh.DeleteBackupRequest
+	8,  // 54: virsh.SlaveVirshService.AttachNic:input_type -> virsh.VmNicRequest
+	8,  // 55: virsh.SlaveVirshService.DetachNic:input_type -> virsh.VmNicRequest
+	14, // 56: virsh.SlaveVirshService.GetGuestInfo:input_type -> virsh.GetVmByNameRequest
+	37, // 57: virsh.SlaveVirshService.GuestExec:input_type -> virsh.GuestExecRequest
+	14, // 58: virsh.SlaveVirshService.EnableGuestAgent:input_type -> virsh.GetVmByNameRequest
+	39, // 59: virsh.SlaveVirshService.SerialConsole:input_type -> virsh.ConsoleData
+	33, // 60: virsh.SlaveVirshService.SetVmHA:input_type -> virsh.HaVmRequest
+	33, // 61: virsh.SlaveVirshService.RecoverVM:input_type -> virsh.HaVmRequest
+	33, // 62: virsh.SlaveVirshService.ReleaseVM:input_type -> virsh.HaVmRequest
+	1,  // 63: virsh.SlaveVirshService.GetHostResources:input_type -> virsh.Empty
+	2,  // 64: virsh.SlaveVirshService.GetCpuFeatures:output_type -> virsh.GetCpuFeaturesResponse
+	18, // 65: virsh.SlaveVirshService.GetCPUXML:output_type -> virsh.CPUXMLResponse
+	18, // 66: virsh.SlaveVirshService.GetVmCPUXML:output_type -> virsh.CPUXMLResponse
+	5,  // 67: virsh.SlaveVirshService.CompareCPU:output_type -> virsh.OkResponse
+	5,  // 68: virsh.SlaveVirshService.CreateVm:output_type -> virsh.OkResponse
+	5,  // 69: virsh.SlaveVirshService.CreateLiveVM:output_type -> virsh.OkResponse
+	5,  // 70: virsh.SlaveVirshService.CloneVM:output_type -> virsh.OkResponse
+	5,  // 71: virsh.SlaveVirshService.MigrateVM:output_type -> virsh.OkResponse
+	5,  // 72: virsh.SlaveVirshService.CancelMigration:output_type -> virsh.OkResponse
+	5,  // 73: virsh.SlaveVirshService.SetMigrationLimits:output_type -> virsh.OkResponse
+	5,  // 74: virsh.SlaveVirshService.ShutdownVM:output_type -> virsh.OkResponse
+	5,  // 75: virsh.SlaveVirshService.ForceShutdownVM:output_type -> virsh.OkResponse
+	5,  // 76: virsh.SlaveVirshService.StartVM:output_type -> virsh.OkResponse
+	5,  // 77: virsh.SlaveVirshService.RemoveVM:output_type -> virsh.OkResponse
+	31, // 78: virsh.SlaveVirshService.TrashVM:output_type -> virsh.TrashedVm
+	5,  // 79: virsh.SlaveVirshService.RestoreTrashedVM:output_type -> virsh.OkResponse
+	5,  // 80: virsh.SlaveVirshService.PurgeTrashedVM:output_type -> virsh.OkResponse
+	5,  // 81: virsh.SlaveVirshService.RestartVM:output_type -> virsh.OkResponse
+	5,  // 82: virsh.SlaveVirshService.PauseVM:output_type -> virsh.OkResponse
+	5,  // 83: virsh.SlaveVirshService.ResumeVM:output_type -> virsh.OkResponse
+	15, // 84: virsh.SlaveVirshService.GetAllVms:output_type -> virsh.GetAllVmsResponse
+	6,  // 85: virsh.SlaveVirshService.GetVmByName:output_type -> virsh.Vm
+	5,  // 86: virsh.SlaveVirshService.RemoveIsoFromVm:output_type -> virsh.OkResponse
+	5,  // 87: virsh.SlaveVirshService.EditVmResources:output_type -> virsh.OkResponse
+	19, // 88: virsh.SlaveVirshService.CreateSnapshot:output_type -> virsh.Snapshot
+	22, // 89: virsh.SlaveVirshService.ListSnapshots:output_type -> virsh.ListSnapshotsResponse
+	5,  // 90: virsh.SlaveVirshService.RevertSnapshot:output_type -> virsh.OkResponse
+	5,  // 91: virsh.SlaveVirshService.DeleteSnapshot:output_type -> virsh.OkResponse
+	5,  // 92: virsh.SlaveVirshService.DefineSnapshot:output_type -> virsh.OkResponse
+	9,  // 93: virsh.SlaveVirshService.AddDisk:output_type -> virsh.VmDisk
+	9,  // 94: virsh.SlaveVirshService.AttachDisk:output_type -> virsh.VmDisk
+	5,  // 95: virsh.SlaveVirshService.DetachDisk:output_type -> virsh.OkResponse
+	9,  // 96: virsh.SlaveVirshService.ResizeDisk:output_type -> virsh.VmDisk
+	10, // 97: virsh.SlaveVirshService.MoveVmStorage:output_type -> virsh.MoveVmStorageResponse
+	12, // 98: virsh.SlaveVirshService.GetBackingFileUsers:output_type -> virsh.BackingFileUsers
+	26, // 99: virsh.SlaveVirshService.BackupVM:output_type -> virsh.BackupVmResponse
+	5,  // 100: virsh.SlaveVirshService.RestoreBackup:output_type -> virsh.OkResponse
+	5,  // 101: virsh.SlaveVirshService.DeleteBackup:output_type -> virsh.OkResponse
+	7,  // 102: virsh.SlaveVirshService.AttachNic:output_type -> virsh.VmNic
+	5,  // 103: virsh.SlaveVirshService.DetachNic:output_type -> virsh.OkResponse
+	34, // 104: virsh.SlaveVirshService.GetGuestInfo:output_type -> virsh.GuestInfo
+	38, // 105: virsh.SlaveVirshService.GuestExec:output_type -> virsh.GuestExecResponse
+	5,  // 106: virsh.SlaveVirshService.EnableGuestAgent:output_type -> virsh.OkResponse
+	39, // 107: virsh.SlaveVirshService.SerialConsole:output_type -> virsh.ConsoleData
+	5,  // 108: virsh.SlaveVirshService.SetVmHA:output_type -> virsh.OkResponse
+	5,  // 109: virsh.SlaveVirshService.RecoverVM:output_type -> virsh.OkResponse
+	5,  // 110: virsh.SlaveVirshService.ReleaseVM:output_type -> virsh.OkResponse
+	40, // 111: virsh.SlaveVirshService.GetHostResources:output_type -> virsh.HostResources
+	64, // [64:112] is the sub-list for method output_type
+	16, // [16:64] is the sub-list for method input_type
+	16, // [16:16] is the sub-list for extension type_name
+	16, // [16:16] is the sub-list for extension extendee
+	0,  // [0:16] is the sub-list for field type_name
 }
 
 func init() { file_virsh_proto_init() }
@@ -948,8 +3638,80 @@ func file_virsh_proto_init() {
 				return nil
 			}
 		}
-		file_virsh_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetCpuFeaturesResponse); i {
+		file_virsh_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetCpuFeaturesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_virsh_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateVmRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_virsh_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CloudInit); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_virsh_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OkResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_virsh_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Vm); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_virsh_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VmNic); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_virsh_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VmNicRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -960,8 +3722,8 @@ func file_virsh_proto_init() {
 				return nil
 			}
 		}
-		file_virsh_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CreateVmRequest); i {
+		file_virsh_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VmDisk); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -972,8 +3734,8 @@ func file_virsh_proto_init() {
 				return nil
 			}
 		}
-		file_virsh_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*OkResponse); i {
+		file_virsh_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MoveVmStorageResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -984,8 +3746,8 @@ func file_virsh_proto_init() {
 				return nil
 			}
 		}
-		file_virsh_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Vm); i {
+		file_virsh_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BackingFileRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -996,7 +3758,31 @@ func file_virsh_proto_init() {
 				return nil
 			}
 		}
-		file_virsh_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+		file_virsh_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BackingFileUsers); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_virsh_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VmDiskRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_virsh_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*GetVmByNameRequest); i {
 			case 0:
 				return &v.state
@@ -1008,7 +3794,7 @@ func file_virsh_proto_init() {
 				return nil
 			}
 		}
-		file_virsh_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+		file_virsh_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*GetAllVmsResponse); i {
 			case 0:
 				return &v.state
@@ -1020,7 +3806,7 @@ func file_virsh_proto_init() {
 				return nil
 			}
 		}
-		file_virsh_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+		file_virsh_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*CreateVmLiveRequest); i {
 			case 0:
 				return &v.state
@@ -1032,7 +3818,7 @@ func file_virsh_proto_init() {
 				return nil
 			}
 		}
-		file_virsh_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+		file_virsh_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*MigrateVmRequest); i {
 			case 0:
 				return &v.state
@@ -1044,7 +3830,7 @@ func file_virsh_proto_init() {
 				return nil
 			}
 		}
-		file_virsh_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+		file_virsh_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*CPUXMLResponse); i {
 			case 0:
 				return &v.state
@@ -1056,6 +3842,270 @@ func file_virsh_proto_init() {
 				return nil
 			}
 		}
+		file_virsh_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Snapshot); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_virsh_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateSnapshotRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_virsh_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SnapshotRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_virsh_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListSnapshotsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_virsh_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CloneVmRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_virsh_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BackupDisk); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_virsh_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BackupVmRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_virsh_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BackupVmResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_virsh_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RestoreBackupRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_virsh_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteBackupRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_virsh_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TrashVmRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_virsh_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TrashFile); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_virsh_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TrashedVm); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_virsh_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DefineSnapshotRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_virsh_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HaVmRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_virsh_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GuestInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_virsh_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GuestInterface); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_virsh_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GuestFilesystem); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_virsh_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GuestExecRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_virsh_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GuestExecResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_virsh_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ConsoleData); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_virsh_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HostResources); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -1063,7 +4113,7 @@ func file_virsh_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_virsh_proto_rawDesc,
 			NumEnums:      1,
-			NumMessages:   10,
+			NumMessages:   40,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,22 +19,54 @@ import (
 const _ = grpc.SupportPackageIsVersion8
 
 const (
-	SlaveVirshService_GetCpuFeatures_FullMethodName  = "/virsh.SlaveVirshService/GetCpuFeatures"
-	SlaveVirshService_GetCPUXML_FullMethodName       = "/virsh.SlaveVirshService/GetCPUXML"
-	SlaveVirshService_CreateVm_FullMethodName        = "/virsh.SlaveVirshService/CreateVm"
-	SlaveVirshService_CreateLiveVM_FullMethodName    = "/virsh.SlaveVirshService/CreateLiveVM"
-	SlaveVirshService_MigrateVM_FullMethodName       = "/virsh.SlaveVirshService/MigrateVM"
-	SlaveVirshService_ShutdownVM_FullMethodName      = "/virsh.SlaveVirshService/ShutdownVM"
-	SlaveVirshService_ForceShutdownVM_FullMethodName = "/virsh.SlaveVirshService/ForceShutdownVM"
-	SlaveVirshService_StartVM_FullMethodName         = "/virsh.SlaveVirshService/StartVM"
-	SlaveVirshService_RemoveVM_FullMethodName        = "/virsh.SlaveVirshService/RemoveVM"
-	SlaveVirshService_RestartVM_FullMethodName       = "/virsh.SlaveVirshService/RestartVM"
-	SlaveVirshService_PauseVM_FullMethodName         = "/virsh.SlaveVirshService/PauseVM"
-	SlaveVirshService_ResumeVM_FullMethodName        = "/virsh.SlaveVirshService/ResumeVM"
-	SlaveVirshService_GetAllVms_FullMethodName       = "/virsh.SlaveVirshService/GetAllVms"
-	SlaveVirshService_GetVmByName_FullMethodName     = "/virsh.SlaveVirshService/GetVmByName"
-	SlaveVirshService_RemoveIsoFromVm_FullMethodName = "/virsh.SlaveVirshService/RemoveIsoFromVm"
-	SlaveVirshService_EditVmResources_FullMethodName = "/virsh.SlaveVirshService/EditVmResources"
+	SlaveVirshService_GetCpuFeatures_FullMethodName      = "/virsh.SlaveVirshService/GetCpuFeatures"
+	SlaveVirshService_GetCPUXML_FullMethodName           = "/virsh.SlaveVirshService/GetCPUXML"
+	SlaveVirshService_GetVmCPUXML_FullMethodName         = "/virsh.SlaveVirshService/GetVmCPUXML"
+	SlaveVirshService_CompareCPU_FullMethodName          = "/virsh.SlaveVirshService/CompareCPU"
+	SlaveVirshService_CreateVm_FullMethodName            = "/virsh.SlaveVirshService/CreateVm"
+	SlaveVirshService_CreateLiveVM_FullMethodName        = "/virsh.SlaveVirshService/CreateLiveVM"
+	SlaveVirshService_CloneVM_FullMethodName             = "/virsh.SlaveVirshService/CloneVM"
+	SlaveVirshService_MigrateVM_FullMethodName           = "/virsh.SlaveVirshService/MigrateVM"
+	SlaveVirshService_CancelMigration_FullMethodName     = "/virsh.SlaveVirshService/CancelMigration"
+	SlaveVirshService_SetMigrationLimits_FullMethodName  = "/virsh.SlaveVirshService/SetMigrationLimits"
+	SlaveVirshService_ShutdownVM_FullMethodName          = "/virsh.SlaveVirshService/ShutdownVM"
+	SlaveVirshService_ForceShutdownVM_FullMethodName     = "/virsh.SlaveVirshService/ForceShutdownVM"
+	SlaveVirshService_StartVM_FullMethodName             = "/virsh.SlaveVirshService/StartVM"
+	SlaveVirshService_RemoveVM_FullMethodName            = "/virsh.SlaveVirshService/RemoveVM"
+	SlaveVirshService_TrashVM_FullMethodName             = "/virsh.SlaveVirshService/TrashVM"
+	SlaveVirshService_RestoreTrashedVM_FullMethodName    = "/virsh.SlaveVirshService/RestoreTrashedVM"
+	SlaveVirshService_PurgeTrashedVM_FullMethodName      = "/virsh.SlaveVirshService/PurgeTrashedVM"
+	SlaveVirshService_RestartVM_FullMethodName           = "/virsh.SlaveVirshService/RestartVM"
+	SlaveVirshService_PauseVM_FullMethodName             = "/virsh.SlaveVirshService/PauseVM"
+	SlaveVirshService_ResumeVM_FullMethodName            = "/virsh.SlaveVirshService/ResumeVM"
+	SlaveVirshService_GetAllVms_FullMethodName           = "/virsh.SlaveVirshService/GetAllVms"
+	SlaveVirshService_GetVmByName_FullMethodName         = "/virsh.SlaveVirshService/GetVmByName"
+	SlaveVirshService_RemoveIsoFromVm_FullMethodName     = "/virsh.SlaveVirshService/RemoveIsoFromVm"
+	SlaveVirshService_EditVmResources_FullMethodName     = "/virsh.SlaveVirshService/EditVmResources"
+	SlaveVirshService_CreateSnapshot_FullMethodName      = "/virsh.SlaveVirshService/CreateSnapshot"
+	SlaveVirshService_ListSnapshots_FullMethodName       = "/virsh.SlaveVirshService/ListSnapshots"
+	SlaveVirshService_RevertSnapshot_FullMethodName      = "/virsh.SlaveVirshService/RevertSnapshot"
+	SlaveVirshService_DeleteSnapshot_FullMethodName      = "/virsh.SlaveVirshService/DeleteSnapshot"
+	SlaveVirshService_DefineSnapshot_FullMethodName      = "/virsh.SlaveVirshService/DefineSnapshot"
+	SlaveVirshService_AddDisk_FullMethodName             = "/virsh.SlaveVirshService/AddDisk"
+	SlaveVirshService_AttachDisk_FullMethodName          = "/virsh.SlaveVirshService/AttachDisk"
+	SlaveVirshService_DetachDisk_FullMethodName          = "/virsh.SlaveVirshService/DetachDisk"
+	SlaveVirshService_ResizeDisk_FullMethodName          = "/virsh.SlaveVirshService/ResizeDisk"
+	SlaveVirshService_MoveVmStorage_FullMethodName       = "/virsh.SlaveVirshService/MoveVmStorage"
+	SlaveVirshService_GetBackingFileUsers_FullMethodName = "/virsh.SlaveVirshService/GetBackingFileUsers"
+	SlaveVirshService_BackupVM_FullMethodName            = "/virsh.SlaveVirshService/BackupVM"
+	SlaveVirshService_RestoreBackup_FullMethodName       = "/virsh.SlaveVirshService/RestoreBackup"
+	SlaveVirshService_DeleteBackup_FullMethodName        = "/virsh.SlaveVirshService/DeleteBackup"
+	SlaveVirshService_AttachNic_FullMethodName           = "/virsh.SlaveVirshService/AttachNic"
+	SlaveVirshService_DetachNic_FullMethodName           = "/virsh.SlaveVirshService/DetachNic"
+	SlaveVirshService_GetGuestInfo_FullMethodName        = "/virsh.SlaveVirshService/GetGuestInfo"
+	SlaveVirshService_GuestExec_FullMethodName           = "/virsh.SlaveVirshService/GuestExec"
+	SlaveVirshService_EnableGuestAgent_FullMethodName    = "/virsh.SlaveVirshService/EnableGuestAgent"
+	SlaveVirshService_SerialConsole_FullMethodName       = "/virsh.SlaveVirshService/SerialConsole"
+	SlaveVirshService_SetVmHA_FullMethodName             = "/virsh.SlaveVirshService/SetVmHA"
+	SlaveVirshService_RecoverVM_FullMethodName           = "/virsh.SlaveVirshService/RecoverVM"
+	SlaveVirshService_ReleaseVM_FullMethodName           = "/virsh.SlaveVirshService/ReleaseVM"
+	SlaveVirshService_GetHostResources_FullMethodName    = "/virsh.SlaveVirshService/GetHostResources"
 )
 
 // SlaveVirshServiceClient is the client API for SlaveVirshService service.
@@ -43,13 +75,21 @@ const (
 type SlaveVirshServiceClient interface {
 	GetCpuFeatures(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*GetCpuFeaturesResponse, error)
 	GetCPUXML(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*CPUXMLResponse, error)
+	GetVmCPUXML(ctx context.Context, in *GetVmByNameRequest, opts ...grpc.CallOption) (*CPUXMLResponse, error)
+	CompareCPU(ctx context.Context, in *CPUXMLResponse, opts ...grpc.CallOption) (*OkResponse, error)
 	CreateVm(ctx context.Context, in *CreateVmRequest, opts ...grpc.CallOption) (*OkResponse, error)
 	CreateLiveVM(ctx context.Context, in *CreateVmLiveRequest, opts ...grpc.CallOption) (*OkResponse, error)
+	CloneVM(ctx context.Context, in *CloneVmRequest, opts ...grpc.CallOption) (*OkResponse, error)
 	MigrateVM(ctx context.Context, in *MigrateVmRequest, opts ...grpc.CallOption) (*OkResponse, error)
+	CancelMigration(ctx context.Context, in *GetVmByNameRequest, opts ...grpc.CallOption) (*OkResponse, error)
+	SetMigrationLimits(ctx context.Context, in *MigrateVmRequest, opts ...grpc.CallOption) (*OkResponse, error)
 	ShutdownVM(ctx context.Context, in *Vm, opts ...grpc.CallOption) (*OkResponse, error)
 	ForceShutdownVM(ctx context.Context, in *Vm, opts ...grpc.CallOption) (*OkResponse, error)
 	StartVM(ctx context.Context, in *Vm, opts ...grpc.CallOption) (*OkResponse, error)
 	RemoveVM(ctx context.Context, in *Vm, opts ...grpc.CallOption) (*OkResponse, error)
+	TrashVM(ctx context.Context, in *TrashVmRequest, opts ...grpc.CallOption) (*TrashedVm, error)
+	RestoreTrashedVM(ctx context.Context, in *TrashedVm, opts ...grpc.CallOption) (*OkResponse, error)
+	PurgeTrashedVM(ctx context.Context, in *TrashedVm, opts ...grpc.CallOption) (*OkResponse, error)
 	RestartVM(ctx context.Context, in *Vm, opts ...grpc.CallOption) (*OkResponse, error)
 	PauseVM(ctx context.Context, in *Vm, opts ...grpc.CallOption) (*OkResponse, error)
 	ResumeVM(ctx context.Context, in *Vm, opts ...grpc.CallOption) (*OkResponse, error)
@@ -59,6 +99,31 @@ type SlaveVirshServiceClient interface {
 	// only sees machine name, cpuCount and memoryMB
 	// cpuCount and memoryMB are the new values to set
 	EditVmResources(ctx context.Context, in *Vm, opts ...grpc.CallOption) (*OkResponse, error)
+	CreateSnapshot(ctx context.Context, in *CreateSnapshotRequest, opts ...grpc.CallOption) (*Snapshot, error)
+	ListSnapshots(ctx context.Context, in *GetVmByNameRequest, opts ...grpc.CallOption) (*ListSnapshotsResponse, error)
+	RevertSnapshot(ctx context.Context, in *SnapshotRequest, opts ...grpc.CallOption) (*OkResponse, error)
+	DeleteSnapshot(ctx context.Context, in *SnapshotRequest, opts ...grpc.CallOption) (*OkResponse, error)
+	// redefines snapshot metadata saved on master (ex: after a migration)
+	DefineSnapshot(ctx context.Context, in *DefineSnapshotRequest, opts ...grpc.CallOption) (*OkResponse, error)
+	AddDisk(ctx context.Context, in *VmDiskRequest, opts ...grpc.CallOption) (*VmDisk, error)
+	AttachDisk(ctx context.Context, in *VmDiskRequest, opts ...grpc.CallOption) (*VmDisk, error)
+	DetachDisk(ctx context.Context, in *VmDiskRequest, opts ...grpc.CallOption) (*OkResponse, error)
+	ResizeDisk(ctx context.Context, in *VmDiskRequest, opts ...grpc.CallOption) (*VmDisk, error)
+	MoveVmStorage(ctx context.Context, in *VmDiskRequest, opts ...grpc.CallOption) (*MoveVmStorageResponse, error)
+	GetBackingFileUsers(ctx context.Context, in *BackingFileRequest, opts ...grpc.CallOption) (*BackingFileUsers, error)
+	BackupVM(ctx context.Context, in *BackupVmRequest, opts ...grpc.CallOption) (*BackupVmResponse, error)
+	RestoreBackup(ctx context.Context, in *RestoreBackupRequest, opts ...grpc.CallOption) (*OkResponse, error)
+	DeleteBackup(ctx context.Context, in *DeleteBackupRequest, opts ...grpc.CallOption) (*OkResponse, error)
+	AttachNic(ctx context.Context, in *VmNicRequest, opts ...grpc.CallOption) (*VmNic, error)
+	DetachNic(ctx context.Context, in *VmNicRequest, opts ...grpc.CallOption) (*OkResponse, error)
+	GetGuestInfo(ctx context.Context, in *GetVmByNameRequest, opts ...grpc.CallOption) (*GuestInfo, error)
+	GuestExec(ctx context.Context, in *GuestExecRequest, opts ...grpc.CallOption) (*GuestExecResponse, error)
+	EnableGuestAgent(ctx context.Context, in *GetVmByNameRequest, opts ...grpc.CallOption) (*OkResponse, error)
+	SerialConsole(ctx context.Context, opts ...grpc.CallOption) (SlaveVirshService_SerialConsoleClient, error)
+	SetVmHA(ctx context.Context, in *HaVmRequest, opts ...grpc.CallOption) (*OkResponse, error)
+	RecoverVM(ctx context.Context, in *HaVmRequest, opts ...grpc.CallOption) (*OkResponse, error)
+	ReleaseVM(ctx context.Context, in *HaVmRequest, opts ...grpc.CallOption) (*OkResponse, error)
+	GetHostResources(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*HostResources, error)
 }
 
 type slaveVirshServiceClient struct {
@@ -89,6 +154,26 @@ func (c *slaveVirshServiceClient) GetCPUXML(ctx context.Context, in *Empty, opts
 	return out, nil
 }
 
+func (c *slaveVirshServiceClient) GetVmCPUXML(ctx context.Context, in *GetVmByNameRequest, opts ...grpc.CallOption) (*CPUXMLResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CPUXMLResponse)
+	err := c.cc.Invoke(ctx, SlaveVirshService_GetVmCPUXML_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *slaveVirshServiceClient) CompareCPU(ctx context.Context, in *CPUXMLResponse, opts ...grpc.CallOption) (*OkResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(OkResponse)
+	err := c.cc.Invoke(ctx, SlaveVirshService_CompareCPU_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *slaveVirshServiceClient) CreateVm(ctx context.Context, in *CreateVmRequest, opts ...grpc.CallOption) (*OkResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(OkResponse)
@@ -109,6 +194,16 @@ func (c *slaveVirshServiceClient) CreateLiveVM(ctx context.Context, in *CreateVm
 	return out, nil
 }
 
+func (c *slaveVirshServiceClient) CloneVM(ctx context.Context, in *CloneVmRequest, opts ...grpc.CallOption) (*OkResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(OkResponse)
+	err := c.cc.Invoke(ctx, SlaveVirshService_CloneVM_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *slaveVirshServiceClient) MigrateVM(ctx context.Context, in *MigrateVmRequest, opts ...grpc.CallOption) (*OkResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(OkResponse)
@@ -119,6 +214,26 @@ func (c *slaveVirshServiceClient) MigrateVM(ctx context.Context, in *MigrateVmRe
 	return out, nil
 }
 
+func (c *slaveVirshServiceClient) CancelMigration(ctx context.Context, in *GetVmByNameRequest, opts ...grpc.CallOption) (*OkResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(OkResponse)
+	err := c.cc.Invoke(ctx, SlaveVirshService_CancelMigration_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *slaveVirshServiceClient) SetMigrationLimits(ctx context.Context, in *MigrateVmRequest, opts ...grpc.CallOption) (*OkResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(OkResponse)
+	err := c.cc.Invoke(ctx, SlaveVirshService_SetMigrationLimits_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *slaveVirshServiceClient) ShutdownVM(ctx context.Context, in *Vm, opts ...grpc.CallOption) (*OkResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(OkResponse)
@@ -159,6 +274,36 @@ func (c *slaveVirshServiceClient) RemoveVM(ctx context.Context, in *Vm, opts ...
 	return out, nil
 }
 
+func (c *slaveVirshServiceClient) TrashVM(ctx context.Context, in *TrashVmRequest, opts ...grpc.CallOption) (*TrashedVm, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TrashedVm)
+	err := c.cc.Invoke(ctx, SlaveVirshService_TrashVM_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *slaveVirshServiceClient) RestoreTrashedVM(ctx context.Context, in *TrashedVm, opts ...grpc.CallOption) (*OkResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(OkResponse)
+	err := c.cc.Invoke(ctx, SlaveVirshService_RestoreTrashedVM_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *slaveVirshServiceClient) PurgeTrashedVM(ctx context.Context, in *TrashedVm, opts ...grpc.CallOption) (*OkResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(OkResponse)
+	err := c.cc.Invoke(ctx, SlaveVirshService_PurgeTrashedVM_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *slaveVirshServiceClient) RestartVM(ctx context.Context, in *Vm, opts ...grpc.CallOption) (*OkResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(OkResponse)
@@ -229,19 +374,289 @@ func (c *slaveVirshServiceClient) EditVmResources(ctx context.Context, in *Vm, o
 	return out, nil
 }
 
+func (c *slaveVirshServiceClient) CreateSnapshot(ctx context.Context, in *CreateSnapshotRequest, opts ...grpc.CallOption) (*Snapshot, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Snapshot)
+	err := c.cc.Invoke(ctx, SlaveVirshService_CreateSnapshot_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *slaveVirshServiceClient) ListSnapshots(ctx context.Context, in *GetVmByNameRequest, opts ...grpc.CallOption) (*ListSnapshotsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSnapshotsResponse)
+	err := c.cc.Invoke(ctx, SlaveVirshService_ListSnapshots_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *slaveVirshServiceClient) RevertSnapshot(ctx context.Context, in *SnapshotRequest, opts ...grpc.CallOption) (*OkResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(OkResponse)
+	err := c.cc.Invoke(ctx, SlaveVirshService_RevertSnapshot_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *slaveVirshServiceClient) DeleteSnapshot(ctx context.Context, in *SnapshotRequest, opts ...grpc.CallOption) (*OkResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(OkResponse)
+	err := c.cc.Invoke(ctx, SlaveVirshService_DeleteSnapshot_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *slaveVirshServiceClient) DefineSnapshot(ctx context.Context, in *DefineSnapshotRequest, opts ...grpc.CallOption) (*OkResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(OkResponse)
+	err := c.cc.Invoke(ctx, SlaveVirshService_DefineSnapshot_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *slaveVirshServiceClient) AddDisk(ctx context.Context, in *VmDiskRequest, opts ...grpc.CallOption) (*VmDisk, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VmDisk)
+	err := c.cc.Invoke(ctx, SlaveVirshService_AddDisk_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *slaveVirshServiceClient) AttachDisk(ctx context.Context, in *VmDiskRequest, opts ...grpc.CallOption) (*VmDisk, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VmDisk)
+	err := c.cc.Invoke(ctx, SlaveVirshService_AttachDisk_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *slaveVirshServiceClient) DetachDisk(ctx context.Context, in *VmDiskRequest, opts ...grpc.CallOption) (*OkResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(OkResponse)
+	err := c.cc.Invoke(ctx, SlaveVirshService_DetachDisk_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *slaveVirshServiceClient) ResizeDisk(ctx context.Context, in *VmDiskRequest, opts ...grpc.CallOption) (*VmDisk, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VmDisk)
+	err := c.cc.Invoke(ctx, SlaveVirshService_ResizeDisk_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *slaveVirshServiceClient) MoveVmStorage(ctx context.Context, in *VmDiskRequest, opts ...grpc.CallOption) (*MoveVmStorageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MoveVmStorageResponse)
+	err := c.cc.Invoke(ctx, SlaveVirshService_MoveVmStorage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *slaveVirshServiceClient) GetBackingFileUsers(ctx context.Context, in *BackingFileRequest, opts ...grpc.CallOption) (*BackingFileUsers, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BackingFileUsers)
+	err := c.cc.Invoke(ctx, SlaveVirshService_GetBackingFileUsers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *slaveVirshServiceClient) BackupVM(ctx context.Context, in *BackupVmRequest, opts ...grpc.CallOption) (*BackupVmResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BackupVmResponse)
+	err := c.cc.Invoke(ctx, SlaveVirshService_BackupVM_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *slaveVirshServiceClient) RestoreBackup(ctx context.Context, in *RestoreBackupRequest, opts ...grpc.CallOption) (*OkResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(OkResponse)
+	err := c.cc.Invoke(ctx, SlaveVirshService_RestoreBackup_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *slaveVirshServiceClient) DeleteBackup(ctx context.Context, in *DeleteBackupRequest, opts ...grpc.CallOption) (*OkResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(OkResponse)
+	err := c.cc.Invoke(ctx, SlaveVirshService_DeleteBackup_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *slaveVirshServiceClient) AttachNic(ctx context.Context, in *VmNicRequest, opts ...grpc.CallOption) (*VmNic, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VmNic)
+	err := c.cc.Invoke(ctx, SlaveVirshService_AttachNic_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *slaveVirshServiceClient) DetachNic(ctx context.Context, in *VmNicRequest, opts ...grpc.CallOption) (*OkResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(OkResponse)
+	err := c.cc.Invoke(ctx, SlaveVirshService_DetachNic_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *slaveVirshServiceClient) GetGuestInfo(ctx context.Context, in *GetVmByNameRequest, opts ...grpc.CallOption) (*GuestInfo, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GuestInfo)
+	err := c.cc.Invoke(ctx, SlaveVirshService_GetGuestInfo_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *slaveVirshServiceClient) GuestExec(ctx context.Context, in *GuestExecRequest, opts ...grpc.CallOption) (*GuestExecResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GuestExecResponse)
+	err := c.cc.Invoke(ctx, SlaveVirshService_GuestExec_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *slaveVirshServiceClient) EnableGuestAgent(ctx context.Context, in *GetVmByNameRequest, opts ...grpc.CallOption) (*OkResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(OkResponse)
+	err := c.cc.Invoke(ctx, SlaveVirshService_EnableGuestAgent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *slaveVirshServiceClient) SerialConsole(ctx context.Context, opts ...grpc.CallOption) (SlaveVirshService_SerialConsoleClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &SlaveVirshService_ServiceDesc.Streams[0], SlaveVirshService_SerialConsole_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &slaveVirshServiceSerialConsoleClient{ClientStream: stream}
+	return x, nil
+}
+
+type SlaveVirshService_SerialConsoleClient interface {
+	Send(*ConsoleData) error
+	Recv() (*ConsoleData, error)
+	grpc.ClientStream
+}
+
+type slaveVirshServiceSerialConsoleClient struct {
+	grpc.ClientStream
+}
+
+func (x *slaveVirshServiceSerialConsoleClient) Send(m *ConsoleData) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *slaveVirshServiceSerialConsoleClient) Recv() (*ConsoleData, error) {
+	m := new(ConsoleData)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *slaveVirshServiceClient) SetVmHA(ctx context.Context, in *HaVmRequest, opts ...grpc.CallOption) (*OkResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(OkResponse)
+	err := c.cc.Invoke(ctx, SlaveVirshService_SetVmHA_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *slaveVirshServiceClient) RecoverVM(ctx context.Context, in *HaVmRequest, opts ...grpc.CallOption) (*OkResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(OkResponse)
+	err := c.cc.Invoke(ctx, SlaveVirshService_RecoverVM_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *slaveVirshServiceClient) ReleaseVM(ctx context.Context, in *HaVmRequest, opts ...grpc.CallOption) (*OkResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(OkResponse)
+	err := c.cc.Invoke(ctx, SlaveVirshService_ReleaseVM_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *slaveVirshServiceClient) GetHostResources(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*HostResources, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(HostResources)
+	err := c.cc.Invoke(ctx, SlaveVirshService_GetHostResources_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // SlaveVirshServiceServer is the server API for SlaveVirshService service.
 // All implementations must embed UnimplementedSlaveVirshServiceServer
 // for forward compatibility
 type SlaveVirshServiceServer interface {
 	GetCpuFeatures(context.Context, *Empty) (*GetCpuFeaturesResponse, error)
 	GetCPUXML(context.Context, *Empty) (*CPUXMLResponse, error)
+	GetVmCPUXML(context.Context, *GetVmByNameRequest) (*CPUXMLResponse, error)
+	CompareCPU(context.Context, *CPUXMLResponse) (*OkResponse, error)
 	CreateVm(context.Context, *CreateVmRequest) (*OkResponse, error)
 	CreateLiveVM(context.Context, *CreateVmLiveRequest) (*OkResponse, error)
+	CloneVM(context.Context, *CloneVmRequest) (*OkResponse, error)
 	MigrateVM(context.Context, *MigrateVmRequest) (*OkResponse, error)
+	CancelMigration(context.Context, *GetVmByNameRequest) (*OkResponse, error)
+	SetMigrationLimits(context.Context, *MigrateVmRequest) (*OkResponse, error)
 	ShutdownVM(context.Context, *Vm) (*OkResponse, error)
 	ForceShutdownVM(context.Context, *Vm) (*OkResponse, error)
 	StartVM(context.Context, *Vm) (*OkResponse, error)
 	RemoveVM(context.Context, *Vm) (*OkResponse, error)
+	TrashVM(context.Context, *TrashVmRequest) (*TrashedVm, error)
+	RestoreTrashedVM(context.Context, *TrashedVm) (*OkResponse, error)
+	PurgeTrashedVM(context.Context, *TrashedVm) (*OkResponse, error)
 	RestartVM(context.Context, *Vm) (*OkResponse, error)
 	PauseVM(context.Context, *Vm) (*OkResponse, error)
 	ResumeVM(context.Context, *Vm) (*OkResponse, error)
@@ -251,6 +666,31 @@ type SlaveVirshServiceServer interface {
 	// only sees machine name, cpuCount and memoryMB
 	// cpuCount and memoryMB are the new values to set
 	EditVmResources(context.Context, *Vm) (*OkResponse, error)
+	CreateSnapshot(context.Context, *CreateSnapshotRequest) (*Snapshot, error)
+	ListSnapshots(context.Context, *GetVmByNameRequest) (*ListSnapshotsResponse, error)
+	RevertSnapshot(context.Context, *SnapshotRequest) (*OkResponse, error)
+	DeleteSnapshot(context.Context, *SnapshotRequest) (*OkResponse, error)
+	// redefines snapshot metadata saved on master (ex: after a migration)
+	DefineSnapshot(context.Context, *DefineSnapshotRequest) (*OkResponse, error)
+	AddDisk(context.Context, *VmDiskRequest) (*VmDisk, error)
+	AttachDisk(context.Context, *VmDiskRequest) (*VmDisk, error)
+	DetachDisk(context.Context, *VmDiskRequest) (*OkResponse, error)
+	ResizeDisk(context.Context, *VmDiskRequest) (*VmDisk, error)
+	MoveVmStorage(context.Context, *VmDiskRequest) (*MoveVmStorageResponse, error)
+	GetBackingFileUsers(context.Context, *BackingFileRequest) (*BackingFileUsers, error)
+	BackupVM(context.Context, *BackupVmRequest) (*BackupVmResponse, error)
+	RestoreBackup(context.Context, *RestoreBackupRequest) (*OkResponse, error)
+	DeleteBackup(context.Context, *DeleteBackupRequest) (*OkResponse, error)
+	AttachNic(context.Context, *VmNicRequest) (*VmNic, error)
+	DetachNic(context.Context, *VmNicRequest) (*OkResponse, error)
+	GetGuestInfo(context.Context, *GetVmByNameRequest) (*GuestInfo, error)
+	GuestExec(context.Context, *GuestExecRequest) (*GuestExecResponse, error)
+	EnableGuestAgent(context.Context, *GetVmByNameRequest) (*OkResponse, error)
+	SerialConsole(SlaveVirshService_SerialConsoleServer) error
+	SetVmHA(context.Context, *HaVmRequest) (*OkResponse, error)
+	RecoverVM(context.Context, *HaVmRequest) (*OkResponse, error)
+	ReleaseVM(context.Context, *HaVmRequest) (*OkResponse, error)
+	GetHostResources(context.Context, *Empty) (*HostResources, error)
 	mustEmbedUnimplementedSlaveVirshServiceServer()
 }
 
@@ -264,15 +704,30 @@ func (UnimplementedSlaveVirshServiceServer) GetCpuFeatures(context.Context, *Emp
 func (UnimplementedSlaveVirshServiceServer) GetCPUXML(context.Context, *Empty) (*CPUXMLResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetCPUXML not implemented")
 }
+func (UnimplementedSlaveVirshServiceServer) GetVmCPUXML(context.Context, *GetVmByNameRequest) (*CPUXMLResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetVmCPUXML not implemented")
+}
+func (UnimplementedSlaveVirshServiceServer) CompareCPU(context.Context, *CPUXMLResponse) (*OkResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CompareCPU not implemented")
+}
 func (UnimplementedSlaveVirshServiceServer) CreateVm(context.Context, *CreateVmRequest) (*OkResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateVm not implemented")
 }
 func (UnimplementedSlaveVirshServiceServer) CreateLiveVM(context.Context, *CreateVmLiveRequest) (*OkResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateLiveVM not implemented")
 }
+func (UnimplementedSlaveVirshServiceServer) CloneVM(context.Context, *CloneVmRequest) (*OkResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CloneVM not implemented")
+}
 func (UnimplementedSlaveVirshServiceServer) MigrateVM(context.Context, *MigrateVmRequest) (*OkResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method MigrateVM not implemented")
 }
+func (UnimplementedSlaveVirshServiceServer) CancelMigration(context.Context, *GetVmByNameRequest) (*OkResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelMigration not implemented")
+}
+func (UnimplementedSlaveVirshServiceServer) SetMigrationLimits(context.Context, *MigrateVmRequest) (*OkResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetMigrationLimits not implemented")
+}
 func (UnimplementedSlaveVirshServiceServer) ShutdownVM(context.Context, *Vm) (*OkResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ShutdownVM not implemented")
 }
@@ -285,6 +740,15 @@ func (UnimplementedSlaveVirshServiceServer) StartVM(context.Context, *Vm) (*OkRe
 func (UnimplementedSlaveVirshServiceServer) RemoveVM(context.Context, *Vm) (*OkResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RemoveVM not implemented")
 }
+func (UnimplementedSlaveVirshServiceServer) TrashVM(context.Context, *TrashVmRequest) (*TrashedVm, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TrashVM not implemented")
+}
+func (UnimplementedSlaveVirshServiceServer) RestoreTrashedVM(context.Context, *TrashedVm) (*OkResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreTrashedVM not implemented")
+}
+func (UnimplementedSlaveVirshServiceServer) PurgeTrashedVM(context.Context, *TrashedVm) (*OkResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PurgeTrashedVM not implemented")
+}
 func (UnimplementedSlaveVirshServiceServer) RestartVM(context.Context, *Vm) (*OkResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RestartVM not implemented")
 }
@@ -294,315 +758,971 @@ func (UnimplementedSlaveVirshServiceServer) PauseVM(context.Context, *Vm) (*OkRe
 func (UnimplementedSlaveVirshServiceServer) ResumeVM(context.Context, *Vm) (*OkResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ResumeVM not implemented")
 }
-func (UnimplementedSlaveVirshServiceServer) GetAllVms(context.Context, *Empty) (*GetAllVmsResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetAllVms not implemented")
+func (UnimplementedSlaveVirshServiceServer) GetAllVms(context.Context, *Empty) (*GetAllVmsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAllVms not implemented")
+}
+func (UnimplementedSlaveVirshServiceServer) GetVmByName(context.Context, *GetVmByNameRequest) (*Vm, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetVmByName not implemented")
+}
+func (UnimplementedSlaveVirshServiceServer) RemoveIsoFromVm(context.Context, *Vm) (*OkResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveIsoFromVm not implemented")
+}
+func (UnimplementedSlaveVirshServiceServer) EditVmResources(context.Context, *Vm) (*OkResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EditVmResources not implemented")
+}
+func (UnimplementedSlaveVirshServiceServer) CreateSnapshot(context.Context, *CreateSnapshotRequest) (*Snapshot, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateSnapshot not implemented")
+}
+func (UnimplementedSlaveVirshServiceServer) ListSnapshots(context.Context, *GetVmByNameRequest) (*ListSnapshotsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSnapshots not implemented")
+}
+func (UnimplementedSlaveVirshServiceServer) RevertSnapshot(context.Context, *SnapshotRequest) (*OkResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevertSnapshot not implemented")
+}
+func (UnimplementedSlaveVirshServiceServer) DeleteSnapshot(context.Context, *SnapshotRequest) (*OkResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteSnapshot not implemented")
+}
+func (UnimplementedSlaveVirshServiceServer) DefineSnapshot(context.Context, *DefineSnapshotRequest) (*OkResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DefineSnapshot not implemented")
+}
+func (UnimplementedSlaveVirshServiceServer) AddDisk(context.Context, *VmDiskRequest) (*VmDisk, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddDisk not implemented")
+}
+func (UnimplementedSlaveVirshServiceServer) AttachDisk(context.Context, *VmDiskRequest) (*VmDisk, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AttachDisk not implemented")
+}
+func (UnimplementedSlaveVirshServiceServer) DetachDisk(context.Context, *VmDiskRequest) (*OkResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DetachDisk not implemented")
+}
+func (UnimplementedSlaveVirshServiceServer) ResizeDisk(context.Context, *VmDiskRequest) (*VmDisk, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResizeDisk not implemented")
+}
+func (UnimplementedSlaveVirshServiceServer) MoveVmStorage(context.Context, *VmDiskRequest) (*MoveVmStorageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MoveVmStorage not implemented")
+}
+func (UnimplementedSlaveVirshServiceServer) GetBackingFileUsers(context.Context, *BackingFileRequest) (*BackingFileUsers, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBackingFileUsers not implemented")
+}
+func (UnimplementedSlaveVirshServiceServer) BackupVM(context.Context, *BackupVmRequest) (*BackupVmResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BackupVM not implemented")
+}
+func (UnimplementedSlaveVirshServiceServer) RestoreBackup(context.Context, *RestoreBackupRequest) (*OkResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreBackup not implemented")
+}
+func (UnimplementedSlaveVirshServiceServer) DeleteBackup(context.Context, *DeleteBackupRequest) (*OkResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteBackup not implemented")
+}
+func (UnimplementedSlaveVirshServiceServer) AttachNic(context.Context, *VmNicRequest) (*VmNic, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AttachNic not implemented")
+}
+func (UnimplementedSlaveVirshServiceServer) DetachNic(context.Context, *VmNicRequest) (*OkResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DetachNic not implemented")
+}
+func (UnimplementedSlaveVirshServiceServer) GetGuestInfo(context.Context, *GetVmByNameRequest) (*GuestInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetGuestInfo not implemented")
+}
+func (UnimplementedSlaveVirshServiceServer) GuestExec(context.Context, *GuestExecRequest) (*GuestExecResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GuestExec not implemented")
+}
+func (UnimplementedSlaveVirshServiceServer) EnableGuestAgent(context.Context, *GetVmByNameRequest) (*OkResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EnableGuestAgent not implemented")
+}
+func (UnimplementedSlaveVirshServiceServer) SerialConsole(SlaveVirshService_SerialConsoleServer) error {
+	return status.Errorf(codes.Unimplemented, "method SerialConsole not implemented")
+}
+func (UnimplementedSlaveVirshServiceServer) SetVmHA(context.Context, *HaVmRequest) (*OkResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetVmHA not implemented")
+}
+func (UnimplementedSlaveVirshServiceServer) RecoverVM(context.Context, *HaVmRequest) (*OkResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RecoverVM not implemented")
+}
+func (UnimplementedSlaveVirshServiceServer) ReleaseVM(context.Context, *HaVmRequest) (*OkResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReleaseVM not implemented")
+}
+func (UnimplementedSlaveVirshServiceServer) GetHostResources(context.Context, *Empty) (*HostResources, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetHostResources not implemented")
+}
+func (UnimplementedSlaveVirshServiceServer) mustEmbedUnimplementedSlaveVirshServiceServer() {}
+
+// UnsafeSlaveVirshServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SlaveVirshServiceServer will
+// result in compilation errors.
+type UnsafeSlaveVirshServiceServer interface {
+	mustEmbedUnimplementedSlaveVirshServiceServer()
+}
+
+func RegisterSlaveVirshServiceServer(s grpc.ServiceRegistrar, srv SlaveVirshServiceServer) {
+	s.RegisterService(&SlaveVirshService_ServiceDesc, srv)
+}
+
+func _SlaveVirshService_GetCpuFeatures_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SlaveVirshServiceServer).GetCpuFeatures(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SlaveVirshService_GetCpuFeatures_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SlaveVirshServiceServer).GetCpuFeatures(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SlaveVirshService_GetCPUXML_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SlaveVirshServiceServer).GetCPUXML(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SlaveVirshService_GetCPUXML_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SlaveVirshServiceServer).GetCPUXML(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SlaveVirshService_GetVmCPUXML_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetVmByNameRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SlaveVirshServiceServer).GetVmCPUXML(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SlaveVirshService_GetVmCPUXML_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SlaveVirshServiceServer).GetVmCPUXML(ctx, req.(*GetVmByNameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SlaveVirshService_CompareCPU_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CPUXMLResponse)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SlaveVirshServiceServer).CompareCPU(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SlaveVirshService_CompareCPU_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SlaveVirshServiceServer).CompareCPU(ctx, req.(*CPUXMLResponse))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SlaveVirshService_CreateVm_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateVmRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SlaveVirshServiceServer).CreateVm(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SlaveVirshService_CreateVm_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SlaveVirshServiceServer).CreateVm(ctx, req.(*CreateVmRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SlaveVirshService_CreateLiveVM_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateVmLiveRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SlaveVirshServiceServer).CreateLiveVM(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SlaveVirshService_CreateLiveVM_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SlaveVirshServiceServer).CreateLiveVM(ctx, req.(*CreateVmLiveRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SlaveVirshService_CloneVM_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CloneVmRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SlaveVirshServiceServer).CloneVM(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SlaveVirshService_CloneVM_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SlaveVirshServiceServer).CloneVM(ctx, req.(*CloneVmRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SlaveVirshService_MigrateVM_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MigrateVmRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SlaveVirshServiceServer).MigrateVM(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SlaveVirshService_MigrateVM_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SlaveVirshServiceServer).MigrateVM(ctx, req.(*MigrateVmRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SlaveVirshService_CancelMigration_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetVmByNameRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SlaveVirshServiceServer).CancelMigration(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SlaveVirshService_CancelMigration_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SlaveVirshServiceServer).CancelMigration(ctx, req.(*GetVmByNameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SlaveVirshService_SetMigrationLimits_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MigrateVmRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SlaveVirshServiceServer).SetMigrationLimits(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SlaveVirshService_SetMigrationLimits_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SlaveVirshServiceServer).SetMigrationLimits(ctx, req.(*MigrateVmRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SlaveVirshService_ShutdownVM_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Vm)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SlaveVirshServiceServer).ShutdownVM(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SlaveVirshService_ShutdownVM_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SlaveVirshServiceServer).ShutdownVM(ctx, req.(*Vm))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SlaveVirshService_ForceShutdownVM_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Vm)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SlaveVirshServiceServer).ForceShutdownVM(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SlaveVirshService_ForceShutdownVM_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SlaveVirshServiceServer).ForceShutdownVM(ctx, req.(*Vm))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SlaveVirshService_StartVM_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Vm)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SlaveVirshServiceServer).StartVM(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SlaveVirshService_StartVM_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SlaveVirshServiceServer).StartVM(ctx, req.(*Vm))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SlaveVirshService_RemoveVM_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Vm)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SlaveVirshServiceServer).RemoveVM(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SlaveVirshService_RemoveVM_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SlaveVirshServiceServer).RemoveVM(ctx, req.(*Vm))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SlaveVirshService_TrashVM_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TrashVmRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SlaveVirshServiceServer).TrashVM(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SlaveVirshService_TrashVM_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SlaveVirshServiceServer).TrashVM(ctx, req.(*TrashVmRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SlaveVirshService_RestoreTrashedVM_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TrashedVm)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SlaveVirshServiceServer).RestoreTrashedVM(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SlaveVirshService_RestoreTrashedVM_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SlaveVirshServiceServer).RestoreTrashedVM(ctx, req.(*TrashedVm))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SlaveVirshService_PurgeTrashedVM_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TrashedVm)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SlaveVirshServiceServer).PurgeTrashedVM(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SlaveVirshService_PurgeTrashedVM_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SlaveVirshServiceServer).PurgeTrashedVM(ctx, req.(*TrashedVm))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SlaveVirshService_RestartVM_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Vm)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SlaveVirshServiceServer).RestartVM(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SlaveVirshService_RestartVM_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SlaveVirshServiceServer).RestartVM(ctx, req.(*Vm))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SlaveVirshService_PauseVM_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Vm)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SlaveVirshServiceServer).PauseVM(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SlaveVirshService_PauseVM_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SlaveVirshServiceServer).PauseVM(ctx, req.(*Vm))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SlaveVirshService_ResumeVM_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Vm)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SlaveVirshServiceServer).ResumeVM(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SlaveVirshService_ResumeVM_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SlaveVirshServiceServer).ResumeVM(ctx, req.(*Vm))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SlaveVirshService_GetAllVms_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SlaveVirshServiceServer).GetAllVms(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SlaveVirshService_GetAllVms_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SlaveVirshServiceServer).GetAllVms(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SlaveVirshService_GetVmByName_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetVmByNameRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SlaveVirshServiceServer).GetVmByName(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SlaveVirshService_GetVmByName_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SlaveVirshServiceServer).GetVmByName(ctx, req.(*GetVmByNameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SlaveVirshService_RemoveIsoFromVm_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Vm)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SlaveVirshServiceServer).RemoveIsoFromVm(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SlaveVirshService_RemoveIsoFromVm_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SlaveVirshServiceServer).RemoveIsoFromVm(ctx, req.(*Vm))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SlaveVirshService_EditVmResources_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Vm)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SlaveVirshServiceServer).EditVmResources(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SlaveVirshService_EditVmResources_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SlaveVirshServiceServer).EditVmResources(ctx, req.(*Vm))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SlaveVirshService_CreateSnapshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateSnapshotRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SlaveVirshServiceServer).CreateSnapshot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SlaveVirshService_CreateSnapshot_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SlaveVirshServiceServer).CreateSnapshot(ctx, req.(*CreateSnapshotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SlaveVirshService_ListSnapshots_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetVmByNameRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SlaveVirshServiceServer).ListSnapshots(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SlaveVirshService_ListSnapshots_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SlaveVirshServiceServer).ListSnapshots(ctx, req.(*GetVmByNameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedSlaveVirshServiceServer) GetVmByName(context.Context, *GetVmByNameRequest) (*Vm, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetVmByName not implemented")
+
+func _SlaveVirshService_RevertSnapshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SnapshotRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SlaveVirshServiceServer).RevertSnapshot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SlaveVirshService_RevertSnapshot_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SlaveVirshServiceServer).RevertSnapshot(ctx, req.(*SnapshotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedSlaveVirshServiceServer) RemoveIsoFromVm(context.Context, *Vm) (*OkResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method RemoveIsoFromVm not implemented")
+
+func _SlaveVirshService_DeleteSnapshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SnapshotRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SlaveVirshServiceServer).DeleteSnapshot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SlaveVirshService_DeleteSnapshot_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SlaveVirshServiceServer).DeleteSnapshot(ctx, req.(*SnapshotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedSlaveVirshServiceServer) EditVmResources(context.Context, *Vm) (*OkResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method EditVmResources not implemented")
+
+func _SlaveVirshService_DefineSnapshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DefineSnapshotRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SlaveVirshServiceServer).DefineSnapshot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SlaveVirshService_DefineSnapshot_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SlaveVirshServiceServer).DefineSnapshot(ctx, req.(*DefineSnapshotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedSlaveVirshServiceServer) mustEmbedUnimplementedSlaveVirshServiceServer() {}
 
-// UnsafeSlaveVirshServiceServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to SlaveVirshServiceServer will
-// result in compilation errors.
-type UnsafeSlaveVirshServiceServer interface {
-	mustEmbedUnimplementedSlaveVirshServiceServer()
+func _SlaveVirshService_AddDisk_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VmDiskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SlaveVirshServiceServer).AddDisk(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SlaveVirshService_AddDisk_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SlaveVirshServiceServer).AddDisk(ctx, req.(*VmDiskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func RegisterSlaveVirshServiceServer(s grpc.ServiceRegistrar, srv SlaveVirshServiceServer) {
-	s.RegisterService(&SlaveVirshService_ServiceDesc, srv)
+func _SlaveVirshService_AttachDisk_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VmDiskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SlaveVirshServiceServer).AttachDisk(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SlaveVirshService_AttachDisk_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SlaveVirshServiceServer).AttachDisk(ctx, req.(*VmDiskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _SlaveVirshService_GetCpuFeatures_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Empty)
+func _SlaveVirshService_DetachDisk_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VmDiskRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(SlaveVirshServiceServer).GetCpuFeatures(ctx, in)
+		return srv.(SlaveVirshServiceServer).DetachDisk(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: SlaveVirshService_GetCpuFeatures_FullMethodName,
+		FullMethod: SlaveVirshService_DetachDisk_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(SlaveVirshServiceServer).GetCpuFeatures(ctx, req.(*Empty))
+		return srv.(SlaveVirshServiceServer).DetachDisk(ctx, req.(*VmDiskRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _SlaveVirshService_GetCPUXML_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Empty)
+func _SlaveVirshService_ResizeDisk_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VmDiskRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(SlaveVirshServiceServer).GetCPUXML(ctx, in)
+		return srv.(SlaveVirshServiceServer).ResizeDisk(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: SlaveVirshService_GetCPUXML_FullMethodName,
+		FullMethod: SlaveVirshService_ResizeDisk_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(SlaveVirshServiceServer).GetCPUXML(ctx, req.(*Empty))
+		return srv.(SlaveVirshServiceServer).ResizeDisk(ctx, req.(*VmDiskRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _SlaveVirshService_CreateVm_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateVmRequest)
+func _SlaveVirshService_MoveVmStorage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VmDiskRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(SlaveVirshServiceServer).CreateVm(ctx, in)
+		return srv.(SlaveVirshServiceServer).MoveVmStorage(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: SlaveVirshService_CreateVm_FullMethodName,
+		FullMethod: SlaveVirshService_MoveVmStorage_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(SlaveVirshServiceServer).CreateVm(ctx, req.(*CreateVmRequest))
+		return srv.(SlaveVirshServiceServer).MoveVmStorage(ctx, req.(*VmDiskRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _SlaveVirshService_CreateLiveVM_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateVmLiveRequest)
+func _SlaveVirshService_GetBackingFileUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BackingFileRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(SlaveVirshServiceServer).CreateLiveVM(ctx, in)
+		return srv.(SlaveVirshServiceServer).GetBackingFileUsers(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: SlaveVirshService_CreateLiveVM_FullMethodName,
+		FullMethod: SlaveVirshService_GetBackingFileUsers_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(SlaveVirshServiceServer).CreateLiveVM(ctx, req.(*CreateVmLiveRequest))
+		return srv.(SlaveVirshServiceServer).GetBackingFileUsers(ctx, req.(*BackingFileRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _SlaveVirshService_MigrateVM_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(MigrateVmRequest)
+func _SlaveVirshService_BackupVM_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BackupVmRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(SlaveVirshServiceServer).MigrateVM(ctx, in)
+		return srv.(SlaveVirshServiceServer).BackupVM(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: SlaveVirshService_MigrateVM_FullMethodName,
+		FullMethod: SlaveVirshService_BackupVM_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(SlaveVirshServiceServer).MigrateVM(ctx, req.(*MigrateVmRequest))
+		return srv.(SlaveVirshServiceServer).BackupVM(ctx, req.(*BackupVmRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _SlaveVirshService_ShutdownVM_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Vm)
+func _SlaveVirshService_RestoreBackup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreBackupRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(SlaveVirshServiceServer).ShutdownVM(ctx, in)
+		return srv.(SlaveVirshServiceServer).RestoreBackup(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: SlaveVirshService_ShutdownVM_FullMethodName,
+		FullMethod: SlaveVirshService_RestoreBackup_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(SlaveVirshServiceServer).ShutdownVM(ctx, req.(*Vm))
+		return srv.(SlaveVirshServiceServer).RestoreBackup(ctx, req.(*RestoreBackupRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _SlaveVirshService_ForceShutdownVM_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Vm)
+func _SlaveVirshService_DeleteBackup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteBackupRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(SlaveVirshServiceServer).ForceShutdownVM(ctx, in)
+		return srv.(SlaveVirshServiceServer).DeleteBackup(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: SlaveVirshService_ForceShutdownVM_FullMethodName,
+		FullMethod: SlaveVirshService_DeleteBackup_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(SlaveVirshServiceServer).ForceShutdownVM(ctx, req.(*Vm))
+		return srv.(SlaveVirshServiceServer).DeleteBackup(ctx, req.(*DeleteBackupRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _SlaveVirshService_StartVM_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Vm)
+func _SlaveVirshService_AttachNic_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VmNicRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(SlaveVirshServiceServer).StartVM(ctx, in)
+		return srv.(SlaveVirshServiceServer).AttachNic(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: SlaveVirshService_StartVM_FullMethodName,
+		FullMethod: SlaveVirshService_AttachNic_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(SlaveVirshServiceServer).StartVM(ctx, req.(*Vm))
+		return srv.(SlaveVirshServiceServer).AttachNic(ctx, req.(*VmNicRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _SlaveVirshService_RemoveVM_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Vm)
+func _SlaveVirshService_DetachNic_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VmNicRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(SlaveVirshServiceServer).RemoveVM(ctx, in)
+		return srv.(SlaveVirshServiceServer).DetachNic(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: SlaveVirshService_RemoveVM_FullMethodName,
+		FullMethod: SlaveVirshService_DetachNic_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(SlaveVirshServiceServer).RemoveVM(ctx, req.(*Vm))
+		return srv.(SlaveVirshServiceServer).DetachNic(ctx, req.(*VmNicRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _SlaveVirshService_RestartVM_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Vm)
+func _SlaveVirshService_GetGuestInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetVmByNameRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(SlaveVirshServiceServer).RestartVM(ctx, in)
+		return srv.(SlaveVirshServiceServer).GetGuestInfo(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: SlaveVirshService_RestartVM_FullMethodName,
+		FullMethod: SlaveVirshService_GetGuestInfo_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(SlaveVirshServiceServer).RestartVM(ctx, req.(*Vm))
+		return srv.(SlaveVirshServiceServer).GetGuestInfo(ctx, req.(*GetVmByNameRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _SlaveVirshService_PauseVM_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Vm)
+func _SlaveVirshService_GuestExec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GuestExecRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(SlaveVirshServiceServer).PauseVM(ctx, in)
+		return srv.(SlaveVirshServiceServer).GuestExec(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: SlaveVirshService_PauseVM_FullMethodName,
+		FullMethod: SlaveVirshService_GuestExec_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(SlaveVirshServiceServer).PauseVM(ctx, req.(*Vm))
+		return srv.(SlaveVirshServiceServer).GuestExec(ctx, req.(*GuestExecRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _SlaveVirshService_ResumeVM_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Vm)
+func _SlaveVirshService_EnableGuestAgent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetVmByNameRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(SlaveVirshServiceServer).ResumeVM(ctx, in)
+		return srv.(SlaveVirshServiceServer).EnableGuestAgent(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: SlaveVirshService_ResumeVM_FullMethodName,
+		FullMethod: SlaveVirshService_EnableGuestAgent_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(SlaveVirshServiceServer).ResumeVM(ctx, req.(*Vm))
+		return srv.(SlaveVirshServiceServer).EnableGuestAgent(ctx, req.(*GetVmByNameRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _SlaveVirshService_GetAllVms_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Empty)
+func _SlaveVirshService_SerialConsole_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(SlaveVirshServiceServer).SerialConsole(&slaveVirshServiceSerialConsoleServer{ServerStream: stream})
+}
+
+type SlaveVirshService_SerialConsoleServer interface {
+	Send(*ConsoleData) error
+	Recv() (*ConsoleData, error)
+	grpc.ServerStream
+}
+
+type slaveVirshServiceSerialConsoleServer struct {
+	grpc.ServerStream
+}
+
+func (x *slaveVirshServiceSerialConsoleServer) Send(m *ConsoleData) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *slaveVirshServiceSerialConsoleServer) Recv() (*ConsoleData, error) {
+	m := new(ConsoleData)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _SlaveVirshService_SetVmHA_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HaVmRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(SlaveVirshServiceServer).GetAllVms(ctx, in)
+		return srv.(SlaveVirshServiceServer).SetVmHA(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: SlaveVirshService_GetAllVms_FullMethodName,
+		FullMethod: SlaveVirshService_SetVmHA_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(SlaveVirshServiceServer).GetAllVms(ctx, req.(*Empty))
+		return srv.(SlaveVirshServiceServer).SetVmHA(ctx, req.(*HaVmRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _SlaveVirshService_GetVmByName_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetVmByNameRequest)
+func _SlaveVirshService_RecoverVM_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HaVmRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(SlaveVirshServiceServer).GetVmByName(ctx, in)
+		return srv.(SlaveVirshServiceServer).RecoverVM(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: SlaveVirshService_GetVmByName_FullMethodName,
+		FullMethod: SlaveVirshService_RecoverVM_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(SlaveVirshServiceServer).GetVmByName(ctx, req.(*GetVmByNameRequest))
+		return srv.(SlaveVirshServiceServer).RecoverVM(ctx, req.(*HaVmRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _SlaveVirshService_RemoveIsoFromVm_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Vm)
+func _SlaveVirshService_ReleaseVM_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HaVmRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(SlaveVirshServiceServer).RemoveIsoFromVm(ctx, in)
+		return srv.(SlaveVirshServiceServer).ReleaseVM(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: SlaveVirshService_RemoveIsoFromVm_FullMethodName,
+		FullMethod: SlaveVirshService_ReleaseVM_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(SlaveVirshServiceServer).RemoveIsoFromVm(ctx, req.(*Vm))
+		return srv.(SlaveVirshServiceServer).ReleaseVM(ctx, req.(*HaVmRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _SlaveVirshService_EditVmResources_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Vm)
+func _SlaveVirshService_GetHostResources_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(SlaveVirshServiceServer).EditVmResources(ctx, in)
+		return srv.(SlaveVirshServiceServer).GetHostResources(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: SlaveVirshService_EditVmResources_FullMethodName,
+		FullMethod: SlaveVirshService_GetHostResources_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(SlaveVirshServiceServer).EditVmResources(ctx, req.(*Vm))
+		return srv.(SlaveVirshServiceServer).GetHostResources(ctx, req.(*Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -622,6 +1742,14 @@ var SlaveVirshService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetCPUXML",
 			Handler:    _SlaveVirshService_GetCPUXML_Handler,
 		},
+		{
+			MethodName: "GetVmCPUXML",
+			Handler:    _SlaveVirshService_GetVmCPUXML_Handler,
+		},
+		{
+			MethodName: "CompareCPU",
+			Handler:    _SlaveVirshService_CompareCPU_Handler,
+		},
 		{
 			MethodName: "CreateVm",
 			Handler:    _SlaveVirshService_CreateVm_Handler,
@@ -630,10 +1758,22 @@ var SlaveVirshService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CreateLiveVM",
 			Handler:    _SlaveVirshService_CreateLiveVM_Handler,
 		},
+		{
+			MethodName: "CloneVM",
+			Handler:    _SlaveVirshService_CloneVM_Handler,
+		},
 		{
 			MethodName: "MigrateVM",
 			Handler:    _SlaveVirshService_MigrateVM_Handler,
 		},
+		{
+			MethodName: "CancelMigration",
+			Handler:    _SlaveVirshService_CancelMigration_Handler,
+		},
+		{
+			MethodName: "SetMigrationLimits",
+			Handler:    _SlaveVirshService_SetMigrationLimits_Handler,
+		},
 		{
 			MethodName: "ShutdownVM",
 			Handler:    _SlaveVirshService_ShutdownVM_Handler,
@@ -650,6 +1790,18 @@ var SlaveVirshService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RemoveVM",
 			Handler:    _SlaveVirshService_RemoveVM_Handler,
 		},
+		{
+			MethodName: "TrashVM",
+			Handler:    _SlaveVirshService_TrashVM_Handler,
+		},
+		{
+			MethodName: "RestoreTrashedVM",
+			Handler:    _SlaveVirshService_RestoreTrashedVM_Handler,
+		},
+		{
+			MethodName: "PurgeTrashedVM",
+			Handler:    _SlaveVirshService_PurgeTrashedVM_Handler,
+		},
 		{
 			MethodName: "RestartVM",
 			Handler:    _SlaveVirshService_RestartVM_Handler,
@@ -678,7 +1830,106 @@ var SlaveVirshService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "EditVmResources",
 			Handler:    _SlaveVirshService_EditVmResources_Handler,
 		},
+		{
+			MethodName: "CreateSnapshot",
+			Handler:    _SlaveVirshService_CreateSnapshot_Handler,
+		},
+		{
+			MethodName: "ListSnapshots",
+			Handler:    _SlaveVirshService_ListSnapshots_Handler,
+		},
+		{
+			MethodName: "RevertSnapshot",
+			Handler:    _SlaveVirshService_RevertSnapshot_Handler,
+		},
+		{
+			MethodName: "DeleteSnapshot",
+			Handler:    _SlaveVirshService_DeleteSnapshot_Handler,
+		},
+		{
+			MethodName: "DefineSnapshot",
+			Handler:    _SlaveVirshService_DefineSnapshot_Handler,
+		},
+		{
+			MethodName: "AddDisk",
+			Handler:    _SlaveVirshService_AddDisk_Handler,
+		},
+		{
+			MethodName: "AttachDisk",
+			Handler:    _SlaveVirshService_AttachDisk_Handler,
+		},
+		{
+			MethodName: "DetachDisk",
+			Handler:    _SlaveVirshService_DetachDisk_Handler,
+		},
+		{
+			MethodName: "ResizeDisk",
+			Handler:    _SlaveVirshService_ResizeDisk_Handler,
+		},
+		{
+			MethodName: "MoveVmStorage",
+			Handler:    _SlaveVirshService_MoveVmStorage_Handler,
+		},
+		{
+			MethodName: "GetBackingFileUsers",
+			Handler:    _SlaveVirshService_GetBackingFileUsers_Handler,
+		},
+		{
+			MethodName: "BackupVM",
+			Handler:    _SlaveVirshService_BackupVM_Handler,
+		},
+		{
+			MethodName: "RestoreBackup",
+			Handler:    _SlaveVirshService_RestoreBackup_Handler,
+		},
+		{
+			MethodName: "DeleteBackup",
+			Handler:    _SlaveVirshService_DeleteBackup_Handler,
+		},
+		{
+			MethodName: "AttachNic",
+			Handler:    _SlaveVirshService_AttachNic_Handler,
+		},
+		{
+			MethodName: "DetachNic",
+			Handler:    _SlaveVirshService_DetachNic_Handler,
+		},
+		{
+			MethodName: "GetGuestInfo",
+			Handler:    _SlaveVirshService_GetGuestInfo_Handler,
+		},
+		{
+			MethodName: "GuestExec",
+			Handler:    _SlaveVirshService_GuestExec_Handler,
+		},
+		{
+			MethodName: "EnableGuestAgent",
+			Handler:    _SlaveVirshService_EnableGuestAgent_Handler,
+		},
+		{
+			MethodName: "SetVmHA",
+			Handler:    _SlaveVirshService_SetVmHA_Handler,
+		},
+		{
+			MethodName: "RecoverVM",
+			Handler:    _SlaveVirshService_RecoverVM_Handler,
+		},
+		{
+			MethodName: "ReleaseVM",
+			Handler:    _SlaveVirshService_ReleaseVM_Handler,
+		},
+		{
+			MethodName: "GetHostResources",
+			Handler:    _SlaveVirshService_GetHostResources_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SerialConsole",
+			Handler:       _SlaveVirshService_SerialConsole_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "virsh.proto",
 }
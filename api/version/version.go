@@ -0,0 +1,56 @@
+// Package version holds what master and slave have to agree on, both are built from this one copy
+package version
+
+import "runtime/debug"
+
+// ProtoVersion goes up every time the rpcs between master and slave change
+//
+//	2: NotifyResponse.known
+const ProtoVersion = 2
+
+// Build is set at build time with -ldflags "-X github.com/Maruqes/512SvMan/api/version.Build=...", the vcs
+// revision is used otherwise
+var Build = "dev"
+
+// slave features, the slave announces them and the master checks for them before relying on one
+const (
+	FeatureLiveMigration   = "live_migration"
+	FeatureMigrationLimits = "migration_limits"
+	FeatureCompareCPU      = "compare_cpu"
+	FeatureSerialConsole   = "serial_console"
+	FeatureGuestAgent      = "guest_agent"
+	FeatureCloudInit       = "cloud_init"
+	FeatureHostMetrics     = "host_metrics"
+	FeatureSystemdServices = "systemd_services"
+	FeatureCertRenewal     = "cert_renewal"
+	FeatureSlaveEvents     = "slave_events"
+)
+
+func GetBuild() string {
+	if Build != "dev" {
+		return Build
+	}
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return Build
+	}
+	revision, dirty := "", false
+	for _, s := range info.Settings {
+		switch s.Key {
+		case "vcs.revision":
+			revision = s.Value
+		case "vcs.modified":
+			dirty = s.Value == "true"
+		}
+	}
+	if revision == "" {
+		return Build
+	}
+	if len(revision) > 12 {
+		revision = revision[:12]
+	}
+	if dirty {
+		revision += "-dirty"
+	}
+	return revision
+}
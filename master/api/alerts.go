@@ -0,0 +1,199 @@
+package api
+
+import (
+	"512SvMan/db"
+	"512SvMan/services"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func writeAlertJSON(w http.ResponseWriter, v any, err error) {
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
+
+func alertIdParam(w http.ResponseWriter, r *http.Request) (int, bool) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "invalid id", http.StatusBadRequest)
+		return 0, false
+	}
+	return id, true
+}
+
+// ?state=firing for the open ones only
+func getAlerts(w http.ResponseWriter, r *http.Request) {
+	alertService := services.AlertService{}
+	alerts, err := alertService.GetAlerts(r.URL.Query().Get("state") == db.AlertStateFiring)
+	writeAlertJSON(w, alerts, err)
+}
+
+func getAlertRules(w http.ResponseWriter, r *http.Request) {
+	alertService := services.AlertService{}
+	rules, err := alertService.GetRules()
+	writeAlertJSON(w, rules, err)
+}
+
+func addAlertRule(w http.ResponseWriter, r *http.Request) {
+	var rule db.AlertRule
+	if err := json.NewDecoder(r.Body).Decode(&rule); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	alertService := services.AlertService{}
+	created, err := alertService.AddRule(rule)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	writeAlertJSON(w, created, nil)
+}
+
+func updateAlertRule(w http.ResponseWriter, r *http.Request) {
+	id, ok := alertIdParam(w, r)
+	if !ok {
+		return
+	}
+	var rule db.AlertRule
+	if err := json.NewDecoder(r.Body).Decode(&rule); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	rule.Id = id
+
+	alertService := services.AlertService{}
+	if err := alertService.UpdateRule(rule); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("Alert rule updated"))
+}
+
+func removeAlertRule(w http.ResponseWriter, r *http.Request) {
+	id, ok := alertIdParam(w, r)
+	if !ok {
+		return
+	}
+	alertService := services.AlertService{}
+	if err := alertService.RemoveRule(id); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("Alert rule removed"))
+}
+
+func getAlertChannels(w http.ResponseWriter, r *http.Request) {
+	alertService := services.AlertService{}
+	channels, err := alertService.GetChannels()
+	writeAlertJSON(w, channels, err)
+}
+
+func addAlertChannel(w http.ResponseWriter, r *http.Request) {
+	var channel db.AlertChannel
+	if err := json.NewDecoder(r.Body).Decode(&channel); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	alertService := services.AlertService{}
+	created, err := alertService.AddChannel(channel)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	writeAlertJSON(w, created, nil)
+}
+
+func removeAlertChannel(w http.ResponseWriter, r *http.Request) {
+	id, ok := alertIdParam(w, r)
+	if !ok {
+		return
+	}
+	alertService := services.AlertService{}
+	if err := alertService.RemoveChannel(id); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("Alert channel removed"))
+}
+
+func testAlertChannel(w http.ResponseWriter, r *http.Request) {
+	id, ok := alertIdParam(w, r)
+	if !ok {
+		return
+	}
+	alertService := services.AlertService{}
+	if err := alertService.TestChannel(id); err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("Test notification sent"))
+}
+
+func getAlertSilences(w http.ResponseWriter, r *http.Request) {
+	alertService := services.AlertService{}
+	silences, err := alertService.GetSilences()
+	writeAlertJSON(w, silences, err)
+}
+
+func addAlertSilence(w http.ResponseWriter, r *http.Request) {
+	var silence db.AlertSilence
+	if err := json.NewDecoder(r.Body).Decode(&silence); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	alertService := services.AlertService{}
+	created, err := alertService.AddSilence(silence)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	writeAlertJSON(w, created, nil)
+}
+
+func removeAlertSilence(w http.ResponseWriter, r *http.Request) {
+	id, ok := alertIdParam(w, r)
+	if !ok {
+		return
+	}
+	alertService := services.AlertService{}
+	if err := alertService.RemoveSilence(id); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("Alert silence removed"))
+}
+
+func setupAlertsAPI(r chi.Router) chi.Router {
+	return r.Route("/alerts", func(r chi.Router) {
+		r.Get("/", getAlerts)
+
+		r.Get("/rules", getAlertRules)
+		r.Post("/rules", addAlertRule)
+		r.Put("/rules/{id}", updateAlertRule)
+		r.Delete("/rules/{id}", removeAlertRule)
+
+		r.Get("/channels", getAlertChannels)
+		r.Post("/channels", addAlertChannel)
+		r.Delete("/channels/{id}", removeAlertChannel)
+		r.Post("/channels/{id}/test", testAlertChannel)
+
+		r.Get("/silences", getAlertSilences)
+		r.Post("/silences", addAlertSilence)
+		r.Delete("/silences/{id}", removeAlertSilence)
+	})
+}
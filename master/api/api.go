@@ -54,11 +54,13 @@ func StartApi() {
 	r := chi.NewRouter()
 
 	r.Post("/login", loginHandler)
+	setupPrometheusAPI(r)
 
 	//create a group protected by auth middleware
 	r.Group(func(r chi.Router) {
 		r.Use(authMiddleware)
 		setupNoVNCAPI(r)
+		setupConsoleAPI(r)
 		r.Get("/protected", protectedRoutes)
 
 		r.Get("/ws", wsHandler)
@@ -74,6 +76,17 @@ func StartApi() {
 		setupProtocolAPI(r)
 		setupLogsAPI(r)
 		setupISOAPI(r)
+		setupTemplatesAPI(r)
+		setupNetworksAPI(r)
+		setupHAAPI(r)
+		setupMaintenanceAPI(r)
+		setupDRSAPI(r)
+		setupBackupsAPI(r)
+		setupTrashAPI(r)
+		setupHostsAPI(r)
+		setupHistoryAPI(r)
+		setupAlertsAPI(r)
+		setupPKIAPI(r)
 		setupExtraAPI(r)
 	})
 
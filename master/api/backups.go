@@ -0,0 +1,166 @@
+package api
+
+import (
+	"512SvMan/db"
+	"512SvMan/services"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func getBackups(w http.ResponseWriter, r *http.Request) {
+	backupService := services.BackupService{}
+
+	// ?vm_name=x only lists the backups of that vm
+	if vmName := r.URL.Query().Get("vm_name"); vmName != "" {
+		list, err := backupService.ListBackups(vmName)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(list)
+		return
+	}
+
+	status, err := backupService.GetStatus()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(status)
+}
+
+func backupVM(w http.ResponseWriter, r *http.Request) {
+	vmName := chi.URLParam(r, "vm_name")
+	if vmName == "" {
+		http.Error(w, "vm_name is required", http.StatusBadRequest)
+		return
+	}
+
+	var req struct {
+		NfsShareId  int  `json:"nfs_share_id"`
+		Incremental bool `json:"incremental"` // only what changed since the last backup
+	}
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	backupService := services.BackupService{}
+	backup, err := backupService.BackupVM(vmName, req.NfsShareId, req.Incremental)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(backup)
+}
+
+func deleteBackup(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "invalid id", http.StatusBadRequest)
+		return
+	}
+
+	backupService := services.BackupService{}
+	err = backupService.DeleteBackup(id)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("Backup deleted successfully"))
+}
+
+func restoreBackup(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "invalid id", http.StatusBadRequest)
+		return
+	}
+
+	var req struct {
+		Name        string `json:"name"` // the restore is always a new vm
+		NfsShareId  int    `json:"nfs_share_id"`
+		MachineName string `json:"machine_name"` // optional, the slave that took the backup by default
+	}
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if req.Name == "" {
+		http.Error(w, "name is required", http.StatusBadRequest)
+		return
+	}
+
+	backupService := services.BackupService{}
+	err = backupService.RestoreBackup(id, req.Name, req.NfsShareId, req.MachineName)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusCreated)
+	w.Write([]byte("Backup restored successfully"))
+}
+
+func setBackupPolicy(w http.ResponseWriter, r *http.Request) {
+	vmName := chi.URLParam(r, "vm_name")
+	if vmName == "" {
+		http.Error(w, "vm_name is required", http.StatusBadRequest)
+		return
+	}
+
+	var policy db.VmBackupPolicy
+	err := json.NewDecoder(r.Body).Decode(&policy)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	policy.VmName = vmName
+
+	backupService := services.BackupService{}
+	err = backupService.SetPolicy(policy)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("Backup policy saved"))
+}
+
+func removeBackupPolicy(w http.ResponseWriter, r *http.Request) {
+	vmName := chi.URLParam(r, "vm_name")
+	if vmName == "" {
+		http.Error(w, "vm_name is required", http.StatusBadRequest)
+		return
+	}
+
+	backupService := services.BackupService{}
+	err := backupService.RemovePolicy(vmName)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("Backup policy removed"))
+}
+
+func setupBackupsAPI(r chi.Router) chi.Router {
+	return r.Route("/backups", func(r chi.Router) {
+		r.Get("/", getBackups)
+		r.Post("/vm/{vm_name}", backupVM)
+		r.Put("/policies/{vm_name}", setBackupPolicy)
+		r.Delete("/policies/{vm_name}", removeBackupPolicy)
+		r.Post("/{id}/restore", restoreBackup)
+		r.Delete("/{id}", deleteBackup)
+	})
+}
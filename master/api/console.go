@@ -0,0 +1,138 @@
+package api
+
+import (
+	"512SvMan/db"
+	"512SvMan/services"
+	"context"
+	"encoding/json"
+	"net/http"
+	neturl "net/url"
+	"time"
+
+	grpcVirsh "github.com/Maruqes/512SvMan/api/proto/virsh"
+	"github.com/Maruqes/512SvMan/logger"
+	"github.com/go-chi/chi/v5"
+	"github.com/gorilla/websocket"
+)
+
+// ws://localhost:9595/console/serial?token=<token from POST /console/token>    raw terminal bytes both ways, made for xterm.js
+func serveSerialConsole(w http.ResponseWriter, r *http.Request) {
+	consoleService := services.ConsoleService{}
+	session, vm, err := consoleService.OpenSession(baseURL, GetTokenFromContext(r), r.URL.Query().Get("token"), db.ConsoleKindSerial, r.RemoteAddr)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+	defer func() {
+		if err := consoleService.CloseSession(session); err != nil {
+			logger.Error("serial console:", err)
+		}
+	}()
+	vmName := vm.Name
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// opened before the upgrade so a missing vm is still a plain http error
+	virshServices := services.VirshService{}
+	stream, err := virshServices.OpenSerialConsole(ctx, vmName)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+	logger.Info("serial console of VM", vmName, "opened by", session.User)
+
+	go func() {
+		for {
+			msg, err := stream.Recv()
+			if err != nil {
+				// vm shut down or the slave went away, closing makes the read loop below return
+				conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, "console closed"))
+				conn.Close()
+				return
+			}
+			if err := conn.WriteMessage(websocket.BinaryMessage, msg.Data); err != nil {
+				return
+			}
+		}
+	}()
+
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			break
+		}
+		if err := stream.Send(&grpcVirsh.ConsoleData{Data: data}); err != nil {
+			break
+		}
+	}
+	stream.CloseSend()
+	logger.Info("serial console closed for VM", vmName)
+}
+
+func issueConsoleToken(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		VmName     string `json:"vm_name"`
+		Kind       string `json:"kind"`        // vnc or serial
+		TtlSeconds int    `json:"ttl_seconds"` // 60 when 0
+		SingleUse  *bool  `json:"single_use"`  // true when not set
+	}
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if req.VmName == "" {
+		http.Error(w, "vm_name is required", http.StatusBadRequest)
+		return
+	}
+	if req.Kind == "" {
+		req.Kind = db.ConsoleKindVNC
+	}
+	singleUse := req.SingleUse == nil || *req.SingleUse
+
+	consoleService := services.ConsoleService{}
+	token, tok, err := consoleService.IssueToken(baseURL, GetTokenFromContext(r), req.VmName, req.Kind, time.Duration(req.TtlSeconds)*time.Second, singleUse)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	url := "/console/serial?token=" + token
+	if tok.Kind == db.ConsoleKindVNC {
+		url = "/novnc/vnc.html?path=" + neturl.QueryEscape("novnc/ws?token="+token)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]any{
+		"token":      token,
+		"expires_at": tok.ExpiresAt,
+		"url":        url,
+	})
+}
+
+func getConsoleSessions(w http.ResponseWriter, r *http.Request) {
+	consoleService := services.ConsoleService{}
+	sessions, err := consoleService.ListSessions(r.URL.Query().Get("vm_name"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(sessions)
+}
+
+func setupConsoleAPI(r chi.Router) chi.Router {
+	return r.Route("/console", func(r chi.Router) {
+		r.Post("/token", issueConsoleToken)
+		r.Get("/sessions", getConsoleSessions)
+		r.Get("/serial", serveSerialConsole)
+	})
+}
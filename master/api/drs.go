@@ -0,0 +1,125 @@
+package api
+
+import (
+	"512SvMan/db"
+	"512SvMan/services"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func getDRSStatus(w http.ResponseWriter, r *http.Request) {
+	drsService := services.DRSService{}
+	status, err := drsService.GetStatus()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(status)
+}
+
+func setDRSConfig(w http.ResponseWriter, r *http.Request) {
+	cfg, err := db.GetDRSConfig()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// fields left out keep their current value
+	var req struct {
+		Mode            *string  `json:"mode"` // off, dry-run, manual or automatic
+		Threshold       *float64 `json:"threshold"`
+		IntervalSeconds *int     `json:"interval_seconds"`
+		CooldownSeconds *int     `json:"cooldown_seconds"`
+		MaxMigrations   *int     `json:"max_migrations"`
+	}
+	err = json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if req.Mode != nil {
+		cfg.Mode = *req.Mode
+	}
+	if req.Threshold != nil {
+		cfg.Threshold = *req.Threshold
+	}
+	if req.IntervalSeconds != nil {
+		cfg.IntervalSeconds = *req.IntervalSeconds
+	}
+	if req.CooldownSeconds != nil {
+		cfg.CooldownSeconds = *req.CooldownSeconds
+	}
+	if req.MaxMigrations != nil {
+		cfg.MaxMigrations = *req.MaxMigrations
+	}
+
+	drsService := services.DRSService{}
+	err = drsService.SetConfig(cfg)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("DRS config saved"))
+}
+
+func runDRS(w http.ResponseWriter, r *http.Request) {
+	drsService := services.DRSService{}
+	status, err := drsService.Run()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(status)
+}
+
+func approveDRSProposal(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "invalid id", http.StatusBadRequest)
+		return
+	}
+
+	drsService := services.DRSService{}
+	err = drsService.Approve(id)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("VM migrated"))
+}
+
+func rejectDRSProposal(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "invalid id", http.StatusBadRequest)
+		return
+	}
+
+	drsService := services.DRSService{}
+	err = drsService.Reject(id)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("Proposal rejected"))
+}
+
+func setupDRSAPI(r chi.Router) chi.Router {
+	return r.Route("/drs", func(r chi.Router) {
+		r.Get("/", getDRSStatus)
+		r.Put("/config", setDRSConfig)
+		r.Post("/run", runDRS)
+		r.Post("/proposals/{id}/approve", approveDRSProposal)
+		r.Delete("/proposals/{id}", rejectDRSProposal)
+	})
+}
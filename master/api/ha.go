@@ -0,0 +1,59 @@
+package api
+
+import (
+	"512SvMan/db"
+	"512SvMan/services"
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func setVmHA(w http.ResponseWriter, r *http.Request) {
+	vmName := chi.URLParam(r, "vm_name")
+	if vmName == "" {
+		http.Error(w, "vm_name is required", http.StatusBadRequest)
+		return
+	}
+
+	var req struct {
+		Policy   string `json:"policy"`   // restart or none
+		Priority int    `json:"priority"` // higher restarts first
+	}
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	haService := services.HAService{}
+	err = haService.SetPolicy(vmName, req.Policy, req.Priority)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("HA policy set"))
+}
+
+func getHAPolicies(w http.ResponseWriter, r *http.Request) {
+	haService := services.HAService{}
+	policies, err := haService.GetPolicies()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(struct {
+		Policies []db.VmHA         `json:"policies"`
+		Pending  map[string]string `json:"pending"` // vm -> slave it was lost with
+	}{policies, haService.Pending()})
+}
+
+func setupHAAPI(r chi.Router) chi.Router {
+	return r.Route("/ha", func(r chi.Router) {
+		r.Get("/", getHAPolicies)
+		r.Post("/{vm_name}", setVmHA)
+	})
+}
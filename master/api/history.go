@@ -0,0 +1,53 @@
+package api
+
+import (
+	"512SvMan/db"
+	"512SvMan/services"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// ?from=&to=&step= in unix seconds, all optional
+func historyQuery(w http.ResponseWriter, r *http.Request, kind, name string) {
+	var params [3]int64
+	for i, key := range []string{"from", "to", "step"} {
+		raw := r.URL.Query().Get(key)
+		if raw == "" {
+			continue
+		}
+		v, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			http.Error(w, "invalid "+key, http.StatusBadRequest)
+			return
+		}
+		params[i] = v
+	}
+
+	historyService := services.HistoryService{}
+	history, err := historyService.Query(kind, name, params[0], params[1], params[2])
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(history)
+}
+
+func getHostHistory(w http.ResponseWriter, r *http.Request) {
+	historyQuery(w, r, db.MetricKindHost, chi.URLParam(r, "machine"))
+}
+
+func getVmHistory(w http.ResponseWriter, r *http.Request) {
+	historyQuery(w, r, db.MetricKindVM, chi.URLParam(r, "vm_name"))
+}
+
+func setupHistoryAPI(r chi.Router) chi.Router {
+	return r.Route("/history", func(r chi.Router) {
+		r.Get("/hosts/{machine}", getHostHistory)
+		r.Get("/vms/{vm_name}", getVmHistory)
+	})
+}
@@ -0,0 +1,146 @@
+package api
+
+import (
+	"512SvMan/services"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func writeHostJSON(w http.ResponseWriter, v any, err error) {
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
+
+// cpu, memory, disks and network in one reading
+func getHostMetrics(w http.ResponseWriter, r *http.Request) {
+	hostService := services.HostService{}
+	metrics, err := hostService.GetMetrics(chi.URLParam(r, "machine"))
+	writeHostJSON(w, metrics, err)
+}
+
+func getHostCPU(w http.ResponseWriter, r *http.Request) {
+	hostService := services.HostService{}
+	cpu, err := hostService.GetCPUInfo(chi.URLParam(r, "machine"))
+	writeHostJSON(w, cpu, err)
+}
+
+func getHostMem(w http.ResponseWriter, r *http.Request) {
+	hostService := services.HostService{}
+	mem, err := hostService.GetMemInfo(chi.URLParam(r, "machine"))
+	writeHostJSON(w, mem, err)
+}
+
+func getHostDisks(w http.ResponseWriter, r *http.Request) {
+	hostService := services.HostService{}
+	disks, err := hostService.GetDiskInfo(chi.URLParam(r, "machine"))
+	writeHostJSON(w, disks, err)
+}
+
+func getHostNetwork(w http.ResponseWriter, r *http.Request) {
+	hostService := services.HostService{}
+	network, err := hostService.GetNetworkInfo(chi.URLParam(r, "machine"))
+	writeHostJSON(w, network, err)
+}
+
+// ?limit=20&sort=memory, all processes sorted by cpu by default
+func getHostProcesses(w http.ResponseWriter, r *http.Request) {
+	limit := 0
+	if raw := r.URL.Query().Get("limit"); raw != "" {
+		var err error
+		limit, err = strconv.Atoi(raw)
+		if err != nil || limit < 0 {
+			http.Error(w, "invalid limit", http.StatusBadRequest)
+			return
+		}
+	}
+
+	hostService := services.HostService{}
+	procs, err := hostService.GetProcesses(chi.URLParam(r, "machine"), limit, r.URL.Query().Get("sort"))
+	writeHostJSON(w, procs, err)
+}
+
+// versions, cpu and features the slave reported when it last connected
+func getHostCapabilities(w http.ResponseWriter, r *http.Request) {
+	hostService := services.HostService{}
+	caps, err := hostService.GetCapabilities(chi.URLParam(r, "machine"))
+	writeHostJSON(w, caps, err)
+}
+
+func getAllHostCapabilities(w http.ResponseWriter, r *http.Request) {
+	hostService := services.HostService{}
+	caps, err := hostService.GetAllCapabilities()
+	writeHostJSON(w, caps, err)
+}
+
+func getHostServices(w http.ResponseWriter, r *http.Request) {
+	hostService := services.HostService{}
+	list, err := hostService.GetServices(chi.URLParam(r, "machine"))
+	writeHostJSON(w, list, err)
+}
+
+func getHostServiceStatus(w http.ResponseWriter, r *http.Request) {
+	hostService := services.HostService{}
+	status, err := hostService.GetServiceStatus(chi.URLParam(r, "machine"), chi.URLParam(r, "service"))
+	writeHostJSON(w, status, err)
+}
+
+// ?lines=200, the last 100 by default
+func getHostServiceLogs(w http.ResponseWriter, r *http.Request) {
+	lines := 0
+	if raw := r.URL.Query().Get("lines"); raw != "" {
+		var err error
+		lines, err = strconv.Atoi(raw)
+		if err != nil || lines < 0 {
+			http.Error(w, "invalid lines", http.StatusBadRequest)
+			return
+		}
+	}
+
+	hostService := services.HostService{}
+	logs, err := hostService.GetServiceLogs(chi.URLParam(r, "machine"), chi.URLParam(r, "service"), lines)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Write([]byte(logs))
+}
+
+// POST /hosts/{machine}/services/libvirtd/restart
+func hostServiceAction(w http.ResponseWriter, r *http.Request) {
+	hostService := services.HostService{}
+	err := hostService.ServiceAction(chi.URLParam(r, "machine"), chi.URLParam(r, "service"), chi.URLParam(r, "action"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("Service action done"))
+}
+
+// the same readings are pushed to /ws as HostMetrics every few seconds
+func setupHostsAPI(r chi.Router) chi.Router {
+	r.Get("/hosts/capabilities", getAllHostCapabilities)
+	return r.Route("/hosts/{machine}", func(r chi.Router) {
+		r.Get("/capabilities", getHostCapabilities)
+
+		r.Get("/metrics", getHostMetrics)
+		r.Get("/metrics/cpu", getHostCPU)
+		r.Get("/metrics/mem", getHostMem)
+		r.Get("/metrics/disks", getHostDisks)
+		r.Get("/metrics/network", getHostNetwork)
+		r.Get("/processes", getHostProcesses)
+
+		r.Get("/services", getHostServices)
+		r.Get("/services/{service}", getHostServiceStatus)
+		r.Get("/services/{service}/logs", getHostServiceLogs)
+		r.Post("/services/{service}/{action}", hostServiceAction)
+	})
+}
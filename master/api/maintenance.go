@@ -0,0 +1,63 @@
+package api
+
+import (
+	"512SvMan/services"
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func enterMaintenance(w http.ResponseWriter, r *http.Request) {
+	machineName := chi.URLParam(r, "machine_name")
+	if machineName == "" {
+		http.Error(w, "machine_name is required", http.StatusBadRequest)
+		return
+	}
+
+	maintenanceService := services.MaintenanceService{}
+	err := maintenanceService.EnterMaintenance(machineName)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusAccepted)
+	w.Write([]byte("Maintenance started, evacuating VMs"))
+}
+
+func exitMaintenance(w http.ResponseWriter, r *http.Request) {
+	machineName := chi.URLParam(r, "machine_name")
+	if machineName == "" {
+		http.Error(w, "machine_name is required", http.StatusBadRequest)
+		return
+	}
+
+	maintenanceService := services.MaintenanceService{}
+	err := maintenanceService.ExitMaintenance(machineName)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("Maintenance ended"))
+}
+
+func getMaintenance(w http.ResponseWriter, r *http.Request) {
+	maintenanceService := services.MaintenanceService{}
+	status, err := maintenanceService.GetStatus()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(status)
+}
+
+func setupMaintenanceAPI(r chi.Router) chi.Router {
+	return r.Route("/maintenance", func(r chi.Router) {
+		r.Get("/", getMaintenance)
+		r.Post("/{machine_name}", enterMaintenance)
+		r.Delete("/{machine_name}", exitMaintenance)
+	})
+}
@@ -0,0 +1,103 @@
+package api
+
+import (
+	"512SvMan/db"
+	"512SvMan/services"
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func createNetwork(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Name          string `json:"name"`
+		Mode          string `json:"mode"`           // nat, route, isolated or bridge
+		Bridge        string `json:"bridge"`         // optional, on bridge mode an existing host bridge
+		HostInterface string `json:"host_interface"` // route uplink or physical nic for macvtap bridge
+		IPAddress     string `json:"ip_address"`
+		Netmask       string `json:"netmask"`
+		DHCPStart     string `json:"dhcp_start"`
+		DHCPEnd       string `json:"dhcp_end"`
+		Autostart     *bool  `json:"autostart"` // defaults to true
+	}
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	autostart := true
+	if req.Autostart != nil {
+		autostart = *req.Autostart
+	}
+
+	networkService := services.NetworkService{}
+	err = networkService.CreateNetwork(db.Network{
+		Name:          req.Name,
+		Mode:          req.Mode,
+		Bridge:        req.Bridge,
+		HostInterface: req.HostInterface,
+		IPAddress:     req.IPAddress,
+		Netmask:       req.Netmask,
+		DHCPStart:     req.DHCPStart,
+		DHCPEnd:       req.DHCPEnd,
+		Autostart:     autostart,
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	w.Write([]byte("Network created"))
+}
+
+func getAllNetworks(w http.ResponseWriter, r *http.Request) {
+	networkService := services.NetworkService{}
+	networks, err := networkService.GetAllNetworks()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(networks)
+}
+
+func removeNetwork(w http.ResponseWriter, r *http.Request) {
+	name := chi.URLParam(r, "name")
+	if name == "" {
+		http.Error(w, "name is required", http.StatusBadRequest)
+		return
+	}
+
+	networkService := services.NetworkService{}
+	err := networkService.RemoveNetwork(name)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("Network removed"))
+}
+
+func syncNetworks(w http.ResponseWriter, r *http.Request) {
+	networkService := services.NetworkService{}
+	err := networkService.SyncNetworks()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("Networks synced"))
+}
+
+func setupNetworksAPI(r chi.Router) chi.Router {
+	return r.Route("/networks", func(r chi.Router) {
+		r.Post("/", createNetwork)
+		r.Get("/", getAllNetworks)
+		r.Post("/sync", syncNetworks)
+		r.Delete("/{name}", removeNetwork)
+	})
+}
@@ -1,11 +1,12 @@
 package api
 
 import (
+	"512SvMan/db"
 	"512SvMan/protocol"
-	"512SvMan/virsh"
+	"512SvMan/services"
+	"context"
 	"net/http"
 
-	grpcVirsh "github.com/Maruqes/512SvMan/api/proto/virsh"
 	"github.com/Maruqes/512SvMan/logger"
 	"github.com/evangwt/go-vncproxy"
 	"github.com/go-chi/chi/v5"
@@ -16,43 +17,47 @@ import (
 
 var vp *vncproxy.Proxy
 
-// http://localhost:9595/novnc/vnc.html?path=/novnc/ws?token=vm1
-// http://localhost:9595/novnc/vnc.html?path=/novnc/ws%3Fvm%3Dplsfunfa%26slave%3Dslave1    change plsfunfa and slave1
+type consoleTargetKey struct{}
+
+// get a token from POST /console/token first, then
+// http://localhost:9595/novnc/vnc.html?path=novnc/ws%3Ftoken%3D<token>
 func initNoVNC() {
 	vp = vncproxy.New(&vncproxy.Config{
 		LogLevel: vncproxy.DebugLevel,
 		TokenHandler: func(r *http.Request) (string, error) {
-			// map token -> VNC backend
-			// e.g., token=vm1 -> localhost:5901 (adjust as needed)
-			vmName := r.URL.Query().Get("vm")
-			slaveName := r.URL.Query().Get("slave")
-			if vmName == "" || slaveName == "" {
-				logger.Error("novnc: missing vm or slave parameter")
-				return "", http.ErrNoLocation
-			}
-
-			slaveMachine := protocol.GetConnectionByMachineName(slaveName)
-			if slaveMachine == nil {
-				logger.Error("novnc: slave machine not found")
+			// the backend was resolved from the console token in serveNoVNCWebSocket
+			target, ok := r.Context().Value(consoleTargetKey{}).(string)
+			if !ok || target == "" {
+				logger.Error("novnc: no console target for the request")
 				return "", http.ErrNoLocation
 			}
-
-			vm, err := virsh.GetVmByName(slaveMachine.Connection, &grpcVirsh.GetVmByNameRequest{Name: vmName})
-			if err != nil {
-				logger.Error("novnc: failed to get VM by name")
-				return "", http.ErrNoLocation
-			}
-			if vm == nil {
-				logger.Error("novnc: VM not found or VNC not configured")
-				return "", http.ErrNoLocation
-			}
-			logger.Info("novnc: connecting to VM %s on slave %s at %s:%d", vmName, slaveName, slaveMachine.Addr, vm.NovncPort)
-			return slaveMachine.Addr + ":" + vm.NovncPort, nil
+			return target, nil
 		},
 	})
 }
 
 func serveNoVNCWebSocket(w http.ResponseWriter, r *http.Request) {
+	consoleService := services.ConsoleService{}
+	session, vm, err := consoleService.OpenSession(baseURL, GetTokenFromContext(r), r.URL.Query().Get("token"), db.ConsoleKindVNC, r.RemoteAddr)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+	defer func() {
+		if err := consoleService.CloseSession(session); err != nil {
+			logger.Error("novnc:", err)
+		}
+	}()
+
+	slaveMachine := protocol.GetConnectionByMachineName(vm.MachineName)
+	if slaveMachine == nil {
+		http.Error(w, "slave "+vm.MachineName+" not connected", http.StatusBadGateway)
+		return
+	}
+	logger.Info("novnc: user", session.User, "connecting to VM", vm.Name, "on slave", vm.MachineName)
+
+	target := slaveMachine.Addr + ":" + vm.NovncPort
+	r = r.WithContext(context.WithValue(r.Context(), consoleTargetKey{}, target))
 	websocket.Handler(vp.ServeWS).ServeHTTP(w, r)
 }
 
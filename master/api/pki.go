@@ -0,0 +1,104 @@
+package api
+
+import (
+	"512SvMan/services"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// the token is only in this answer, give it to the new slave as ENROLL_TOKEN
+func createEnrollmentToken(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		MachineName string `json:"machine_name"` // optional, the token only works for this slave
+		TTLMinutes  int    `json:"ttl_minutes"`  // 60 when 0
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	pkiService := services.PKIService{}
+	token, tok, err := pkiService.CreateEnrollmentToken(req.MachineName, time.Duration(req.TTLMinutes)*time.Minute)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]any{
+		"id":           tok.Id,
+		"token":        token,
+		"machine_name": tok.MachineName,
+		"expires_at":   tok.ExpiresAt,
+	})
+}
+
+func getEnrollmentTokens(w http.ResponseWriter, r *http.Request) {
+	pkiService := services.PKIService{}
+	tokens, err := pkiService.GetEnrollmentTokens()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(tokens)
+}
+
+func removeEnrollmentToken(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "invalid id", http.StatusBadRequest)
+		return
+	}
+	pkiService := services.PKIService{}
+	if err := pkiService.RemoveEnrollmentToken(id); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("Enrollment token removed"))
+}
+
+func getSlaveCerts(w http.ResponseWriter, r *http.Request) {
+	pkiService := services.PKIService{}
+	certs, err := pkiService.GetSlaveCerts()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(certs)
+}
+
+func revokeSlaveCerts(w http.ResponseWriter, r *http.Request) {
+	pkiService := services.PKIService{}
+	if err := pkiService.RevokeSlave(chi.URLParam(r, "machine")); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("Slave certificates revoked"))
+}
+
+func getCA(w http.ResponseWriter, r *http.Request) {
+	pkiService := services.PKIService{}
+	ca, fingerprint := pkiService.GetCA()
+	w.Header().Set("Content-Type", "application/x-pem-file")
+	w.Header().Set("X-CA-Fingerprint", fingerprint)
+	w.Write(ca)
+}
+
+func setupPKIAPI(r chi.Router) chi.Router {
+	return r.Route("/pki", func(r chi.Router) {
+		r.Get("/ca", getCA)
+		r.Get("/tokens", getEnrollmentTokens)
+		r.Post("/tokens", createEnrollmentToken)
+		r.Delete("/tokens/{id}", removeEnrollmentToken)
+		r.Get("/certs", getSlaveCerts)
+		r.Delete("/certs/{machine}", revokeSlaveCerts)
+	})
+}
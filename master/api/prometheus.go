@@ -0,0 +1,33 @@
+package api
+
+import (
+	"512SvMan/env512"
+	"512SvMan/services"
+	"crypto/subtle"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// metricsAuth lets prometheus in with METRICS_TOKEN as bearer token, anyone else needs a normal login
+func metricsAuth(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := tokenFromRequest(r)
+		if env512.MetricsToken != "" && subtle.ConstantTimeCompare([]byte(token), []byte(env512.MetricsToken)) == 1 {
+			next.ServeHTTP(w, r)
+			return
+		}
+		authMiddleware(next).ServeHTTP(w, r)
+	})
+}
+
+func getPrometheusMetrics(w http.ResponseWriter, r *http.Request) {
+	prometheusService := services.PrometheusService{}
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	w.Write([]byte(prometheusService.Render()))
+}
+
+// outside the auth group, prometheus can not log in to npm
+func setupPrometheusAPI(r chi.Router) {
+	r.With(metricsAuth).Get("/metrics", getPrometheusMetrics)
+}
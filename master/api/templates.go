@@ -0,0 +1,98 @@
+package api
+
+import (
+	"512SvMan/db"
+	"512SvMan/services"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/Maruqes/512SvMan/logger"
+	"github.com/go-chi/chi/v5"
+)
+
+func addTemplate(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Name        string `json:"name"`
+		NfsShareID  int    `json:"nfs_share_id"`
+		FileName    string `json:"file_name"` // qcow2 path inside the nfs share
+		Description string `json:"description"`
+	}
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	templateService := services.TemplateService{}
+	err = templateService.AddTemplate(req.Name, req.NfsShareID, req.FileName, req.Description)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	w.Write([]byte("Template added"))
+}
+
+func getAllTemplates(w http.ResponseWriter, r *http.Request) {
+	templates, err := db.GetAllVmTemplates()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	type resStruct struct {
+		db.VmTemplate
+		AvailableOnSlaves map[string]bool `json:"available_on_slaves"`
+	}
+	templatesRes := make([]resStruct, len(templates))
+	for i := range templates {
+		templatesRes[i] = resStruct{
+			VmTemplate:        templates[i],
+			AvailableOnSlaves: make(map[string]bool),
+		}
+	}
+
+	nfsService := services.NFSService{}
+	for i := range templates {
+		workingFile, err := nfsService.CanFindFileOrDirOnAllSlaves(templates[i].DiskPath)
+		if err != nil {
+			logger.Error("CanFindFileOrDirOnAllSlaves failed: %v", err)
+			continue
+		}
+		templatesRes[i].AvailableOnSlaves = workingFile
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(templatesRes)
+}
+
+func removeTemplateByID(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	if idStr == "" {
+		http.Error(w, "id is required", http.StatusBadRequest)
+		return
+	}
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		http.Error(w, "invalid id", http.StatusBadRequest)
+		return
+	}
+
+	templateService := services.TemplateService{}
+	err = templateService.RemoveTemplate(id)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("Template removed"))
+}
+
+func setupTemplatesAPI(r chi.Router) chi.Router {
+	return r.Route("/templates", func(r chi.Router) {
+		r.Post("/", addTemplate)
+		r.Get("/", getAllTemplates)
+		r.Delete("/{id}", removeTemplateByID)
+	})
+}
@@ -0,0 +1,96 @@
+package api
+
+import (
+	"512SvMan/services"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func getTrash(w http.ResponseWriter, r *http.Request) {
+	trashService := services.TrashService{}
+	status, err := trashService.GetStatus()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(status)
+}
+
+func setTrashConfig(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		RetentionHours int `json:"retention_hours"` // trashed vms older than this are purged
+	}
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	trashService := services.TrashService{}
+	err = trashService.SetRetention(req.RetentionHours)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("Trash config saved"))
+}
+
+func restoreTrashedVM(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "invalid id", http.StatusBadRequest)
+		return
+	}
+
+	// the body is optional, machine_name defaults to the slave the vm was deleted on
+	var req struct {
+		MachineName string `json:"machine_name"`
+	}
+	if r.ContentLength > 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	trashService := services.TrashService{}
+	err = trashService.Restore(id, req.MachineName)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("VM restored successfully"))
+}
+
+func purgeTrashedVM(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "invalid id", http.StatusBadRequest)
+		return
+	}
+
+	trashService := services.TrashService{}
+	err = trashService.Purge(id)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("VM purged successfully"))
+}
+
+func setupTrashAPI(r chi.Router) chi.Router {
+	return r.Route("/trash", func(r chi.Router) {
+		r.Get("/", getTrash)
+		r.Put("/config", setTrashConfig)
+		r.Post("/{id}/restore", restoreTrashedVM)
+		r.Delete("/{id}", purgeTrashedVM)
+	})
+}
@@ -37,15 +37,19 @@ func getCpuFeatures(w http.ResponseWriter, r *http.Request) {
 
 func createVM(w http.ResponseWriter, r *http.Request) {
 	type VMRequest struct {
-		MachineName string `json:"machine_name"`
-		Name        string `json:"name"`
-		Memory      int32  `json:"memory"`
-		Vcpu        int32  `json:"vcpu"`
-		DiskSizeGB  int32  `json:"disk_sizeGB"`
-		IsoID       int    `json:"iso_id"`
-		NfsShareId  int    `json:"nfs_share_id"`
-		Network     string `json:"network"`
-		VNCPassword string `json:"VNC_password"`
+		MachineName string                    `json:"machine_name"`
+		Name        string                    `json:"name"`
+		Memory      int32                     `json:"memory"`
+		Vcpu        int32                     `json:"vcpu"`
+		DiskSizeGB  int32                     `json:"disk_sizeGB"`
+		IsoID       int                       `json:"iso_id"`
+		NfsShareId  int                       `json:"nfs_share_id"`
+		Network     string                    `json:"network"`
+		VNCPassword string                    `json:"VNC_password"`
+		TemplateID  int                       `json:"template_id"` // alternative to iso_id
+		CloudInit   *services.CloudInitConfig `json:"cloud_init"`
+		Nics        []services.NicConfig      `json:"nics"` // replaces network, one entry per nic
+		Placement   *services.PlacementConfig `json:"placement"`
 	}
 
 	var vmReq VMRequest
@@ -56,14 +60,36 @@ func createVM(w http.ResponseWriter, r *http.Request) {
 	}
 
 	virshServices := services.VirshService{}
-	err = virshServices.CreateVM(vmReq.MachineName, vmReq.Name, vmReq.Memory, vmReq.Vcpu, vmReq.NfsShareId, vmReq.DiskSizeGB, vmReq.IsoID, vmReq.Network, vmReq.VNCPassword)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+	decision, err := virshServices.CreateVM(vmReq.MachineName, vmReq.Name, vmReq.Memory, vmReq.Vcpu, vmReq.NfsShareId, vmReq.DiskSizeGB, vmReq.IsoID, vmReq.Network, vmReq.VNCPassword, vmReq.TemplateID, vmReq.CloudInit, vmReq.Nics, vmReq.Placement)
+	writeCreateVMResponse(w, "VM created successfully", decision, err)
+}
+
+// writeCreateVMResponse answers with the placement decision when the scheduler picked the slave
+func writeCreateVMResponse(w http.ResponseWriter, message string, decision *services.PlacementDecision, err error) {
+	if decision == nil {
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(message))
 		return
 	}
 
-	w.WriteHeader(http.StatusCreated)
-	w.Write([]byte("VM created successfully"))
+	res := struct {
+		Message   string                      `json:"message"`
+		Error     string                      `json:"error,omitempty"`
+		Placement *services.PlacementDecision `json:"placement"`
+	}{Message: message, Placement: decision}
+	status := http.StatusCreated
+	if err != nil {
+		res.Message = ""
+		res.Error = err.Error()
+		status = http.StatusInternalServerError
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(res)
 }
 
 func getAllVms(w http.ResponseWriter, r *http.Request) {
@@ -91,14 +117,21 @@ func deleteVM(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// ?purge=true deletes the files right away instead of moving the vm to the trash
+	purge := r.URL.Query().Get("purge") == "true"
+
 	virshServices := services.VirshService{}
-	err := virshServices.DeleteVM(vmName)
+	err := virshServices.DeleteVM(vmName, purge)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
 	w.WriteHeader(http.StatusOK)
+	if !purge {
+		w.Write([]byte("VM moved to trash"))
+		return
+	}
 	w.Write([]byte("VM deleted successfully"))
 }
 
@@ -234,16 +267,20 @@ func editVM(w http.ResponseWriter, r *http.Request) {
 
 func createLiveVM(w http.ResponseWriter, r *http.Request) {
 	type VMLiveRequest struct {
-		MachineName string `json:"machine_name"`
-		Name        string `json:"name"`
-		Memory      int32  `json:"memory"`
-		Vcpu        int32  `json:"vcpu"`
-		DiskSizeGB  int32  `json:"disk_sizeGB"`
-		IsoID       int    `json:"iso_id"`
-		NfsShareId  int    `json:"nfs_share_id"`
-		Network     string `json:"network"`
-		VNCPassword string `json:"VNC_password"`
-		CpuXml      string `json:"cpu_xml"` 
+		MachineName string                    `json:"machine_name"`
+		Name        string                    `json:"name"`
+		Memory      int32                     `json:"memory"`
+		Vcpu        int32                     `json:"vcpu"`
+		DiskSizeGB  int32                     `json:"disk_sizeGB"`
+		IsoID       int                       `json:"iso_id"`
+		NfsShareId  int                       `json:"nfs_share_id"`
+		Network     string                    `json:"network"`
+		VNCPassword string                    `json:"VNC_password"`
+		CpuXml      string                    `json:"cpu_xml"`
+		TemplateID  int                       `json:"template_id"` // alternative to iso_id
+		CloudInit   *services.CloudInitConfig `json:"cloud_init"`
+		Nics        []services.NicConfig      `json:"nics"` // replaces network, one entry per nic
+		Placement   *services.PlacementConfig `json:"placement"`
 	}
 
 	var vmReq VMLiveRequest
@@ -254,14 +291,8 @@ func createLiveVM(w http.ResponseWriter, r *http.Request) {
 	}
 
 	virshServices := services.VirshService{}
-	err = virshServices.CreateLiveVM(vmReq.MachineName, vmReq.Name, vmReq.Memory, vmReq.Vcpu, vmReq.NfsShareId, vmReq.DiskSizeGB, vmReq.IsoID, vmReq.Network, vmReq.VNCPassword, vmReq.CpuXml)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-
-	w.WriteHeader(http.StatusCreated)
-	w.Write([]byte("Live VM created successfully"))
+	decision, err := virshServices.CreateLiveVM(vmReq.MachineName, vmReq.Name, vmReq.Memory, vmReq.Vcpu, vmReq.NfsShareId, vmReq.DiskSizeGB, vmReq.IsoID, vmReq.Network, vmReq.VNCPassword, vmReq.CpuXml, vmReq.TemplateID, vmReq.CloudInit, vmReq.Nics, vmReq.Placement)
+	writeCreateVMResponse(w, "Live VM created successfully", decision, err)
 }
 
 func migrateLiveVM(w http.ResponseWriter, r *http.Request) {
@@ -279,6 +310,8 @@ func migrateLiveVM(w http.ResponseWriter, r *http.Request) {
 		OriginMachine      string `json:"origin_machine"`
 		DestinationMachine string `json:"destination_machine"`
 		Live               bool   `json:"live"`
+		BandwidthMiB       uint64 `json:"bandwidth_mib"`   // optional, MiB/s
+		MaxDowntimeMs      uint64 `json:"max_downtime_ms"` // optional
 	}
 
 	var migReq MigrateRequest
@@ -289,7 +322,8 @@ func migrateLiveVM(w http.ResponseWriter, r *http.Request) {
 	}
 
 	virshServices := services.VirshService{}
-	err = virshServices.MigrateVm(migReq.OriginMachine, migReq.DestinationMachine, vmName, migReq.Live)
+	limits := services.MigrationLimits{BandwidthMiB: migReq.BandwidthMiB, MaxDowntimeMs: migReq.MaxDowntimeMs}
+	err = virshServices.MigrateVm(migReq.OriginMachine, migReq.DestinationMachine, vmName, migReq.Live, limits)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -299,6 +333,49 @@ func migrateLiveVM(w http.ResponseWriter, r *http.Request) {
 	w.Write([]byte("VM migrated successfully"))
 }
 
+func cancelMigration(w http.ResponseWriter, r *http.Request) {
+	vmName := chi.URLParam(r, "vm_name")
+	if vmName == "" {
+		http.Error(w, "vm_name is required", http.StatusBadRequest)
+		return
+	}
+
+	virshServices := services.VirshService{}
+	err := virshServices.CancelMigration(vmName)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("Migration cancelled"))
+}
+
+func setMigrationLimits(w http.ResponseWriter, r *http.Request) {
+	vmName := chi.URLParam(r, "vm_name")
+	if vmName == "" {
+		http.Error(w, "vm_name is required", http.StatusBadRequest)
+		return
+	}
+
+	var limits services.MigrationLimits
+	err := json.NewDecoder(r.Body).Decode(&limits)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	virshServices := services.VirshService{}
+	err = virshServices.SetMigrationLimits(vmName, limits)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("Migration limits set"))
+}
+
 func removeIso(w http.ResponseWriter, r *http.Request) {
 	vmName := chi.URLParam(r, "vm_name")
 	if vmName == "" {
@@ -353,6 +430,447 @@ func pauseVm(w http.ResponseWriter, r *http.Request) {
 	w.Write([]byte("VM paused successfully"))
 }
 
+func cloneVM(w http.ResponseWriter, r *http.Request) {
+	vmName := chi.URLParam(r, "vm_name")
+	if vmName == "" {
+		http.Error(w, "vm_name is required", http.StatusBadRequest)
+		return
+	}
+
+	type CloneRequest struct {
+		Name       string `json:"name"`
+		NfsShareId int    `json:"nfs_share_id"`
+		Linked     bool   `json:"linked"` // qcow2 overlay on top of the source disk instead of a full copy
+		Start      bool   `json:"start"`
+	}
+
+	var cloneReq CloneRequest
+	err := json.NewDecoder(r.Body).Decode(&cloneReq)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if cloneReq.Name == "" {
+		http.Error(w, "name is required", http.StatusBadRequest)
+		return
+	}
+
+	virshServices := services.VirshService{}
+	err = virshServices.CloneVM(vmName, cloneReq.Name, cloneReq.NfsShareId, cloneReq.Linked, cloneReq.Start)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	w.Write([]byte("VM cloned successfully"))
+}
+
+func createSnapshot(w http.ResponseWriter, r *http.Request) {
+	vmName := chi.URLParam(r, "vm_name")
+	if vmName == "" {
+		http.Error(w, "vm_name is required", http.StatusBadRequest)
+		return
+	}
+
+	type SnapshotRequest struct {
+		Name        string `json:"name"`
+		Description string `json:"description"`
+		External    bool   `json:"external"` // disk-only qcow2 overlay instead of an internal snapshot
+	}
+
+	var snapReq SnapshotRequest
+	err := json.NewDecoder(r.Body).Decode(&snapReq)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if snapReq.Name == "" {
+		http.Error(w, "name is required", http.StatusBadRequest)
+		return
+	}
+
+	virshServices := services.VirshService{}
+	snap, err := virshServices.CreateSnapshot(vmName, snapReq.Name, snapReq.Description, snapReq.External)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	data, err := json.Marshal(snap)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusCreated)
+	w.Write(data)
+}
+
+func listSnapshots(w http.ResponseWriter, r *http.Request) {
+	vmName := chi.URLParam(r, "vm_name")
+	if vmName == "" {
+		http.Error(w, "vm_name is required", http.StatusBadRequest)
+		return
+	}
+
+	virshServices := services.VirshService{}
+	snaps, err := virshServices.ListSnapshots(vmName)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	data, err := json.Marshal(snaps)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Write(data)
+}
+
+func revertSnapshot(w http.ResponseWriter, r *http.Request) {
+	vmName := chi.URLParam(r, "vm_name")
+	snapName := chi.URLParam(r, "snapshot_name")
+	if vmName == "" || snapName == "" {
+		http.Error(w, "vm_name and snapshot_name are required", http.StatusBadRequest)
+		return
+	}
+
+	virshServices := services.VirshService{}
+	err := virshServices.RevertSnapshot(vmName, snapName)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("VM reverted to snapshot successfully"))
+}
+
+func deleteSnapshot(w http.ResponseWriter, r *http.Request) {
+	vmName := chi.URLParam(r, "vm_name")
+	snapName := chi.URLParam(r, "snapshot_name")
+	if vmName == "" || snapName == "" {
+		http.Error(w, "vm_name and snapshot_name are required", http.StatusBadRequest)
+		return
+	}
+
+	virshServices := services.VirshService{}
+	err := virshServices.DeleteSnapshot(vmName, snapName)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("Snapshot deleted successfully"))
+}
+
+func addDisk(w http.ResponseWriter, r *http.Request) {
+	vmName := chi.URLParam(r, "vm_name")
+	if vmName == "" {
+		http.Error(w, "vm_name is required", http.StatusBadRequest)
+		return
+	}
+
+	type AddDiskRequest struct {
+		NfsShareId int   `json:"nfs_share_id"`
+		SizeGB     int32 `json:"size_gb"`
+	}
+
+	var diskReq AddDiskRequest
+	err := json.NewDecoder(r.Body).Decode(&diskReq)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if diskReq.SizeGB <= 0 {
+		http.Error(w, "size_gb is required", http.StatusBadRequest)
+		return
+	}
+
+	virshServices := services.VirshService{}
+	disk, err := virshServices.AddDisk(vmName, diskReq.NfsShareId, diskReq.SizeGB)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	data, err := json.Marshal(disk)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusCreated)
+	w.Write(data)
+}
+
+func attachDisk(w http.ResponseWriter, r *http.Request) {
+	vmName := chi.URLParam(r, "vm_name")
+	if vmName == "" {
+		http.Error(w, "vm_name is required", http.StatusBadRequest)
+		return
+	}
+
+	type AttachDiskRequest struct {
+		NfsShareId int    `json:"nfs_share_id"`
+		FileName   string `json:"file_name"` // existing disk inside the nfs share
+	}
+
+	var diskReq AttachDiskRequest
+	err := json.NewDecoder(r.Body).Decode(&diskReq)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	virshServices := services.VirshService{}
+	disk, err := virshServices.AttachDisk(vmName, diskReq.NfsShareId, diskReq.FileName)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	data, err := json.Marshal(disk)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+func detachDisk(w http.ResponseWriter, r *http.Request) {
+	vmName := chi.URLParam(r, "vm_name")
+	target := chi.URLParam(r, "target")
+	if vmName == "" || target == "" {
+		http.Error(w, "vm_name and target are required", http.StatusBadRequest)
+		return
+	}
+
+	// ?delete_file=true also deletes disks created for this vm
+	deleteFile := r.URL.Query().Get("delete_file") == "true"
+
+	virshServices := services.VirshService{}
+	err := virshServices.DetachDisk(vmName, target, deleteFile)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("Disk detached successfully"))
+}
+
+func resizeDisk(w http.ResponseWriter, r *http.Request) {
+	vmName := chi.URLParam(r, "vm_name")
+	target := chi.URLParam(r, "target")
+	if vmName == "" || target == "" {
+		http.Error(w, "vm_name and target are required", http.StatusBadRequest)
+		return
+	}
+
+	type ResizeDiskRequest struct {
+		SizeGB int32 `json:"size_gb"`
+	}
+
+	var diskReq ResizeDiskRequest
+	err := json.NewDecoder(r.Body).Decode(&diskReq)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	virshServices := services.VirshService{}
+	disk, err := virshServices.ResizeDisk(vmName, target, diskReq.SizeGB)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	data, err := json.Marshal(disk)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+func moveVmStorage(w http.ResponseWriter, r *http.Request) {
+	vmName := chi.URLParam(r, "vm_name")
+	if vmName == "" {
+		http.Error(w, "vm_name is required", http.StatusBadRequest)
+		return
+	}
+
+	type MoveStorageRequest struct {
+		NfsShareId int `json:"nfs_share_id"` // share the disks go to
+	}
+
+	var moveReq MoveStorageRequest
+	err := json.NewDecoder(r.Body).Decode(&moveReq)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	virshServices := services.VirshService{}
+	err = virshServices.MoveVmStorage(vmName, moveReq.NfsShareId)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("VM storage moved successfully"))
+}
+
+func attachNic(w http.ResponseWriter, r *http.Request) {
+	vmName := chi.URLParam(r, "vm_name")
+	if vmName == "" {
+		http.Error(w, "vm_name is required", http.StatusBadRequest)
+		return
+	}
+
+	var nicReq services.NicConfig
+	err := json.NewDecoder(r.Body).Decode(&nicReq)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	virshServices := services.VirshService{}
+	nic, err := virshServices.AttachNic(vmName, nicReq)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	data, err := json.Marshal(nic)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+func detachNic(w http.ResponseWriter, r *http.Request) {
+	vmName := chi.URLParam(r, "vm_name")
+	mac := chi.URLParam(r, "mac")
+	if vmName == "" || mac == "" {
+		http.Error(w, "vm_name and mac are required", http.StatusBadRequest)
+		return
+	}
+
+	virshServices := services.VirshService{}
+	err := virshServices.DetachNic(vmName, mac)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("Nic detached successfully"))
+}
+
+func getGuestInfo(w http.ResponseWriter, r *http.Request) {
+	vmName := chi.URLParam(r, "vm_name")
+	if vmName == "" {
+		http.Error(w, "vm_name is required", http.StatusBadRequest)
+		return
+	}
+
+	virshServices := services.VirshService{}
+	info, err := virshServices.GetGuestInfo(vmName)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(info)
+}
+
+func guestExec(w http.ResponseWriter, r *http.Request) {
+	vmName := chi.URLParam(r, "vm_name")
+	if vmName == "" {
+		http.Error(w, "vm_name is required", http.StatusBadRequest)
+		return
+	}
+
+	var req services.GuestExecConfig
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	virshServices := services.VirshService{}
+	res, err := virshServices.GuestExec(vmName, req)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res)
+}
+
+func enableGuestAgent(w http.ResponseWriter, r *http.Request) {
+	vmName := chi.URLParam(r, "vm_name")
+	if vmName == "" {
+		http.Error(w, "vm_name is required", http.StatusBadRequest)
+		return
+	}
+
+	virshServices := services.VirshService{}
+	err := virshServices.EnableGuestAgent(vmName)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("Guest agent channel added, install qemu-guest-agent in the guest"))
+}
+
+func getGuestExecAllowlist(w http.ResponseWriter, r *http.Request) {
+	virshServices := services.VirshService{}
+	paths, err := virshServices.GetGuestExecAllowlist()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(paths)
+}
+
+func setGuestExecAllowlist(w http.ResponseWriter, r *http.Request) {
+	var paths []string
+	err := json.NewDecoder(r.Body).Decode(&paths)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	virshServices := services.VirshService{}
+	err = virshServices.SetGuestExecAllowlist(paths)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("Guest exec allowlist saved"))
+}
+
 func setupVirshAPI(r chi.Router) chi.Router {
 	return r.Route("/virsh", func(r chi.Router) {
 		r.Get("/getcpudisablefeatures", getCpuFeatures)
@@ -360,6 +878,9 @@ func setupVirshAPI(r chi.Router) chi.Router {
 		r.Post("/createvm", createVM)
 		r.Post("/createlivevm", createLiveVM)
 		r.Post("/migratevm/{vm_name}", migrateLiveVM)
+		r.Post("/cancelmigration/{vm_name}", cancelMigration)
+		r.Post("/migrationlimits/{vm_name}", setMigrationLimits)
+		r.Post("/clonevm/{vm_name}", cloneVM)
 		r.Delete("/deletevm/{vm_name}", deleteVM)
 		r.Post("/startvm/{vm_name}", startVM)
 		r.Post("/shutdownvm/{vm_name}", shutdownVM)
@@ -370,5 +891,21 @@ func setupVirshAPI(r chi.Router) chi.Router {
 		r.Post("/resumevm/{vm_name}", resumeVm)
 		r.Get("/getvmbyname/{vm_name}", getVmByName)
 		r.Post("/removeiso/{vm_name}", removeIso)
+		r.Get("/listsnapshots/{vm_name}", listSnapshots)
+		r.Post("/createsnapshot/{vm_name}", createSnapshot)
+		r.Post("/revertsnapshot/{vm_name}/{snapshot_name}", revertSnapshot)
+		r.Delete("/deletesnapshot/{vm_name}/{snapshot_name}", deleteSnapshot)
+		r.Post("/adddisk/{vm_name}", addDisk)
+		r.Post("/attachdisk/{vm_name}", attachDisk)
+		r.Post("/detachdisk/{vm_name}/{target}", detachDisk)
+		r.Post("/resizedisk/{vm_name}/{target}", resizeDisk)
+		r.Post("/movestorage/{vm_name}", moveVmStorage)
+		r.Post("/attachnic/{vm_name}", attachNic)
+		r.Post("/detachnic/{vm_name}/{mac}", detachNic)
+		r.Get("/guestinfo/{vm_name}", getGuestInfo)
+		r.Post("/guestexec/{vm_name}", guestExec)
+		r.Post("/enableguestagent/{vm_name}", enableGuestAgent)
+		r.Get("/guestexecallowlist", getGuestExecAllowlist)
+		r.Put("/guestexecallowlist", setGuestExecAllowlist)
 	})
 }
@@ -0,0 +1,399 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+)
+
+const (
+	AlertKindSlaveUnreachable = "slave_unreachable"  // threshold: seconds without answering
+	AlertKindNFSShareUsage    = "nfs_share_usage"    // threshold: percent used
+	AlertKindVMCrashed        = "vm_crashed"         // no threshold
+	AlertKindNFSRemountFailed = "nfs_remount_failed" // reported by the slave, no threshold
+	AlertKindCertExpiry       = "cert_expiry"        // threshold: days left
+
+	AlertChannelWebhook = "webhook"
+	AlertChannelSMTP    = "smtp"
+
+	AlertStateFiring   = "firing"
+	AlertStateResolved = "resolved"
+)
+
+type AlertRule struct {
+	Id            int     `json:"id"`
+	Name          string  `json:"name"`
+	Kind          string  `json:"kind"`
+	Threshold     float64 `json:"threshold"`
+	Target        string  `json:"target"`         // only this slave/share/vm/cert, every one when empty
+	RepeatMinutes int     `json:"repeat_minutes"` // notify again while still firing, never when 0
+	Enabled       bool    `json:"enabled"`
+}
+
+// where notifications go, the smtp fields are empty for webhooks and the other way around
+type AlertChannel struct {
+	Id       int    `json:"id"`
+	Name     string `json:"name"`
+	Kind     string `json:"kind"`
+	URL      string `json:"url"`
+	SMTPHost string `json:"smtp_host"`
+	SMTPPort int    `json:"smtp_port"`
+	SMTPUser string `json:"smtp_user"`
+	SMTPPass string `json:"smtp_pass,omitempty"`
+	SMTPFrom string `json:"smtp_from"`
+	SMTPTo   string `json:"smtp_to"` // comma separated
+	Enabled  bool   `json:"enabled"`
+}
+
+// no notifications for the rule (every rule when 0) and subject (every subject when empty) between StartsAt and EndsAt
+type AlertSilence struct {
+	Id       int    `json:"id"`
+	RuleId   int    `json:"rule_id"`
+	Subject  string `json:"subject"`
+	StartsAt int64  `json:"starts_at"`
+	EndsAt   int64  `json:"ends_at"`
+	Comment  string `json:"comment"`
+}
+
+// one firing of a rule for one subject, there is at most one open per rule and subject
+type Alert struct {
+	Id             int    `json:"id"`
+	RuleId         int    `json:"rule_id"`
+	RuleName       string `json:"rule_name"`
+	Kind           string `json:"kind"`
+	Subject        string `json:"subject"` // slave, share, vm or certificate the alert is about
+	Message        string `json:"message"`
+	State          string `json:"state"`
+	StartedAt      int64  `json:"started_at"`
+	LastNotifiedAt int64  `json:"last_notified_at"`
+	ResolvedAt     int64  `json:"resolved_at"`
+}
+
+func CreateAlertTables() error {
+	query := `
+	CREATE TABLE IF NOT EXISTS alert_rules (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		name TEXT NOT NULL UNIQUE,
+		kind TEXT NOT NULL,
+		threshold REAL NOT NULL,
+		target TEXT NOT NULL,
+		repeat_minutes INTEGER NOT NULL,
+		enabled INTEGER NOT NULL
+	);
+	CREATE TABLE IF NOT EXISTS alert_channels (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		name TEXT NOT NULL UNIQUE,
+		kind TEXT NOT NULL,
+		url TEXT NOT NULL,
+		smtp_host TEXT NOT NULL,
+		smtp_port INTEGER NOT NULL,
+		smtp_user TEXT NOT NULL,
+		smtp_pass TEXT NOT NULL,
+		smtp_from TEXT NOT NULL,
+		smtp_to TEXT NOT NULL,
+		enabled INTEGER NOT NULL
+	);
+	CREATE TABLE IF NOT EXISTS alert_silences (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		rule_id INTEGER NOT NULL,
+		subject TEXT NOT NULL,
+		starts_at INTEGER NOT NULL,
+		ends_at INTEGER NOT NULL,
+		comment TEXT NOT NULL
+	);
+	CREATE TABLE IF NOT EXISTS alerts (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		rule_id INTEGER NOT NULL,
+		subject TEXT NOT NULL,
+		message TEXT NOT NULL,
+		started_at INTEGER NOT NULL,
+		last_notified_at INTEGER NOT NULL,
+		resolved_at INTEGER
+	);
+	CREATE UNIQUE INDEX IF NOT EXISTS alerts_open ON alerts (rule_id, subject) WHERE resolved_at IS NULL;
+	`
+	_, err := DB.Exec(query)
+	return err
+}
+
+func AddAlertRule(r *AlertRule) error {
+	query := `
+	INSERT INTO alert_rules (name, kind, threshold, target, repeat_minutes, enabled)
+	VALUES (?, ?, ?, ?, ?, ?);
+	`
+	res, err := DB.Exec(query, r.Name, r.Kind, r.Threshold, r.Target, r.RepeatMinutes, r.Enabled)
+	if err != nil {
+		return err
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return err
+	}
+	r.Id = int(id)
+	return nil
+}
+
+func UpdateAlertRule(r AlertRule) error {
+	query := `
+	UPDATE alert_rules
+	SET name = ?, kind = ?, threshold = ?, target = ?, repeat_minutes = ?, enabled = ?
+	WHERE id = ?;
+	`
+	res, err := DB.Exec(query, r.Name, r.Kind, r.Threshold, r.Target, r.RepeatMinutes, r.Enabled, r.Id)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// RemoveAlertRule also drops the alerts and silences of the rule
+func RemoveAlertRule(id int) error {
+	tx, err := DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`DELETE FROM alerts WHERE rule_id = ?;`, id); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(`DELETE FROM alert_silences WHERE rule_id = ?;`, id); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(`DELETE FROM alert_rules WHERE id = ?;`, id); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func scanAlertRules(rows *sql.Rows) ([]AlertRule, error) {
+	defer rows.Close()
+	var rules []AlertRule
+	for rows.Next() {
+		var r AlertRule
+		if err := rows.Scan(&r.Id, &r.Name, &r.Kind, &r.Threshold, &r.Target, &r.RepeatMinutes, &r.Enabled); err != nil {
+			return nil, err
+		}
+		rules = append(rules, r)
+	}
+	return rules, rows.Err()
+}
+
+func GetAlertRules() ([]AlertRule, error) {
+	rows, err := DB.Query(`SELECT id, name, kind, threshold, target, repeat_minutes, enabled FROM alert_rules ORDER BY id;`)
+	if err != nil {
+		return nil, err
+	}
+	return scanAlertRules(rows)
+}
+
+func GetEnabledAlertRulesByKind(kind string) ([]AlertRule, error) {
+	rows, err := DB.Query(`SELECT id, name, kind, threshold, target, repeat_minutes, enabled FROM alert_rules WHERE kind = ? AND enabled = 1;`, kind)
+	if err != nil {
+		return nil, err
+	}
+	return scanAlertRules(rows)
+}
+
+func AddAlertChannel(c *AlertChannel) error {
+	query := `
+	INSERT INTO alert_channels (name, kind, url, smtp_host, smtp_port, smtp_user, smtp_pass, smtp_from, smtp_to, enabled)
+	VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?);
+	`
+	res, err := DB.Exec(query, c.Name, c.Kind, c.URL, c.SMTPHost, c.SMTPPort, c.SMTPUser, c.SMTPPass, c.SMTPFrom, c.SMTPTo, c.Enabled)
+	if err != nil {
+		return err
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return err
+	}
+	c.Id = int(id)
+	return nil
+}
+
+func RemoveAlertChannel(id int) error {
+	_, err := DB.Exec(`DELETE FROM alert_channels WHERE id = ?;`, id)
+	return err
+}
+
+func GetAlertChannels() ([]AlertChannel, error) {
+	query := `
+	SELECT id, name, kind, url, smtp_host, smtp_port, smtp_user, smtp_pass, smtp_from, smtp_to, enabled
+	FROM alert_channels
+	ORDER BY id;
+	`
+	rows, err := DB.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var channels []AlertChannel
+	for rows.Next() {
+		var c AlertChannel
+		if err := rows.Scan(&c.Id, &c.Name, &c.Kind, &c.URL, &c.SMTPHost, &c.SMTPPort, &c.SMTPUser, &c.SMTPPass, &c.SMTPFrom, &c.SMTPTo, &c.Enabled); err != nil {
+			return nil, err
+		}
+		channels = append(channels, c)
+	}
+	return channels, rows.Err()
+}
+
+func GetAlertChannelByID(id int) (*AlertChannel, error) {
+	channels, err := GetAlertChannels()
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range channels {
+		if c.Id == id {
+			return &c, nil
+		}
+	}
+	return nil, sql.ErrNoRows
+}
+
+func AddAlertSilence(s *AlertSilence) error {
+	query := `
+	INSERT INTO alert_silences (rule_id, subject, starts_at, ends_at, comment)
+	VALUES (?, ?, ?, ?, ?);
+	`
+	res, err := DB.Exec(query, s.RuleId, s.Subject, s.StartsAt, s.EndsAt, s.Comment)
+	if err != nil {
+		return err
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return err
+	}
+	s.Id = int(id)
+	return nil
+}
+
+func RemoveAlertSilence(id int) error {
+	_, err := DB.Exec(`DELETE FROM alert_silences WHERE id = ?;`, id)
+	return err
+}
+
+// GetAlertSilences returns the silences that have not ended yet
+func GetAlertSilences(now int64) ([]AlertSilence, error) {
+	rows, err := DB.Query(`SELECT id, rule_id, subject, starts_at, ends_at, comment FROM alert_silences WHERE ends_at > ? ORDER BY starts_at;`, now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var silences []AlertSilence
+	for rows.Next() {
+		var s AlertSilence
+		if err := rows.Scan(&s.Id, &s.RuleId, &s.Subject, &s.StartsAt, &s.EndsAt, &s.Comment); err != nil {
+			return nil, err
+		}
+		silences = append(silences, s)
+	}
+	return silences, rows.Err()
+}
+
+func IsAlertSilenced(ruleId int, subject string, now int64) (bool, error) {
+	query := `
+	SELECT COUNT(*) FROM alert_silences
+	WHERE (rule_id = 0 OR rule_id = ?) AND (subject = '' OR subject = ?) AND starts_at <= ? AND ends_at > ?;
+	`
+	var n int
+	err := DB.QueryRow(query, ruleId, subject, now, now).Scan(&n)
+	return n > 0, err
+}
+
+func DeleteEndedAlertSilences(now int64) error {
+	_, err := DB.Exec(`DELETE FROM alert_silences WHERE ends_at <= ?;`, now)
+	return err
+}
+
+const alertColumns = `
+	a.id, a.rule_id, r.name, r.kind, a.subject, a.message, a.started_at, a.last_notified_at, a.resolved_at
+	FROM alerts a JOIN alert_rules r ON r.id = a.rule_id
+`
+
+func scanAlerts(rows *sql.Rows) ([]Alert, error) {
+	defer rows.Close()
+	var alerts []Alert
+	for rows.Next() {
+		var a Alert
+		var resolvedAt sql.NullInt64
+		if err := rows.Scan(&a.Id, &a.RuleId, &a.RuleName, &a.Kind, &a.Subject, &a.Message, &a.StartedAt, &a.LastNotifiedAt, &resolvedAt); err != nil {
+			return nil, err
+		}
+		a.State = AlertStateFiring
+		if resolvedAt.Valid {
+			a.State = AlertStateResolved
+			a.ResolvedAt = resolvedAt.Int64
+		}
+		alerts = append(alerts, a)
+	}
+	return alerts, rows.Err()
+}
+
+// GetOpenAlert returns nil when the rule is not firing for the subject
+func GetOpenAlert(ruleId int, subject string) (*Alert, error) {
+	rows, err := DB.Query(`SELECT `+alertColumns+` WHERE a.rule_id = ? AND a.subject = ? AND a.resolved_at IS NULL;`, ruleId, subject)
+	if err != nil {
+		return nil, err
+	}
+	alerts, err := scanAlerts(rows)
+	if err != nil || len(alerts) == 0 {
+		return nil, err
+	}
+	return &alerts[0], nil
+}
+
+func GetOpenAlertsByRule(ruleId int) ([]Alert, error) {
+	rows, err := DB.Query(`SELECT `+alertColumns+` WHERE a.rule_id = ? AND a.resolved_at IS NULL;`, ruleId)
+	if err != nil {
+		return nil, err
+	}
+	return scanAlerts(rows)
+}
+
+// GetAlerts returns the newest alerts first, only the firing ones when openOnly
+func GetAlerts(openOnly bool, limit int) ([]Alert, error) {
+	rows, err := DB.Query(`SELECT `+alertColumns+` WHERE (? = 0 OR a.resolved_at IS NULL) ORDER BY a.started_at DESC, a.id DESC LIMIT ?;`, openOnly, limit)
+	if err != nil {
+		return nil, err
+	}
+	return scanAlerts(rows)
+}
+
+func AddAlert(a *Alert) error {
+	query := `
+	INSERT INTO alerts (rule_id, subject, message, started_at, last_notified_at)
+	VALUES (?, ?, ?, ?, ?);
+	`
+	res, err := DB.Exec(query, a.RuleId, a.Subject, a.Message, a.StartedAt, a.LastNotifiedAt)
+	if err != nil {
+		return err
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return err
+	}
+	a.Id = int(id)
+	return nil
+}
+
+func UpdateAlertNotified(id int, message string, notifiedAt int64) error {
+	_, err := DB.Exec(`UPDATE alerts SET message = ?, last_notified_at = ? WHERE id = ?;`, message, notifiedAt, id)
+	return err
+}
+
+func ResolveAlert(id int, resolvedAt int64) error {
+	res, err := DB.Exec(`UPDATE alerts SET resolved_at = ? WHERE id = ? AND resolved_at IS NULL;`, resolvedAt, id)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return errors.New("alert already resolved")
+	}
+	return nil
+}
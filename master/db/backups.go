@@ -0,0 +1,276 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+)
+
+// scheduled backups of one vm, a chain is a full backup followed by its incrementals
+type VmBackupPolicy struct {
+	VmName                   string `json:"vm_name"`
+	NfsShareId               int    `json:"nfs_share_id"`               // share the backups go to
+	FullIntervalHours        int    `json:"full_interval_hours"`        // a new chain starts after this
+	IncrementalIntervalHours int    `json:"incremental_interval_hours"` // 0 only takes full backups
+	KeepChains               int    `json:"keep_chains"`                // older chains are deleted, 0 keeps all
+}
+
+type VmBackupDisk struct {
+	Target string `json:"target"`
+	Path   string `json:"path"`
+}
+
+// the catalog outlives the vm, a deleted vm can still be restored from it
+type VmBackup struct {
+	Id          int            `json:"id"`
+	VmName      string         `json:"vm_name"`
+	Name        string         `json:"name"`      // also the checkpoint name on the slave
+	ParentId    int            `json:"parent_id"` // backup this one is an increment of, 0 for a full backup
+	Checkpoint  bool           `json:"checkpoint"`
+	MachineName string         `json:"machine_name"`
+	NfsShareId  int            `json:"nfs_share_id"`
+	XmlPath     string         `json:"xml_path"`
+	SizeBytes   int64          `json:"size_bytes"`
+	CreatedAt   int64          `json:"created_at"`
+	Disks       []VmBackupDisk `json:"disks"`
+}
+
+func CreateVmBackupPoliciesTable() error {
+	query := `
+	CREATE TABLE IF NOT EXISTS vm_backup_policies (
+		vm_name TEXT PRIMARY KEY,
+		nfs_share_id INTEGER NOT NULL,
+		full_interval_hours INTEGER NOT NULL,
+		incremental_interval_hours INTEGER NOT NULL DEFAULT 0,
+		keep_chains INTEGER NOT NULL DEFAULT 0
+	);
+	`
+	_, err := DB.Exec(query)
+	return err
+}
+
+func CreateVmBackupsTable() error {
+	query := `
+	CREATE TABLE IF NOT EXISTS vm_backups (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		vm_name TEXT NOT NULL,
+		name TEXT NOT NULL,
+		parent_id INTEGER NOT NULL DEFAULT 0,
+		checkpoint INTEGER NOT NULL DEFAULT 0,
+		machine_name TEXT NOT NULL,
+		nfs_share_id INTEGER NOT NULL,
+		xml_path TEXT NOT NULL,
+		size_bytes INTEGER NOT NULL DEFAULT 0,
+		created_at INTEGER NOT NULL,
+		UNIQUE(vm_name, name)
+	);
+	CREATE TABLE IF NOT EXISTS vm_backup_disks (
+		backup_id INTEGER NOT NULL,
+		target TEXT NOT NULL,
+		path TEXT NOT NULL,
+		PRIMARY KEY (backup_id, target)
+	);
+	`
+	_, err := DB.Exec(query)
+	return err
+}
+
+func SetVmBackupPolicy(policy VmBackupPolicy) error {
+	query := `
+	INSERT INTO vm_backup_policies (vm_name, nfs_share_id, full_interval_hours, incremental_interval_hours, keep_chains)
+	VALUES (?, ?, ?, ?, ?)
+	ON CONFLICT(vm_name) DO UPDATE SET
+		nfs_share_id = excluded.nfs_share_id,
+		full_interval_hours = excluded.full_interval_hours,
+		incremental_interval_hours = excluded.incremental_interval_hours,
+		keep_chains = excluded.keep_chains;
+	`
+	_, err := DB.Exec(query, policy.VmName, policy.NfsShareId, policy.FullIntervalHours, policy.IncrementalIntervalHours, policy.KeepChains)
+	return err
+}
+
+func RemoveVmBackupPolicy(vmName string) error {
+	query := `
+	DELETE FROM vm_backup_policies
+	WHERE vm_name = ?;
+	`
+	_, err := DB.Exec(query, vmName)
+	return err
+}
+
+func GetAllVmBackupPolicies() ([]VmBackupPolicy, error) {
+	const query = `
+	SELECT vm_name, nfs_share_id, full_interval_hours, incremental_interval_hours, keep_chains
+	FROM vm_backup_policies
+	ORDER BY vm_name ASC;
+	`
+	rows, err := DB.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var policies []VmBackupPolicy
+	for rows.Next() {
+		var policy VmBackupPolicy
+		if err := rows.Scan(&policy.VmName, &policy.NfsShareId, &policy.FullIntervalHours, &policy.IncrementalIntervalHours, &policy.KeepChains); err != nil {
+			return nil, err
+		}
+		policies = append(policies, policy)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return policies, nil
+}
+
+// AddVmBackup saves the backup and its files, the new id is set on backup
+func AddVmBackup(backup *VmBackup) error {
+	tx, err := DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `
+	INSERT INTO vm_backups (vm_name, name, parent_id, checkpoint, machine_name, nfs_share_id, xml_path, size_bytes, created_at)
+	VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?);
+	`
+	res, err := tx.Exec(query, backup.VmName, backup.Name, backup.ParentId, backup.Checkpoint, backup.MachineName, backup.NfsShareId, backup.XmlPath, backup.SizeBytes, backup.CreatedAt)
+	if err != nil {
+		return err
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return err
+	}
+	for _, disk := range backup.Disks {
+		if _, err := tx.Exec(`INSERT INTO vm_backup_disks (backup_id, target, path) VALUES (?, ?, ?);`, id, disk.Target, disk.Path); err != nil {
+			return err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	backup.Id = int(id)
+	return nil
+}
+
+func RemoveVmBackup(id int) error {
+	tx, err := DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`DELETE FROM vm_backup_disks WHERE backup_id = ?;`, id); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(`DELETE FROM vm_backups WHERE id = ?;`, id); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func getVmBackupDisks(id int) ([]VmBackupDisk, error) {
+	const query = `
+	SELECT target, path
+	FROM vm_backup_disks
+	WHERE backup_id = ?
+	ORDER BY target ASC;
+	`
+	rows, err := DB.Query(query, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var disks []VmBackupDisk
+	for rows.Next() {
+		var disk VmBackupDisk
+		if err := rows.Scan(&disk.Target, &disk.Path); err != nil {
+			return nil, err
+		}
+		disks = append(disks, disk)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return disks, nil
+}
+
+func queryVmBackups(query string, args ...any) ([]VmBackup, error) {
+	rows, err := DB.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	var backups []VmBackup
+	for rows.Next() {
+		var backup VmBackup
+		if err := rows.Scan(&backup.Id, &backup.VmName, &backup.Name, &backup.ParentId, &backup.Checkpoint, &backup.MachineName, &backup.NfsShareId, &backup.XmlPath, &backup.SizeBytes, &backup.CreatedAt); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		backups = append(backups, backup)
+	}
+	if err := rows.Err(); err != nil {
+		rows.Close()
+		return nil, err
+	}
+	rows.Close()
+
+	// the disks are read after the rows are closed, no nested queries while sqlite holds the read
+	for i := range backups {
+		disks, err := getVmBackupDisks(backups[i].Id)
+		if err != nil {
+			return nil, err
+		}
+		backups[i].Disks = disks
+	}
+	return backups, nil
+}
+
+// GetVmBackups lists the backups of vmName oldest first, every backup when vmName is empty
+func GetVmBackups(vmName string) ([]VmBackup, error) {
+	if vmName == "" {
+		return queryVmBackups(`
+		SELECT id, vm_name, name, parent_id, checkpoint, machine_name, nfs_share_id, xml_path, size_bytes, created_at
+		FROM vm_backups
+		ORDER BY vm_name ASC, created_at ASC, id ASC;
+		`)
+	}
+	return queryVmBackups(`
+	SELECT id, vm_name, name, parent_id, checkpoint, machine_name, nfs_share_id, xml_path, size_bytes, created_at
+	FROM vm_backups
+	WHERE vm_name = ?
+	ORDER BY created_at ASC, id ASC;
+	`, vmName)
+}
+
+func GetVmBackup(id int) (*VmBackup, error) {
+	backups, err := queryVmBackups(`
+	SELECT id, vm_name, name, parent_id, checkpoint, machine_name, nfs_share_id, xml_path, size_bytes, created_at
+	FROM vm_backups
+	WHERE id = ?;
+	`, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(backups) == 0 {
+		return nil, nil
+	}
+	return &backups[0], nil
+}
+
+// GetLatestVmBackup returns the newest backup of the vm, nil when it has none
+func GetLatestVmBackup(vmName string) (*VmBackup, error) {
+	var id int
+	err := DB.QueryRow(`SELECT id FROM vm_backups WHERE vm_name = ? ORDER BY created_at DESC, id DESC LIMIT 1;`, vmName).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return GetVmBackup(id)
+}
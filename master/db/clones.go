@@ -0,0 +1,60 @@
+package db
+
+// linked clones use the source disk as qcow2 backing file, the source must stay untouched while they exist
+type VmLinkedClone struct {
+	Name       string
+	SourceName string
+}
+
+func CreateVmLinkedClonesTable() error {
+	query := `
+	CREATE TABLE IF NOT EXISTS vm_linked_clones (
+		name TEXT PRIMARY KEY,
+		source_name TEXT NOT NULL
+	);
+	`
+	_, err := DB.Exec(query)
+	return err
+}
+
+func AddVmLinkedClone(name, sourceName string) error {
+	query := `
+	INSERT INTO vm_linked_clones (name, source_name)
+	VALUES (?, ?);
+	`
+	_, err := DB.Exec(query, name, sourceName)
+	return err
+}
+
+func RemoveVmLinkedClone(name string) error {
+	query := `
+	DELETE FROM vm_linked_clones
+	WHERE name = ?;
+	`
+	_, err := DB.Exec(query, name)
+	return err
+}
+
+func GetVmLinkedClones(sourceName string) ([]VmLinkedClone, error) {
+	const query = `
+	SELECT name, source_name
+	FROM vm_linked_clones
+	WHERE source_name = ?
+	ORDER BY name;
+	`
+	rows, err := DB.Query(query, sourceName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var clones []VmLinkedClone
+	for rows.Next() {
+		var clone VmLinkedClone
+		if err := rows.Scan(&clone.Name, &clone.SourceName); err != nil {
+			return nil, err
+		}
+		clones = append(clones, clone)
+	}
+	return clones, rows.Err()
+}
@@ -0,0 +1,95 @@
+package db
+
+import "database/sql"
+
+const (
+	ConsoleKindVNC    = "vnc"
+	ConsoleKindSerial = "serial"
+)
+
+// audit of who opened the console of which vm and for how long
+type ConsoleSession struct {
+	Id          int    `json:"id"`
+	VmName      string `json:"vm_name"`
+	MachineName string `json:"machine_name"`
+	User        string `json:"user"` // email of the npm user the token was issued to
+	Kind        string `json:"kind"`
+	TokenId     string `json:"token_id"`
+	RemoteAddr  string `json:"remote_addr"`
+	StartedAt   int64  `json:"started_at"`
+	EndedAt     int64  `json:"ended_at"` // 0 while open
+}
+
+func CreateConsoleSessionsTable() error {
+	query := `
+	CREATE TABLE IF NOT EXISTS console_sessions (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		vm_name TEXT NOT NULL,
+		machine_name TEXT NOT NULL,
+		user TEXT NOT NULL,
+		kind TEXT NOT NULL,
+		token_id TEXT NOT NULL,
+		remote_addr TEXT NOT NULL,
+		started_at INTEGER NOT NULL,
+		ended_at INTEGER
+	);
+	`
+	_, err := DB.Exec(query)
+	return err
+}
+
+// EndOpenConsoleSessions closes what a previous run left open, those connections died with it
+func EndOpenConsoleSessions() error {
+	_, err := DB.Exec(`UPDATE console_sessions SET ended_at = CAST(strftime('%s', 'now') AS INTEGER) WHERE ended_at IS NULL;`)
+	return err
+}
+
+func AddConsoleSession(s *ConsoleSession) error {
+	query := `
+	INSERT INTO console_sessions (vm_name, machine_name, user, kind, token_id, remote_addr, started_at)
+	VALUES (?, ?, ?, ?, ?, ?, ?);
+	`
+	res, err := DB.Exec(query, s.VmName, s.MachineName, s.User, s.Kind, s.TokenId, s.RemoteAddr, s.StartedAt)
+	if err != nil {
+		return err
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return err
+	}
+	s.Id = int(id)
+	return nil
+}
+
+func EndConsoleSession(id int, endedAt int64) error {
+	_, err := DB.Exec(`UPDATE console_sessions SET ended_at = ? WHERE id = ?;`, endedAt, id)
+	return err
+}
+
+// GetConsoleSessions returns the newest sessions first, of every vm when vmName is empty
+func GetConsoleSessions(vmName string, limit int) ([]ConsoleSession, error) {
+	query := `
+	SELECT id, vm_name, machine_name, user, kind, token_id, remote_addr, started_at, ended_at
+	FROM console_sessions
+	WHERE (? = '' OR vm_name = ?)
+	ORDER BY started_at DESC, id DESC
+	LIMIT ?;
+	`
+	rows, err := DB.Query(query, vmName, vmName, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var sessions []ConsoleSession
+	for rows.Next() {
+		var s ConsoleSession
+		var endedAt sql.NullInt64
+		if err := rows.Scan(&s.Id, &s.VmName, &s.MachineName, &s.User, &s.Kind, &s.TokenId, &s.RemoteAddr, &s.StartedAt, &endedAt); err != nil {
+			return nil, err
+		}
+		s.EndedAt = endedAt.Int64
+		sessions = append(sessions, s)
+	}
+	return sessions, rows.Err()
+}
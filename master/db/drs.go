@@ -0,0 +1,222 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+)
+
+const (
+	DRSModeOff       = "off"
+	DRSModeDryRun    = "dry-run"   // proposals are only listed
+	DRSModeManual    = "manual"    // proposals wait for approval
+	DRSModeAutomatic = "automatic" // proposals are migrated right away
+
+	DRSProposed  = "proposed"
+	DRSMigrating = "migrating"
+	DRSDone      = "done"
+	DRSFailed    = "failed"
+	DRSRejected  = "rejected"
+)
+
+// settings of the load balancer, a single row
+type DRSConfig struct {
+	Mode            string
+	Threshold       float64 // pressure gap between the busiest and idlest slave that triggers a move, 0 to 1
+	IntervalSeconds int
+	CooldownSeconds int // a vm is not moved again before this
+	MaxMigrations   int // per round
+}
+
+func DefaultDRSConfig() DRSConfig {
+	return DRSConfig{
+		Mode:            DRSModeOff,
+		Threshold:       0.25,
+		IntervalSeconds: 300,
+		CooldownSeconds: 3600,
+		MaxMigrations:   2,
+	}
+}
+
+// a migration the balancer wants to do, kept with the last finished ones
+type DRSProposal struct {
+	Id        int     `json:"id"`
+	VmName    string  `json:"vm_name"`
+	From      string  `json:"from"`
+	To        string  `json:"to"`
+	Gain      float64 `json:"gain"` // expected drop of the busiest slave pressure
+	CreatedAt int64   `json:"created_at"`
+	Status    string  `json:"status"`
+	Error     string  `json:"error,omitempty"`
+}
+
+func CreateDRSTables() error {
+	query := `
+	CREATE TABLE IF NOT EXISTS drs_config (
+		id INTEGER PRIMARY KEY CHECK (id = 1),
+		mode TEXT NOT NULL,
+		threshold REAL NOT NULL,
+		interval_seconds INTEGER NOT NULL,
+		cooldown_seconds INTEGER NOT NULL,
+		max_migrations INTEGER NOT NULL
+	);
+	CREATE TABLE IF NOT EXISTS drs_proposals (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		vm_name TEXT NOT NULL,
+		from_machine TEXT NOT NULL,
+		to_machine TEXT NOT NULL,
+		gain REAL NOT NULL,
+		created_at INTEGER NOT NULL,
+		status TEXT NOT NULL,
+		error TEXT NOT NULL DEFAULT ''
+	);
+	CREATE TABLE IF NOT EXISTS vm_migrations (
+		vm_name TEXT PRIMARY KEY,
+		migrated_at INTEGER NOT NULL
+	);
+	`
+	_, err := DB.Exec(query)
+	return err
+}
+
+func SetDRSConfig(cfg DRSConfig) error {
+	query := `
+	INSERT INTO drs_config (id, mode, threshold, interval_seconds, cooldown_seconds, max_migrations)
+	VALUES (1, ?, ?, ?, ?, ?)
+	ON CONFLICT(id) DO UPDATE SET
+		mode = excluded.mode,
+		threshold = excluded.threshold,
+		interval_seconds = excluded.interval_seconds,
+		cooldown_seconds = excluded.cooldown_seconds,
+		max_migrations = excluded.max_migrations;
+	`
+	_, err := DB.Exec(query, cfg.Mode, cfg.Threshold, cfg.IntervalSeconds, cfg.CooldownSeconds, cfg.MaxMigrations)
+	return err
+}
+
+// GetDRSConfig returns the saved settings or the defaults when none were saved
+func GetDRSConfig() (DRSConfig, error) {
+	const query = `
+	SELECT mode, threshold, interval_seconds, cooldown_seconds, max_migrations
+	FROM drs_config
+	WHERE id = 1;
+	`
+	var cfg DRSConfig
+	err := DB.QueryRow(query).Scan(&cfg.Mode, &cfg.Threshold, &cfg.IntervalSeconds, &cfg.CooldownSeconds, &cfg.MaxMigrations)
+	if errors.Is(err, sql.ErrNoRows) {
+		return DefaultDRSConfig(), nil
+	}
+	if err != nil {
+		return DRSConfig{}, err
+	}
+	return cfg, nil
+}
+
+// SetDRSProposals replaces the open proposals with proposals and keeps the last history finished ones,
+// the new ids are set on proposals
+func SetDRSProposals(proposals []DRSProposal, history int) error {
+	tx, err := DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`DELETE FROM drs_proposals WHERE status = ?;`, DRSProposed); err != nil {
+		return err
+	}
+	_, err = tx.Exec(`
+	DELETE FROM drs_proposals
+	WHERE id NOT IN (SELECT id FROM drs_proposals ORDER BY id DESC LIMIT ?);
+	`, history)
+	if err != nil {
+		return err
+	}
+	for i := range proposals {
+		p := &proposals[i]
+		res, err := tx.Exec(`
+		INSERT INTO drs_proposals (vm_name, from_machine, to_machine, gain, created_at, status, error)
+		VALUES (?, ?, ?, ?, ?, ?, ?);
+		`, p.VmName, p.From, p.To, p.Gain, p.CreatedAt, p.Status, p.Error)
+		if err != nil {
+			return err
+		}
+		id, err := res.LastInsertId()
+		if err != nil {
+			return err
+		}
+		p.Id = int(id)
+	}
+	return tx.Commit()
+}
+
+func UpdateDRSProposal(id int, status, errMsg string) error {
+	query := `
+	UPDATE drs_proposals
+	SET status = ?, error = CASE WHEN ? = '' THEN error ELSE ? END
+	WHERE id = ?;
+	`
+	_, err := DB.Exec(query, status, errMsg, errMsg, id)
+	return err
+}
+
+func scanDRSProposal(row rowScanner) (DRSProposal, error) {
+	var p DRSProposal
+	err := row.Scan(&p.Id, &p.VmName, &p.From, &p.To, &p.Gain, &p.CreatedAt, &p.Status, &p.Error)
+	return p, err
+}
+
+// GetDRSProposals returns the open and the last finished proposals, oldest first
+func GetDRSProposals() ([]DRSProposal, error) {
+	rows, err := DB.Query(`
+	SELECT id, vm_name, from_machine, to_machine, gain, created_at, status, error
+	FROM drs_proposals
+	ORDER BY id;
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var proposals []DRSProposal
+	for rows.Next() {
+		p, err := scanDRSProposal(rows)
+		if err != nil {
+			return nil, err
+		}
+		proposals = append(proposals, p)
+	}
+	return proposals, rows.Err()
+}
+
+func GetDRSProposal(id int) (*DRSProposal, error) {
+	row := DB.QueryRow(`
+	SELECT id, vm_name, from_machine, to_machine, gain, created_at, status, error
+	FROM drs_proposals
+	WHERE id = ?;
+	`, id)
+	p, err := scanDRSProposal(row)
+	if err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// SetVmMigratedAt saves when the vm was last migrated, by the balancer or by hand
+func SetVmMigratedAt(vmName string, at int64) error {
+	query := `
+	INSERT INTO vm_migrations (vm_name, migrated_at)
+	VALUES (?, ?)
+	ON CONFLICT(vm_name) DO UPDATE SET migrated_at = excluded.migrated_at;
+	`
+	_, err := DB.Exec(query, vmName, at)
+	return err
+}
+
+// GetVmMigratedAt returns 0 when the vm was never migrated
+func GetVmMigratedAt(vmName string) (int64, error) {
+	var at int64
+	err := DB.QueryRow(`SELECT migrated_at FROM vm_migrations WHERE vm_name = ?;`, vmName).Scan(&at)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	}
+	return at, err
+}
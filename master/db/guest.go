@@ -0,0 +1,58 @@
+package db
+
+// programs the api may run inside vms through the guest agent, nothing is allowed until listed
+func CreateGuestExecAllowlistTable() error {
+	query := `
+	CREATE TABLE IF NOT EXISTS guest_exec_allowlist (
+		path TEXT PRIMARY KEY
+	);
+	`
+	_, err := DB.Exec(query)
+	return err
+}
+
+// SetGuestExecAllowlist replaces the whole list
+func SetGuestExecAllowlist(paths []string) error {
+	tx, err := DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`DELETE FROM guest_exec_allowlist;`); err != nil {
+		return err
+	}
+	for _, path := range paths {
+		if _, err := tx.Exec(`INSERT INTO guest_exec_allowlist (path) VALUES (?) ON CONFLICT(path) DO NOTHING;`, path); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func GetGuestExecAllowlist() ([]string, error) {
+	rows, err := DB.Query(`SELECT path FROM guest_exec_allowlist ORDER BY path ASC;`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var paths []string
+	for rows.Next() {
+		var path string
+		if err := rows.Scan(&path); err != nil {
+			return nil, err
+		}
+		paths = append(paths, path)
+	}
+	return paths, rows.Err()
+}
+
+func IsGuestExecAllowed(path string) (bool, error) {
+	var count int
+	err := DB.QueryRow(`SELECT COUNT(*) FROM guest_exec_allowlist WHERE path = ?;`, path).Scan(&count)
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
@@ -0,0 +1,146 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+)
+
+const (
+	HAPolicyNone    = "none"
+	HAPolicyRestart = "restart" // restarted on a surviving slave when its slave dies
+)
+
+// HA policy of a vm and where it was last seen running, the master needs the disk path to
+// restart it after its slave is gone
+type VmHA struct {
+	VmName      string
+	Policy      string
+	Priority    int // higher restarts first
+	MachineName string
+	DiskPath    string
+	UpdatedAt   int64
+	// slave the vm was lost with while it waits for a restart, kept here so a master restart
+	// does not forget it. empty when nothing is pending
+	PendingFrom string
+}
+
+func CreateVmHATable() error {
+	query := `
+	CREATE TABLE IF NOT EXISTS vm_ha (
+		vm_name TEXT PRIMARY KEY,
+		policy TEXT NOT NULL,
+		priority INTEGER NOT NULL DEFAULT 0,
+		machine_name TEXT,
+		disk_path TEXT,
+		updated_at INTEGER NOT NULL,
+		pending_from TEXT
+	);
+	`
+	if _, err := DB.Exec(query); err != nil {
+		return err
+	}
+
+	// tables made before the pending restarts were saved
+	var hasPending int
+	err := DB.QueryRow(`SELECT COUNT(*) FROM pragma_table_info('vm_ha') WHERE name = 'pending_from';`).Scan(&hasPending)
+	if err != nil || hasPending > 0 {
+		return err
+	}
+	_, err = DB.Exec(`ALTER TABLE vm_ha ADD COLUMN pending_from TEXT;`)
+	return err
+}
+
+func SetVmHA(ha VmHA) error {
+	query := `
+	INSERT INTO vm_ha (vm_name, policy, priority, machine_name, disk_path, updated_at)
+	VALUES (?, ?, ?, ?, ?, ?)
+	ON CONFLICT(vm_name) DO UPDATE SET
+		policy = excluded.policy,
+		priority = excluded.priority,
+		machine_name = excluded.machine_name,
+		disk_path = excluded.disk_path,
+		updated_at = excluded.updated_at;
+	`
+	_, err := DB.Exec(query, ha.VmName, ha.Policy, ha.Priority, ha.MachineName, ha.DiskPath, time.Now().Unix())
+	return err
+}
+
+func UpdateVmHAPlacement(vmName, machineName, diskPath string) error {
+	query := `
+	UPDATE vm_ha
+	SET machine_name = ?, disk_path = ?, updated_at = ?
+	WHERE vm_name = ?;
+	`
+	_, err := DB.Exec(query, machineName, diskPath, time.Now().Unix(), vmName)
+	return err
+}
+
+// SetVmHAPending marks the vm as waiting for a restart, machineName empty clears it
+func SetVmHAPending(vmName, machineName string) error {
+	query := `
+	UPDATE vm_ha
+	SET pending_from = NULLIF(?, ''), updated_at = ?
+	WHERE vm_name = ?;
+	`
+	_, err := DB.Exec(query, machineName, time.Now().Unix(), vmName)
+	return err
+}
+
+func RemoveVmHA(vmName string) error {
+	query := `
+	DELETE FROM vm_ha
+	WHERE vm_name = ?;
+	`
+	_, err := DB.Exec(query, vmName)
+	return err
+}
+
+func scanVmHA(row rowScanner) (VmHA, error) {
+	var ha VmHA
+	var machineName, diskPath, pendingFrom sql.NullString
+	err := row.Scan(&ha.VmName, &ha.Policy, &ha.Priority, &machineName, &diskPath, &ha.UpdatedAt, &pendingFrom)
+	ha.MachineName = machineName.String
+	ha.DiskPath = diskPath.String
+	ha.PendingFrom = pendingFrom.String
+	return ha, err
+}
+
+func GetAllVmHA() ([]VmHA, error) {
+	const query = `
+	SELECT vm_name, policy, priority, machine_name, disk_path, updated_at, pending_from
+	FROM vm_ha
+	ORDER BY priority DESC, vm_name;
+	`
+	rows, err := DB.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []VmHA
+	for rows.Next() {
+		ha, err := scanVmHA(rows)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, ha)
+	}
+	return res, rows.Err()
+}
+
+func GetVmHA(vmName string) (*VmHA, error) {
+	const query = `
+	SELECT vm_name, policy, priority, machine_name, disk_path, updated_at, pending_from
+	FROM vm_ha
+	WHERE vm_name = ?;
+	`
+	ha, err := scanVmHA(DB.QueryRow(query, vmName))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, sql.ErrNoRows
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &ha, nil
+}
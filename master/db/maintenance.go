@@ -0,0 +1,75 @@
+package db
+
+import "time"
+
+// slaves in maintenance get no new vms, the master moves their vms away when they enter it
+type SlaveMaintenance struct {
+	MachineName string
+	Since       int64
+}
+
+func CreateSlaveMaintenanceTable() error {
+	query := `
+	CREATE TABLE IF NOT EXISTS slave_maintenance (
+		machine_name TEXT PRIMARY KEY,
+		since INTEGER NOT NULL
+	);
+	`
+	_, err := DB.Exec(query)
+	return err
+}
+
+func AddSlaveMaintenance(machineName string) error {
+	query := `
+	INSERT INTO slave_maintenance (machine_name, since)
+	VALUES (?, ?)
+	ON CONFLICT(machine_name) DO NOTHING;
+	`
+	_, err := DB.Exec(query, machineName, time.Now().Unix())
+	return err
+}
+
+func RemoveSlaveMaintenance(machineName string) error {
+	query := `
+	DELETE FROM slave_maintenance
+	WHERE machine_name = ?;
+	`
+	_, err := DB.Exec(query, machineName)
+	return err
+}
+
+func IsSlaveInMaintenance(machineName string) (bool, error) {
+	const query = `
+	SELECT COUNT(1)
+	FROM slave_maintenance
+	WHERE machine_name = ?;
+	`
+	var count int
+	if err := DB.QueryRow(query, machineName).Scan(&count); err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func GetAllSlaveMaintenance() ([]SlaveMaintenance, error) {
+	const query = `
+	SELECT machine_name, since
+	FROM slave_maintenance
+	ORDER BY machine_name;
+	`
+	rows, err := DB.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []SlaveMaintenance
+	for rows.Next() {
+		var m SlaveMaintenance
+		if err := rows.Scan(&m.MachineName, &m.Since); err != nil {
+			return nil, err
+		}
+		res = append(res, m)
+	}
+	return res, rows.Err()
+}
@@ -0,0 +1,121 @@
+package db
+
+const (
+	MetricKindHost = "host"
+	MetricKindVM   = "vm"
+
+	// every sample lands in both tiers, averaged into the bucket it falls in
+	MetricResolutionMinute = 60
+	MetricResolutionHour   = 3600
+)
+
+// one reading of a host or vm, rates in bytes per second
+type MetricSample struct {
+	Ts           int64   `json:"ts"` // unix seconds
+	CPU          float64 `json:"cpu"`
+	CPUMax       float64 `json:"cpu_max"` // highest reading inside the bucket
+	MemUsedMB    float64 `json:"mem_used_mb"`
+	MemTotalMB   float64 `json:"mem_total_mb"`
+	DiskReadBps  float64 `json:"disk_read_bps"` // hosts only
+	DiskWriteBps float64 `json:"disk_write_bps"`
+	NetRxBps     float64 `json:"net_rx_bps"`
+	NetTxBps     float64 `json:"net_tx_bps"`
+}
+
+func CreateMetricSamplesTable() error {
+	query := `
+	CREATE TABLE IF NOT EXISTS metric_samples (
+		kind TEXT NOT NULL,
+		name TEXT NOT NULL,
+		resolution INTEGER NOT NULL,
+		ts INTEGER NOT NULL,
+		samples INTEGER NOT NULL,
+		cpu REAL NOT NULL,
+		cpu_max REAL NOT NULL,
+		mem_used_mb REAL NOT NULL,
+		mem_total_mb REAL NOT NULL,
+		disk_read_bps REAL NOT NULL,
+		disk_write_bps REAL NOT NULL,
+		net_rx_bps REAL NOT NULL,
+		net_tx_bps REAL NOT NULL,
+		PRIMARY KEY (kind, name, resolution, ts)
+	);
+	`
+	_, err := DB.Exec(query)
+	return err
+}
+
+// AddMetricSample folds the reading into its minute and hour buckets as a running average
+func AddMetricSample(kind, name string, s MetricSample) error {
+	query := `
+	INSERT INTO metric_samples (kind, name, resolution, ts, samples, cpu, cpu_max, mem_used_mb, mem_total_mb,
+		disk_read_bps, disk_write_bps, net_rx_bps, net_tx_bps)
+	VALUES (?, ?, ?, ?, 1, ?, ?, ?, ?, ?, ?, ?, ?)
+	ON CONFLICT(kind, name, resolution, ts) DO UPDATE SET
+		cpu = (cpu * samples + excluded.cpu) / (samples + 1),
+		cpu_max = MAX(cpu_max, excluded.cpu_max),
+		mem_used_mb = (mem_used_mb * samples + excluded.mem_used_mb) / (samples + 1),
+		mem_total_mb = excluded.mem_total_mb,
+		disk_read_bps = (disk_read_bps * samples + excluded.disk_read_bps) / (samples + 1),
+		disk_write_bps = (disk_write_bps * samples + excluded.disk_write_bps) / (samples + 1),
+		net_rx_bps = (net_rx_bps * samples + excluded.net_rx_bps) / (samples + 1),
+		net_tx_bps = (net_tx_bps * samples + excluded.net_tx_bps) / (samples + 1),
+		samples = samples + 1;
+	`
+	tx, err := DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, res := range []int64{MetricResolutionMinute, MetricResolutionHour} {
+		bucket := s.Ts - s.Ts%res
+		_, err := tx.Exec(query, kind, name, res, bucket, s.CPU, s.CPU, s.MemUsedMB, s.MemTotalMB,
+			s.DiskReadBps, s.DiskWriteBps, s.NetRxBps, s.NetTxBps)
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// GetMetricSamples averages the buckets of one resolution into steps of step seconds, oldest first
+func GetMetricSamples(kind, name string, resolution, from, to, step int64) ([]MetricSample, error) {
+	query := `
+	SELECT (ts / ?) * ? AS bucket,
+		SUM(cpu * samples) / SUM(samples),
+		MAX(cpu_max),
+		SUM(mem_used_mb * samples) / SUM(samples),
+		MAX(mem_total_mb),
+		SUM(disk_read_bps * samples) / SUM(samples),
+		SUM(disk_write_bps * samples) / SUM(samples),
+		SUM(net_rx_bps * samples) / SUM(samples),
+		SUM(net_tx_bps * samples) / SUM(samples)
+	FROM metric_samples
+	WHERE kind = ? AND name = ? AND resolution = ? AND ts >= ? AND ts <= ?
+	GROUP BY bucket
+	ORDER BY bucket;
+	`
+	rows, err := DB.Query(query, step, step, kind, name, resolution, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var samples []MetricSample
+	for rows.Next() {
+		var s MetricSample
+		if err := rows.Scan(&s.Ts, &s.CPU, &s.CPUMax, &s.MemUsedMB, &s.MemTotalMB,
+			&s.DiskReadBps, &s.DiskWriteBps, &s.NetRxBps, &s.NetTxBps); err != nil {
+			return nil, err
+		}
+		samples = append(samples, s)
+	}
+	return samples, rows.Err()
+}
+
+// DeleteMetricSamplesBefore drops the buckets of one resolution older than before
+func DeleteMetricSamplesBefore(resolution, before int64) error {
+	_, err := DB.Exec(`DELETE FROM metric_samples WHERE resolution = ? AND ts < ?;`, resolution, before)
+	return err
+}
@@ -0,0 +1,127 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+)
+
+// libvirt networks the master keeps defined on every slave
+type Network struct {
+	Id            int
+	Name          string
+	Mode          string // nat, route, isolated or bridge
+	Bridge        string
+	HostInterface string
+	IPAddress     string
+	Netmask       string
+	DHCPStart     string
+	DHCPEnd       string
+	Autostart     bool
+}
+
+func CreateNetworksTable() error {
+	query := `
+	CREATE TABLE IF NOT EXISTS networks (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		name TEXT NOT NULL UNIQUE,
+		mode TEXT NOT NULL,
+		bridge TEXT,
+		host_interface TEXT,
+		ip_address TEXT,
+		netmask TEXT,
+		dhcp_start TEXT,
+		dhcp_end TEXT,
+		autostart INTEGER NOT NULL DEFAULT 1
+	);
+	`
+	_, err := DB.Exec(query)
+	return err
+}
+
+func AddNetwork(n Network) error {
+	query := `
+	INSERT INTO networks (name, mode, bridge, host_interface, ip_address, netmask, dhcp_start, dhcp_end, autostart)
+	VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?);
+	`
+	_, err := DB.Exec(query, n.Name, n.Mode, n.Bridge, n.HostInterface, n.IPAddress, n.Netmask, n.DHCPStart, n.DHCPEnd, n.Autostart)
+	return err
+}
+
+func UpdateNetwork(n Network) error {
+	query := `
+	UPDATE networks
+	SET mode = ?, bridge = ?, host_interface = ?, ip_address = ?, netmask = ?, dhcp_start = ?, dhcp_end = ?, autostart = ?
+	WHERE name = ?;
+	`
+	_, err := DB.Exec(query, n.Mode, n.Bridge, n.HostInterface, n.IPAddress, n.Netmask, n.DHCPStart, n.DHCPEnd, n.Autostart, n.Name)
+	return err
+}
+
+func RemoveNetwork(name string) error {
+	query := `
+	DELETE FROM networks
+	WHERE name = ?;
+	`
+	_, err := DB.Exec(query, name)
+	return err
+}
+
+// *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanNetwork(row rowScanner) (Network, error) {
+	var n Network
+	var bridge, hostInterface, ipAddress, netmask, dhcpStart, dhcpEnd sql.NullString
+	err := row.Scan(&n.Id, &n.Name, &n.Mode, &bridge, &hostInterface, &ipAddress, &netmask, &dhcpStart, &dhcpEnd, &n.Autostart)
+	if err != nil {
+		return n, err
+	}
+	n.Bridge = bridge.String
+	n.HostInterface = hostInterface.String
+	n.IPAddress = ipAddress.String
+	n.Netmask = netmask.String
+	n.DHCPStart = dhcpStart.String
+	n.DHCPEnd = dhcpEnd.String
+	return n, nil
+}
+
+func GetAllNetworks() ([]Network, error) {
+	const query = `
+	SELECT id, name, mode, bridge, host_interface, ip_address, netmask, dhcp_start, dhcp_end, autostart
+	FROM networks
+	ORDER BY name;
+	`
+	rows, err := DB.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var networks []Network
+	for rows.Next() {
+		n, err := scanNetwork(rows)
+		if err != nil {
+			return nil, err
+		}
+		networks = append(networks, n)
+	}
+	return networks, rows.Err()
+}
+
+func GetNetworkByName(name string) (*Network, error) {
+	const query = `
+	SELECT id, name, mode, bridge, host_interface, ip_address, netmask, dhcp_start, dhcp_end, autostart
+	FROM networks
+	WHERE name = ?;
+	`
+	n, err := scanNetwork(DB.QueryRow(query, name))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, sql.ErrNoRows
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &n, nil
+}
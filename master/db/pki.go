@@ -0,0 +1,190 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+)
+
+// a certificate the master CA issued to a slave, only these are accepted on the grpc ports
+type SlaveCert struct {
+	Serial      string `json:"serial"` // hex
+	MachineName string `json:"machine_name"`
+	IssuedAt    int64  `json:"issued_at"`
+	NotAfter    int64  `json:"not_after"`
+	Revoked     bool   `json:"revoked"`
+}
+
+// one-time token a new slave trades for its certificate, only the hash of the secret is kept
+type EnrollmentToken struct {
+	Id          int    `json:"id"`
+	SecretHash  string `json:"-"`
+	MachineName string `json:"machine_name"` // any name when empty
+	CreatedAt   int64  `json:"created_at"`
+	ExpiresAt   int64  `json:"expires_at"`
+	UsedAt      int64  `json:"used_at"` // 0 while unused
+	UsedBy      string `json:"used_by"`
+}
+
+func CreatePKITables() error {
+	query := `
+	CREATE TABLE IF NOT EXISTS slave_certs (
+		serial TEXT PRIMARY KEY,
+		machine_name TEXT NOT NULL,
+		issued_at INTEGER NOT NULL,
+		not_after INTEGER NOT NULL,
+		revoked INTEGER NOT NULL DEFAULT 0
+	);
+	CREATE TABLE IF NOT EXISTS enrollment_tokens (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		secret_hash TEXT NOT NULL UNIQUE,
+		machine_name TEXT NOT NULL,
+		created_at INTEGER NOT NULL,
+		expires_at INTEGER NOT NULL,
+		used_at INTEGER,
+		used_by TEXT
+	);
+	`
+	_, err := DB.Exec(query)
+	return err
+}
+
+func AddSlaveCert(c SlaveCert) error {
+	query := `
+	INSERT INTO slave_certs (serial, machine_name, issued_at, not_after)
+	VALUES (?, ?, ?, ?);
+	`
+	_, err := DB.Exec(query, c.Serial, c.MachineName, c.IssuedAt, c.NotAfter)
+	return err
+}
+
+// GetSlaveCert returns sql.ErrNoRows for a serial the master never issued
+func GetSlaveCert(serial string) (*SlaveCert, error) {
+	query := `
+	SELECT serial, machine_name, issued_at, not_after, revoked
+	FROM slave_certs
+	WHERE serial = ?;
+	`
+	var c SlaveCert
+	err := DB.QueryRow(query, serial).Scan(&c.Serial, &c.MachineName, &c.IssuedAt, &c.NotAfter, &c.Revoked)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, sql.ErrNoRows
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// GetSlaveCerts returns the certificates that have not expired yet, newest first
+func GetSlaveCerts(now int64) ([]SlaveCert, error) {
+	query := `
+	SELECT serial, machine_name, issued_at, not_after, revoked
+	FROM slave_certs
+	WHERE not_after > ?
+	ORDER BY issued_at DESC;
+	`
+	rows, err := DB.Query(query, now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var certs []SlaveCert
+	for rows.Next() {
+		var c SlaveCert
+		if err := rows.Scan(&c.Serial, &c.MachineName, &c.IssuedAt, &c.NotAfter, &c.Revoked); err != nil {
+			return nil, err
+		}
+		certs = append(certs, c)
+	}
+	return certs, rows.Err()
+}
+
+// HasActiveSlaveCert tells if the slave holds a certificate that is neither revoked nor expired
+func HasActiveSlaveCert(machineName string, now int64) (bool, error) {
+	var n int
+	err := DB.QueryRow(`SELECT COUNT(*) FROM slave_certs WHERE machine_name = ? AND revoked = 0 AND not_after > ?;`, machineName, now).Scan(&n)
+	return n > 0, err
+}
+
+// RevokeSlaveCerts revokes every certificate of the slave, it has to enroll again with a new token
+func RevokeSlaveCerts(machineName string) (int, error) {
+	res, err := DB.Exec(`UPDATE slave_certs SET revoked = 1 WHERE machine_name = ? AND revoked = 0;`, machineName)
+	if err != nil {
+		return 0, err
+	}
+	n, err := res.RowsAffected()
+	return int(n), err
+}
+
+func AddEnrollmentToken(t *EnrollmentToken) error {
+	query := `
+	INSERT INTO enrollment_tokens (secret_hash, machine_name, created_at, expires_at)
+	VALUES (?, ?, ?, ?);
+	`
+	res, err := DB.Exec(query, t.SecretHash, t.MachineName, t.CreatedAt, t.ExpiresAt)
+	if err != nil {
+		return err
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return err
+	}
+	t.Id = int(id)
+	return nil
+}
+
+// UseEnrollmentToken marks the token used by machineName, it fails for unknown, expired, used or
+// tokens bound to another name. boundOnly also refuses tokens made for any name
+func UseEnrollmentToken(secretHash, machineName string, now int64, boundOnly bool) error {
+	query := `
+	UPDATE enrollment_tokens
+	SET used_at = ?, used_by = ?
+	WHERE secret_hash = ? AND used_at IS NULL AND expires_at > ?
+		AND (machine_name = ? OR (machine_name = '' AND ? = 0));
+	`
+	res, err := DB.Exec(query, now, machineName, secretHash, now, machineName, boundOnly)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return errors.New("invalid, expired or already used enrollment token")
+	}
+	return nil
+}
+
+func GetEnrollmentTokens() ([]EnrollmentToken, error) {
+	query := `
+	SELECT id, secret_hash, machine_name, created_at, expires_at, used_at, used_by
+	FROM enrollment_tokens
+	ORDER BY created_at DESC;
+	`
+	rows, err := DB.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tokens []EnrollmentToken
+	for rows.Next() {
+		var t EnrollmentToken
+		var usedAt sql.NullInt64
+		var usedBy sql.NullString
+		if err := rows.Scan(&t.Id, &t.SecretHash, &t.MachineName, &t.CreatedAt, &t.ExpiresAt, &usedAt, &usedBy); err != nil {
+			return nil, err
+		}
+		t.UsedAt = usedAt.Int64
+		t.UsedBy = usedBy.String
+		tokens = append(tokens, t)
+	}
+	return tokens, rows.Err()
+}
+
+func RemoveEnrollmentToken(id int) error {
+	_, err := DB.Exec(`DELETE FROM enrollment_tokens WHERE id = ?;`, id)
+	return err
+}
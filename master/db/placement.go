@@ -0,0 +1,73 @@
+package db
+
+const (
+	PlacementAffinity     = "affinity"      // run on the same slave
+	PlacementAntiAffinity = "anti-affinity" // never run on the same slave
+)
+
+// placement rules saved with a vm, they hold both ways and are checked again when vms move
+type VmPlacementRule struct {
+	VmName  string
+	Rule    string
+	OtherVm string
+}
+
+func CreateVmPlacementRulesTable() error {
+	query := `
+	CREATE TABLE IF NOT EXISTS vm_placement_rules (
+		vm_name TEXT NOT NULL,
+		rule TEXT NOT NULL,
+		other_vm TEXT NOT NULL,
+		PRIMARY KEY (vm_name, rule, other_vm)
+	);
+	`
+	_, err := DB.Exec(query)
+	return err
+}
+
+func AddVmPlacementRule(rule VmPlacementRule) error {
+	query := `
+	INSERT INTO vm_placement_rules (vm_name, rule, other_vm)
+	VALUES (?, ?, ?)
+	ON CONFLICT(vm_name, rule, other_vm) DO NOTHING;
+	`
+	_, err := DB.Exec(query, rule.VmName, rule.Rule, rule.OtherVm)
+	return err
+}
+
+// RemoveVmPlacementRules drops every rule the vm is part of, on either side
+func RemoveVmPlacementRules(vmName string) error {
+	query := `
+	DELETE FROM vm_placement_rules
+	WHERE vm_name = ? OR other_vm = ?;
+	`
+	_, err := DB.Exec(query, vmName, vmName)
+	return err
+}
+
+// GetVmPlacementRules returns the rules the vm is part of, seen from the vm (VmName is always vmName)
+func GetVmPlacementRules(vmName string) ([]VmPlacementRule, error) {
+	const query = `
+	SELECT vm_name, rule, other_vm
+	FROM vm_placement_rules
+	WHERE vm_name = ? OR other_vm = ?;
+	`
+	rows, err := DB.Query(query, vmName, vmName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var rules []VmPlacementRule
+	for rows.Next() {
+		var r VmPlacementRule
+		if err := rows.Scan(&r.VmName, &r.Rule, &r.OtherVm); err != nil {
+			return nil, err
+		}
+		if r.VmName != vmName {
+			r.VmName, r.OtherVm = r.OtherVm, r.VmName
+		}
+		rules = append(rules, r)
+	}
+	return rules, rows.Err()
+}
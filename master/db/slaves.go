@@ -0,0 +1,131 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+)
+
+// SlaveCapabilities is the last handshake of a slave, kept after it disconnects
+type SlaveCapabilities struct {
+	MachineName    string   `json:"machine_name"`
+	BuildVersion   string   `json:"build_version"`
+	ProtoVersion   uint32   `json:"proto_version"`
+	LibvirtVersion string   `json:"libvirt_version"`
+	QemuVersion    string   `json:"qemu_version"`
+	CpuArch        string   `json:"cpu_arch"`
+	CpuVendor      string   `json:"cpu_vendor"`
+	CpuModel       string   `json:"cpu_model"`
+	CpuFeatures    []string `json:"cpu_features"`
+	MemoryTotalMB  int64    `json:"memory_total_mb"`
+	Cpus           int32    `json:"cpus"`
+	Features       []string `json:"features"`
+	Warnings       []string `json:"warnings"` // what the master found when the slave connected
+	UpdatedAt      int64    `json:"updated_at"`
+}
+
+func CreateSlaveCapabilitiesTable() error {
+	query := `
+	CREATE TABLE IF NOT EXISTS slave_capabilities (
+		machine_name TEXT PRIMARY KEY,
+		build_version TEXT NOT NULL,
+		proto_version INTEGER NOT NULL,
+		libvirt_version TEXT NOT NULL,
+		qemu_version TEXT NOT NULL,
+		cpu_arch TEXT NOT NULL,
+		cpu_vendor TEXT NOT NULL,
+		cpu_model TEXT NOT NULL,
+		cpu_features TEXT NOT NULL,
+		memory_total_mb INTEGER NOT NULL,
+		cpus INTEGER NOT NULL,
+		features TEXT NOT NULL,
+		warnings TEXT NOT NULL,
+		updated_at INTEGER NOT NULL
+	);
+	`
+	_, err := DB.Exec(query)
+	return err
+}
+
+// lists are kept one per line, none of the values can hold a newline
+func joinList(list []string) string {
+	return strings.Join(list, "\n")
+}
+
+func splitList(s string) []string {
+	if s == "" {
+		return []string{}
+	}
+	return strings.Split(s, "\n")
+}
+
+func SetSlaveCapabilities(c SlaveCapabilities) error {
+	query := `
+	INSERT INTO slave_capabilities (machine_name, build_version, proto_version, libvirt_version, qemu_version, cpu_arch,
+		cpu_vendor, cpu_model, cpu_features, memory_total_mb, cpus, features, warnings, updated_at)
+	VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	ON CONFLICT(machine_name) DO UPDATE SET
+		build_version = excluded.build_version,
+		proto_version = excluded.proto_version,
+		libvirt_version = excluded.libvirt_version,
+		qemu_version = excluded.qemu_version,
+		cpu_arch = excluded.cpu_arch,
+		cpu_vendor = excluded.cpu_vendor,
+		cpu_model = excluded.cpu_model,
+		cpu_features = excluded.cpu_features,
+		memory_total_mb = excluded.memory_total_mb,
+		cpus = excluded.cpus,
+		features = excluded.features,
+		warnings = excluded.warnings,
+		updated_at = excluded.updated_at;
+	`
+	_, err := DB.Exec(query, c.MachineName, c.BuildVersion, c.ProtoVersion, c.LibvirtVersion, c.QemuVersion, c.CpuArch,
+		c.CpuVendor, c.CpuModel, joinList(c.CpuFeatures), c.MemoryTotalMB, c.Cpus, joinList(c.Features),
+		joinList(c.Warnings), c.UpdatedAt)
+	return err
+}
+
+func scanSlaveCapabilities(row rowScanner) (*SlaveCapabilities, error) {
+	var c SlaveCapabilities
+	var cpuFeatures, features, warnings string
+	err := row.Scan(&c.MachineName, &c.BuildVersion, &c.ProtoVersion, &c.LibvirtVersion, &c.QemuVersion, &c.CpuArch,
+		&c.CpuVendor, &c.CpuModel, &cpuFeatures, &c.MemoryTotalMB, &c.Cpus, &features, &warnings, &c.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	c.CpuFeatures = splitList(cpuFeatures)
+	c.Features = splitList(features)
+	c.Warnings = splitList(warnings)
+	return &c, nil
+}
+
+const slaveCapabilitiesColumns = `machine_name, build_version, proto_version, libvirt_version, qemu_version, cpu_arch,
+	cpu_vendor, cpu_model, cpu_features, memory_total_mb, cpus, features, warnings, updated_at`
+
+// GetSlaveCapabilities returns nil for a slave that never connected
+func GetSlaveCapabilities(machineName string) (*SlaveCapabilities, error) {
+	row := DB.QueryRow(`SELECT `+slaveCapabilitiesColumns+` FROM slave_capabilities WHERE machine_name = ?;`, machineName)
+	c, err := scanSlaveCapabilities(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	return c, err
+}
+
+func GetAllSlaveCapabilities() ([]SlaveCapabilities, error) {
+	rows, err := DB.Query(`SELECT ` + slaveCapabilitiesColumns + ` FROM slave_capabilities ORDER BY machine_name;`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []SlaveCapabilities
+	for rows.Next() {
+		c, err := scanSlaveCapabilities(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, *c)
+	}
+	return out, rows.Err()
+}
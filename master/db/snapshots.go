@@ -0,0 +1,118 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+)
+
+// snapshot metadata lives in libvirt on the slave that owns the vm,
+// keeping a copy here lets us redefine it after the vm moves to another slave
+type VmSnapshot struct {
+	Id          int    `json:"id"`
+	VmName      string `json:"vm_name"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	External    bool   `json:"external"`
+	State       string `json:"state"`
+	Parent      string `json:"parent"`
+	CreatedAt   int64  `json:"created_at"`
+	Current     bool   `json:"current"`
+	Xml         string `json:"-"`
+}
+
+func CreateVmSnapshotsTable() error {
+	query := `
+	CREATE TABLE IF NOT EXISTS vm_snapshots (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		vm_name TEXT NOT NULL,
+		name TEXT NOT NULL,
+		description TEXT,
+		external INTEGER NOT NULL DEFAULT 0,
+		state TEXT,
+		parent TEXT,
+		created_at INTEGER NOT NULL,
+		current INTEGER NOT NULL DEFAULT 0,
+		xml TEXT NOT NULL,
+		UNIQUE(vm_name, name)
+	);
+	`
+	_, err := DB.Exec(query)
+	return err
+}
+
+// ReplaceVmSnapshots swaps every stored snapshot of vmName for snaps, the slave is the source of truth
+func ReplaceVmSnapshots(vmName string, snaps []VmSnapshot) error {
+	tx, err := DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`DELETE FROM vm_snapshots WHERE vm_name = ?;`, vmName); err != nil {
+		return err
+	}
+
+	query := `
+	INSERT INTO vm_snapshots (vm_name, name, description, external, state, parent, created_at, current, xml)
+	VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?);
+	`
+	for _, snap := range snaps {
+		if _, err := tx.Exec(query, vmName, snap.Name, snap.Description, snap.External, snap.State, snap.Parent, snap.CreatedAt, snap.Current, snap.Xml); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func GetVmSnapshots(vmName string) ([]VmSnapshot, error) {
+	const query = `
+	SELECT id, vm_name, name, description, external, state, parent, created_at, current, xml
+	FROM vm_snapshots
+	WHERE vm_name = ?
+	ORDER BY created_at ASC;
+	`
+	rows, err := DB.Query(query, vmName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var snaps []VmSnapshot
+	for rows.Next() {
+		var snap VmSnapshot
+		if err := rows.Scan(&snap.Id, &snap.VmName, &snap.Name, &snap.Description, &snap.External, &snap.State, &snap.Parent, &snap.CreatedAt, &snap.Current, &snap.Xml); err != nil {
+			return nil, err
+		}
+		snaps = append(snaps, snap)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return snaps, nil
+}
+
+func GetVmSnapshot(vmName, name string) (*VmSnapshot, error) {
+	const query = `
+	SELECT id, vm_name, name, description, external, state, parent, created_at, current, xml
+	FROM vm_snapshots
+	WHERE vm_name = ? AND name = ?;
+	`
+	var snap VmSnapshot
+	err := DB.QueryRow(query, vmName, name).Scan(&snap.Id, &snap.VmName, &snap.Name, &snap.Description, &snap.External, &snap.State, &snap.Parent, &snap.CreatedAt, &snap.Current, &snap.Xml)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &snap, nil
+}
+
+func RemoveVmSnapshots(vmName string) error {
+	query := `
+	DELETE FROM vm_snapshots
+	WHERE vm_name = ?;
+	`
+	_, err := DB.Exec(query, vmName)
+	return err
+}
@@ -0,0 +1,91 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+)
+
+// golden qcow2 images on a nfs share, new vms use them as backing file instead of installing from an iso
+type VmTemplate struct {
+	Id          int
+	Name        string
+	NfsShareId  int
+	DiskPath    string // path as mounted on the slaves
+	Description string
+}
+
+func CreateVmTemplatesTable() error {
+	query := `
+	CREATE TABLE IF NOT EXISTS vm_templates (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		name TEXT NOT NULL UNIQUE,
+		nfs_share_id INTEGER NOT NULL,
+		disk_path TEXT NOT NULL,
+		description TEXT
+	);
+	`
+	_, err := DB.Exec(query)
+	return err
+}
+
+func AddVmTemplate(name string, nfsShareId int, diskPath, description string) error {
+	query := `
+	INSERT INTO vm_templates (name, nfs_share_id, disk_path, description)
+	VALUES (?, ?, ?, ?);
+	`
+	_, err := DB.Exec(query, name, nfsShareId, diskPath, description)
+	return err
+}
+
+func GetAllVmTemplates() ([]VmTemplate, error) {
+	const query = `
+	SELECT id, name, nfs_share_id, disk_path, description
+	FROM vm_templates;
+	`
+	rows, err := DB.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var templates []VmTemplate
+	for rows.Next() {
+		var t VmTemplate
+		var description sql.NullString
+		if err := rows.Scan(&t.Id, &t.Name, &t.NfsShareId, &t.DiskPath, &description); err != nil {
+			return nil, err
+		}
+		t.Description = description.String
+		templates = append(templates, t)
+	}
+	return templates, rows.Err()
+}
+
+func GetVmTemplateByID(id int) (*VmTemplate, error) {
+	const query = `
+	SELECT id, name, nfs_share_id, disk_path, description
+	FROM vm_templates
+	WHERE id = ?;
+	`
+
+	var t VmTemplate
+	var description sql.NullString
+	err := DB.QueryRow(query, id).Scan(&t.Id, &t.Name, &t.NfsShareId, &t.DiskPath, &description)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, sql.ErrNoRows
+	}
+	if err != nil {
+		return nil, err
+	}
+	t.Description = description.String
+	return &t, nil
+}
+
+func RemoveVmTemplateByID(id int) error {
+	query := `
+	DELETE FROM vm_templates
+	WHERE id = ?;
+	`
+	_, err := DB.Exec(query, id)
+	return err
+}
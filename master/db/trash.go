@@ -0,0 +1,222 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+)
+
+const DefaultTrashRetentionHours = 7 * 24
+
+// a deleted vm waits here until it is restored or purged, the files sit in share/.trash
+type TrashedVm struct {
+	Id          int           `json:"id"`
+	VmName      string        `json:"vm_name"`
+	MachineName string        `json:"machine_name"` // slave it was deleted on
+	Live        bool          `json:"live"`
+	Xml         string        `json:"-"`
+	DeletedAt   int64         `json:"deleted_at"`
+	Files       []TrashedFile `json:"files"`
+	Snapshots   []VmSnapshot  `json:"-"` // only xml and current are kept, enough to redefine them
+}
+
+type TrashedFile struct {
+	Original string `json:"original"`
+	Trashed  string `json:"trashed"`
+}
+
+func CreateVmTrashTable() error {
+	query := `
+	CREATE TABLE IF NOT EXISTS vm_trash (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		vm_name TEXT NOT NULL,
+		machine_name TEXT NOT NULL,
+		live INTEGER NOT NULL DEFAULT 0,
+		xml TEXT NOT NULL,
+		deleted_at INTEGER NOT NULL
+	);
+	CREATE TABLE IF NOT EXISTS vm_trash_files (
+		trash_id INTEGER NOT NULL,
+		original TEXT NOT NULL,
+		trashed TEXT NOT NULL
+	);
+	CREATE TABLE IF NOT EXISTS vm_trash_snapshots (
+		trash_id INTEGER NOT NULL,
+		name TEXT NOT NULL,
+		created_at INTEGER NOT NULL,
+		current INTEGER NOT NULL DEFAULT 0,
+		xml TEXT NOT NULL
+	);
+	CREATE TABLE IF NOT EXISTS trash_config (
+		id INTEGER PRIMARY KEY CHECK (id = 1),
+		retention_hours INTEGER NOT NULL
+	);
+	`
+	_, err := DB.Exec(query)
+	return err
+}
+
+// AddTrashedVm saves the entry with its files and snapshots, the new id is set on vm
+func AddTrashedVm(vm *TrashedVm) error {
+	tx, err := DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `
+	INSERT INTO vm_trash (vm_name, machine_name, live, xml, deleted_at)
+	VALUES (?, ?, ?, ?, ?);
+	`
+	res, err := tx.Exec(query, vm.VmName, vm.MachineName, vm.Live, vm.Xml, vm.DeletedAt)
+	if err != nil {
+		return err
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return err
+	}
+	for _, file := range vm.Files {
+		if _, err := tx.Exec(`INSERT INTO vm_trash_files (trash_id, original, trashed) VALUES (?, ?, ?);`, id, file.Original, file.Trashed); err != nil {
+			return err
+		}
+	}
+	for _, snap := range vm.Snapshots {
+		if _, err := tx.Exec(`INSERT INTO vm_trash_snapshots (trash_id, name, created_at, current, xml) VALUES (?, ?, ?, ?, ?);`, id, snap.Name, snap.CreatedAt, snap.Current, snap.Xml); err != nil {
+			return err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	vm.Id = int(id)
+	return nil
+}
+
+func RemoveTrashedVm(id int) error {
+	tx, err := DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`DELETE FROM vm_trash_files WHERE trash_id = ?;`, id); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(`DELETE FROM vm_trash_snapshots WHERE trash_id = ?;`, id); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(`DELETE FROM vm_trash WHERE id = ?;`, id); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func getTrashedVmDetails(vm *TrashedVm) error {
+	rows, err := DB.Query(`SELECT original, trashed FROM vm_trash_files WHERE trash_id = ?;`, vm.Id)
+	if err != nil {
+		return err
+	}
+	for rows.Next() {
+		var file TrashedFile
+		if err := rows.Scan(&file.Original, &file.Trashed); err != nil {
+			rows.Close()
+			return err
+		}
+		vm.Files = append(vm.Files, file)
+	}
+	if err := rows.Err(); err != nil {
+		rows.Close()
+		return err
+	}
+	rows.Close()
+
+	rows, err = DB.Query(`SELECT name, created_at, current, xml FROM vm_trash_snapshots WHERE trash_id = ? ORDER BY created_at ASC;`, vm.Id)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		snap := VmSnapshot{VmName: vm.VmName}
+		if err := rows.Scan(&snap.Name, &snap.CreatedAt, &snap.Current, &snap.Xml); err != nil {
+			return err
+		}
+		vm.Snapshots = append(vm.Snapshots, snap)
+	}
+	return rows.Err()
+}
+
+func queryTrashedVms(query string, args ...any) ([]TrashedVm, error) {
+	rows, err := DB.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	var vms []TrashedVm
+	for rows.Next() {
+		var vm TrashedVm
+		if err := rows.Scan(&vm.Id, &vm.VmName, &vm.MachineName, &vm.Live, &vm.Xml, &vm.DeletedAt); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		vms = append(vms, vm)
+	}
+	if err := rows.Err(); err != nil {
+		rows.Close()
+		return nil, err
+	}
+	rows.Close()
+
+	for i := range vms {
+		if err := getTrashedVmDetails(&vms[i]); err != nil {
+			return nil, err
+		}
+	}
+	return vms, nil
+}
+
+func GetAllTrashedVms() ([]TrashedVm, error) {
+	return queryTrashedVms(`
+	SELECT id, vm_name, machine_name, live, xml, deleted_at
+	FROM vm_trash
+	ORDER BY deleted_at ASC;
+	`)
+}
+
+func GetTrashedVm(id int) (*TrashedVm, error) {
+	vms, err := queryTrashedVms(`
+	SELECT id, vm_name, machine_name, live, xml, deleted_at
+	FROM vm_trash
+	WHERE id = ?;
+	`, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(vms) == 0 {
+		return nil, nil
+	}
+	return &vms[0], nil
+}
+
+func SetTrashRetentionHours(hours int) error {
+	query := `
+	INSERT INTO trash_config (id, retention_hours)
+	VALUES (1, ?)
+	ON CONFLICT(id) DO UPDATE SET
+		retention_hours = excluded.retention_hours;
+	`
+	_, err := DB.Exec(query, hours)
+	return err
+}
+
+// GetTrashRetentionHours returns how long a deleted vm is kept, the default when never set
+func GetTrashRetentionHours() (int, error) {
+	var hours int
+	err := DB.QueryRow(`SELECT retention_hours FROM trash_config WHERE id = 1;`).Scan(&hours)
+	if errors.Is(err, sql.ErrNoRows) {
+		return DefaultTrashRetentionHours, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return hours, nil
+}
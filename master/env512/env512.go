@@ -10,12 +10,14 @@ import (
 var (
 	PingInterval   int
 	Mode          string
+	MetricsToken  string // bearer token prometheus scrapes /metrics with, the npm login works too
 )
 
 func Setup() error {
 	godotenv.Load(".env")
 	PingInterval, _ = strconv.Atoi(os.Getenv("PING_INTERVAL"))
 	Mode = os.Getenv("MODE")
+	MetricsToken = os.Getenv("METRICS_TOKEN")
 
 	if PingInterval == 0 {
 		PingInterval = 10 //default 10 seconds
@@ -1,6 +1,7 @@
 package extra
 
 import (
+	"512SvMan/pki"
 	"512SvMan/websocket"
 	"context"
 
@@ -30,3 +31,21 @@ func (s *ExtraServiceServer) SendWebsocketMessage(ctx context.Context, req *extr
 	websocket.BroadcastMessage(websocketMsg)
 	return &extraGrpc.Empty{}, nil
 }
+
+var slaveEventFunc func(event *extraGrpc.SlaveEvent)
+
+// OnSlaveEvent sets who handles the events the slaves report
+func OnSlaveEvent(f func(event *extraGrpc.SlaveEvent)) {
+	slaveEventFunc = f
+}
+
+func (s *ExtraServiceServer) ReportEvent(ctx context.Context, req *extraGrpc.SlaveEvent) (*extraGrpc.Empty, error) {
+	// the name in the certificate, not whatever the slave wrote in the event
+	if name, err := pki.PeerMachineName(ctx); err == nil {
+		req.MachineName = name
+	}
+	if slaveEventFunc != nil {
+		go slaveEventFunc(req)
+	}
+	return &extraGrpc.Empty{}, nil
+}
@@ -0,0 +1,64 @@
+package info
+
+import (
+	"context"
+
+	infoGrpc "github.com/Maruqes/512SvMan/api/proto/info"
+	"google.golang.org/grpc"
+)
+
+func GetCPUInfo(conn *grpc.ClientConn) (*infoGrpc.CpuInfo, error) {
+	client := infoGrpc.NewSlaveInfoServiceClient(conn)
+	return client.GetCPUInfo(context.Background(), &infoGrpc.Empty{})
+}
+
+func GetMemInfo(conn *grpc.ClientConn) (*infoGrpc.MemInfo, error) {
+	client := infoGrpc.NewSlaveInfoServiceClient(conn)
+	return client.GetMemInfo(context.Background(), &infoGrpc.Empty{})
+}
+
+func GetDiskInfo(conn *grpc.ClientConn) (*infoGrpc.DisksInfo, error) {
+	client := infoGrpc.NewSlaveInfoServiceClient(conn)
+	return client.GetDiskInfo(context.Background(), &infoGrpc.Empty{})
+}
+
+func GetNetworkInfo(conn *grpc.ClientConn) (*infoGrpc.NetworkInfo, error) {
+	client := infoGrpc.NewSlaveInfoServiceClient(conn)
+	return client.GetNetworkInfo(context.Background(), &infoGrpc.Empty{})
+}
+
+func GetProcesses(conn *grpc.ClientConn, req *infoGrpc.ProcessesRequest) (*infoGrpc.ProcessList, error) {
+	client := infoGrpc.NewSlaveInfoServiceClient(conn)
+	return client.GetProcesses(context.Background(), req)
+}
+
+func GetMetrics(conn *grpc.ClientConn) (*infoGrpc.HostMetrics, error) {
+	client := infoGrpc.NewSlaveInfoServiceClient(conn)
+	return client.GetMetrics(context.Background(), &infoGrpc.Empty{})
+}
+
+// StreamMetrics keeps sending readings until ctx is cancelled or the slave goes away
+func StreamMetrics(ctx context.Context, conn *grpc.ClientConn, intervalSeconds int32) (infoGrpc.SlaveInfoService_StreamMetricsClient, error) {
+	client := infoGrpc.NewSlaveInfoServiceClient(conn)
+	return client.StreamMetrics(ctx, &infoGrpc.StreamMetricsRequest{IntervalSeconds: intervalSeconds})
+}
+
+func GetServices(conn *grpc.ClientConn) (*infoGrpc.ServiceList, error) {
+	client := infoGrpc.NewSlaveInfoServiceClient(conn)
+	return client.GetServices(context.Background(), &infoGrpc.Empty{})
+}
+
+func GetServiceStatus(conn *grpc.ClientConn, req *infoGrpc.ServiceRequest) (*infoGrpc.Service, error) {
+	client := infoGrpc.NewSlaveInfoServiceClient(conn)
+	return client.GetServiceStatus(context.Background(), req)
+}
+
+func GetServiceLogs(conn *grpc.ClientConn, req *infoGrpc.ServiceLogsRequest) (*infoGrpc.ServiceLogs, error) {
+	client := infoGrpc.NewSlaveInfoServiceClient(conn)
+	return client.GetServiceLogs(context.Background(), req)
+}
+
+func ServiceAction(conn *grpc.ClientConn, req *infoGrpc.ServiceActionRequest) (*infoGrpc.Empty, error) {
+	client := infoGrpc.NewSlaveInfoServiceClient(conn)
+	return client.ServiceAction(context.Background(), req)
+}
@@ -4,7 +4,9 @@ import (
 	"512SvMan/api"
 	"512SvMan/db"
 	"512SvMan/env512"
+	"512SvMan/extra"
 	"512SvMan/logs512"
+	"512SvMan/pki"
 	"512SvMan/protocol"
 	"512SvMan/services"
 	"bytes"
@@ -28,6 +30,16 @@ func newSlave(addr, machineName string, conn *grpc.ClientConn) error {
 		return err
 	}
 
+	//a network that fails here (missing nic on this slave) must not drop the slave
+	networkService := services.NetworkService{}
+	if err := networkService.SyncNetworksOn(conn); err != nil {
+		logger.Error("SyncNetworks failed:", err)
+	}
+
+	//vms restarted elsewhere while this slave was gone must not come back here too
+	haService := services.HAService{}
+	go haService.SlaveJoined(conn, machineName)
+
 	return nil
 }
 
@@ -103,6 +115,100 @@ func main() {
 		log.Fatalf("create vm_live table: %v", err)
 	}
 
+	err = db.CreateVmSnapshotsTable()
+	if err != nil {
+		log.Fatalf("create vm_snapshots table: %v", err)
+	}
+
+	err = db.CreateVmLinkedClonesTable()
+	if err != nil {
+		log.Fatalf("create vm_linked_clones table: %v", err)
+	}
+
+	err = db.CreateVmTemplatesTable()
+	if err != nil {
+		log.Fatalf("create vm_templates table: %v", err)
+	}
+
+	err = db.CreateNetworksTable()
+	if err != nil {
+		log.Fatalf("create networks table: %v", err)
+	}
+
+	err = db.CreateVmHATable()
+	if err != nil {
+		log.Fatalf("create vm_ha table: %v", err)
+	}
+
+	err = db.CreateSlaveMaintenanceTable()
+	if err != nil {
+		log.Fatalf("create slave_maintenance table: %v", err)
+	}
+
+	err = db.CreateVmPlacementRulesTable()
+	if err != nil {
+		log.Fatalf("create vm_placement_rules table: %v", err)
+	}
+
+	err = db.CreateDRSTables()
+	if err != nil {
+		log.Fatalf("create drs tables: %v", err)
+	}
+
+	err = db.CreateVmBackupPoliciesTable()
+	if err != nil {
+		log.Fatalf("create vm_backup_policies table: %v", err)
+	}
+
+	err = db.CreateVmBackupsTable()
+	if err != nil {
+		log.Fatalf("create vm_backups table: %v", err)
+	}
+
+	err = db.CreateVmTrashTable()
+	if err != nil {
+		log.Fatalf("create vm_trash table: %v", err)
+	}
+
+	err = db.CreateGuestExecAllowlistTable()
+	if err != nil {
+		log.Fatalf("create guest_exec_allowlist table: %v", err)
+	}
+
+	err = db.CreateConsoleSessionsTable()
+	if err != nil {
+		log.Fatalf("create console_sessions table: %v", err)
+	}
+	err = db.EndOpenConsoleSessions()
+	if err != nil {
+		log.Fatalf("end open console sessions: %v", err)
+	}
+
+	err = db.CreateMetricSamplesTable()
+	if err != nil {
+		log.Fatalf("create metric_samples table: %v", err)
+	}
+
+	err = db.CreateAlertTables()
+	if err != nil {
+		log.Fatalf("create alert tables: %v", err)
+	}
+
+	err = db.CreateSlaveCapabilitiesTable()
+	if err != nil {
+		log.Fatalf("create slave capabilities table: %v", err)
+	}
+
+	err = db.CreatePKITables()
+	if err != nil {
+		log.Fatalf("create pki tables: %v", err)
+	}
+	err = pki.Setup()
+	if err != nil {
+		log.Fatalf("pki setup: %v", err)
+	}
+	pki.StartRotation()
+
 	err = db.CreateLogsTable()
 	if err != nil {
 		log.Fatalf("create logs table: %v", err)
@@ -115,7 +221,32 @@ func main() {
 
 	//listen and connects to gRPC
 	logger.SetCallBack(logs512.LoggerCallBack)
+	haService := services.HAService{}
+	alertService := services.AlertService{}
+	protocol.OnSlaveLost(func(addr, machineName string) {
+		alertService.SlaveLost(addr, machineName)
+		haService.SlaveLost(addr, machineName)
+	})
+	extra.OnSlaveEvent(alertService.SlaveEvent)
 	protocol.ListenGRPC(newSlave)
+	haService.StartMonitor()
+
+	drsService := services.DRSService{}
+	drsService.Start()
+
+	backupService := services.BackupService{}
+	backupService.Start()
+
+	trashService := services.TrashService{}
+	trashService.Start()
+
+	hostService := services.HostService{}
+	hostService.Start()
+
+	historyService := services.HistoryService{}
+	historyService.Start()
+
+	alertService.Start()
 
 	api.StartApi()
 
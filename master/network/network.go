@@ -0,0 +1,52 @@
+package network
+
+import (
+	"context"
+
+	pbnetwork "github.com/Maruqes/512SvMan/api/proto/network"
+	"github.com/Maruqes/512SvMan/logger"
+	"google.golang.org/grpc"
+)
+
+func DefineNetwork(conn *grpc.ClientConn, network *pbnetwork.Network) error {
+	client := pbnetwork.NewNetworkServiceClient(conn)
+
+	res, err := client.DefineNetwork(context.Background(), network)
+	if err != nil {
+		return err
+	}
+	logger.Info("Response from DefineNetwork: ", res.GetOk(), ", Defined network:", network.Name)
+	return nil
+}
+
+func RemoveNetwork(conn *grpc.ClientConn, name string) error {
+	client := pbnetwork.NewNetworkServiceClient(conn)
+
+	res, err := client.RemoveNetwork(context.Background(), &pbnetwork.NetworkName{Name: name})
+	if err != nil {
+		return err
+	}
+	logger.Info("Response from RemoveNetwork: ", res.GetOk(), ", Removed network:", name)
+	return nil
+}
+
+func SyncNetworks(conn *grpc.ClientConn, networks *pbnetwork.NetworkList) error {
+	client := pbnetwork.NewNetworkServiceClient(conn)
+
+	res, err := client.SyncNetworks(context.Background(), networks)
+	if err != nil {
+		return err
+	}
+	logger.Info("Response from SyncNetworks: ", res.GetOk(), ", Synced networks:", len(networks.Networks))
+	return nil
+}
+
+func ListNetworks(conn *grpc.ClientConn) ([]*pbnetwork.NetworkStatus, error) {
+	client := pbnetwork.NewNetworkServiceClient(conn)
+
+	res, err := client.ListNetworks(context.Background(), &pbnetwork.Empty{})
+	if err != nil {
+		return nil, err
+	}
+	return res.Networks, nil
+}
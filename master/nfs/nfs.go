@@ -80,8 +80,13 @@ func GetAllSharedFolders() ([]db.NFSShare, error) {
 }
 
 func GetSharedFolderStatus(conn *grpc.ClientConn, folderMount *pbnfs.FolderMount) (*pbnfs.SharedFolderStatusResponse, error) {
+	return GetSharedFolderStatusCtx(conn, context.Background(), folderMount)
+}
+
+// GetSharedFolderStatusCtx is GetSharedFolderStatus for callers that can not wait on a share that hangs
+func GetSharedFolderStatusCtx(conn *grpc.ClientConn, ctx context.Context, folderMount *pbnfs.FolderMount) (*pbnfs.SharedFolderStatusResponse, error) {
 	client := pbnfs.NewNFSServiceClient(conn)
-	return client.GetSharedFolderStatus(context.Background(), folderMount)
+	return client.GetSharedFolderStatus(ctx, folderMount)
 }
 
 func ListFolderContents(conn *grpc.ClientConn, path string) (*pbnfs.FolderContents, error) {
@@ -0,0 +1,61 @@
+package npm
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// a certificate npm serves, read from its volumes so no login is needed
+type CertFile struct {
+	Name     string // npm-<id> folder of the certificate
+	Domains  []string
+	NotAfter time.Time
+}
+
+// LocalCertFiles reads the letsencrypt and custom certificates out of the npm-ssl and npm-data folders PullImage created
+func LocalCertFiles() ([]CertFile, error) {
+	work, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+	patterns := []string{
+		filepath.Join(work, "npm-ssl", "live", "*", "fullchain.pem"),
+		filepath.Join(work, "npm-data", "custom_ssl", "*", "fullchain.pem"),
+	}
+
+	var certs []CertFile
+	for _, pattern := range patterns {
+		files, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range files {
+			data, err := os.ReadFile(file)
+			if err != nil {
+				return nil, err
+			}
+			// the leaf comes first in the chain
+			block, _ := pem.Decode(data)
+			if block == nil {
+				continue
+			}
+			cert, err := x509.ParseCertificate(block.Bytes)
+			if err != nil {
+				continue
+			}
+			domains := cert.DNSNames
+			if len(domains) == 0 && cert.Subject.CommonName != "" {
+				domains = []string{cert.Subject.CommonName}
+			}
+			certs = append(certs, CertFile{
+				Name:     filepath.Base(filepath.Dir(file)),
+				Domains:  domains,
+				NotAfter: cert.NotAfter,
+			})
+		}
+	}
+	return certs, nil
+}
@@ -244,3 +244,30 @@ func GetAllUsers(baseURL, token string) ([]User, error) {
 
 	return users, nil
 }
+
+// GetMe returns the user the token belongs to
+func GetMe(baseURL, token string) (*User, error) {
+	req, err := http.NewRequest("GET", baseURL+"/api/users/me", nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	client := &http.Client{Timeout: 20 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	respBody, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != 200 && resp.StatusCode != 201 {
+		return nil, fmt.Errorf("get me failed (%d): %s", resp.StatusCode, respBody)
+	}
+
+	var user User
+	if err := json.Unmarshal(respBody, &user); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
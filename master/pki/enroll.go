@@ -0,0 +1,63 @@
+package pki
+
+import (
+	"512SvMan/db"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+)
+
+func hashSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+// NewEnrollmentToken returns the token to give the new slave as ENROLL_TOKEN, <secret>.<ca fingerprint>,
+// the secret is not kept anywhere and can not be shown again
+func NewEnrollmentToken(machineName string, ttl time.Duration) (string, *db.EnrollmentToken, error) {
+	buf := make([]byte, 24)
+	if _, err := rand.Read(buf); err != nil {
+		return "", nil, err
+	}
+	secret := base64.RawURLEncoding.EncodeToString(buf)
+
+	now := time.Now()
+	tok := &db.EnrollmentToken{
+		SecretHash:  hashSecret(secret),
+		MachineName: strings.TrimSpace(machineName),
+		CreatedAt:   now.Unix(),
+		ExpiresAt:   now.Add(ttl).Unix(),
+	}
+	if err := db.AddEnrollmentToken(tok); err != nil {
+		return "", nil, err
+	}
+	return secret + "." + CAFingerprint(), tok, nil
+}
+
+// Enroll burns the token and signs the CSR of the new slave. a name that already holds a valid
+// certificate only enrolls again with a token made for that name, an open token can not take it over
+func Enroll(secret, machineName string, csrPEM []byte) ([]byte, error) {
+	machineName = strings.TrimSpace(machineName)
+	// a bad request must not spend the token
+	csr, err := parseCSR(csrPEM, machineName)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().Unix()
+	active, err := db.HasActiveSlaveCert(machineName, now)
+	if err != nil {
+		return nil, fmt.Errorf("check certificates of %s: %w", machineName, err)
+	}
+	if err := db.UseEnrollmentToken(hashSecret(secret), machineName, now, active); err != nil {
+		if active {
+			return nil, fmt.Errorf("%s already has a certificate, revoke it or use a token made for %s: %w", machineName, machineName, err)
+		}
+		return nil, err
+	}
+	return signCSR(csr, machineName)
+}
@@ -0,0 +1,391 @@
+package pki
+
+import (
+	"512SvMan/db"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"database/sql"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	pb "github.com/Maruqes/512SvMan/api/proto/protocol"
+	"github.com/Maruqes/512SvMan/logger"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// name in the master certificate, the slaves check it instead of an ip that may change
+	MasterServerName = "512svman-master"
+
+	pkiDir             = "pki"
+	caValidity         = 10 * 365 * 24 * time.Hour
+	masterCertValidity = 365 * 24 * time.Hour
+	SlaveCertValidity  = 90 * 24 * time.Hour
+	// certificates closer than this to expiring are replaced
+	RenewBefore      = 30 * 24 * time.Hour
+	rotationInterval = 12 * time.Hour
+)
+
+var (
+	caCert *x509.Certificate
+	caKey  crypto.Signer
+	caPEM  []byte
+	caPool *x509.CertPool
+
+	// swapped on rotation, the tls configs read it on every handshake
+	masterCert atomic.Pointer[tls.Certificate]
+)
+
+func newKey() (*ecdsa.PrivateKey, error) {
+	return ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+}
+
+func newSerial() (*big.Int, error) {
+	return rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+}
+
+func writePEM(path, blockType string, der []byte, mode os.FileMode) error {
+	return os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), mode)
+}
+
+func readPEM(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("%s is not pem", path)
+	}
+	return block.Bytes, nil
+}
+
+func loadOrCreateCA() error {
+	certPath := filepath.Join(pkiDir, "ca.crt")
+	keyPath := filepath.Join(pkiDir, "ca.key")
+
+	if _, err := os.Stat(certPath); errors.Is(err, os.ErrNotExist) {
+		key, err := newKey()
+		if err != nil {
+			return err
+		}
+		serial, err := newSerial()
+		if err != nil {
+			return err
+		}
+		tmpl := &x509.Certificate{
+			SerialNumber:          serial,
+			Subject:               pkix.Name{CommonName: "512SvMan CA"},
+			NotBefore:             time.Now().Add(-5 * time.Minute),
+			NotAfter:              time.Now().Add(caValidity),
+			KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+			BasicConstraintsValid: true,
+			IsCA:                  true,
+			MaxPathLenZero:        true,
+		}
+		der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+		if err != nil {
+			return fmt.Errorf("create ca: %w", err)
+		}
+		keyDER, err := x509.MarshalECPrivateKey(key)
+		if err != nil {
+			return err
+		}
+		if err := writePEM(keyPath, "EC PRIVATE KEY", keyDER, 0o600); err != nil {
+			return err
+		}
+		if err := writePEM(certPath, "CERTIFICATE", der, 0o644); err != nil {
+			return err
+		}
+		logger.Info("created master CA in", pkiDir)
+	}
+
+	der, err := readPEM(certPath)
+	if err != nil {
+		return err
+	}
+	caCert, err = x509.ParseCertificate(der)
+	if err != nil {
+		return fmt.Errorf("parse ca: %w", err)
+	}
+	keyDER, err := readPEM(keyPath)
+	if err != nil {
+		return err
+	}
+	caKey, err = x509.ParseECPrivateKey(keyDER)
+	if err != nil {
+		return fmt.Errorf("parse ca key: %w", err)
+	}
+	caPEM = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	caPool = x509.NewCertPool()
+	caPool.AddCert(caCert)
+	return nil
+}
+
+// loadOrIssueMasterCert keeps the master certificate on disk, a new one is issued when it is about to expire
+func loadOrIssueMasterCert() error {
+	certPath := filepath.Join(pkiDir, "master.crt")
+	keyPath := filepath.Join(pkiDir, "master.key")
+
+	if cert, err := tls.LoadX509KeyPair(certPath, keyPath); err == nil && cert.Leaf != nil && time.Until(cert.Leaf.NotAfter) > RenewBefore {
+		// the ca goes along so a slave enrolling can pin it
+		cert.Certificate = append(cert.Certificate, caCert.Raw)
+		masterCert.Store(&cert)
+		return nil
+	}
+
+	key, err := newKey()
+	if err != nil {
+		return err
+	}
+	serial, err := newSerial()
+	if err != nil {
+		return err
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: MasterServerName},
+		DNSNames:     []string{MasterServerName},
+		NotBefore:    time.Now().Add(-5 * time.Minute),
+		NotAfter:     time.Now().Add(masterCertValidity),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, caCert, &key.PublicKey, caKey)
+	if err != nil {
+		return fmt.Errorf("issue master cert: %w", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return err
+	}
+	if err := writePEM(keyPath, "EC PRIVATE KEY", keyDER, 0o600); err != nil {
+		return err
+	}
+	if err := writePEM(certPath, "CERTIFICATE", der, 0o644); err != nil {
+		return err
+	}
+
+	cert, err := tls.LoadX509KeyPair(certPath, keyPath)
+	if err != nil {
+		return err
+	}
+	cert.Certificate = append(cert.Certificate, caCert.Raw)
+	masterCert.Store(&cert)
+	logger.Info("issued master certificate, valid until", tmpl.NotAfter.Format(time.RFC3339))
+	return nil
+}
+
+// Setup loads the CA and the master certificate from ./pki, creating them the first time
+func Setup() error {
+	if err := os.MkdirAll(pkiDir, 0o700); err != nil {
+		return err
+	}
+	if err := loadOrCreateCA(); err != nil {
+		return err
+	}
+	return loadOrIssueMasterCert()
+}
+
+// StartRotation replaces the master certificate before it expires, open connections keep the old one
+func StartRotation() {
+	go func() {
+		for {
+			time.Sleep(rotationInterval)
+			if err := loadOrIssueMasterCert(); err != nil {
+				logger.Error("pki: failed to rotate master certificate:", err)
+			}
+		}
+	}()
+}
+
+func CAPEM() []byte {
+	return caPEM
+}
+
+// CAFingerprint is the sha256 of the CA, enrollment tokens carry it so the slave knows it talks to this master
+func CAFingerprint() string {
+	sum := sha256.Sum256(caCert.Raw)
+	return hex.EncodeToString(sum[:])
+}
+
+// parseCSR checks the CSR is a valid, self signed request for machineName
+func parseCSR(csrPEM []byte, machineName string) (*x509.CertificateRequest, error) {
+	if machineName == "" || machineName == MasterServerName {
+		return nil, fmt.Errorf("invalid machine name %q", machineName)
+	}
+	block, _ := pem.Decode(csrPEM)
+	if block == nil || block.Type != "CERTIFICATE REQUEST" {
+		return nil, fmt.Errorf("csr is not a pem certificate request")
+	}
+	csr, err := x509.ParseCertificateRequest(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("parse csr: %w", err)
+	}
+	if err := csr.CheckSignature(); err != nil {
+		return nil, fmt.Errorf("csr signature: %w", err)
+	}
+	if csr.Subject.CommonName != machineName {
+		return nil, fmt.Errorf("csr is for %q, not %q", csr.Subject.CommonName, machineName)
+	}
+	// a slave holding the master name could drive the other slaves
+	if strings.EqualFold(machineName, MasterServerName) {
+		return nil, fmt.Errorf("%s is reserved for the master", MasterServerName)
+	}
+	return csr, nil
+}
+
+// SignCSR issues the certificate of a slave, the CSR must be for machineName
+func SignCSR(csrPEM []byte, machineName string) ([]byte, error) {
+	csr, err := parseCSR(csrPEM, machineName)
+	if err != nil {
+		return nil, err
+	}
+	return signCSR(csr, machineName)
+}
+
+func signCSR(csr *x509.CertificateRequest, machineName string) ([]byte, error) {
+	serial, err := newSerial()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	tmpl := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: machineName},
+		DNSNames:     []string{machineName},
+		NotBefore:    now.Add(-5 * time.Minute),
+		NotAfter:     now.Add(SlaveCertValidity),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, caCert, csr.PublicKey, caKey)
+	if err != nil {
+		return nil, fmt.Errorf("sign csr: %w", err)
+	}
+
+	err = db.AddSlaveCert(db.SlaveCert{
+		Serial:      serial.Text(16),
+		MachineName: machineName,
+		IssuedAt:    now.Unix(),
+		NotAfter:    tmpl.NotAfter.Unix(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("record certificate: %w", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), nil
+}
+
+// checkSlaveCert accepts only certificates the master issued and did not revoke, the chain was already verified
+func checkSlaveCert(cert *x509.Certificate) (string, error) {
+	known, err := db.GetSlaveCert(cert.SerialNumber.Text(16))
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", fmt.Errorf("unknown certificate for %s", cert.Subject.CommonName)
+	}
+	if err != nil {
+		return "", err
+	}
+	if known.Revoked {
+		return "", fmt.Errorf("certificate of %s was revoked", known.MachineName)
+	}
+	if known.MachineName != cert.Subject.CommonName {
+		return "", fmt.Errorf("certificate was issued to %s, not %s", known.MachineName, cert.Subject.CommonName)
+	}
+	return known.MachineName, nil
+}
+
+func currentMasterCert() (*tls.Certificate, error) {
+	cert := masterCert.Load()
+	if cert == nil {
+		return nil, fmt.Errorf("master certificate not loaded")
+	}
+	return cert, nil
+}
+
+// ServerTLSConfig is for :50051, the client certificate is optional so a new slave can enroll, the interceptors
+// refuse every other call without one
+func ServerTLSConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS13,
+		GetCertificate: func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+			return currentMasterCert()
+		},
+		ClientAuth: tls.VerifyClientCertIfGiven,
+		ClientCAs:  caPool,
+	}
+}
+
+// ClientTLSConfig is for dialing the slave machineName on :50052
+func ClientTLSConfig(machineName string) *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS13,
+		ServerName: machineName,
+		RootCAs:    caPool,
+		GetClientCertificate: func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			return currentMasterCert()
+		},
+		VerifyConnection: func(cs tls.ConnectionState) error {
+			if len(cs.PeerCertificates) == 0 {
+				return fmt.Errorf("slave sent no certificate")
+			}
+			_, err := checkSlaveCert(cs.PeerCertificates[0])
+			return err
+		},
+	}
+}
+
+// PeerMachineName is the slave behind the grpc call, from its verified client certificate
+func PeerMachineName(ctx context.Context) (string, error) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return "", fmt.Errorf("no peer")
+	}
+	info, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(info.State.VerifiedChains) == 0 || len(info.State.VerifiedChains[0]) == 0 {
+		return "", fmt.Errorf("no client certificate")
+	}
+	return checkSlaveCert(info.State.VerifiedChains[0][0])
+}
+
+func authorize(ctx context.Context, method string) error {
+	if method == pb.ProtocolService_Enroll_FullMethodName {
+		return nil
+	}
+	if _, err := PeerMachineName(ctx); err != nil {
+		return status.Error(codes.Unauthenticated, err.Error())
+	}
+	return nil
+}
+
+func UnaryServerInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if err := authorize(ctx, info.FullMethod); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func StreamServerInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if err := authorize(ss.Context(), info.FullMethod); err != nil {
+		return err
+	}
+	return handler(srv, ss)
+}
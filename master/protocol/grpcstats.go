@@ -0,0 +1,87 @@
+package protocol
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+// upper bounds in seconds, migrations and iso downloads end up past the last one
+var GRPCLatencyBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60}
+
+// calls the master made to the slaves, per grpc method
+type GRPCMethodStats struct {
+	Method       string
+	Calls        uint64
+	Errors       uint64
+	Buckets      []uint64 // calls that took at most GRPCLatencyBuckets[i], cumulative
+	LatencySum   float64  // seconds, unary calls only
+	StreamsOpen  uint64   // streams opened, their duration is not a latency
+	StreamErrors uint64
+}
+
+var (
+	grpcStats   = map[string]*GRPCMethodStats{}
+	grpcStatsMu sync.Mutex
+)
+
+func grpcMethodStats(method string) *GRPCMethodStats {
+	s, ok := grpcStats[method]
+	if !ok {
+		s = &GRPCMethodStats{Method: method, Buckets: make([]uint64, len(GRPCLatencyBuckets))}
+		grpcStats[method] = s
+	}
+	return s
+}
+
+func grpcStatsUnaryInterceptor(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	start := time.Now()
+	err := invoker(ctx, method, req, reply, cc, opts...)
+	elapsed := time.Since(start).Seconds()
+
+	grpcStatsMu.Lock()
+	s := grpcMethodStats(method)
+	s.Calls++
+	if err != nil {
+		s.Errors++
+	}
+	s.LatencySum += elapsed
+	for i, bound := range GRPCLatencyBuckets {
+		if elapsed <= bound {
+			s.Buckets[i]++
+		}
+	}
+	grpcStatsMu.Unlock()
+	return err
+}
+
+func grpcStatsStreamInterceptor(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	stream, err := streamer(ctx, desc, cc, method, opts...)
+
+	grpcStatsMu.Lock()
+	s := grpcMethodStats(method)
+	s.StreamsOpen++
+	if err != nil {
+		s.StreamErrors++
+	}
+	grpcStatsMu.Unlock()
+	return stream, err
+}
+
+// GetGRPCStats returns a copy of the counters sorted by method
+func GetGRPCStats() []GRPCMethodStats {
+	grpcStatsMu.Lock()
+	defer grpcStatsMu.Unlock()
+
+	res := make([]GRPCMethodStats, 0, len(grpcStats))
+	for _, s := range grpcStats {
+		c := *s
+		c.Buckets = append([]uint64(nil), s.Buckets...)
+		res = append(res, c)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Method < res[j].Method })
+	return res
+}
@@ -1,8 +1,10 @@
 package protocol
 
 import (
+	"512SvMan/db"
 	"512SvMan/extra"
 	"512SvMan/logs512"
+	"512SvMan/pki"
 	"context"
 	"fmt"
 	"log"
@@ -13,9 +15,12 @@ import (
 	extraGrpc "github.com/Maruqes/512SvMan/api/proto/extra"
 	logsGrpc "github.com/Maruqes/512SvMan/api/proto/logsserve"
 	pb "github.com/Maruqes/512SvMan/api/proto/protocol"
+	"github.com/Maruqes/512SvMan/api/version"
 	"github.com/Maruqes/512SvMan/logger"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
 )
 
 type ConnectionsStruct struct {
@@ -23,10 +28,25 @@ type ConnectionsStruct struct {
 	MachineName string
 	Connection  *grpc.ClientConn
 	LastSeen    time.Time
+	// from the SetConnection handshake, placement and migration checks read it instead of asking the slave
+	Capabilities *pb.SlaveCapabilities
 }
 
 var recievedNewSlaveFunc func(addr, machineName string, conn *grpc.ClientConn) error
 
+// called once a slave stops answering pings and is removed
+var slaveLostFunc func(addr, machineName string)
+
+func OnSlaveLost(f func(addr, machineName string)) {
+	slaveLostFunc = f
+}
+
+func slaveLost(connection ConnectionsStruct) {
+	if slaveLostFunc != nil {
+		go slaveLostFunc(connection.Addr, connection.MachineName)
+	}
+}
+
 var (
 	connections   []*ConnectionsStruct
 	connectionsMu sync.RWMutex
@@ -152,6 +172,7 @@ func CheckConnection(connection ConnectionsStruct) {
 		if removed := removeConnection(connection.Addr); removed != nil && removed.Connection != nil {
 			_ = removed.Connection.Close()
 		}
+		slaveLost(connection)
 		return
 	}
 
@@ -172,6 +193,7 @@ func CheckConnection(connection ConnectionsStruct) {
 	if removed := removeConnection(connection.Addr); removed != nil && removed.Connection != nil {
 		_ = removed.Connection.Close()
 	}
+	slaveLost(connection)
 }
 
 func PingAllSlaves(ctx context.Context) {
@@ -198,11 +220,16 @@ func PingAllSlaves(ctx context.Context) {
 	}
 }
 
-func NewSlaveConnection(addr, machineName string) error {
+func NewSlaveConnection(addr, machineName string, caps *pb.SlaveCapabilities) error {
 
 	target := addr + ":50052"
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	conn, err := grpc.DialContext(ctx, target, grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithBlock())
+	conn, err := grpc.DialContext(ctx, target,
+		grpc.WithTransportCredentials(credentials.NewTLS(pki.ClientTLSConfig(machineName))),
+		grpc.WithBlock(),
+		grpc.WithChainUnaryInterceptor(grpcStatsUnaryInterceptor),
+		grpc.WithChainStreamInterceptor(grpcStatsStreamInterceptor),
+	)
 	cancel()
 	if err != nil {
 		return fmt.Errorf("dial slave %s: %w", target, err)
@@ -218,10 +245,11 @@ func NewSlaveConnection(addr, machineName string) error {
 	pingCancel()
 
 	entry := &ConnectionsStruct{
-		Addr:        addr,
-		MachineName: machineName,
-		Connection:  conn,
-		LastSeen:    time.Now(),
+		Addr:         addr,
+		MachineName:  machineName,
+		Connection:   conn,
+		LastSeen:     time.Now(),
+		Capabilities: caps,
 	}
 
 	replaced, err := addOrReplaceConnection(entry)
@@ -230,6 +258,8 @@ func NewSlaveConnection(addr, machineName string) error {
 		return err
 	}
 	if replaced != nil && replaced.Connection != nil {
+		// the slave reconnected before the pings noticed it was gone, the old channel is dead anyway
+		logger.Info("slave registered again, replacing old connection:", addr, machineName)
 		_ = replaced.Connection.Close()
 	}
 
@@ -252,16 +282,95 @@ type protocolServer struct {
 
 func (s *protocolServer) SetConnection(ctx context.Context, req *pb.SetConnectionRequest) (*pb.SetConnectionResponse, error) {
 	log.Printf("Master recebeu SetConnection: %s", req.GetAddr())
+	// a slave can only register under the name in its certificate
+	name, err := pki.PeerMachineName(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	if name != req.GetMachineName() {
+		return nil, status.Errorf(codes.PermissionDenied, "certificate is for %s, not %s", name, req.GetMachineName())
+	}
+	caps := req.GetCapabilities()
+	warnings, err := checkSlaveCompat(name, caps)
+	if err != nil {
+		logger.Warn("refused slave", name, ":", err)
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	for _, warning := range warnings {
+		logger.Warn("slave", name, ":", warning)
+	}
 	PingAllSlaves(ctx)
-	err := NewSlaveConnection(req.GetAddr(), req.GetMachineName())
+	err = NewSlaveConnection(req.GetAddr(), req.GetMachineName(), caps)
 	if err != nil {
 		return &pb.SetConnectionResponse{Ok: "Erro ao conectar ao slave"}, err
 	}
-	return &pb.SetConnectionResponse{Ok: "OK do Master"}, nil
+	if err := db.SetSlaveCapabilities(db.SlaveCapabilities{
+		MachineName:    name,
+		BuildVersion:   caps.BuildVersion,
+		ProtoVersion:   caps.ProtoVersion,
+		LibvirtVersion: caps.LibvirtVersion,
+		QemuVersion:    caps.QemuVersion,
+		CpuArch:        caps.CpuArch,
+		CpuVendor:      caps.CpuVendor,
+		CpuModel:       caps.CpuModel,
+		CpuFeatures:    caps.CpuFeatures,
+		MemoryTotalMB:  caps.MemoryTotalMB,
+		Cpus:           caps.Cpus,
+		Features:       caps.Features,
+		Warnings:       warnings,
+		UpdatedAt:      time.Now().Unix(),
+	}); err != nil {
+		logger.Error("failed to save capabilities of", name, ":", err)
+	}
+	return &pb.SetConnectionResponse{
+		Ok:           "OK do Master",
+		ProtoVersion: version.ProtoVersion,
+		BuildVersion: version.GetBuild(),
+		Warnings:     warnings,
+	}, nil
 }
 
+// Notify answers the pings of the slaves, known tells a slave whose registration we lost to register again
 func (s *protocolServer) Notify(ctx context.Context, req *pb.NotifyRequest) (*pb.NotifyResponse, error) {
-	return &pb.NotifyResponse{Ok: "OK do Master"}, nil
+	known := false
+	if name, err := pki.PeerMachineName(ctx); err == nil {
+		known = GetConnectionByMachineName(name) != nil
+	}
+	return &pb.NotifyResponse{Ok: "OK do Master", Known: known}, nil
+}
+
+func (s *protocolServer) Enroll(ctx context.Context, req *pb.EnrollRequest) (*pb.EnrollResponse, error) {
+	cert, err := pki.Enroll(req.GetToken(), req.GetMachineName(), req.GetCsr())
+	if err != nil {
+		logger.Warn("enrollment refused for", req.GetMachineName(), ":", err)
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+	logger.Info("slave enrolled:", req.GetMachineName())
+	return &pb.EnrollResponse{Certificate: cert, Ca: pki.CAPEM()}, nil
+}
+
+func (s *protocolServer) RenewCertificate(ctx context.Context, req *pb.RenewRequest) (*pb.EnrollResponse, error) {
+	name, err := pki.PeerMachineName(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	cert, err := pki.SignCSR(req.GetCsr(), name)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	logger.Info("renewed certificate of", name)
+	return &pb.EnrollResponse{Certificate: cert, Ca: pki.CAPEM()}, nil
+}
+
+// DisconnectSlave drops the connection to the slave without treating it as lost, used when its certificate is revoked
+func DisconnectSlave(machineName string) {
+	conn := GetConnectionByMachineName(machineName)
+	if conn == nil {
+		return
+	}
+	if removed := removeConnection(conn.Addr); removed != nil && removed.Connection != nil {
+		_ = removed.Connection.Close()
+	}
 }
 
 func ListenGRPC(recievedNewConnectionFunction func(addr, machineName string, conn *grpc.ClientConn) error) {
@@ -277,7 +386,11 @@ func ListenGRPC(recievedNewConnectionFunction func(addr, machineName string, con
 	if err != nil {
 		log.Fatalf("listen: %v", err)
 	}
-	s := grpc.NewServer()
+	s := grpc.NewServer(
+		grpc.Creds(credentials.NewTLS(pki.ServerTLSConfig())),
+		grpc.ChainUnaryInterceptor(pki.UnaryServerInterceptor),
+		grpc.ChainStreamInterceptor(pki.StreamServerInterceptor),
+	)
 	pb.RegisterProtocolServiceServer(s, &protocolServer{})
 	logsGrpc.RegisterLogsServeServer(s, &logs512.LogsServer{})
 	extraGrpc.RegisterExtraServiceServer(s, &extra.ExtraServiceServer{})
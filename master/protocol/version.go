@@ -0,0 +1,81 @@
+package protocol
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	pb "github.com/Maruqes/512SvMan/api/proto/protocol"
+	"github.com/Maruqes/512SvMan/api/version"
+)
+
+// slaves below this are refused, the master calls rpcs they do not have
+const MinSlaveProtoVersion = 1
+
+func HasFeature(caps *pb.SlaveCapabilities, feature string) bool {
+	if caps == nil {
+		return false
+	}
+	for _, f := range caps.Features {
+		if f == feature {
+			return true
+		}
+	}
+	return false
+}
+
+// CompareVersions compares dotted versions like 8.2.0, missing parts count as 0
+func CompareVersions(a, b string) int {
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) || i < len(bs); i++ {
+		var x, y int
+		if i < len(as) {
+			x, _ = strconv.Atoi(as[i])
+		}
+		if i < len(bs) {
+			y, _ = strconv.Atoi(bs[i])
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// checkSlaveCompat refuses a slave the master can not talk to, anything else that does not match the
+// master or the other slaves is only a warning
+func checkSlaveCompat(machineName string, caps *pb.SlaveCapabilities) ([]string, error) {
+	if caps == nil || caps.ProtoVersion == 0 {
+		return nil, fmt.Errorf("slave sent no capabilities, it is older than the master, upgrade it")
+	}
+	if caps.ProtoVersion < MinSlaveProtoVersion {
+		return nil, fmt.Errorf("slave speaks protocol v%d, the master needs at least v%d, upgrade it", caps.ProtoVersion, MinSlaveProtoVersion)
+	}
+
+	var warnings []string
+	if caps.ProtoVersion > version.ProtoVersion {
+		warnings = append(warnings, fmt.Sprintf("slave speaks protocol v%d, newer than the master v%d, upgrade the master", caps.ProtoVersion, version.ProtoVersion))
+	}
+	if master := version.GetBuild(); caps.BuildVersion != master {
+		warnings = append(warnings, fmt.Sprintf("slave build %s is not the master build %s", caps.BuildVersion, master))
+	}
+	for _, other := range GetConnectionsSnapshot() {
+		oc := other.Capabilities
+		if other.MachineName == machineName || oc == nil {
+			continue
+		}
+		switch {
+		case oc.CpuArch != caps.CpuArch:
+			warnings = append(warnings, fmt.Sprintf("cpu arch %s is not %s of %s, vms can not move between them", caps.CpuArch, oc.CpuArch, other.MachineName))
+		case oc.CpuVendor != caps.CpuVendor:
+			warnings = append(warnings, fmt.Sprintf("cpu vendor %s is not %s of %s, live migration between them is refused", caps.CpuVendor, oc.CpuVendor, other.MachineName))
+		}
+		if CompareVersions(caps.QemuVersion, oc.QemuVersion) != 0 {
+			warnings = append(warnings, fmt.Sprintf("qemu %s is not %s of %s, running vms can not live migrate to the older one", caps.QemuVersion, oc.QemuVersion, other.MachineName))
+		}
+	}
+	return warnings, nil
+}
@@ -0,0 +1,156 @@
+package protocol
+
+import (
+	"strings"
+	"testing"
+
+	pb "github.com/Maruqes/512SvMan/api/proto/protocol"
+	"github.com/Maruqes/512SvMan/api/version"
+)
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"8.2.0", "8.2.0", 0},
+		{"8.2", "8.2.0", 0},
+		{"8.2.1", "8.2", 1},
+		{"7.2.0", "8.0.0", -1},
+		{"10.0.0", "9.9.9", 1}, // numeric, not lexical
+		{"8.10", "8.9", 1},
+		{"", "", 0},
+		{"", "1", -1},
+	}
+	for _, tt := range tests {
+		if got := CompareVersions(tt.a, tt.b); got != tt.want {
+			t.Errorf("CompareVersions(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+// setConnections replaces the connected slaves for the test
+func setConnections(t *testing.T, conns ...*ConnectionsStruct) {
+	t.Helper()
+	connectionsMu.Lock()
+	old := connections
+	connections = conns
+	connectionsMu.Unlock()
+	t.Cleanup(func() {
+		connectionsMu.Lock()
+		connections = old
+		connectionsMu.Unlock()
+	})
+}
+
+func TestCheckSlaveCompat(t *testing.T) {
+	current := func() *pb.SlaveCapabilities {
+		return &pb.SlaveCapabilities{
+			ProtoVersion: version.ProtoVersion,
+			BuildVersion: version.GetBuild(),
+			CpuArch:      "x86_64",
+			CpuVendor:    "GenuineIntel",
+			QemuVersion:  "8.2.0",
+		}
+	}
+	setConnections(t,
+		&ConnectionsStruct{MachineName: "self", Capabilities: &pb.SlaveCapabilities{CpuArch: "aarch64"}},
+		&ConnectionsStruct{MachineName: "other", Capabilities: current()},
+		&ConnectionsStruct{MachineName: "old"},
+	)
+
+	tests := []struct {
+		name     string
+		caps     func() *pb.SlaveCapabilities
+		wantErr  bool
+		warnings []string // a substring per expected warning
+	}{
+		{
+			name:    "no capabilities",
+			caps:    func() *pb.SlaveCapabilities { return nil },
+			wantErr: true,
+		},
+		{
+			name:    "pre-capabilities slave",
+			caps:    func() *pb.SlaveCapabilities { return &pb.SlaveCapabilities{} },
+			wantErr: true,
+		},
+		{
+			name: "same as the cluster",
+			caps: current,
+		},
+		{
+			name: "newer protocol",
+			caps: func() *pb.SlaveCapabilities {
+				c := current()
+				c.ProtoVersion = version.ProtoVersion + 1
+				return c
+			},
+			warnings: []string{"upgrade the master"},
+		},
+		{
+			name: "other build",
+			caps: func() *pb.SlaveCapabilities {
+				c := current()
+				c.BuildVersion = "not-the-master"
+				return c
+			},
+			warnings: []string{"is not the master build"},
+		},
+		{
+			name: "other cpu vendor and qemu",
+			caps: func() *pb.SlaveCapabilities {
+				c := current()
+				c.CpuVendor = "AuthenticAMD"
+				c.QemuVersion = "9.0.0"
+				return c
+			},
+			warnings: []string{"live migration between them is refused", "qemu 9.0.0 is not 8.2.0"},
+		},
+		{
+			name: "other cpu arch",
+			caps: func() *pb.SlaveCapabilities {
+				c := current()
+				c.CpuArch = "aarch64"
+				return c
+			},
+			warnings: []string{"vms can not move between them"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// "self" is skipped, a slave is not compared with its own old entry
+			warnings, err := checkSlaveCompat("self", tt.caps())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("want error %v, got %v", tt.wantErr, err)
+			}
+			if len(warnings) != len(tt.warnings) {
+				t.Fatalf("want %d warnings, got %q", len(tt.warnings), warnings)
+			}
+			for i, want := range tt.warnings {
+				if !strings.Contains(warnings[i], want) {
+					t.Errorf("warning %d: want %q in %q", i, want, warnings[i])
+				}
+			}
+		})
+	}
+}
+
+func TestHasFeature(t *testing.T) {
+	caps := &pb.SlaveCapabilities{Features: []string{version.FeatureSerialConsole}}
+	tests := []struct {
+		caps    *pb.SlaveCapabilities
+		feature string
+		want    bool
+	}{
+		{caps, version.FeatureSerialConsole, true},
+		{caps, version.FeatureGuestAgent, false},
+		{nil, version.FeatureSerialConsole, false},
+	}
+	for _, tt := range tests {
+		if got := HasFeature(tt.caps, tt.feature); got != tt.want {
+			t.Errorf("HasFeature(%v, %q) = %v, want %v", tt.caps.GetFeatures(), tt.feature, got, tt.want)
+		}
+	}
+}
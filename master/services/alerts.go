@@ -0,0 +1,702 @@
+package services
+
+import (
+	"512SvMan/db"
+	"512SvMan/nfs"
+	"512SvMan/npm"
+	"512SvMan/protocol"
+	"512SvMan/websocket"
+	"bytes"
+	"context"
+	"crypto/tls"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/smtp"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	extraGrpc "github.com/Maruqes/512SvMan/api/proto/extra"
+	nfsproto "github.com/Maruqes/512SvMan/api/proto/nfs"
+	grpcVirsh "github.com/Maruqes/512SvMan/api/proto/virsh"
+	"github.com/Maruqes/512SvMan/logger"
+)
+
+const (
+	alertEvalInterval     = 30 * time.Second
+	alertNotifyTimeout    = 10 * time.Second
+	alertShareTimeout     = 5 * time.Second
+	alertsListLimit       = 500
+	defaultSMTPPort       = 25
+	defaultSilenceMinutes = 60
+)
+
+type AlertService struct{}
+
+// what the webhooks receive as json and the emails carry as text
+type AlertNotification struct {
+	Rule       string `json:"rule"`
+	Kind       string `json:"kind"`
+	Subject    string `json:"subject"`
+	Message    string `json:"message"`
+	State      string `json:"state"`
+	StartedAt  int64  `json:"started_at"`
+	ResolvedAt int64  `json:"resolved_at,omitempty"`
+}
+
+var (
+	// slaves dropped by CheckConnection, until they connect again
+	lostSlaves   = map[string]time.Time{}
+	lostSlavesMu sync.Mutex
+
+	// nfs remounts the slaves reported as failed, by subject
+	failedRemounts   = map[string]string{}
+	failedRemountsMu sync.Mutex
+)
+
+// SlaveLost is called by the protocol package when a slave stops answering
+func (a *AlertService) SlaveLost(addr, machineName string) {
+	lostSlavesMu.Lock()
+	if _, ok := lostSlaves[machineName]; !ok {
+		lostSlaves[machineName] = time.Now()
+	}
+	lostSlavesMu.Unlock()
+}
+
+// SlaveEvent takes the problems the slaves report on their own
+func (a *AlertService) SlaveEvent(event *extraGrpc.SlaveEvent) {
+	if event.Kind != db.AlertKindNFSRemountFailed {
+		logger.Warn("alerts: unknown event from", event.MachineName, ":", event.Kind)
+		return
+	}
+	subject := event.MachineName + ":" + event.Subject
+	failedRemountsMu.Lock()
+	if event.Resolved {
+		delete(failedRemounts, subject)
+	} else {
+		failedRemounts[subject] = event.Message
+	}
+	failedRemountsMu.Unlock()
+
+	// right away, not on the next round
+	if err := a.evaluateKind(db.AlertKindNFSRemountFailed); err != nil {
+		logger.Error("alerts: failed to evaluate", db.AlertKindNFSRemountFailed, ":", err)
+	}
+}
+
+// targetMatches is true when the rule has no target or it is one of names
+func targetMatches(rule db.AlertRule, names ...string) bool {
+	if rule.Target == "" {
+		return true
+	}
+	for _, n := range names {
+		if n == rule.Target {
+			return true
+		}
+	}
+	return false
+}
+
+// the findings of each kind, subject -> message, for one rule
+
+func slaveUnreachableFindings(rule db.AlertRule) map[string]string {
+	res := map[string]string{}
+	now := time.Now()
+	lostSlavesMu.Lock()
+	for machine, since := range lostSlaves {
+		if protocol.GetConnectionByMachineName(machine) != nil {
+			delete(lostSlaves, machine)
+			continue
+		}
+		if age := now.Sub(since).Seconds(); age >= rule.Threshold && targetMatches(rule, machine) {
+			res[machine] = fmt.Sprintf("slave %s disconnected %.0f seconds ago", machine, age)
+		}
+	}
+	lostSlavesMu.Unlock()
+
+	// still connected but the pings stopped getting through
+	for _, c := range protocol.GetConnectionsSnapshot() {
+		if age := now.Sub(c.LastSeen).Seconds(); age >= rule.Threshold && targetMatches(rule, c.MachineName) {
+			res[c.MachineName] = fmt.Sprintf("slave %s has not answered a ping for %.0f seconds", c.MachineName, age)
+		}
+	}
+	return res
+}
+
+func nfsShareUsageFindings(rule db.AlertRule) (map[string]string, error) {
+	shares, err := db.GetAllNFShares()
+	if err != nil {
+		return nil, err
+	}
+	res := map[string]string{}
+	for _, share := range shares {
+		if !targetMatches(rule, share.MachineName, share.Target, share.Name) {
+			continue
+		}
+		conn := protocol.GetConnectionByMachineName(share.MachineName)
+		if conn == nil || conn.Connection == nil {
+			continue
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), alertShareTimeout)
+		status, err := nfs.GetSharedFolderStatusCtx(conn.Connection, ctx, &nfsproto.FolderMount{
+			MachineName: share.MachineName,
+			FolderPath:  share.FolderPath,
+			Source:      share.Source,
+			Target:      share.Target,
+		})
+		cancel()
+		if err != nil || status.SpaceTotalGB <= 0 {
+			continue
+		}
+		used := float64(status.SpaceOccupiedGB) / float64(status.SpaceTotalGB) * 100
+		if used >= rule.Threshold {
+			res[share.Target] = fmt.Sprintf("NFS share %s is %.0f%% full (%d of %d GB)", share.Target, used, status.SpaceOccupiedGB, status.SpaceTotalGB)
+		}
+	}
+	return res, nil
+}
+
+// vmCrashedFindings uses the last poll of the history service
+func vmCrashedFindings(rule db.AlertRule) map[string]string {
+	lastVmPollMu.RLock()
+	defer lastVmPollMu.RUnlock()
+
+	res := map[string]string{}
+	for machine, vms := range lastVmPoll {
+		for _, vm := range vms {
+			if vm.State == grpcVirsh.VmState_CRASHED && targetMatches(rule, machine, vm.Name) {
+				res[vm.Name] = fmt.Sprintf("VM %s crashed on %s", vm.Name, machine)
+			}
+		}
+	}
+	return res
+}
+
+func nfsRemountFindings(rule db.AlertRule) map[string]string {
+	failedRemountsMu.Lock()
+	defer failedRemountsMu.Unlock()
+
+	res := map[string]string{}
+	for subject, msg := range failedRemounts {
+		machine, target, _ := strings.Cut(subject, ":")
+		if targetMatches(rule, machine, target) {
+			res[subject] = fmt.Sprintf("%s: %s", machine, msg)
+		}
+	}
+	return res
+}
+
+func certExpiryFindings(rule db.AlertRule) (map[string]string, error) {
+	certs, err := npm.LocalCertFiles()
+	if err != nil {
+		return nil, err
+	}
+	res := map[string]string{}
+	for _, c := range certs {
+		if !targetMatches(rule, append([]string{c.Name}, c.Domains...)...) {
+			continue
+		}
+		days := time.Until(c.NotAfter).Hours() / 24
+		if days <= rule.Threshold {
+			res[c.Name] = fmt.Sprintf("certificate %s (%s) expires in %.0f days, on %s", c.Name, strings.Join(c.Domains, ", "), days, c.NotAfter.Format(time.RFC3339))
+		}
+	}
+	return res, nil
+}
+
+func ruleFindings(rule db.AlertRule) (map[string]string, error) {
+	switch rule.Kind {
+	case db.AlertKindSlaveUnreachable:
+		return slaveUnreachableFindings(rule), nil
+	case db.AlertKindNFSShareUsage:
+		return nfsShareUsageFindings(rule)
+	case db.AlertKindVMCrashed:
+		return vmCrashedFindings(rule), nil
+	case db.AlertKindNFSRemountFailed:
+		return nfsRemountFindings(rule), nil
+	case db.AlertKindCertExpiry:
+		return certExpiryFindings(rule)
+	}
+	return nil, fmt.Errorf("unknown alert kind %s", rule.Kind)
+}
+
+func sendWebhook(channel db.AlertChannel, n AlertNotification) error {
+	body, err := json.Marshal(n)
+	if err != nil {
+		return err
+	}
+	client := http.Client{Timeout: alertNotifyTimeout}
+	resp, err := client.Post(channel.URL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook answered %s", resp.Status)
+	}
+	return nil
+}
+
+func sendAlertMail(channel db.AlertChannel, n AlertNotification) error {
+	var to []string
+	for _, addr := range strings.Split(channel.SMTPTo, ",") {
+		if addr = strings.TrimSpace(addr); addr != "" {
+			to = append(to, addr)
+		}
+	}
+
+	subject := fmt.Sprintf("[512SvMan %s] %s: %s", strings.ToUpper(n.State), n.Rule, n.Subject)
+	var msg strings.Builder
+	fmt.Fprintf(&msg, "From: %s\r\nTo: %s\r\nSubject: %s\r\nDate: %s\r\nContent-Type: text/plain; charset=utf-8\r\n\r\n",
+		channel.SMTPFrom, strings.Join(to, ", "), subject, time.Now().Format(time.RFC1123Z))
+	fmt.Fprintf(&msg, "%s\r\n\r\nrule: %s (%s)\r\nstarted: %s\r\n", n.Message, n.Rule, n.Kind, time.Unix(n.StartedAt, 0).Format(time.RFC3339))
+	if n.ResolvedAt != 0 {
+		fmt.Fprintf(&msg, "resolved: %s\r\n", time.Unix(n.ResolvedAt, 0).Format(time.RFC3339))
+	}
+
+	var auth smtp.Auth
+	if channel.SMTPUser != "" {
+		auth = smtp.PlainAuth("", channel.SMTPUser, channel.SMTPPass, channel.SMTPHost)
+	}
+	addr := net.JoinHostPort(channel.SMTPHost, strconv.Itoa(channel.SMTPPort))
+	return sendMail(addr, channel.SMTPHost, auth, channel.SMTPFrom, to, []byte(msg.String()))
+}
+
+// sendMail is smtp.SendMail with one deadline over the whole conversation, a server that accepts
+// the connection and then says nothing would hang it forever
+func sendMail(addr, host string, auth smtp.Auth, from string, to []string, msg []byte) error {
+	conn, err := net.DialTimeout("tcp", addr, alertNotifyTimeout)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	if err := conn.SetDeadline(time.Now().Add(alertNotifyTimeout)); err != nil {
+		return err
+	}
+
+	c, err := smtp.NewClient(conn, host)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		if err := c.StartTLS(&tls.Config{ServerName: host}); err != nil {
+			return err
+		}
+	}
+	if auth != nil {
+		if ok, _ := c.Extension("AUTH"); !ok {
+			return fmt.Errorf("smtp server does not support AUTH")
+		}
+		if err := c.Auth(auth); err != nil {
+			return err
+		}
+	}
+	if err := c.Mail(from); err != nil {
+		return err
+	}
+	for _, addr := range to {
+		if err := c.Rcpt(addr); err != nil {
+			return err
+		}
+	}
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(msg); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return c.Quit()
+}
+
+func sendToChannel(channel db.AlertChannel, n AlertNotification) error {
+	switch channel.Kind {
+	case db.AlertChannelWebhook:
+		return sendWebhook(channel, n)
+	case db.AlertChannelSMTP:
+		return sendAlertMail(channel, n)
+	}
+	return fmt.Errorf("unknown channel kind %s", channel.Kind)
+}
+
+// notify sends to every enabled channel, one failing channel does not stop the others
+func notify(n AlertNotification) {
+	if data, err := json.Marshal(n); err == nil {
+		websocket.BroadcastMessage(websocket.Message{Type: "Alert", Data: string(data)})
+	}
+
+	channels, err := db.GetAlertChannels()
+	if err != nil {
+		logger.Error("alerts: failed to get channels:", err)
+		return
+	}
+	for _, c := range channels {
+		if !c.Enabled {
+			continue
+		}
+		if err := sendToChannel(c, n); err != nil {
+			logger.Error("alerts: failed to notify", c.Name, ":", err)
+		}
+	}
+}
+
+// fire opens the alert if it is new and returns what to notify unless silenced, an open alert is only
+// notified again after RepeatMinutes
+func fire(rule db.AlertRule, subject, message string, now int64) (*AlertNotification, error) {
+	alert, err := db.GetOpenAlert(rule.Id, subject)
+	if err != nil {
+		return nil, err
+	}
+	if alert == nil {
+		alert = &db.Alert{RuleId: rule.Id, Subject: subject, Message: message, StartedAt: now}
+		if err := db.AddAlert(alert); err != nil {
+			return nil, err
+		}
+	}
+
+	due := alert.LastNotifiedAt == 0 ||
+		(rule.RepeatMinutes > 0 && now-alert.LastNotifiedAt >= int64(rule.RepeatMinutes)*60)
+	if !due {
+		return nil, nil
+	}
+	silenced, err := db.IsAlertSilenced(rule.Id, subject, now)
+	if err != nil || silenced {
+		return nil, err
+	}
+
+	if err := db.UpdateAlertNotified(alert.Id, message, now); err != nil {
+		return nil, err
+	}
+	return &AlertNotification{
+		Rule:      rule.Name,
+		Kind:      rule.Kind,
+		Subject:   subject,
+		Message:   message,
+		State:     db.AlertStateFiring,
+		StartedAt: alert.StartedAt,
+	}, nil
+}
+
+// resolve closes the alert, whoever heard it fire hears it is over
+func resolve(rule db.AlertRule, alert db.Alert, now int64) (*AlertNotification, error) {
+	if err := db.ResolveAlert(alert.Id, now); err != nil {
+		return nil, err
+	}
+	if alert.LastNotifiedAt == 0 {
+		return nil, nil
+	}
+	silenced, err := db.IsAlertSilenced(rule.Id, alert.Subject, now)
+	if err != nil || silenced {
+		return nil, err
+	}
+	return &AlertNotification{
+		Rule:       rule.Name,
+		Kind:       rule.Kind,
+		Subject:    alert.Subject,
+		Message:    alert.Message,
+		State:      db.AlertStateResolved,
+		StartedAt:  alert.StartedAt,
+		ResolvedAt: now,
+	}, nil
+}
+
+// alertEvalMu only covers the alert rows, asking the slaves and notifying happen outside of it
+var alertEvalMu sync.Mutex
+
+// evaluateRule opens and closes the alerts of one rule and returns what has to be notified
+func (a *AlertService) evaluateRule(rule db.AlertRule, findings map[string]string) ([]AlertNotification, error) {
+	var pending []AlertNotification
+	now := time.Now().Unix()
+	for subject, message := range findings {
+		n, err := fire(rule, subject, message, now)
+		if err != nil {
+			return pending, err
+		}
+		if n != nil {
+			pending = append(pending, *n)
+		}
+	}
+
+	open, err := db.GetOpenAlertsByRule(rule.Id)
+	if err != nil {
+		return pending, err
+	}
+	for _, alert := range open {
+		if _, still := findings[alert.Subject]; !still {
+			n, err := resolve(rule, alert, now)
+			if err != nil {
+				return pending, err
+			}
+			if n != nil {
+				pending = append(pending, *n)
+			}
+		}
+	}
+	return pending, nil
+}
+
+func (a *AlertService) evaluateKind(kind string) error {
+	rules, err := db.GetEnabledAlertRulesByKind(kind)
+	if err != nil {
+		return err
+	}
+
+	findings := make([]map[string]string, len(rules))
+	for i, rule := range rules {
+		if findings[i], err = ruleFindings(rule); err != nil {
+			logger.Error("alerts: rule", rule.Name, "failed:", err)
+		}
+	}
+
+	var pending []AlertNotification
+	alertEvalMu.Lock()
+	for i, rule := range rules {
+		// a rule whose findings failed keeps its alerts as they are
+		if findings[i] == nil {
+			continue
+		}
+		n, err := a.evaluateRule(rule, findings[i])
+		pending = append(pending, n...)
+		if err != nil {
+			logger.Error("alerts: rule", rule.Name, "failed:", err)
+		}
+	}
+	alertEvalMu.Unlock()
+
+	for _, n := range pending {
+		notify(n)
+	}
+	return nil
+}
+
+var alertKinds = []string{
+	db.AlertKindSlaveUnreachable,
+	db.AlertKindNFSShareUsage,
+	db.AlertKindVMCrashed,
+	db.AlertKindNFSRemountFailed,
+	db.AlertKindCertExpiry,
+}
+
+func validAlertKind(kind string) bool {
+	for _, k := range alertKinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+func (a *AlertService) validateRule(rule *db.AlertRule) error {
+	rule.Name = strings.TrimSpace(rule.Name)
+	rule.Target = strings.TrimSpace(rule.Target)
+	if rule.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+	if !validAlertKind(rule.Kind) {
+		return fmt.Errorf("kind must be one of %s", strings.Join(alertKinds, ", "))
+	}
+	if rule.RepeatMinutes < 0 {
+		return fmt.Errorf("repeat_minutes can not be negative")
+	}
+	switch rule.Kind {
+	case db.AlertKindSlaveUnreachable, db.AlertKindCertExpiry:
+		if rule.Threshold <= 0 {
+			return fmt.Errorf("threshold must be positive (seconds for %s, days for %s)", db.AlertKindSlaveUnreachable, db.AlertKindCertExpiry)
+		}
+	case db.AlertKindNFSShareUsage:
+		if rule.Threshold <= 0 || rule.Threshold > 100 {
+			return fmt.Errorf("threshold must be a percentage between 0 and 100")
+		}
+	}
+	return nil
+}
+
+func (a *AlertService) GetRules() ([]db.AlertRule, error) {
+	rules, err := db.GetAlertRules()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get alert rules: %v", err)
+	}
+	if rules == nil {
+		rules = []db.AlertRule{}
+	}
+	return rules, nil
+}
+
+func (a *AlertService) AddRule(rule db.AlertRule) (*db.AlertRule, error) {
+	if err := a.validateRule(&rule); err != nil {
+		return nil, err
+	}
+	if err := db.AddAlertRule(&rule); err != nil {
+		return nil, fmt.Errorf("failed to add alert rule: %v", err)
+	}
+	return &rule, nil
+}
+
+func (a *AlertService) UpdateRule(rule db.AlertRule) error {
+	if err := a.validateRule(&rule); err != nil {
+		return err
+	}
+	err := db.UpdateAlertRule(rule)
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("alert rule %d not found", rule.Id)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update alert rule: %v", err)
+	}
+	return nil
+}
+
+func (a *AlertService) RemoveRule(id int) error {
+	if err := db.RemoveAlertRule(id); err != nil {
+		return fmt.Errorf("failed to remove alert rule: %v", err)
+	}
+	return nil
+}
+
+func (a *AlertService) GetChannels() ([]db.AlertChannel, error) {
+	channels, err := db.GetAlertChannels()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get alert channels: %v", err)
+	}
+	if channels == nil {
+		channels = []db.AlertChannel{}
+	}
+	// the password never leaves the master
+	for i := range channels {
+		channels[i].SMTPPass = ""
+	}
+	return channels, nil
+}
+
+func (a *AlertService) AddChannel(channel db.AlertChannel) (*db.AlertChannel, error) {
+	channel.Name = strings.TrimSpace(channel.Name)
+	if channel.Name == "" {
+		return nil, fmt.Errorf("name is required")
+	}
+	switch channel.Kind {
+	case db.AlertChannelWebhook:
+		u, err := url.Parse(channel.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return nil, fmt.Errorf("url must be an http or https url")
+		}
+	case db.AlertChannelSMTP:
+		if channel.SMTPHost == "" || channel.SMTPFrom == "" || strings.TrimSpace(channel.SMTPTo) == "" {
+			return nil, fmt.Errorf("smtp_host, smtp_from and smtp_to are required")
+		}
+		if channel.SMTPPort == 0 {
+			channel.SMTPPort = defaultSMTPPort
+		}
+	default:
+		return nil, fmt.Errorf("kind must be %s or %s", db.AlertChannelWebhook, db.AlertChannelSMTP)
+	}
+
+	if err := db.AddAlertChannel(&channel); err != nil {
+		return nil, fmt.Errorf("failed to add alert channel: %v", err)
+	}
+	channel.SMTPPass = ""
+	return &channel, nil
+}
+
+func (a *AlertService) RemoveChannel(id int) error {
+	if err := db.RemoveAlertChannel(id); err != nil {
+		return fmt.Errorf("failed to remove alert channel: %v", err)
+	}
+	return nil
+}
+
+// TestChannel sends a made up alert to one channel and returns what went wrong
+func (a *AlertService) TestChannel(id int) error {
+	channel, err := db.GetAlertChannelByID(id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("alert channel %d not found", id)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get alert channel: %v", err)
+	}
+	err = sendToChannel(*channel, AlertNotification{
+		Rule:      "test",
+		Kind:      "test",
+		Subject:   channel.Name,
+		Message:   "test notification from 512SvMan",
+		State:     db.AlertStateFiring,
+		StartedAt: time.Now().Unix(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to notify %s: %v", channel.Name, err)
+	}
+	return nil
+}
+
+func (a *AlertService) GetSilences() ([]db.AlertSilence, error) {
+	silences, err := db.GetAlertSilences(time.Now().Unix())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get alert silences: %v", err)
+	}
+	if silences == nil {
+		silences = []db.AlertSilence{}
+	}
+	return silences, nil
+}
+
+// AddSilence starts now when StartsAt is 0 and lasts an hour when EndsAt is 0
+func (a *AlertService) AddSilence(silence db.AlertSilence) (*db.AlertSilence, error) {
+	if silence.StartsAt == 0 {
+		silence.StartsAt = time.Now().Unix()
+	}
+	if silence.EndsAt == 0 {
+		silence.EndsAt = silence.StartsAt + defaultSilenceMinutes*60
+	}
+	if silence.EndsAt <= silence.StartsAt {
+		return nil, fmt.Errorf("ends_at must be after starts_at")
+	}
+	silence.Subject = strings.TrimSpace(silence.Subject)
+
+	if err := db.AddAlertSilence(&silence); err != nil {
+		return nil, fmt.Errorf("failed to add alert silence: %v", err)
+	}
+	return &silence, nil
+}
+
+func (a *AlertService) RemoveSilence(id int) error {
+	if err := db.RemoveAlertSilence(id); err != nil {
+		return fmt.Errorf("failed to remove alert silence: %v", err)
+	}
+	return nil
+}
+
+func (a *AlertService) GetAlerts(openOnly bool) ([]db.Alert, error) {
+	alerts, err := db.GetAlerts(openOnly, alertsListLimit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get alerts: %v", err)
+	}
+	if alerts == nil {
+		alerts = []db.Alert{}
+	}
+	return alerts, nil
+}
+
+func (a *AlertService) Start() {
+	go func() {
+		for {
+			for _, kind := range alertKinds {
+				if err := a.evaluateKind(kind); err != nil {
+					logger.Error("alerts: failed to evaluate", kind, ":", err)
+				}
+			}
+			if err := db.DeleteEndedAlertSilences(time.Now().Unix()); err != nil {
+				logger.Error("alerts: failed to delete ended silences:", err)
+			}
+			time.Sleep(alertEvalInterval)
+		}
+	}()
+}
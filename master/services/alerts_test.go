@@ -0,0 +1,281 @@
+package services
+
+import (
+	"512SvMan/db"
+	"bufio"
+	"database/sql"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	extraGrpc "github.com/Maruqes/512SvMan/api/proto/extra"
+)
+
+// setupAlertDB points the db package at a fresh sqlite file with only the alert tables
+func setupAlertDB(t *testing.T) {
+	t.Helper()
+	conn, err := sql.Open("sqlite3", filepath.Join(t.TempDir(), "alerts.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	old := db.DB
+	db.DB = conn
+	t.Cleanup(func() {
+		conn.Close()
+		db.DB = old
+	})
+	if err := db.CreateAlertTables(); err != nil {
+		t.Fatal(err)
+	}
+
+	failedRemountsMu.Lock()
+	failedRemounts = map[string]string{}
+	failedRemountsMu.Unlock()
+}
+
+// webhookRecorder is an httptest server that keeps every notification it receives
+type webhookRecorder struct {
+	srv *httptest.Server
+	mu  sync.Mutex
+	got []AlertNotification
+}
+
+func newWebhookRecorder(t *testing.T) *webhookRecorder {
+	t.Helper()
+	w := &webhookRecorder{}
+	w.srv = httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		var n AlertNotification
+		if err := json.NewDecoder(r.Body).Decode(&n); err != nil {
+			http.Error(rw, err.Error(), http.StatusBadRequest)
+			return
+		}
+		w.mu.Lock()
+		w.got = append(w.got, n)
+		w.mu.Unlock()
+	}))
+	t.Cleanup(w.srv.Close)
+
+	err := db.AddAlertChannel(&db.AlertChannel{Name: "hook", Kind: db.AlertChannelWebhook, URL: w.srv.URL, Enabled: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return w
+}
+
+func (w *webhookRecorder) notifications() []AlertNotification {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return append([]AlertNotification(nil), w.got...)
+}
+
+func addRemountRule(t *testing.T) db.AlertRule {
+	t.Helper()
+	rule := db.AlertRule{Name: "remounts", Kind: db.AlertKindNFSRemountFailed, Enabled: true}
+	if err := db.AddAlertRule(&rule); err != nil {
+		t.Fatal(err)
+	}
+	return rule
+}
+
+func remountEvent(resolved bool) *extraGrpc.SlaveEvent {
+	return &extraGrpc.SlaveEvent{
+		MachineName: "slave1",
+		Kind:        db.AlertKindNFSRemountFailed,
+		Subject:     "/mnt/share",
+		Message:     "mount timed out",
+		Resolved:    resolved,
+	}
+}
+
+func TestAlertFiresOnceAndResolves(t *testing.T) {
+	setupAlertDB(t)
+	hook := newWebhookRecorder(t)
+	addRemountRule(t)
+	a := &AlertService{}
+
+	a.SlaveEvent(remountEvent(false))
+	// the next rounds still see the failure, nothing is sent again without repeat_minutes
+	for i := 0; i < 3; i++ {
+		if err := a.evaluateKind(db.AlertKindNFSRemountFailed); err != nil {
+			t.Fatal(err)
+		}
+	}
+	got := hook.notifications()
+	if len(got) != 1 || got[0].State != db.AlertStateFiring || got[0].Subject != "slave1:/mnt/share" {
+		t.Fatalf("want one firing notification, got %+v", got)
+	}
+
+	a.SlaveEvent(remountEvent(true))
+	if err := a.evaluateKind(db.AlertKindNFSRemountFailed); err != nil {
+		t.Fatal(err)
+	}
+	got = hook.notifications()
+	if len(got) != 2 || got[1].State != db.AlertStateResolved || got[1].ResolvedAt == 0 {
+		t.Fatalf("want firing then resolved, got %+v", got)
+	}
+
+	open, err := db.GetAlerts(true, alertsListLimit)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(open) != 0 {
+		t.Fatalf("want no open alerts, got %+v", open)
+	}
+}
+
+func TestAlertSilenced(t *testing.T) {
+	setupAlertDB(t)
+	hook := newWebhookRecorder(t)
+	rule := addRemountRule(t)
+	a := &AlertService{}
+
+	if _, err := a.AddSilence(db.AlertSilence{RuleId: rule.Id, Subject: "slave1:/mnt/share"}); err != nil {
+		t.Fatal(err)
+	}
+
+	a.SlaveEvent(remountEvent(false))
+	open, err := db.GetAlerts(true, alertsListLimit)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(open) != 1 {
+		t.Fatalf("a silenced alert still opens, got %+v", open)
+	}
+
+	a.SlaveEvent(remountEvent(true))
+	if got := hook.notifications(); len(got) != 0 {
+		t.Fatalf("want nothing sent while silenced, got %+v", got)
+	}
+}
+
+func TestSendWebhookFailsOnErrorStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		http.Error(rw, "nope", http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	err := sendWebhook(db.AlertChannel{URL: srv.URL}, AlertNotification{Rule: "r"})
+	if err == nil || !strings.Contains(err.Error(), "500") {
+		t.Fatalf("want the status in the error, got %v", err)
+	}
+}
+
+// stubSMTP answers just enough of smtp to take one mail and hands the data over
+func stubSMTP(t *testing.T) (host string, port int, mails <-chan string) {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { ln.Close() })
+
+	out := make(chan string, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		conn.SetDeadline(time.Now().Add(10 * time.Second))
+
+		r := bufio.NewReader(conn)
+		reply := func(line string) { conn.Write([]byte(line + "\r\n")) }
+		reply("220 stub ready")
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			cmd := strings.ToUpper(strings.TrimSpace(line))
+			switch {
+			case strings.HasPrefix(cmd, "EHLO"), strings.HasPrefix(cmd, "HELO"):
+				reply("250 stub")
+			case strings.HasPrefix(cmd, "MAIL"), strings.HasPrefix(cmd, "RCPT"):
+				reply("250 ok")
+			case cmd == "DATA":
+				reply("354 go on")
+				var data strings.Builder
+				for {
+					l, err := r.ReadString('\n')
+					if err != nil {
+						return
+					}
+					if l == ".\r\n" {
+						break
+					}
+					data.WriteString(l)
+				}
+				out <- data.String()
+				reply("250 queued")
+			case cmd == "QUIT":
+				reply("221 bye")
+				return
+			default:
+				reply("502 not implemented")
+			}
+		}
+	}()
+
+	addr := ln.Addr().(*net.TCPAddr)
+	return addr.IP.String(), addr.Port, out
+}
+
+func TestSendAlertMail(t *testing.T) {
+	host, port, mails := stubSMTP(t)
+	channel := db.AlertChannel{
+		Kind:     db.AlertChannelSMTP,
+		SMTPHost: host,
+		SMTPPort: port,
+		SMTPFrom: "svman@example.com",
+		SMTPTo:   "ops@example.com, oncall@example.com",
+	}
+	err := sendToChannel(channel, AlertNotification{
+		Rule:      "remounts",
+		Kind:      db.AlertKindNFSRemountFailed,
+		Subject:   "slave1:/mnt/share",
+		Message:   "mount timed out",
+		State:     db.AlertStateFiring,
+		StartedAt: time.Now().Unix(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case mail := <-mails:
+		for _, want := range []string{
+			"To: ops@example.com, oncall@example.com",
+			"Subject: [512SvMan FIRING] remounts: slave1:/mnt/share",
+			"mount timed out",
+		} {
+			if !strings.Contains(mail, want) {
+				t.Errorf("mail is missing %q:\n%s", want, mail)
+			}
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("the stub never got the mail")
+	}
+}
+
+func TestSendAlertMailRefused(t *testing.T) {
+	// a port nothing listens on, the dial fails instead of hanging
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, portStr, _ := net.SplitHostPort(ln.Addr().String())
+	ln.Close()
+	port, _ := strconv.Atoi(portStr)
+
+	err = sendAlertMail(db.AlertChannel{SMTPHost: "127.0.0.1", SMTPPort: port, SMTPFrom: "a@b", SMTPTo: "c@d"}, AlertNotification{})
+	if err == nil {
+		t.Fatal("want an error from a closed port")
+	}
+}
@@ -0,0 +1,377 @@
+package services
+
+import (
+	"512SvMan/db"
+	"512SvMan/protocol"
+	"512SvMan/virsh"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	grpcVirsh "github.com/Maruqes/512SvMan/api/proto/virsh"
+	"github.com/Maruqes/512SvMan/logger"
+)
+
+const backupScheduleInterval = time.Minute
+
+type BackupService struct{}
+
+type BackupStatus struct {
+	Policies []db.VmBackupPolicy `json:"policies"`
+	Running  []string            `json:"running"` // vms being backed up right now
+	Backups  []db.VmBackup       `json:"backups"`
+}
+
+var (
+	// vms with a backup running, a second one would fight over the checkpoint
+	backupsMu sync.Mutex
+	backups   = map[string]struct{}{}
+)
+
+func startBackup(vmName string) (func(), error) {
+	backupsMu.Lock()
+	defer backupsMu.Unlock()
+	if _, ok := backups[vmName]; ok {
+		return nil, fmt.Errorf("VM %s is already being backed up", vmName)
+	}
+	backups[vmName] = struct{}{}
+	return func() {
+		backupsMu.Lock()
+		delete(backups, vmName)
+		backupsMu.Unlock()
+	}, nil
+}
+
+// backupFolder is share/backups/vmname, every backup of the vm goes there
+func backupFolder(nfsShareId int, vmName string) (string, error) {
+	target, err := nfsShareTarget(nfsShareId)
+	if err != nil {
+		return "", err
+	}
+	return target + "/backups/" + vmName, nil
+}
+
+func backupDisksFromGRPC(disks []*grpcVirsh.BackupDisk) []db.VmBackupDisk {
+	res := make([]db.VmBackupDisk, 0, len(disks))
+	for _, disk := range disks {
+		res = append(res, db.VmBackupDisk{Target: disk.Target, Path: disk.Path})
+	}
+	return res
+}
+
+func backupDisksToGRPC(disks []db.VmBackupDisk) []*grpcVirsh.BackupDisk {
+	res := make([]*grpcVirsh.BackupDisk, 0, len(disks))
+	for _, disk := range disks {
+		res = append(res, &grpcVirsh.BackupDisk{Target: disk.Target, Path: disk.Path})
+	}
+	return res
+}
+
+// BackupVM backs the vm up to nfsShareId. incremental copies only what changed since the last backup,
+// the slave falls back to a full backup when it cant (vm shut off, moved, or a new disk)
+func (s *BackupService) BackupVM(vmName string, nfsShareId int, incremental bool) (*db.VmBackup, error) {
+	conn, vm, err := findVmConnection(vmName)
+	if err != nil {
+		return nil, err
+	}
+	folder, err := backupFolder(nfsShareId, vmName)
+	if err != nil {
+		return nil, err
+	}
+
+	endBackup, err := startBackup(vmName)
+	if err != nil {
+		return nil, err
+	}
+	defer endBackup()
+
+	now := time.Now()
+	req := &grpcVirsh.BackupVmRequest{
+		VmName: vmName,
+		Folder: folder,
+		Name:   now.UTC().Format("20060102-150405"),
+	}
+
+	//the increment goes on top of the newest backup, it must still be on the same share and slave
+	var parent *db.VmBackup
+	if incremental {
+		latest, err := db.GetLatestVmBackup(vmName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get latest backup of VM %s: %v", vmName, err)
+		}
+		if latest != nil && latest.Checkpoint && latest.NfsShareId == nfsShareId && latest.MachineName == vm.MachineName {
+			parent = latest
+			req.Parent = latest.Name
+			req.ParentDisks = backupDisksToGRPC(latest.Disks)
+		}
+	}
+
+	resp, err := virsh.BackupVM(conn, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to back up VM %s: %v", vmName, err)
+	}
+
+	backup := &db.VmBackup{
+		VmName:      vmName,
+		Name:        req.Name,
+		Checkpoint:  resp.Checkpoint,
+		MachineName: vm.MachineName,
+		NfsShareId:  nfsShareId,
+		XmlPath:     resp.XmlPath,
+		SizeBytes:   resp.SizeBytes,
+		CreatedAt:   now.Unix(),
+		Disks:       backupDisksFromGRPC(resp.Disks),
+	}
+	if resp.Incremental && parent != nil {
+		backup.ParentId = parent.Id
+	}
+	if err := db.AddVmBackup(backup); err != nil {
+		return nil, fmt.Errorf("backup done but not saved in database: %v", err)
+	}
+	logger.Info("VM backed up:", vmName, backup.Name, "incremental:", backup.ParentId != 0)
+	return backup, nil
+}
+
+func (s *BackupService) ListBackups(vmName string) ([]db.VmBackup, error) {
+	list, err := db.GetVmBackups(vmName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get backups: %v", err)
+	}
+	return list, nil
+}
+
+func (s *BackupService) GetStatus() (*BackupStatus, error) {
+	policies, err := db.GetAllVmBackupPolicies()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get backup policies: %v", err)
+	}
+	list, err := db.GetVmBackups("")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get backups: %v", err)
+	}
+
+	status := &BackupStatus{Policies: policies, Running: []string{}, Backups: list}
+	backupsMu.Lock()
+	for vmName := range backups {
+		status.Running = append(status.Running, vmName)
+	}
+	backupsMu.Unlock()
+	sort.Strings(status.Running)
+	return status, nil
+}
+
+// backupChain returns the backup and every increment built on top of it, newest first
+func backupChain(root db.VmBackup, all []db.VmBackup) []db.VmBackup {
+	chain := []db.VmBackup{root}
+	for i := 0; i < len(chain); i++ {
+		for _, backup := range all {
+			if backup.ParentId == chain[i].Id {
+				chain = append(chain, backup)
+			}
+		}
+	}
+	for i, j := 0, len(chain)-1; i < j; i, j = i+1, j-1 {
+		chain[i], chain[j] = chain[j], chain[i]
+	}
+	return chain
+}
+
+// DeleteBackup removes the backup with the increments that depend on it, files and checkpoints
+func (s *BackupService) DeleteBackup(id int) error {
+	backup, err := db.GetVmBackup(id)
+	if err != nil {
+		return fmt.Errorf("failed to get backup: %v", err)
+	}
+	if backup == nil {
+		return fmt.Errorf("backup %d not found", id)
+	}
+	all, err := db.GetVmBackups(backup.VmName)
+	if err != nil {
+		return fmt.Errorf("failed to get backups of VM %s: %v", backup.VmName, err)
+	}
+
+	//the checkpoint lives with the vm, the files are on a share every slave mounts
+	conn, _, err := findVmConnection(backup.VmName)
+	if err != nil {
+		conn = nil
+		for _, c := range protocol.GetAllGRPCConnections() {
+			if c != nil {
+				conn = c
+				break
+			}
+		}
+		if conn == nil {
+			return fmt.Errorf("no slave connected to delete the backup files")
+		}
+	}
+
+	for _, b := range backupChain(*backup, all) {
+		files := []string{b.XmlPath}
+		for _, disk := range b.Disks {
+			files = append(files, disk.Path)
+		}
+		err := virsh.DeleteBackup(conn, &grpcVirsh.DeleteBackupRequest{
+			VmName:     b.VmName,
+			Checkpoint: b.Name,
+			Files:      files,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to delete backup %s of VM %s: %v", b.Name, b.VmName, err)
+		}
+		if err := db.RemoveVmBackup(b.Id); err != nil {
+			return fmt.Errorf("backup files deleted but not removed from database: %v", err)
+		}
+	}
+	return nil
+}
+
+// RestoreBackup creates the vm name from a backup on machineName (the slave that took the backup when
+// empty), the disks go to the vm folder on nfsShareId. the vm is left shut off
+func (s *BackupService) RestoreBackup(id int, name string, nfsShareId int, machineName string) error {
+	backup, err := db.GetVmBackup(id)
+	if err != nil {
+		return fmt.Errorf("failed to get backup: %v", err)
+	}
+	if backup == nil {
+		return fmt.Errorf("backup %d not found", id)
+	}
+
+	exists, err := virsh.DoesVMExist(name)
+	if err != nil {
+		return fmt.Errorf("error checking if VM exists: %v", err)
+	}
+	if exists {
+		return fmt.Errorf("a VM with the name %s already exists", name)
+	}
+
+	if machineName == "" {
+		machineName = backup.MachineName
+	}
+	if err := ensureNotInMaintenance(machineName); err != nil {
+		return err
+	}
+	conn := protocol.GetConnectionByMachineName(machineName)
+	if conn == nil || conn.Connection == nil {
+		return fmt.Errorf("slave %s not connected", machineName)
+	}
+
+	target, err := nfsShareTarget(nfsShareId)
+	if err != nil {
+		return err
+	}
+	diskFolder := target + "/" + name
+
+	err = virsh.RestoreBackup(conn.Connection, &grpcVirsh.RestoreBackupRequest{
+		Name:       name,
+		XmlPath:    backup.XmlPath,
+		Disks:      backupDisksToGRPC(backup.Disks),
+		DiskFolder: diskFolder,
+		DiskPath:   diskFolder + "/" + name + ".qcow2",
+	})
+	if err != nil {
+		return fmt.Errorf("failed to restore backup %s of VM %s: %v", backup.Name, backup.VmName, err)
+	}
+	return nil
+}
+
+func (s *BackupService) SetPolicy(policy db.VmBackupPolicy) error {
+	if _, _, err := findVmConnection(policy.VmName); err != nil {
+		return err
+	}
+	if _, err := nfsShareTarget(policy.NfsShareId); err != nil {
+		return err
+	}
+	if policy.FullIntervalHours <= 0 {
+		return fmt.Errorf("full_interval_hours must be greater than 0")
+	}
+	if policy.IncrementalIntervalHours < 0 || policy.KeepChains < 0 {
+		return fmt.Errorf("incremental_interval_hours and keep_chains cant be negative")
+	}
+	if err := db.SetVmBackupPolicy(policy); err != nil {
+		return fmt.Errorf("failed to save backup policy: %v", err)
+	}
+	return nil
+}
+
+func (s *BackupService) RemovePolicy(vmName string) error {
+	if err := db.RemoveVmBackupPolicy(vmName); err != nil {
+		return fmt.Errorf("failed to remove backup policy: %v", err)
+	}
+	return nil
+}
+
+// applyRetention keeps the newest keep chains of the vm, a chain only goes as a whole
+func (s *BackupService) applyRetention(vmName string, keep int) error {
+	if keep <= 0 {
+		return nil
+	}
+	all, err := db.GetVmBackups(vmName)
+	if err != nil {
+		return err
+	}
+	var fulls []db.VmBackup
+	for _, backup := range all {
+		if backup.ParentId == 0 {
+			fulls = append(fulls, backup)
+		}
+	}
+	for i := 0; i < len(fulls)-keep; i++ {
+		if err := s.DeleteBackup(fulls[i].Id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// scheduledBackup takes the backup the policy is due for, if any
+func (s *BackupService) scheduledBackup(policy db.VmBackupPolicy) error {
+	all, err := db.GetVmBackups(policy.VmName)
+	if err != nil {
+		return err
+	}
+	var latest, latestFull *db.VmBackup
+	for i := range all {
+		if all[i].NfsShareId != policy.NfsShareId {
+			continue
+		}
+		latest = &all[i]
+		if all[i].ParentId == 0 {
+			latestFull = &all[i]
+		}
+	}
+
+	now := time.Now()
+	full := latestFull == nil || now.Sub(time.Unix(latestFull.CreatedAt, 0)) >= time.Duration(policy.FullIntervalHours)*time.Hour
+	if !full {
+		if policy.IncrementalIntervalHours <= 0 {
+			return nil
+		}
+		if now.Sub(time.Unix(latest.CreatedAt, 0)) < time.Duration(policy.IncrementalIntervalHours)*time.Hour {
+			return nil
+		}
+	}
+
+	if _, err := s.BackupVM(policy.VmName, policy.NfsShareId, !full); err != nil {
+		return err
+	}
+	return s.applyRetention(policy.VmName, policy.KeepChains)
+}
+
+// Start checks the backup policies every minute and runs the backups that are due
+func (s *BackupService) Start() {
+	go func() {
+		for {
+			policies, err := db.GetAllVmBackupPolicies()
+			if err != nil {
+				logger.Error("backups: failed to get policies:", err)
+			}
+			for _, policy := range policies {
+				if err := s.scheduledBackup(policy); err != nil {
+					logger.Error("backups: scheduled backup of", policy.VmName, "failed:", err)
+				}
+			}
+			time.Sleep(backupScheduleInterval)
+		}
+	}()
+}
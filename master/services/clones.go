@@ -0,0 +1,100 @@
+package services
+
+import (
+	"512SvMan/db"
+	"512SvMan/virsh"
+	"fmt"
+	"regexp"
+	"strings"
+
+	grpcVirsh "github.com/Maruqes/512SvMan/api/proto/virsh"
+	"github.com/Maruqes/512SvMan/logger"
+)
+
+// the clone name becomes a folder and a file on the share, keep it to a plain file name
+var cloneNamePattern = regexp.MustCompile(`^[A-Za-z0-9._-]+$`)
+
+// a linked clone writes on top of the source disk, starting or deleting the source would break it
+func ensureNoLinkedClones(name, action string) error {
+	clones, err := db.GetVmLinkedClones(name)
+	if err != nil {
+		return fmt.Errorf("failed to get linked clones of VM %s: %v", name, err)
+	}
+	if len(clones) == 0 {
+		return nil
+	}
+	names := make([]string, 0, len(clones))
+	for _, clone := range clones {
+		names = append(names, clone.Name)
+	}
+	return fmt.Errorf("cannot %s VM %s, it is the base of linked clones: %s", action, name, strings.Join(names, ", "))
+}
+
+// CloneVM copies sourceName into a new vm on the nfs share nfsShareId, the clone is created on the
+// same slave as the source. linked makes a qcow2 overlay backed by the source disk instead of a full copy
+func (v *VirshService) CloneVM(sourceName string, name string, nfsShareId int, linked bool, start bool) error {
+	if !cloneNamePattern.MatchString(name) || strings.Contains(name, "..") {
+		return fmt.Errorf("invalid clone name %q, use only letters, digits, '.', '_' and '-'", name)
+	}
+
+	//cant have two vms with the same name
+	exists, err := virsh.DoesVMExist(name)
+	if err != nil {
+		return fmt.Errorf("error checking if VM exists: %v", err)
+	}
+	if exists {
+		return fmt.Errorf("a VM with the name %s already exists", name)
+	}
+
+	conn, source, err := findVmConnection(sourceName)
+	if err != nil {
+		return err
+	}
+	if err := ensureNotInMaintenance(source.MachineName); err != nil {
+		return err
+	}
+
+	//get disk path from nfsShareId
+	nfsShare, err := db.GetNFSShareByID(nfsShareId)
+	if err != nil {
+		return fmt.Errorf("failed to get NFS share by ID: %v", err)
+	}
+	if nfsShare == nil {
+		return fmt.Errorf("NFS share with ID %d not found", nfsShareId)
+	}
+
+	// mnt/ nfs / vmname / vmname.qcow2
+	diskFolder := strings.TrimSuffix(nfsShare.Target, "/") + "/" + name
+	qcowFile := diskFolder + "/" + name + ".qcow2"
+
+	err = virsh.CloneVM(conn, &grpcVirsh.CloneVmRequest{
+		SourceName: sourceName,
+		Name:       name,
+		DiskFolder: diskFolder,
+		DiskPath:   qcowFile,
+		Linked:     linked,
+		Start:      start,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to clone VM %s to %s: %v", sourceName, name, err)
+	}
+
+	if linked {
+		if err := db.AddVmLinkedClone(name, sourceName); err != nil {
+			return fmt.Errorf("VM cloned but linked clone not saved in database: %v", err)
+		}
+	}
+
+	//the clone keeps the source cpu definition so it is as migratable as the source
+	isLive, err := db.DoesVmLiveExist(sourceName)
+	if err != nil {
+		logger.Error("failed to check if source VM is live:", err)
+		return nil
+	}
+	if isLive {
+		if err := db.AddVmLive(name); err != nil {
+			return fmt.Errorf("VM cloned but not added as live VM in database: %v", err)
+		}
+	}
+	return nil
+}
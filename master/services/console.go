@@ -0,0 +1,203 @@
+package services
+
+import (
+	"512SvMan/db"
+	"512SvMan/npm"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	grpcVirsh "github.com/Maruqes/512SvMan/api/proto/virsh"
+)
+
+const (
+	consoleTokenDefaultTTL = time.Minute
+	consoleTokenMaxTTL     = time.Hour
+	consoleSessionsLimit   = 500
+)
+
+type ConsoleService struct{}
+
+// what the signed console token carries
+type ConsoleToken struct {
+	Id        string `json:"id"`
+	VmName    string `json:"vm"`
+	User      string `json:"user"`
+	Kind      string `json:"kind"`
+	ExpiresAt int64  `json:"exp"`
+	SingleUse bool   `json:"once"`
+}
+
+var (
+	// the key only lives in memory, a restart of the master invalidates every token given out
+	consoleSecret = func() []byte {
+		key := make([]byte, 32)
+		if _, err := rand.Read(key); err != nil {
+			panic(fmt.Sprintf("console token key: %v", err))
+		}
+		return key
+	}()
+
+	// single use tokens already redeemed, kept until they expire
+	usedConsoleTokens   = map[string]int64{}
+	usedConsoleTokensMu sync.Mutex
+)
+
+func signConsolePayload(payload string) string {
+	mac := hmac.New(sha256.New, consoleSecret)
+	mac.Write([]byte(payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func (c *ConsoleService) currentUser(baseUrl, authToken string) (string, error) {
+	user, err := npm.GetMe(baseUrl, authToken)
+	if err != nil {
+		return "", fmt.Errorf("failed to get current user: %v", err)
+	}
+	return user.Email, nil
+}
+
+// IssueToken gives the caller a token that opens the console of one vm, only for them and only until it expires
+func (c *ConsoleService) IssueToken(baseUrl, authToken, vmName, kind string, ttl time.Duration, singleUse bool) (string, *ConsoleToken, error) {
+	if kind != db.ConsoleKindVNC && kind != db.ConsoleKindSerial {
+		return "", nil, fmt.Errorf("kind must be %s or %s", db.ConsoleKindVNC, db.ConsoleKindSerial)
+	}
+	if ttl <= 0 {
+		ttl = consoleTokenDefaultTTL
+	}
+	if ttl > consoleTokenMaxTTL {
+		return "", nil, fmt.Errorf("ttl can not be longer than %s", consoleTokenMaxTTL)
+	}
+
+	if _, _, err := findVmConnection(vmName); err != nil {
+		return "", nil, err
+	}
+	user, err := c.currentUser(baseUrl, authToken)
+	if err != nil {
+		return "", nil, err
+	}
+
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return "", nil, fmt.Errorf("failed to generate token id: %v", err)
+	}
+	tok := &ConsoleToken{
+		Id:        hex.EncodeToString(id),
+		VmName:    vmName,
+		User:      user,
+		Kind:      kind,
+		ExpiresAt: time.Now().Add(ttl).Unix(),
+		SingleUse: singleUse,
+	}
+	data, err := json.Marshal(tok)
+	if err != nil {
+		return "", nil, err
+	}
+	payload := base64.RawURLEncoding.EncodeToString(data)
+	return payload + "." + signConsolePayload(payload), tok, nil
+}
+
+func (c *ConsoleService) parseToken(token string) (*ConsoleToken, error) {
+	payload, sig, ok := strings.Cut(strings.TrimSpace(token), ".")
+	if !ok || !hmac.Equal([]byte(sig), []byte(signConsolePayload(payload))) {
+		return nil, fmt.Errorf("invalid console token")
+	}
+	data, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		return nil, fmt.Errorf("invalid console token")
+	}
+	var tok ConsoleToken
+	if err := json.Unmarshal(data, &tok); err != nil {
+		return nil, fmt.Errorf("invalid console token")
+	}
+	if time.Now().Unix() > tok.ExpiresAt {
+		return nil, fmt.Errorf("console token expired")
+	}
+	return &tok, nil
+}
+
+// markConsoleTokenUsed refuses a single use token the second time
+func markConsoleTokenUsed(tok *ConsoleToken) error {
+	usedConsoleTokensMu.Lock()
+	defer usedConsoleTokensMu.Unlock()
+
+	now := time.Now().Unix()
+	for id, exp := range usedConsoleTokens {
+		if exp < now {
+			delete(usedConsoleTokens, id)
+		}
+	}
+	if _, used := usedConsoleTokens[tok.Id]; used {
+		return fmt.Errorf("console token already used")
+	}
+	usedConsoleTokens[tok.Id] = tok.ExpiresAt
+	return nil
+}
+
+// OpenSession redeems the token for the logged in user and records the session, CloseSession must follow
+func (c *ConsoleService) OpenSession(baseUrl, authToken, token, kind, remoteAddr string) (*db.ConsoleSession, *grpcVirsh.Vm, error) {
+	tok, err := c.parseToken(token)
+	if err != nil {
+		return nil, nil, err
+	}
+	if tok.Kind != kind {
+		return nil, nil, fmt.Errorf("console token is for a %s console", tok.Kind)
+	}
+	user, err := c.currentUser(baseUrl, authToken)
+	if err != nil {
+		return nil, nil, err
+	}
+	if user != tok.User {
+		return nil, nil, fmt.Errorf("console token was issued to another user")
+	}
+
+	// looked up now, the vm may have migrated since the token was issued
+	_, vm, err := findVmConnection(tok.VmName)
+	if err != nil {
+		return nil, nil, err
+	}
+	if tok.SingleUse {
+		if err := markConsoleTokenUsed(tok); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	session := &db.ConsoleSession{
+		VmName:      vm.Name,
+		MachineName: vm.MachineName,
+		User:        user,
+		Kind:        kind,
+		TokenId:     tok.Id,
+		RemoteAddr:  remoteAddr,
+		StartedAt:   time.Now().Unix(),
+	}
+	if err := db.AddConsoleSession(session); err != nil {
+		return nil, nil, fmt.Errorf("failed to record console session: %v", err)
+	}
+	return session, vm, nil
+}
+
+func (c *ConsoleService) CloseSession(session *db.ConsoleSession) error {
+	if err := db.EndConsoleSession(session.Id, time.Now().Unix()); err != nil {
+		return fmt.Errorf("failed to end console session: %v", err)
+	}
+	return nil
+}
+
+func (c *ConsoleService) ListSessions(vmName string) ([]db.ConsoleSession, error) {
+	sessions, err := db.GetConsoleSessions(vmName, consoleSessionsLimit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get console sessions: %v", err)
+	}
+	if sessions == nil {
+		sessions = []db.ConsoleSession{}
+	}
+	return sessions, nil
+}
@@ -0,0 +1,154 @@
+package services
+
+import (
+	"512SvMan/db"
+	"512SvMan/virsh"
+	"fmt"
+	"path"
+	"strings"
+
+	grpcVirsh "github.com/Maruqes/512SvMan/api/proto/virsh"
+)
+
+func nfsShareTarget(nfsShareId int) (string, error) {
+	nfsShare, err := db.GetNFSShareByID(nfsShareId)
+	if err != nil {
+		return "", fmt.Errorf("failed to get NFS share by ID: %v", err)
+	}
+	if nfsShare == nil {
+		return "", fmt.Errorf("NFS share with ID %d not found", nfsShareId)
+	}
+	return strings.TrimSuffix(nfsShare.Target, "/"), nil
+}
+
+// AddDisk creates a new disk for the vm on nfsShareId (share/vmname/vmname-vdX.qcow2) and attaches it
+func (v *VirshService) AddDisk(vmName string, nfsShareId int, sizeGB int32) (*grpcVirsh.VmDisk, error) {
+	conn, _, err := findVmConnection(vmName)
+	if err != nil {
+		return nil, err
+	}
+
+	target, err := nfsShareTarget(nfsShareId)
+	if err != nil {
+		return nil, err
+	}
+
+	disk, err := virsh.AddDisk(conn, &grpcVirsh.VmDiskRequest{
+		VmName:     vmName,
+		DiskFolder: target + "/" + vmName,
+		SizeGB:     sizeGB,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to add disk to VM %s: %v", vmName, err)
+	}
+	return disk, nil
+}
+
+// AttachDisk attaches an existing file (relative to the nfs share), the vm won't delete it when removed
+func (v *VirshService) AttachDisk(vmName string, nfsShareId int, fileName string) (*grpcVirsh.VmDisk, error) {
+	conn, _, err := findVmConnection(vmName)
+	if err != nil {
+		return nil, err
+	}
+
+	target, err := nfsShareTarget(nfsShareId)
+	if err != nil {
+		return nil, err
+	}
+	fileName = path.Clean("/" + strings.TrimSpace(fileName))
+	if fileName == "/" {
+		return nil, fmt.Errorf("disk file is required")
+	}
+
+	disk, err := virsh.AttachDisk(conn, &grpcVirsh.VmDiskRequest{
+		VmName:   vmName,
+		DiskPath: target + fileName,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to attach disk to VM %s: %v", vmName, err)
+	}
+	return disk, nil
+}
+
+func (v *VirshService) DetachDisk(vmName string, target string, deleteFile bool) error {
+	conn, _, err := findVmConnection(vmName)
+	if err != nil {
+		return err
+	}
+
+	err = virsh.DetachDisk(conn, &grpcVirsh.VmDiskRequest{
+		VmName:     vmName,
+		Target:     target,
+		DeleteFile: deleteFile,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to detach disk %s from VM %s: %v", target, vmName, err)
+	}
+	return nil
+}
+
+func (v *VirshService) ResizeDisk(vmName string, target string, sizeGB int32) (*grpcVirsh.VmDisk, error) {
+	conn, _, err := findVmConnection(vmName)
+	if err != nil {
+		return nil, err
+	}
+
+	disk, err := virsh.ResizeDisk(conn, &grpcVirsh.VmDiskRequest{
+		VmName: vmName,
+		Target: target,
+		SizeGB: sizeGB,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to resize disk %s of VM %s: %v", target, vmName, err)
+	}
+	return disk, nil
+}
+
+// MoveVmStorage moves the disks of the vm to its folder on nfsShareId, live with blockcopy when it runs.
+// it is how a share gets drained before DeleteSharePoint, disks attached from a share stay there
+func (v *VirshService) MoveVmStorage(vmName string, nfsShareId int) error {
+	conn, vm, err := findVmConnection(vmName)
+	if err != nil {
+		return err
+	}
+	//the clones write on top of this disk, it cant move under them
+	if err := ensureNoLinkedClones(vmName, "move the storage of"); err != nil {
+		return err
+	}
+
+	target, err := nfsShareTarget(nfsShareId)
+	if err != nil {
+		return err
+	}
+
+	//a migration would move the vm away while its disks are copied
+	endMove, err := startMigration(vmName, vm.MachineName)
+	if err != nil {
+		return err
+	}
+	defer endMove()
+
+	res, err := virsh.MoveVmStorage(conn, &grpcVirsh.VmDiskRequest{
+		VmName:     vmName,
+		DiskFolder: target + "/" + vmName,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to move storage of VM %s: %v", vmName, err)
+	}
+
+	//the copy flattens the backing chain, a linked clone is a standalone vm once its boot disk moved
+	//even when a later disk failed
+	if res.PrimaryMoved {
+		if err := db.RemoveVmLinkedClone(vmName); err != nil {
+			return fmt.Errorf("storage moved but linked clone not removed from database: %v", err)
+		}
+	}
+	if res.Error != "" {
+		moved := make([]string, 0, len(res.Moved))
+		for _, disk := range res.Moved {
+			moved = append(moved, disk.Target)
+		}
+		return fmt.Errorf("storage of VM %s partially moved (moved: %s): %s", vmName, strings.Join(moved, ", "), res.Error)
+	}
+	return nil
+}
@@ -0,0 +1,480 @@
+package services
+
+import (
+	"512SvMan/db"
+	"512SvMan/nfs"
+	"512SvMan/protocol"
+	"512SvMan/virsh"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	grpcVirsh "github.com/Maruqes/512SvMan/api/proto/virsh"
+	"github.com/Maruqes/512SvMan/api/version"
+	"github.com/Maruqes/512SvMan/logger"
+)
+
+// the load balancer looks at how busy every slave is (cpu load or memory in use, whichever is higher)
+// and when the busiest and the idlest slave are further apart than the threshold it moves live vms
+// from the busiest one. a vm is only moved where the scheduler would place it and where its cpu
+// baseline runs
+
+const (
+	drsMinInterval = 30 * time.Second
+	drsHistorySize = 50
+)
+
+type DRSService struct{}
+
+type DRSHost struct {
+	MachineName string  `json:"machine_name"`
+	CPULoad     float64 `json:"cpu_load"`
+	MemoryUsed  float64 `json:"memory_used"`
+	Pressure    float64 `json:"pressure"`
+}
+
+type DRSStatus struct {
+	Config    db.DRSConfig     `json:"config"`
+	LastRun   int64            `json:"last_run"`
+	Imbalance float64          `json:"imbalance"`
+	Hosts     []DRSHost        `json:"hosts"`
+	Proposals []db.DRSProposal `json:"proposals"`
+}
+
+// proposals and the last migration of every vm are in the database, a master restart keeps
+// the cooldown and the proposals waiting for approval
+var (
+	drsMu        sync.Mutex
+	drsRoundMu   sync.Mutex // one round or approval at a time
+	drsLastRun   time.Time
+	drsImbalance float64
+	drsHosts     []DRSHost
+)
+
+// recordVmMigration starts the cooldown of the vm, migrations by hand count too
+func recordVmMigration(vmName string) {
+	if err := db.SetVmMigratedAt(vmName, time.Now().Unix()); err != nil {
+		logger.Error("failed to save migration time of VM", vmName+":", err)
+	}
+}
+
+// vmInCooldown errs on the side of not moving the vm when the time can not be read
+func vmInCooldown(vmName string, cooldown time.Duration) bool {
+	last, err := db.GetVmMigratedAt(vmName)
+	if err != nil {
+		logger.Error("failed to get migration time of VM", vmName+":", err)
+		return true
+	}
+	return last > 0 && time.Since(time.Unix(last, 0)) < cooldown
+}
+
+func drsHostOf(res *grpcVirsh.HostResources, machineName string) DRSHost {
+	h := DRSHost{MachineName: machineName, CPULoad: res.CpuLoad}
+	if res.MemoryTotalMB > 0 {
+		h.MemoryUsed = 1 - float64(res.MemoryFreeMB)/float64(res.MemoryTotalMB)
+	}
+	h.Pressure = max(h.CPULoad, h.MemoryUsed)
+	return h
+}
+
+// vmLoadOn is the share of cpu and memory of res the vm takes
+func vmLoadOn(vm *grpcVirsh.Vm, res *grpcVirsh.HostResources) (cpu, mem float64) {
+	if res.Cpus > 0 {
+		cpu = float64(vm.CurrentCpuUsage) / 100 * float64(vm.CpuCount) / float64(res.Cpus)
+	}
+	if res.MemoryTotalMB > 0 {
+		mem = float64(vm.MemoryMB) / float64(res.MemoryTotalMB)
+	}
+	return cpu, mem
+}
+
+func validateDRSConfig(cfg db.DRSConfig) error {
+	switch cfg.Mode {
+	case db.DRSModeOff, db.DRSModeDryRun, db.DRSModeManual, db.DRSModeAutomatic:
+	default:
+		return fmt.Errorf("invalid mode %q (%s, %s, %s or %s)", cfg.Mode, db.DRSModeOff, db.DRSModeDryRun, db.DRSModeManual, db.DRSModeAutomatic)
+	}
+	if cfg.Threshold <= 0 || cfg.Threshold > 1 {
+		return fmt.Errorf("threshold must be between 0 and 1")
+	}
+	if time.Duration(cfg.IntervalSeconds)*time.Second < drsMinInterval {
+		return fmt.Errorf("interval must be at least %s", drsMinInterval)
+	}
+	if cfg.CooldownSeconds < 0 {
+		return fmt.Errorf("cooldown cannot be negative")
+	}
+	if cfg.MaxMigrations < 1 {
+		return fmt.Errorf("max migrations must be at least 1")
+	}
+	return nil
+}
+
+func (d *DRSService) SetConfig(cfg db.DRSConfig) error {
+	if err := validateDRSConfig(cfg); err != nil {
+		return err
+	}
+	if err := db.SetDRSConfig(cfg); err != nil {
+		return fmt.Errorf("failed to save DRS config: %v", err)
+	}
+	return nil
+}
+
+func (d *DRSService) GetStatus() (*DRSStatus, error) {
+	cfg, err := db.GetDRSConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DRS config: %v", err)
+	}
+	proposals, err := db.GetDRSProposals()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DRS proposals: %v", err)
+	}
+	drsMu.Lock()
+	defer drsMu.Unlock()
+	status := &DRSStatus{
+		Config:    cfg,
+		Imbalance: drsImbalance,
+		Hosts:     append([]DRSHost(nil), drsHosts...),
+		Proposals: proposals,
+	}
+	if !drsLastRun.IsZero() {
+		status.LastRun = drsLastRun.Unix()
+	}
+	return status, nil
+}
+
+func updateDRSProposal(id int, status string, err error) {
+	errMsg := ""
+	if err != nil {
+		errMsg = err.Error()
+	}
+	if err := db.UpdateDRSProposal(id, status, errMsg); err != nil {
+		logger.Error("DRS: failed to update proposal", id, "to", status+":", err)
+	}
+}
+
+type drsMove struct {
+	vm   *grpcVirsh.Vm
+	from *PlacementHost
+	to   *PlacementHost
+	gain float64
+}
+
+// canMoveVm checks what the pressure numbers do not see, the vm disks and cpu baseline on the target
+func canMoveVm(vm *grpcVirsh.Vm, from, to *PlacementHost) error {
+	fromConn := protocol.GetConnectionByMachineName(from.MachineName)
+	toConn := protocol.GetConnectionByMachineName(to.MachineName)
+	if fromConn == nil || toConn == nil {
+		return fmt.Errorf("slave not connected")
+	}
+	// drs only moves running vms, live
+	if err := checkMigrationCompat(fromConn, toConn, true); err != nil {
+		return err
+	}
+	if vm.DiskPath != "" {
+		found, err := nfs.CanFindFileOrDir(toConn.Connection, vm.DiskPath)
+		if err != nil || !found {
+			return fmt.Errorf("disk %s not reachable on %s", vm.DiskPath, to.MachineName)
+		}
+	}
+	if !protocol.HasFeature(toConn.Capabilities, version.FeatureCompareCPU) {
+		return fmt.Errorf("%s can not compare cpus, upgrade it", to.MachineName)
+	}
+	cpuXML, err := virsh.GetVmCPUXML(fromConn.Connection, vm.Name)
+	if err != nil {
+		return fmt.Errorf("failed to get cpu of VM %s: %v", vm.Name, err)
+	}
+	res, err := virsh.CompareCPU(toConn.Connection, cpuXML)
+	if err != nil {
+		return fmt.Errorf("failed to compare cpu on %s: %v", to.MachineName, err)
+	}
+	if !res.Ok {
+		return fmt.Errorf("cpu of VM %s does not run on %s: %s", vm.Name, to.MachineName, res.Message)
+	}
+	return nil
+}
+
+// planDRS picks up to cfg.MaxMigrations moves, each one from the busiest slave at that point.
+// canMove is canMoveVm, the checks that ask the slaves
+func planDRS(cfg db.DRSConfig, hosts []PlacementHost, load map[string]*DRSHost, canMove func(vm *grpcVirsh.Vm, from, to *PlacementHost) error) []db.DRSProposal {
+	vmHosts := map[string]string{}
+	for _, host := range hosts {
+		for _, vm := range host.Vms {
+			vmHosts[vm.Name] = host.MachineName
+		}
+	}
+	pending := (&HAService{}).Pending()
+	cooldown := time.Duration(cfg.CooldownSeconds) * time.Second
+	moved := map[string]bool{}
+	refused := map[string]bool{} // vm -> target pairs that failed canMove
+
+	var proposals []db.DRSProposal
+	for len(proposals) < cfg.MaxMigrations {
+		sort.SliceStable(hosts, func(i, j int) bool {
+			return load[hosts[i].MachineName].Pressure > load[hosts[j].MachineName].Pressure
+		})
+		src := &hosts[0]
+		srcLoad := load[src.MachineName]
+		if srcLoad.Pressure-load[hosts[len(hosts)-1].MachineName].Pressure < cfg.Threshold {
+			break
+		}
+
+		var moves []drsMove
+		for _, vm := range src.Vms {
+			if !isVmActive(vm) || moved[vm.Name] || vmInCooldown(vm.Name, cooldown) {
+				continue
+			}
+			if _, ok := pending[vm.Name]; ok {
+				continue
+			}
+			if isLive, err := db.DoesVmLiveExist(vm.Name); err != nil || !isLive {
+				continue
+			}
+			affinity, antiAffinity, err := placementRules(vm.Name, nil)
+			if err != nil {
+				logger.Error("DRS:", err)
+				continue
+			}
+			req := PlacementRequest{
+				VmName:       vm.Name,
+				MemoryMB:     vm.MemoryMB,
+				VCPUs:        vm.CpuCount,
+				Affinity:     affinity,
+				AntiAffinity: antiAffinity,
+				VmHosts:      vmHosts,
+			}
+
+			srcCPU, srcMem := vmLoadOn(vm, src.Resources)
+			newSrc := max(srcLoad.CPULoad-srcCPU, srcLoad.MemoryUsed-srcMem)
+			for i := 1; i < len(hosts); i++ {
+				dst := &hosts[i]
+				if refused[vm.Name+"\x00"+dst.MachineName] {
+					continue
+				}
+				dstLoad := load[dst.MachineName]
+				dstCPU, dstMem := vmLoadOn(vm, dst.Resources)
+				newDst := max(dstLoad.CPULoad+dstCPU, dstLoad.MemoryUsed+dstMem)
+				gain := srcLoad.Pressure - max(newSrc, newDst)
+				if gain <= 0 {
+					continue
+				}
+				if c := scorePlacementHost(req, *dst); c.Rejected != "" {
+					continue
+				}
+				moves = append(moves, drsMove{vm: vm, from: src, to: dst, gain: gain})
+			}
+		}
+		if len(moves) == 0 {
+			break
+		}
+		sort.SliceStable(moves, func(i, j int) bool { return moves[i].gain > moves[j].gain })
+
+		var chosen *drsMove
+		for i := range moves {
+			m := &moves[i]
+			if err := canMove(m.vm, m.from, m.to); err != nil {
+				refused[m.vm.Name+"\x00"+m.to.MachineName] = true
+				logger.Debug("DRS: skipping move", m.vm.Name, "to", m.to.MachineName, ":", err)
+				continue
+			}
+			chosen = m
+			break
+		}
+		if chosen == nil {
+			break
+		}
+
+		// account the move so the next pick sees the new numbers
+		srcCPU, srcMem := vmLoadOn(chosen.vm, chosen.from.Resources)
+		dstCPU, dstMem := vmLoadOn(chosen.vm, chosen.to.Resources)
+		from, to := load[chosen.from.MachineName], load[chosen.to.MachineName]
+		from.CPULoad, from.MemoryUsed = from.CPULoad-srcCPU, from.MemoryUsed-srcMem
+		from.Pressure = max(from.CPULoad, from.MemoryUsed)
+		to.CPULoad, to.MemoryUsed = to.CPULoad+dstCPU, to.MemoryUsed+dstMem
+		to.Pressure = max(to.CPULoad, to.MemoryUsed)
+		chosen.to.Resources.AllocatedVcpus += chosen.vm.CpuCount
+		chosen.to.Resources.MemoryFreeMB -= int64(chosen.vm.MemoryMB)
+		vmHosts[chosen.vm.Name] = chosen.to.MachineName
+		moved[chosen.vm.Name] = true
+
+		proposals = append(proposals, db.DRSProposal{
+			VmName:    chosen.vm.Name,
+			From:      chosen.from.MachineName,
+			To:        chosen.to.MachineName,
+			Gain:      chosen.gain,
+			CreatedAt: time.Now().Unix(),
+			Status:    db.DRSProposed,
+		})
+	}
+	return proposals
+}
+
+func (d *DRSService) round(cfg db.DRSConfig) error {
+	drsRoundMu.Lock()
+	defer drsRoundMu.Unlock()
+
+	all, _ := gatherPlacementHosts(nil)
+	var hosts []PlacementHost
+	load := map[string]*DRSHost{}
+	var status []DRSHost
+	for _, host := range all {
+		// slaves in maintenance are emptied by their own evacuation
+		if err := ensureNotInMaintenance(host.MachineName); err != nil {
+			continue
+		}
+		h := drsHostOf(host.Resources, host.MachineName)
+		load[host.MachineName] = &h
+		status = append(status, h)
+		hosts = append(hosts, host)
+	}
+
+	imbalance := 0.0
+	if len(status) > 1 {
+		lo, hi := status[0].Pressure, status[0].Pressure
+		for _, h := range status {
+			lo, hi = min(lo, h.Pressure), max(hi, h.Pressure)
+		}
+		imbalance = hi - lo
+	}
+
+	var proposals []db.DRSProposal
+	if len(hosts) > 1 && imbalance >= cfg.Threshold {
+		proposals = planDRS(cfg, hosts, load, canMoveVm)
+	}
+
+	drsMu.Lock()
+	drsLastRun = time.Now()
+	drsImbalance = imbalance
+	drsHosts = status
+	drsMu.Unlock()
+	if err := db.SetDRSProposals(proposals, drsHistorySize); err != nil {
+		return fmt.Errorf("failed to save DRS proposals: %v", err)
+	}
+
+	if len(proposals) > 0 {
+		logger.Info("DRS: imbalance", fmt.Sprintf("%.2f", imbalance), "proposed", len(proposals), "migrations, mode", cfg.Mode)
+	}
+	if cfg.Mode != db.DRSModeAutomatic {
+		return nil
+	}
+
+	// SetDRSProposals numbered them
+	for _, p := range proposals {
+		d.execute(p)
+	}
+	return nil
+}
+
+func (d *DRSService) execute(p db.DRSProposal) error {
+	updateDRSProposal(p.Id, db.DRSMigrating, nil)
+	v := VirshService{}
+	err := v.migrateVm(p.From, p.To, p.VmName, true, MigrationLimits{})
+	if err != nil {
+		updateDRSProposal(p.Id, db.DRSFailed, err)
+		logger.Error("DRS: migration of", p.VmName, "to", p.To, "failed:", err)
+		return err
+	}
+	updateDRSProposal(p.Id, db.DRSDone, nil)
+	logger.Info("DRS: VM", p.VmName, "migrated from", p.From, "to", p.To)
+	return nil
+}
+
+// Run does a round now, with mode off it only proposes like dry-run
+func (d *DRSService) Run() (*DRSStatus, error) {
+	cfg, err := db.GetDRSConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DRS config: %v", err)
+	}
+	if cfg.Mode == db.DRSModeOff {
+		cfg.Mode = db.DRSModeDryRun
+	}
+	if err := d.round(cfg); err != nil {
+		return nil, err
+	}
+	return d.GetStatus()
+}
+
+func (d *DRSService) findProposal(id int) (db.DRSProposal, error) {
+	p, err := db.GetDRSProposal(id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return db.DRSProposal{}, fmt.Errorf("proposal %d not found", id)
+	}
+	if err != nil {
+		return db.DRSProposal{}, fmt.Errorf("failed to get proposal %d: %v", id, err)
+	}
+	return *p, nil
+}
+
+// Approve migrates a proposal, only in manual mode
+func (d *DRSService) Approve(id int) error {
+	cfg, err := db.GetDRSConfig()
+	if err != nil {
+		return fmt.Errorf("failed to get DRS config: %v", err)
+	}
+	if cfg.Mode != db.DRSModeManual {
+		return fmt.Errorf("proposals are only approved in %s mode (mode is %s)", db.DRSModeManual, cfg.Mode)
+	}
+
+	drsRoundMu.Lock()
+	defer drsRoundMu.Unlock()
+	p, err := d.findProposal(id)
+	if err != nil {
+		return err
+	}
+	if p.Status != db.DRSProposed {
+		return fmt.Errorf("proposal %d is %s", id, p.Status)
+	}
+	if vmInCooldown(p.VmName, time.Duration(cfg.CooldownSeconds)*time.Second) {
+		updateDRSProposal(id, db.DRSRejected, fmt.Errorf("VM was migrated during the cooldown"))
+		return fmt.Errorf("VM %s was migrated less than %ds ago", p.VmName, cfg.CooldownSeconds)
+	}
+	return d.execute(p)
+}
+
+func (d *DRSService) Reject(id int) error {
+	p, err := d.findProposal(id)
+	if err != nil {
+		return err
+	}
+	if p.Status != db.DRSProposed {
+		return fmt.Errorf("proposal %d is %s", id, p.Status)
+	}
+	updateDRSProposal(id, db.DRSRejected, nil)
+	return nil
+}
+
+// Start runs a round every configured interval while the mode is not off
+func (d *DRSService) Start() {
+	// a migration the master was waiting on when it stopped has no result anymore
+	if proposals, err := db.GetDRSProposals(); err != nil {
+		logger.Error("DRS: failed to get proposals:", err)
+	} else {
+		for _, p := range proposals {
+			if p.Status == db.DRSMigrating {
+				updateDRSProposal(p.Id, db.DRSFailed, fmt.Errorf("master restarted during the migration"))
+			}
+		}
+	}
+
+	go func() {
+		for {
+			cfg, err := db.GetDRSConfig()
+			if err != nil {
+				logger.Error("DRS: failed to get config:", err)
+				cfg = db.DefaultDRSConfig()
+			}
+			if cfg.Mode != db.DRSModeOff {
+				if err := d.round(cfg); err != nil {
+					logger.Error("DRS: round failed:", err)
+				}
+			}
+			interval := time.Duration(cfg.IntervalSeconds) * time.Second
+			if interval < drsMinInterval {
+				interval = drsMinInterval
+			}
+			time.Sleep(interval)
+		}
+	}()
+}
@@ -0,0 +1,165 @@
+package services
+
+import (
+	"512SvMan/db"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	grpcVirsh "github.com/Maruqes/512SvMan/api/proto/virsh"
+)
+
+// drsTestCluster is a busy s1 (90% cpu) running "big", which takes half of its cpus, and an idle s2
+func drsTestCluster() ([]PlacementHost, map[string]*DRSHost) {
+	hosts := []PlacementHost{
+		{
+			MachineName: "s1",
+			Resources:   &grpcVirsh.HostResources{MemoryTotalMB: 16384, MemoryFreeMB: 12288, Cpus: 8, AllocatedVcpus: 4, CpuLoad: 0.9},
+			Vms: []*grpcVirsh.Vm{
+				{Name: "big", State: grpcVirsh.VmState_RUNNING, CpuCount: 4, MemoryMB: 1024, CurrentCpuUsage: 100},
+			},
+		},
+		{
+			MachineName: "s2",
+			Resources:   &grpcVirsh.HostResources{MemoryTotalMB: 16384, MemoryFreeMB: 14336, Cpus: 8, CpuLoad: 0.1},
+		},
+	}
+	load := map[string]*DRSHost{}
+	for _, host := range hosts {
+		h := drsHostOf(host.Resources, host.MachineName)
+		load[host.MachineName] = &h
+	}
+	return hosts, load
+}
+
+func TestPlanDRS(t *testing.T) {
+	allowed := func(*grpcVirsh.Vm, *PlacementHost, *PlacementHost) error { return nil }
+	live := func(t *testing.T) {
+		if err := db.AddVmLive("big"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name      string
+		threshold float64
+		cooldown  int
+		setup     func(t *testing.T)
+		canMove   func(*grpcVirsh.Vm, *PlacementHost, *PlacementHost) error
+		onS2      []string // running vms on the idle slave
+		want      []string // vm:from->to
+	}{
+		{
+			name:      "gap below the threshold",
+			threshold: 0.9,
+			setup:     live,
+		},
+		{
+			name:      "moves from the busiest slave",
+			threshold: 0.25,
+			setup:     live,
+			want:      []string{"big:s1->s2"},
+		},
+		{
+			name:      "in cooldown",
+			threshold: 0.25,
+			cooldown:  3600,
+			setup: func(t *testing.T) {
+				live(t)
+				if err := db.SetVmMigratedAt("big", time.Now().Add(-10*time.Minute).Unix()); err != nil {
+					t.Fatal(err)
+				}
+			},
+		},
+		{
+			name:      "cooldown over",
+			threshold: 0.25,
+			cooldown:  3600,
+			setup: func(t *testing.T) {
+				live(t)
+				if err := db.SetVmMigratedAt("big", time.Now().Add(-2*time.Hour).Unix()); err != nil {
+					t.Fatal(err)
+				}
+			},
+			want: []string{"big:s1->s2"},
+		},
+		{
+			name:      "not a live vm",
+			threshold: 0.25,
+		},
+		{
+			name:      "refused by the slave checks",
+			threshold: 0.25,
+			setup:     live,
+			canMove: func(*grpcVirsh.Vm, *PlacementHost, *PlacementHost) error {
+				return errors.New("cpu does not run there")
+			},
+		},
+		{
+			name:      "anti-affinity partner on the idle slave",
+			threshold: 0.25,
+			setup: func(t *testing.T) {
+				live(t)
+				if err := db.AddVmPlacementRule(db.VmPlacementRule{VmName: "big", Rule: db.PlacementAntiAffinity, OtherVm: "other"}); err != nil {
+					t.Fatal(err)
+				}
+			},
+			onS2: []string{"other"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setupServiceDB(t, db.CreateVmHATable, db.CreateVmLiveTable, db.CreateVmPlacementRulesTable,
+				db.CreateSlaveMaintenanceTable, db.CreateDRSTables)
+			if tt.setup != nil {
+				tt.setup(t)
+			}
+			canMove := tt.canMove
+			if canMove == nil {
+				canMove = allowed
+			}
+
+			hosts, load := drsTestCluster()
+			for _, name := range tt.onS2 {
+				hosts[1].Vms = append(hosts[1].Vms, &grpcVirsh.Vm{Name: name, State: grpcVirsh.VmState_RUNNING})
+			}
+			cfg := db.DefaultDRSConfig()
+			cfg.Threshold = tt.threshold
+			cfg.CooldownSeconds = tt.cooldown
+
+			var got []string
+			for _, p := range planDRS(cfg, hosts, load, canMove) {
+				got = append(got, fmt.Sprintf("%s:%s->%s", p.VmName, p.From, p.To))
+				if p.Gain <= 0 || p.Status != db.DRSProposed {
+					t.Errorf("bad proposal %+v", p)
+				}
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Fatalf("want %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestDRSProposalsSurviveInDB(t *testing.T) {
+	setupServiceDB(t, db.CreateDRSTables)
+	proposals := []db.DRSProposal{{VmName: "big", From: "s1", To: "s2", Gain: 0.3, Status: db.DRSProposed}}
+	if err := db.SetDRSProposals(proposals, drsHistorySize); err != nil {
+		t.Fatal(err)
+	}
+	updateDRSProposal(proposals[0].Id, db.DRSFailed, errors.New("boom"))
+
+	// a new round drops the open proposals but keeps the finished ones
+	if err := db.SetDRSProposals([]db.DRSProposal{{VmName: "small", From: "s1", To: "s2", Status: db.DRSProposed}}, drsHistorySize); err != nil {
+		t.Fatal(err)
+	}
+	got, err := db.GetDRSProposals()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 || got[0].Status != db.DRSFailed || got[0].Error != "boom" || got[1].VmName != "small" {
+		t.Fatalf("unexpected proposals %+v", got)
+	}
+}
@@ -0,0 +1,109 @@
+package services
+
+import (
+	"512SvMan/db"
+	"512SvMan/virsh"
+	"fmt"
+	"path"
+	"strings"
+
+	grpcVirsh "github.com/Maruqes/512SvMan/api/proto/virsh"
+	"github.com/Maruqes/512SvMan/logger"
+)
+
+type GuestExecConfig struct {
+	Path           string   `json:"path"` // must be in the allowlist
+	Args           []string `json:"args"`
+	Input          string   `json:"input"`           // stdin
+	TimeoutSeconds int      `json:"timeout_seconds"` // 30 when 0, at most 300
+}
+
+func (v *VirshService) GetGuestInfo(vmName string) (*grpcVirsh.GuestInfo, error) {
+	conn, _, err := findVmConnection(vmName)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := virsh.GetGuestInfo(conn, &grpcVirsh.GetVmByNameRequest{Name: vmName})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get guest info of VM %s (is qemu-guest-agent running?): %v", vmName, err)
+	}
+	return info, nil
+}
+
+// GuestExec runs an allowlisted program inside the vm through the guest agent
+func (v *VirshService) GuestExec(vmName string, cfg GuestExecConfig) (*grpcVirsh.GuestExecResponse, error) {
+	cfg.Path = strings.TrimSpace(cfg.Path)
+	if cfg.Path == "" {
+		return nil, fmt.Errorf("path is required")
+	}
+	allowed, err := db.IsGuestExecAllowed(cfg.Path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check guest exec allowlist: %v", err)
+	}
+	if !allowed {
+		return nil, fmt.Errorf("%s is not in the guest exec allowlist", cfg.Path)
+	}
+
+	conn, _, err := findVmConnection(vmName)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info("guest exec on VM", vmName, ":", cfg.Path, cfg.Args)
+	res, err := virsh.GuestExec(conn, &grpcVirsh.GuestExecRequest{
+		VmName:         vmName,
+		Path:           cfg.Path,
+		Args:           cfg.Args,
+		Input:          cfg.Input,
+		TimeoutSeconds: int32(cfg.TimeoutSeconds),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to run %s on VM %s: %v", cfg.Path, vmName, err)
+	}
+	return res, nil
+}
+
+func (v *VirshService) EnableGuestAgent(vmName string) error {
+	conn, _, err := findVmConnection(vmName)
+	if err != nil {
+		return err
+	}
+
+	err = virsh.EnableGuestAgent(conn, &grpcVirsh.GetVmByNameRequest{Name: vmName})
+	if err != nil {
+		return fmt.Errorf("failed to enable guest agent on VM %s: %v", vmName, err)
+	}
+	return nil
+}
+
+func (v *VirshService) GetGuestExecAllowlist() ([]string, error) {
+	paths, err := db.GetGuestExecAllowlist()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get guest exec allowlist: %v", err)
+	}
+	if paths == nil {
+		paths = []string{}
+	}
+	return paths, nil
+}
+
+func (v *VirshService) SetGuestExecAllowlist(paths []string) error {
+	var clean []string
+	for _, p := range paths {
+		p = strings.TrimSpace(p)
+		if p == "" {
+			continue
+		}
+		// windows guests use C:\... paths
+		if !path.IsAbs(p) && !strings.Contains(p, `:\`) {
+			return fmt.Errorf("%s is not an absolute path", p)
+		}
+		clean = append(clean, p)
+	}
+
+	if err := db.SetGuestExecAllowlist(clean); err != nil {
+		return fmt.Errorf("failed to save guest exec allowlist: %v", err)
+	}
+	return nil
+}
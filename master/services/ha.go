@@ -0,0 +1,273 @@
+package services
+
+import (
+	"512SvMan/db"
+	"512SvMan/protocol"
+	"512SvMan/virsh"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	grpcVirsh "github.com/Maruqes/512SvMan/api/proto/virsh"
+	"github.com/Maruqes/512SvMan/logger"
+	"google.golang.org/grpc"
+)
+
+// the slaves refuse to recover a vm while the lease on the share is still being renewed,
+// so a slave that only lost the master keeps its vms and the restart is retried later
+const haMonitorInterval = 15 * time.Second
+
+type HAService struct{}
+
+type slaveVms struct {
+	conn        *grpc.ClientConn
+	machineName string
+	vms         []*grpcVirsh.Vm
+}
+
+func isVmActive(vm *grpcVirsh.Vm) bool {
+	switch vm.State {
+	case grpcVirsh.VmState_RUNNING, grpcVirsh.VmState_PAUSED, grpcVirsh.VmState_BLOCKED:
+		return true
+	}
+	return false
+}
+
+// every vm of every connected slave, unlike GetAllVms a vm defined twice shows up twice
+func listSlaveVms() []slaveVms {
+	var res []slaveVms
+	for _, c := range protocol.GetConnectionsSnapshot() {
+		if c.Connection == nil {
+			continue
+		}
+		vms, err := virsh.GetAllVms(c.Connection, &grpcVirsh.Empty{})
+		if err != nil {
+			logger.Error("HA: failed to get VMs from", c.MachineName, ":", err)
+			continue
+		}
+		res = append(res, slaveVms{conn: c.Connection, machineName: c.MachineName, vms: vms.Vms})
+	}
+	return res
+}
+
+func (h *HAService) SetPolicy(vmName, policy string, priority int) error {
+	if policy != db.HAPolicyRestart && policy != db.HAPolicyNone {
+		return fmt.Errorf("invalid HA policy %q (%s or %s)", policy, db.HAPolicyRestart, db.HAPolicyNone)
+	}
+
+	conn, vm, err := findVmConnection(vmName)
+	if err != nil {
+		return err
+	}
+
+	err = virsh.SetVmHA(conn, &grpcVirsh.HaVmRequest{VmName: vmName, Enabled: policy == db.HAPolicyRestart})
+	if err != nil {
+		return fmt.Errorf("failed to set HA on VM %s: %v", vmName, err)
+	}
+
+	if policy == db.HAPolicyNone {
+		if err := db.RemoveVmHA(vmName); err != nil {
+			return fmt.Errorf("failed to remove HA policy from database: %v", err)
+		}
+		return nil
+	}
+
+	err = db.SetVmHA(db.VmHA{
+		VmName:      vmName,
+		Policy:      policy,
+		Priority:    priority,
+		MachineName: vm.MachineName,
+		DiskPath:    vm.DiskPath,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save HA policy: %v", err)
+	}
+	return nil
+}
+
+func (h *HAService) GetPolicies() ([]db.VmHA, error) {
+	return db.GetAllVmHA()
+}
+
+// Pending returns the protected vms still waiting for a restart and the slave they were lost with
+func (h *HAService) Pending() map[string]string {
+	res := map[string]string{}
+	policies, err := db.GetAllVmHA()
+	if err != nil {
+		logger.Error("HA: failed to get policies:", err)
+		return res
+	}
+	for _, ha := range policies {
+		if ha.PendingFrom != "" {
+			res[ha.VmName] = ha.PendingFrom
+		}
+	}
+	return res
+}
+
+// SlaveLost queues the protected vms that were running on a slave declared dead
+func (h *HAService) SlaveLost(addr, machineName string) {
+	policies, err := db.GetAllVmHA()
+	if err != nil {
+		logger.Error("HA: failed to get policies:", err)
+		return
+	}
+
+	for _, ha := range policies {
+		if ha.Policy != db.HAPolicyRestart || ha.MachineName != machineName {
+			continue
+		}
+		if err := db.SetVmHAPending(ha.VmName, machineName); err != nil {
+			logger.Error("HA: failed to queue VM", ha.VmName, "for restart:", err)
+			continue
+		}
+		logger.Warn("HA: slave", machineName, "lost, VM", ha.VmName, "will be restarted")
+	}
+}
+
+// SlaveJoined drops the stale definitions a returning slave has of vms restarted elsewhere,
+// the slave itself refuses when it still holds the lease
+func (h *HAService) SlaveJoined(conn *grpc.ClientConn, machineName string) {
+	policies, err := db.GetAllVmHA()
+	if err != nil {
+		logger.Error("HA: failed to get policies:", err)
+		return
+	}
+	vms, err := virsh.GetAllVms(conn, &grpcVirsh.Empty{})
+	if err != nil {
+		logger.Error("HA: failed to get VMs from", machineName, ":", err)
+		return
+	}
+
+	for _, vm := range vms.Vms {
+		for _, ha := range policies {
+			if ha.VmName != vm.Name || ha.MachineName == "" || ha.MachineName == machineName {
+				continue
+			}
+			if err := virsh.ReleaseVM(conn, &grpcVirsh.HaVmRequest{VmName: vm.Name}); err != nil {
+				logger.Warn("HA: VM", vm.Name, "not released on", machineName, ":", err)
+				continue
+			}
+			logger.Info("HA: released stale VM", vm.Name, "on", machineName)
+		}
+	}
+}
+
+// keeps the last known slave and disk of every protected vm
+func trackHAPlacement(slaves []slaveVms, policies []db.VmHA) {
+	for _, ha := range policies {
+		for _, s := range slaves {
+			for _, vm := range s.vms {
+				if vm.Name != ha.VmName || !isVmActive(vm) {
+					continue
+				}
+				if vm.MachineName == ha.MachineName && vm.DiskPath == ha.DiskPath {
+					continue
+				}
+				if err := db.UpdateVmHAPlacement(vm.Name, s.machineName, vm.DiskPath); err != nil {
+					logger.Error("HA: failed to update placement of", vm.Name, ":", err)
+				}
+			}
+		}
+	}
+}
+
+// leastLoadedSlaves orders the slaves that can take vms by running vms, emptiest first.
+// slaves in maintenance are left out
+func leastLoadedSlaves(slaves []slaveVms) []slaveVms {
+	var targets []slaveVms
+	for _, s := range slaves {
+		if err := ensureNotInMaintenance(s.machineName); err != nil {
+			continue
+		}
+		targets = append(targets, s)
+	}
+	running := func(s slaveVms) int {
+		n := 0
+		for _, vm := range s.vms {
+			if isVmActive(vm) {
+				n++
+			}
+		}
+		return n
+	}
+	sort.SliceStable(targets, func(i, j int) bool { return running(targets[i]) < running(targets[j]) })
+	return targets
+}
+
+func recoverHAVm(ha db.VmHA, slaves []slaveVms) error {
+	for _, s := range slaves {
+		for _, vm := range s.vms {
+			if vm.Name == ha.VmName && isVmActive(vm) {
+				// back on its own slave or already restarted, nothing to do
+				return db.UpdateVmHAPlacement(vm.Name, s.machineName, vm.DiskPath)
+			}
+		}
+	}
+	if ha.DiskPath == "" {
+		return fmt.Errorf("no disk path known for VM %s", ha.VmName)
+	}
+
+	var errs []error
+	vmHosts := slaveVmHosts(slaves)
+	for _, target := range leastLoadedSlaves(slaves) {
+		if err := checkMovePlacement(ha.VmName, ha.MachineName, target.machineName, vmHosts); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %v", target.machineName, err))
+			continue
+		}
+		err := virsh.RecoverVM(target.conn, &grpcVirsh.HaVmRequest{VmName: ha.VmName, DiskPath: ha.DiskPath})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %v", target.machineName, err))
+			continue
+		}
+		logger.Info("HA: VM", ha.VmName, "restarted on", target.machineName)
+		if err := db.UpdateVmHAPlacement(ha.VmName, target.machineName, ha.DiskPath); err != nil {
+			logger.Error("HA: failed to update placement of", ha.VmName, ":", err)
+		}
+		if err := restoreSnapshotMetadata(target.conn, ha.VmName); err != nil {
+			logger.Error("HA: snapshots of", ha.VmName, "not restored:", err)
+		}
+		return nil
+	}
+	if len(errs) == 0 {
+		return fmt.Errorf("no slave available")
+	}
+	return errors.Join(errs...)
+}
+
+func (h *HAService) monitor() {
+	policies, err := db.GetAllVmHA()
+	if err != nil {
+		logger.Error("HA: failed to get policies:", err)
+		return
+	}
+	slaves := listSlaveVms()
+	trackHAPlacement(slaves, policies)
+
+	// policies come ordered by priority
+	for _, ha := range policies {
+		if ha.PendingFrom == "" {
+			continue
+		}
+		if err := recoverHAVm(ha, slaves); err != nil {
+			logger.Warn("HA: VM", ha.VmName, "not restarted yet:", err)
+			continue
+		}
+		if err := db.SetVmHAPending(ha.VmName, ""); err != nil {
+			logger.Error("HA: failed to clear pending restart of VM", ha.VmName+":", err)
+		}
+		// the next vm has to see this one as running on its new slave
+		slaves = listSlaveVms()
+	}
+}
+
+// StartMonitor tracks where the protected vms run and restarts the ones lost with a slave
+func (h *HAService) StartMonitor() {
+	go func() {
+		for {
+			h.monitor()
+			time.Sleep(haMonitorInterval)
+		}
+	}()
+}
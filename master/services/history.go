@@ -0,0 +1,242 @@
+package services
+
+import (
+	"512SvMan/db"
+	"512SvMan/protocol"
+	"512SvMan/virsh"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	infoGrpc "github.com/Maruqes/512SvMan/api/proto/info"
+	grpcVirsh "github.com/Maruqes/512SvMan/api/proto/virsh"
+	"github.com/Maruqes/512SvMan/logger"
+)
+
+const (
+	vmMetricsInterval    = time.Minute
+	metricsPruneInterval = time.Hour
+	// minute buckets are kept for two days, hour buckets for a year
+	minuteMetricsRetention = 48 * time.Hour
+	hourMetricsRetention   = 365 * 24 * time.Hour
+	defaultHistoryRange    = time.Hour
+	maxHistoryPoints       = 5000
+)
+
+var (
+	// vms of every slave as of the last poll, the prometheus exporter reads them instead of asking the slaves again
+	lastVmPoll   = map[string][]*grpcVirsh.Vm{}
+	lastVmPollAt time.Time
+	lastVmPollMu sync.RWMutex
+
+	// cumulative cpu time of every running vm at the previous poll by machine/vm, only recordVmMetrics uses it
+	vmCPUSamples = map[string]vmCPUSample{}
+)
+
+type vmCPUSample struct {
+	ns uint64
+	at int64 // unix ms on the slave
+}
+
+// HistoryService keeps the readings of the hosts (from their metrics stream) and of the vms (polled every minute)
+type HistoryService struct{}
+
+type MetricsHistory struct {
+	Kind       string            `json:"kind"`
+	Name       string            `json:"name"`
+	From       int64             `json:"from"`
+	To         int64             `json:"to"`
+	Step       int64             `json:"step"`
+	Resolution int64             `json:"resolution"` // the stored buckets the steps were built from
+	Samples    []db.MetricSample `json:"samples"`
+}
+
+// hostSample sums the disks and the network, lo and interfaces without an address are left out so the taps
+// of the vms and the nics enslaved to a bridge do not count the same traffic twice
+func hostSample(m *infoGrpc.HostMetrics) db.MetricSample {
+	s := db.MetricSample{Ts: m.Timestamp}
+	if m.Cpu != nil {
+		s.CPU = m.Cpu.UsageTotal
+	}
+	if m.Mem != nil {
+		s.MemUsedMB = float64(m.Mem.UsedMB)
+		s.MemTotalMB = float64(m.Mem.TotalMB)
+	}
+	for _, d := range m.GetDisks().GetDisks() {
+		s.DiskReadBps += float64(d.ReadBytesPerSec)
+		s.DiskWriteBps += float64(d.WriteBytesPerSec)
+	}
+	for _, iface := range m.GetNetwork().GetInterfaces() {
+		if iface.Name == "lo" || len(iface.Addrs) == 0 {
+			continue
+		}
+		s.NetRxBps += float64(iface.RxBytesPerSec)
+		s.NetTxBps += float64(iface.TxBytesPerSec)
+	}
+	return s
+}
+
+func recordHostMetrics(machineName string, m *infoGrpc.HostMetrics) {
+	if err := db.AddMetricSample(db.MetricKindHost, machineName, hostSample(m)); err != nil {
+		logger.Error("history: failed to store metrics of", machineName, ":", err)
+	}
+}
+
+// vmCPUPercent is the share of its vcpus a vm used since the previous poll. the first poll of a vm, and
+// the first after it restarted, has nothing to compare with and takes the short sample of the slave
+func vmCPUPercent(machineName string, vm *grpcVirsh.Vm, seen map[string]vmCPUSample) float64 {
+	key := machineName + "/" + vm.Name
+	cur := vmCPUSample{ns: vm.CpuTimeNs, at: vm.CpuTimeAt}
+	prev, ok := vmCPUSamples[key]
+	if cur.at > 0 {
+		seen[key] = cur
+	}
+	if !ok || cur.at <= prev.at || cur.ns < prev.ns || vm.CpuCount <= 0 {
+		return float64(vm.CurrentCpuUsage)
+	}
+	elapsedNs := float64(cur.at-prev.at) * float64(time.Millisecond)
+	percent := float64(cur.ns-prev.ns) / (elapsedNs * float64(vm.CpuCount)) * 100
+	return max(0, min(percent, 100))
+}
+
+// recordVmMetrics samples every running vm, a slave that does not answer is skipped until the next round.
+// the slaves are asked in parallel, each one takes a moment per running vm to answer
+func (h *HistoryService) recordVmMetrics() {
+	now := time.Now().Unix()
+	polled := map[string][]*grpcVirsh.Vm{}
+	defer func() {
+		lastVmPollMu.Lock()
+		lastVmPoll = polled
+		lastVmPollAt = time.Unix(now, 0)
+		lastVmPollMu.Unlock()
+	}()
+
+	var (
+		mu sync.Mutex
+		wg sync.WaitGroup
+	)
+	for _, c := range protocol.GetConnectionsSnapshot() {
+		if c.Connection == nil {
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			vms, err := virsh.GetAllVms(c.Connection, &grpcVirsh.Empty{})
+			if err != nil {
+				logger.Error("history: failed to get VMs of", c.MachineName, ":", err)
+				return
+			}
+			mu.Lock()
+			polled[c.MachineName] = vms.Vms
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	seen := map[string]vmCPUSample{}
+	for machineName, vms := range polled {
+		for _, vm := range vms {
+			if vm.State != grpcVirsh.VmState_RUNNING {
+				continue
+			}
+			err := db.AddMetricSample(db.MetricKindVM, vm.Name, db.MetricSample{
+				Ts:         now,
+				CPU:        vmCPUPercent(machineName, vm, seen),
+				MemUsedMB:  float64(vm.CurrentMemoryUsageMB),
+				MemTotalMB: float64(vm.MemoryMB),
+			})
+			if err != nil {
+				logger.Error("history: failed to store metrics of VM", vm.Name, ":", err)
+			}
+		}
+	}
+	// a slave that did not answer keeps its readings, the next delta is then over two intervals
+	for key, sample := range vmCPUSamples {
+		machineName, _, _ := strings.Cut(key, "/")
+		if _, answered := polled[machineName]; !answered {
+			seen[key] = sample
+		}
+	}
+	vmCPUSamples = seen
+}
+
+func (h *HistoryService) prune() error {
+	now := time.Now()
+	if err := db.DeleteMetricSamplesBefore(db.MetricResolutionMinute, now.Add(-minuteMetricsRetention).Unix()); err != nil {
+		return err
+	}
+	return db.DeleteMetricSamplesBefore(db.MetricResolutionHour, now.Add(-hourMetricsRetention).Unix())
+}
+
+// Query returns the history of a host or vm between from and to (unix seconds) in steps of step seconds,
+// to defaults to now, from to an hour before and step to a minute
+func (h *HistoryService) Query(kind, name string, from, to, step int64) (*MetricsHistory, error) {
+	if kind != db.MetricKindHost && kind != db.MetricKindVM {
+		return nil, fmt.Errorf("kind must be %s or %s", db.MetricKindHost, db.MetricKindVM)
+	}
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, fmt.Errorf("name is required")
+	}
+	now := time.Now()
+	if to <= 0 {
+		to = now.Unix()
+	}
+	if from <= 0 {
+		from = to - int64(defaultHistoryRange.Seconds())
+	}
+	if from >= to {
+		return nil, fmt.Errorf("from must be before to")
+	}
+
+	// minute buckets while they still exist and the step is fine enough to need them
+	resolution := int64(db.MetricResolutionMinute)
+	if step >= db.MetricResolutionHour || from < now.Add(-minuteMetricsRetention).Unix() {
+		resolution = db.MetricResolutionHour
+	}
+	if step <= 0 {
+		step = resolution
+	}
+	if step < resolution || step%resolution != 0 {
+		return nil, fmt.Errorf("step must be a multiple of %d seconds for this range", resolution)
+	}
+	if (to-from)/step > maxHistoryPoints {
+		return nil, fmt.Errorf("range and step give more than %d points, use a bigger step", maxHistoryPoints)
+	}
+
+	samples, err := db.GetMetricSamples(kind, name, resolution, from, to, step)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get metrics history: %v", err)
+	}
+	if samples == nil {
+		samples = []db.MetricSample{}
+	}
+	return &MetricsHistory{
+		Kind:       kind,
+		Name:       name,
+		From:       from,
+		To:         to,
+		Step:       step,
+		Resolution: resolution,
+		Samples:    samples,
+	}, nil
+}
+
+func (h *HistoryService) Start() {
+	go func() {
+		for {
+			h.recordVmMetrics()
+			time.Sleep(vmMetricsInterval)
+		}
+	}()
+	go func() {
+		for {
+			if err := h.prune(); err != nil {
+				logger.Error("history: failed to prune old metrics:", err)
+			}
+			time.Sleep(metricsPruneInterval)
+		}
+	}()
+}
@@ -0,0 +1,265 @@
+package services
+
+import (
+	"512SvMan/db"
+	"512SvMan/info"
+	"512SvMan/protocol"
+	"512SvMan/websocket"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	infoGrpc "github.com/Maruqes/512SvMan/api/proto/info"
+	"github.com/Maruqes/512SvMan/logger"
+	"google.golang.org/grpc"
+)
+
+const (
+	hostMetricsInterval      = 5 // seconds between readings pushed to the websocket
+	hostMetricsCheckInterval = 10 * time.Second
+)
+
+type HostService struct{}
+
+// what goes out on the websocket as HostMetrics
+type HostMetricsMessage struct {
+	MachineName string                `json:"machine_name"`
+	Metrics     *infoGrpc.HostMetrics `json:"metrics"`
+}
+
+var (
+	// slaves with a metrics stream open, so Start does not open a second one
+	hostMetricsStreams   = map[string]bool{}
+	hostMetricsStreamsMu sync.Mutex
+)
+
+func hostConnection(machineName string) (*grpc.ClientConn, error) {
+	conn := protocol.GetConnectionByMachineName(machineName)
+	if conn == nil || conn.Connection == nil {
+		return nil, fmt.Errorf("no connection found for machine: %s", machineName)
+	}
+	return conn.Connection, nil
+}
+
+func (h *HostService) GetMetrics(machineName string) (*infoGrpc.HostMetrics, error) {
+	conn, err := hostConnection(machineName)
+	if err != nil {
+		return nil, err
+	}
+	metrics, err := info.GetMetrics(conn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get metrics of %s: %v", machineName, err)
+	}
+	return metrics, nil
+}
+
+func (h *HostService) GetCPUInfo(machineName string) (*infoGrpc.CpuInfo, error) {
+	conn, err := hostConnection(machineName)
+	if err != nil {
+		return nil, err
+	}
+	cpu, err := info.GetCPUInfo(conn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cpu info of %s: %v", machineName, err)
+	}
+	return cpu, nil
+}
+
+func (h *HostService) GetMemInfo(machineName string) (*infoGrpc.MemInfo, error) {
+	conn, err := hostConnection(machineName)
+	if err != nil {
+		return nil, err
+	}
+	mem, err := info.GetMemInfo(conn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get memory info of %s: %v", machineName, err)
+	}
+	return mem, nil
+}
+
+func (h *HostService) GetDiskInfo(machineName string) (*infoGrpc.DisksInfo, error) {
+	conn, err := hostConnection(machineName)
+	if err != nil {
+		return nil, err
+	}
+	disks, err := info.GetDiskInfo(conn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get disk info of %s: %v", machineName, err)
+	}
+	return disks, nil
+}
+
+func (h *HostService) GetNetworkInfo(machineName string) (*infoGrpc.NetworkInfo, error) {
+	conn, err := hostConnection(machineName)
+	if err != nil {
+		return nil, err
+	}
+	network, err := info.GetNetworkInfo(conn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get network info of %s: %v", machineName, err)
+	}
+	return network, nil
+}
+
+// GetProcesses lists the processes of the slave like htop, sortBy is cpu or memory
+func (h *HostService) GetProcesses(machineName string, limit int, sortBy string) (*infoGrpc.ProcessList, error) {
+	sortBy = strings.ToLower(strings.TrimSpace(sortBy))
+	if sortBy != "" && sortBy != "cpu" && sortBy != "memory" {
+		return nil, fmt.Errorf("sort must be cpu or memory")
+	}
+	if limit < 0 {
+		return nil, fmt.Errorf("limit can not be negative")
+	}
+
+	conn, err := hostConnection(machineName)
+	if err != nil {
+		return nil, err
+	}
+	procs, err := info.GetProcesses(conn, &infoGrpc.ProcessesRequest{Limit: int32(limit), SortBy: sortBy})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get processes of %s: %v", machineName, err)
+	}
+	return procs, nil
+}
+
+// GetCapabilities is what the slave sent the last time it connected, also kept for a slave that is gone
+func (h *HostService) GetCapabilities(machineName string) (*db.SlaveCapabilities, error) {
+	caps, err := db.GetSlaveCapabilities(machineName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get capabilities of %s: %v", machineName, err)
+	}
+	if caps == nil {
+		return nil, fmt.Errorf("%s never connected", machineName)
+	}
+	return caps, nil
+}
+
+func (h *HostService) GetAllCapabilities() ([]db.SlaveCapabilities, error) {
+	caps, err := db.GetAllSlaveCapabilities()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get slave capabilities: %v", err)
+	}
+	if caps == nil {
+		caps = []db.SlaveCapabilities{}
+	}
+	return caps, nil
+}
+
+// GetServices lists the systemd units of the slave, manageable ones can be started and stopped
+func (h *HostService) GetServices(machineName string) (*infoGrpc.ServiceList, error) {
+	conn, err := hostConnection(machineName)
+	if err != nil {
+		return nil, err
+	}
+	services, err := info.GetServices(conn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list services of %s: %v", machineName, err)
+	}
+	return services, nil
+}
+
+func (h *HostService) GetServiceStatus(machineName, service string) (*infoGrpc.Service, error) {
+	conn, err := hostConnection(machineName)
+	if err != nil {
+		return nil, err
+	}
+	status, err := info.GetServiceStatus(conn, &infoGrpc.ServiceRequest{Name: service})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get status of %s on %s: %v", service, machineName, err)
+	}
+	return status, nil
+}
+
+func (h *HostService) GetServiceLogs(machineName, service string, lines int) (string, error) {
+	if lines < 0 {
+		return "", fmt.Errorf("lines can not be negative")
+	}
+	conn, err := hostConnection(machineName)
+	if err != nil {
+		return "", err
+	}
+	logs, err := info.GetServiceLogs(conn, &infoGrpc.ServiceLogsRequest{Name: service, Lines: int32(lines)})
+	if err != nil {
+		return "", fmt.Errorf("failed to get logs of %s on %s: %v", service, machineName, err)
+	}
+	return logs.Logs, nil
+}
+
+// ServiceAction starts/stops/restarts/enables/disables a unit, the slave refuses units outside its allow-list
+func (h *HostService) ServiceAction(machineName, service, action string) error {
+	switch action {
+	case "start", "stop", "restart", "enable", "disable":
+	default:
+		return fmt.Errorf("unknown action %s, must be start, stop, restart, enable or disable", action)
+	}
+	conn, err := hostConnection(machineName)
+	if err != nil {
+		return err
+	}
+
+	logger.Info("service", action, service, "on", machineName)
+	_, err = info.ServiceAction(conn, &infoGrpc.ServiceActionRequest{Name: service, Action: action})
+	if err != nil {
+		return fmt.Errorf("failed to %s %s on %s: %v", action, service, machineName, err)
+	}
+	return nil
+}
+
+// streamHostMetrics relays the readings of one slave to the websocket and the history until the stream breaks
+func (h *HostService) streamHostMetrics(machineName string, conn *grpc.ClientConn) {
+	defer func() {
+		hostMetricsStreamsMu.Lock()
+		delete(hostMetricsStreams, machineName)
+		hostMetricsStreamsMu.Unlock()
+	}()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream, err := info.StreamMetrics(ctx, conn, hostMetricsInterval)
+	if err != nil {
+		logger.Error("host metrics: failed to open stream to", machineName, ":", err)
+		return
+	}
+	for {
+		metrics, err := stream.Recv()
+		if err != nil {
+			logger.Error("host metrics: stream of", machineName, "ended:", err)
+			return
+		}
+		recordHostMetrics(machineName, metrics)
+
+		data, err := json.Marshal(HostMetricsMessage{MachineName: machineName, Metrics: metrics})
+		if err != nil {
+			logger.Error("host metrics: failed to encode reading of", machineName, ":", err)
+			continue
+		}
+		websocket.BroadcastMessage(websocket.Message{Type: "HostMetrics", Data: string(data)})
+	}
+}
+
+// Start keeps a metrics stream open to every connected slave, slaves that come back get a new one on the next check
+func (h *HostService) Start() {
+	go func() {
+		for {
+			for _, c := range protocol.GetConnectionsSnapshot() {
+				if c.Connection == nil {
+					continue
+				}
+				hostMetricsStreamsMu.Lock()
+				open := hostMetricsStreams[c.MachineName]
+				if !open {
+					hostMetricsStreams[c.MachineName] = true
+				}
+				hostMetricsStreamsMu.Unlock()
+				if !open {
+					go h.streamHostMetrics(c.MachineName, c.Connection)
+				}
+			}
+			time.Sleep(hostMetricsCheckInterval)
+		}
+	}()
+}
@@ -0,0 +1,229 @@
+package services
+
+import (
+	"512SvMan/db"
+	"512SvMan/protocol"
+	"512SvMan/virsh"
+	"fmt"
+	"sync"
+	"time"
+
+	grpcVirsh "github.com/Maruqes/512SvMan/api/proto/virsh"
+	"github.com/Maruqes/512SvMan/logger"
+	"google.golang.org/grpc"
+)
+
+// how long a vm that cannot be live migrated gets to shut down before it is left behind
+const maintenanceShutdownTimeout = 3 * time.Minute
+
+type MaintenanceService struct{}
+
+type MaintenanceStatus struct {
+	MachineName string            `json:"machine_name"`
+	Since       int64             `json:"since"`
+	Evacuating  bool              `json:"evacuating"`
+	Moved       []string          `json:"moved"`
+	Failed      map[string]string `json:"failed"` // vm -> why it stayed
+}
+
+var (
+	evacuationsMu sync.Mutex
+	evacuations   = map[string]*MaintenanceStatus{}
+)
+
+// ensureNotInMaintenance refuses a slave in maintenance as the target of a vm
+func ensureNotInMaintenance(machineName string) error {
+	inMaintenance, err := db.IsSlaveInMaintenance(machineName)
+	if err != nil {
+		return fmt.Errorf("failed to check maintenance of %s: %v", machineName, err)
+	}
+	if inMaintenance {
+		return fmt.Errorf("machine %s is in maintenance", machineName)
+	}
+	return nil
+}
+
+// EnterMaintenance stops new vms from landing on the slave and moves the ones it has to
+// the other slaves in the background, GetStatus shows the progress
+func (m *MaintenanceService) EnterMaintenance(machineName string) error {
+	slave := protocol.GetConnectionByMachineName(machineName)
+	if slave == nil {
+		return fmt.Errorf("machine %s not found", machineName)
+	}
+
+	evacuationsMu.Lock()
+	defer evacuationsMu.Unlock()
+	if status, ok := evacuations[machineName]; ok && status.Evacuating {
+		return fmt.Errorf("machine %s is already being evacuated", machineName)
+	}
+
+	if err := db.AddSlaveMaintenance(machineName); err != nil {
+		return fmt.Errorf("failed to save maintenance: %v", err)
+	}
+	status := &MaintenanceStatus{
+		MachineName: machineName,
+		Since:       time.Now().Unix(),
+		Evacuating:  true,
+		Failed:      map[string]string{},
+	}
+	evacuations[machineName] = status
+
+	go m.evacuate(slave.Connection, status)
+	return nil
+}
+
+// ExitMaintenance makes the slave a target again, an evacuation still running stops after the current vm
+func (m *MaintenanceService) ExitMaintenance(machineName string) error {
+	if err := db.RemoveSlaveMaintenance(machineName); err != nil {
+		return fmt.Errorf("failed to remove maintenance: %v", err)
+	}
+	return nil
+}
+
+func (m *MaintenanceService) GetStatus() ([]MaintenanceStatus, error) {
+	rows, err := db.GetAllSlaveMaintenance()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get maintenance: %v", err)
+	}
+
+	evacuationsMu.Lock()
+	defer evacuationsMu.Unlock()
+	res := make([]MaintenanceStatus, 0, len(rows))
+	for _, row := range rows {
+		status := MaintenanceStatus{MachineName: row.MachineName, Since: row.Since, Failed: map[string]string{}}
+		if ev, ok := evacuations[row.MachineName]; ok {
+			status.Evacuating = ev.Evacuating
+			status.Moved = append([]string(nil), ev.Moved...)
+			for vm, reason := range ev.Failed {
+				status.Failed[vm] = reason
+			}
+		}
+		res = append(res, status)
+	}
+	return res, nil
+}
+
+func (m *MaintenanceService) evacuate(conn *grpc.ClientConn, status *MaintenanceStatus) {
+	machineName := status.MachineName
+	defer func() {
+		evacuationsMu.Lock()
+		status.Evacuating = false
+		evacuationsMu.Unlock()
+		logger.Info("maintenance: evacuation of", machineName, "finished, moved", len(status.Moved), "failed", len(status.Failed))
+	}()
+
+	vms, err := virsh.GetAllVms(conn, &grpcVirsh.Empty{})
+	if err != nil {
+		logger.Error("maintenance: failed to get VMs from", machineName, ":", err)
+		evacuationsMu.Lock()
+		status.Failed["*"] = err.Error()
+		evacuationsMu.Unlock()
+		return
+	}
+
+	for _, vm := range vms.Vms {
+		if err := ensureNotInMaintenance(machineName); err == nil {
+			logger.Info("maintenance: left by", machineName, ", evacuation stopped")
+			return
+		}
+
+		err := m.evacuateVm(conn, machineName, vm)
+		evacuationsMu.Lock()
+		if err != nil {
+			status.Failed[vm.Name] = err.Error()
+		} else {
+			status.Moved = append(status.Moved, vm.Name)
+		}
+		evacuationsMu.Unlock()
+		if err != nil {
+			logger.Error("maintenance: VM", vm.Name, "not moved from", machineName, ":", err)
+		}
+	}
+}
+
+// evacuationTarget asks the scheduler where a vm of a slave in maintenance goes, the slave itself is
+// rejected by the maintenance scorer and the share the disk is on has to be mounted on the target
+func evacuationTarget(v *VirshService, machineName string, vm *grpcVirsh.Vm) (string, error) {
+	share, err := vmShare(vm.DiskPath)
+	if err != nil {
+		return "", err
+	}
+	decision, err := v.PlaceVM(PlacementRequest{
+		VmName:      vm.Name,
+		MemoryMB:    vm.MemoryMB,
+		VCPUs:       vm.CpuCount,
+		NfsShare:    share,
+		FromMachine: machineName,
+	})
+	if err != nil {
+		return "", err
+	}
+	return decision.MachineName, nil
+}
+
+// evacuateVm live migrates a live capable vm, any other running vm is shut down, moved and started again
+func (m *MaintenanceService) evacuateVm(conn *grpc.ClientConn, machineName string, vm *grpcVirsh.Vm) error {
+	v := VirshService{}
+	dest, err := evacuationTarget(&v, machineName, vm)
+	if err != nil {
+		return err
+	}
+
+	isLive, err := db.DoesVmLiveExist(vm.Name)
+	if err != nil {
+		return fmt.Errorf("failed to check if live VM exists in database: %v", err)
+	}
+
+	if !isVmActive(vm) {
+		return v.migrateVm(machineName, dest, vm.Name, false, MigrationLimits{})
+	}
+	if isLive {
+		if err := v.migrateVm(machineName, dest, vm.Name, true, MigrationLimits{}); err != nil {
+			return err
+		}
+		logger.Info("maintenance: VM", vm.Name, "live migrated to", dest)
+		return nil
+	}
+
+	if err := virsh.ShutdownVM(conn, vm); err != nil {
+		return fmt.Errorf("failed to shut down: %v", err)
+	}
+	if err := waitVmShutOff(conn, vm.Name, maintenanceShutdownTimeout); err != nil {
+		return err
+	}
+	if err := v.migrateVm(machineName, dest, vm.Name, false, MigrationLimits{}); err != nil {
+		// it was running when the evacuation got to it, it stays running where it was
+		if startErr := virsh.StartVm(conn, vm); startErr != nil {
+			return fmt.Errorf("%v, and failed to start it again on %s: %v", err, machineName, startErr)
+		}
+		logger.Info("maintenance: VM", vm.Name, "not moved, started again on", machineName)
+		return fmt.Errorf("%v, VM started again on %s", err, machineName)
+	}
+
+	destConn := protocol.GetConnectionByMachineName(dest)
+	if destConn == nil {
+		return fmt.Errorf("moved to %s but the machine is gone, VM left shut off", dest)
+	}
+	if err := virsh.StartVm(destConn.Connection, vm); err != nil {
+		return fmt.Errorf("moved to %s but failed to start: %v", dest, err)
+	}
+	logger.Info("maintenance: VM", vm.Name, "restarted on", dest)
+	return nil
+}
+
+func waitVmShutOff(conn *grpc.ClientConn, vmName string, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		vm, err := virsh.GetVmByName(conn, &grpcVirsh.GetVmByNameRequest{Name: vmName})
+		if err != nil {
+			return fmt.Errorf("failed to get VM state: %v", err)
+		}
+		if vm.State == grpcVirsh.VmState_SHUTOFF {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("VM did not shut down within %s", timeout)
+		}
+		time.Sleep(2 * time.Second)
+	}
+}
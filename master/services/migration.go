@@ -0,0 +1,103 @@
+package services
+
+import (
+	"512SvMan/protocol"
+	"512SvMan/virsh"
+	"fmt"
+	"sync"
+
+	grpcVirsh "github.com/Maruqes/512SvMan/api/proto/virsh"
+	"github.com/Maruqes/512SvMan/api/version"
+)
+
+// MigrationLimits caps a live migration, zero values leave the hypervisor defaults
+type MigrationLimits struct {
+	BandwidthMiB  uint64 `json:"bandwidth_mib"`   // MiB/s
+	MaxDowntimeMs uint64 `json:"max_downtime_ms"` // pause allowed at the switch over
+}
+
+var (
+	// vms being migrated and the slave they leave from, the progress goes through the websocket
+	migrationsMu sync.Mutex
+	migrations   = map[string]string{}
+)
+
+// startMigration marks the vm as migrating, the returned func clears it
+func startMigration(vmName, originMachine string) (func(), error) {
+	migrationsMu.Lock()
+	defer migrationsMu.Unlock()
+	if origin, ok := migrations[vmName]; ok {
+		return nil, fmt.Errorf("VM %s is already being migrated from %s", vmName, origin)
+	}
+	migrations[vmName] = originMachine
+	return func() {
+		migrationsMu.Lock()
+		delete(migrations, vmName)
+		migrationsMu.Unlock()
+	}, nil
+}
+
+func migrationOrigin(vmName string) (*protocol.ConnectionsStruct, error) {
+	migrationsMu.Lock()
+	origin, ok := migrations[vmName]
+	migrationsMu.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("VM %s is not being migrated", vmName)
+	}
+	conn := protocol.GetConnectionByMachineName(origin)
+	if conn == nil {
+		return nil, fmt.Errorf("origin machine %s not found", origin)
+	}
+	return conn, nil
+}
+
+// checkMigrationCompat refuses from what the slaves sent on connect a live migration libvirt would only fail
+// half way through, a shut off vm just has its definition moved and is not checked
+func checkMigrationCompat(origin, dest *protocol.ConnectionsStruct, live bool) error {
+	from, to := origin.Capabilities, dest.Capabilities
+	if !live || from == nil || to == nil {
+		return nil
+	}
+	if !protocol.HasFeature(to, version.FeatureLiveMigration) {
+		return fmt.Errorf("%s does not support live migration, upgrade it", dest.MachineName)
+	}
+	if from.CpuArch != to.CpuArch {
+		return fmt.Errorf("cpu arch of %s (%s) is not the one of %s (%s)", dest.MachineName, to.CpuArch, origin.MachineName, from.CpuArch)
+	}
+	if from.CpuVendor != to.CpuVendor {
+		return fmt.Errorf("cpu vendor of %s (%s) is not the one of %s (%s), live migration between them does not work", dest.MachineName, to.CpuVendor, origin.MachineName, from.CpuVendor)
+	}
+	if protocol.CompareVersions(to.QemuVersion, from.QemuVersion) < 0 {
+		return fmt.Errorf("qemu %s on %s is older than qemu %s on %s, a running vm can not move to an older qemu", to.QemuVersion, dest.MachineName, from.QemuVersion, origin.MachineName)
+	}
+	return nil
+}
+
+// CancelMigration aborts the running migration of the vm, it keeps running on the origin
+func (v *VirshService) CancelMigration(vmName string) error {
+	origin, err := migrationOrigin(vmName)
+	if err != nil {
+		return err
+	}
+	if err := virsh.CancelMigration(origin.Connection, vmName); err != nil {
+		return fmt.Errorf("failed to cancel migration of VM %s: %v", vmName, err)
+	}
+	return nil
+}
+
+// SetMigrationLimits changes the limits of the running migration of the vm
+func (v *VirshService) SetMigrationLimits(vmName string, limits MigrationLimits) error {
+	origin, err := migrationOrigin(vmName)
+	if err != nil {
+		return err
+	}
+	err = virsh.SetMigrationLimits(origin.Connection, &grpcVirsh.MigrateVmRequest{
+		Name:          vmName,
+		BandwidthMiB:  limits.BandwidthMiB,
+		MaxDowntimeMs: limits.MaxDowntimeMs,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set migration limits of VM %s: %v", vmName, err)
+	}
+	return nil
+}
@@ -0,0 +1,241 @@
+package services
+
+import (
+	"512SvMan/db"
+	"512SvMan/network"
+	"512SvMan/protocol"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	proto "github.com/Maruqes/512SvMan/api/proto/network"
+	"github.com/Maruqes/512SvMan/logger"
+	"google.golang.org/grpc"
+)
+
+// libvirt ships this one on every host, vms can use it without it being in the catalog
+const defaultNetworkName = "default"
+
+type NetworkService struct{}
+
+type NetworkStatus struct {
+	Network  db.Network
+	Machines map[string]*proto.NetworkStatus // nil when the network is missing on that slave
+}
+
+func networkToGRPC(n db.Network) *proto.Network {
+	return &proto.Network{
+		Name:          n.Name,
+		Mode:          n.Mode,
+		Bridge:        n.Bridge,
+		HostInterface: n.HostInterface,
+		IpAddress:     n.IPAddress,
+		Netmask:       n.Netmask,
+		DhcpStart:     n.DHCPStart,
+		DhcpEnd:       n.DHCPEnd,
+		Autostart:     n.Autostart,
+	}
+}
+
+func networkListToGRPC() (*proto.NetworkList, error) {
+	networks, err := db.GetAllNetworks()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get networks: %v", err)
+	}
+	list := &proto.NetworkList{Networks: make([]*proto.Network, 0, len(networks))}
+	for _, n := range networks {
+		list.Networks = append(list.Networks, networkToGRPC(n))
+	}
+	return list, nil
+}
+
+// CreateNetwork defines the network on every connected slave before saving it, if one slave
+// refuses (bad config, missing host nic) it is removed again from the others
+func (s *NetworkService) CreateNetwork(n db.Network) error {
+	if n.Name == defaultNetworkName {
+		return fmt.Errorf("network %s already exists on every slave", defaultNetworkName)
+	}
+	if _, err := db.GetNetworkByName(n.Name); err == nil {
+		return fmt.Errorf("network %s already exists", n.Name)
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("failed to check if network exists: %v", err)
+	}
+
+	conns := protocol.GetAllGRPCConnections()
+	machineNames := protocol.GetAllMachineNames()
+	if len(conns) != len(machineNames) {
+		return fmt.Errorf("length of connections and machine names must be the same")
+	}
+
+	var defined []*grpc.ClientConn
+	for i, conn := range conns {
+		if conn == nil {
+			continue
+		}
+		if err := network.DefineNetwork(conn, networkToGRPC(n)); err != nil {
+			for _, c := range defined {
+				if rmErr := network.RemoveNetwork(c, n.Name); rmErr != nil {
+					logger.Error("RemoveNetwork rollback failed:", rmErr)
+				}
+			}
+			return fmt.Errorf("failed to define network %s on %s: %v", n.Name, machineNames[i], err)
+		}
+		defined = append(defined, conn)
+	}
+
+	if err := db.AddNetwork(n); err != nil {
+		return fmt.Errorf("failed to add network to database: %v", err)
+	}
+	return nil
+}
+
+func (s *NetworkService) RemoveNetwork(name string) error {
+	if _, err := db.GetNetworkByName(name); errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("network %s not found", name)
+	} else if err != nil {
+		return fmt.Errorf("failed to get network: %v", err)
+	}
+
+	virshService := VirshService{}
+	vms, err := virshService.GetAllVms()
+	if err != nil {
+		return fmt.Errorf("failed to get VMs: %v", err)
+	}
+	users := []string{}
+	for _, vm := range vms {
+		for _, nic := range vm.Nics {
+			if nic.Network == name {
+				users = append(users, vm.Name)
+				break
+			}
+		}
+	}
+	if len(users) > 0 {
+		return fmt.Errorf("cannot remove network %s, there are VMs using it: %v", name, users)
+	}
+
+	conns := protocol.GetAllGRPCConnections()
+	machineNames := protocol.GetAllMachineNames()
+	if len(conns) != len(machineNames) {
+		return fmt.Errorf("length of connections and machine names must be the same")
+	}
+
+	for i, conn := range conns {
+		if conn == nil {
+			continue
+		}
+		if err := network.RemoveNetwork(conn, name); err != nil {
+			// slaves that already removed it get it back
+			if syncErr := s.SyncNetworks(); syncErr != nil {
+				logger.Error("SyncNetworks after failed remove:", syncErr)
+			}
+			return fmt.Errorf("failed to remove network %s on %s: %v", name, machineNames[i], err)
+		}
+	}
+
+	if err := db.RemoveNetwork(name); err != nil {
+		return fmt.Errorf("failed to remove network from database: %v", err)
+	}
+	return nil
+}
+
+// SyncNetworksOn pushes the whole catalog to one slave
+func (s *NetworkService) SyncNetworksOn(conn *grpc.ClientConn) error {
+	list, err := networkListToGRPC()
+	if err != nil {
+		return err
+	}
+	return network.SyncNetworks(conn, list)
+}
+
+// make sure every connected slave has exactly the networks of the catalog
+func (s *NetworkService) SyncNetworks() error {
+	list, err := networkListToGRPC()
+	if err != nil {
+		return err
+	}
+
+	conns := protocol.GetAllGRPCConnections()
+	machineNames := protocol.GetAllMachineNames()
+	if len(conns) != len(machineNames) {
+		return fmt.Errorf("length of connections and machine names must be the same")
+	}
+
+	failed := []string{}
+	for i, conn := range conns {
+		if conn == nil {
+			continue
+		}
+		if err := network.SyncNetworks(conn, list); err != nil {
+			logger.Error("SyncNetworks failed on", machineNames[i], ":", err)
+			failed = append(failed, machineNames[i])
+		}
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to sync networks on slaves: %v", failed)
+	}
+	return nil
+}
+
+func (s *NetworkService) GetAllNetworks() ([]NetworkStatus, error) {
+	networks, err := db.GetAllNetworks()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get networks: %v", err)
+	}
+
+	res := make([]NetworkStatus, len(networks))
+	for i := range networks {
+		res[i] = NetworkStatus{
+			Network:  networks[i],
+			Machines: make(map[string]*proto.NetworkStatus),
+		}
+	}
+
+	conns := protocol.GetAllGRPCConnections()
+	machineNames := protocol.GetAllMachineNames()
+	if len(conns) != len(machineNames) {
+		return nil, fmt.Errorf("length of connections and machine names must be the same")
+	}
+
+	for i, conn := range conns {
+		if conn == nil {
+			continue
+		}
+		onSlave, err := network.ListNetworks(conn)
+		if err != nil {
+			logger.Error("ListNetworks failed for machine %s: %v", machineNames[i], err)
+			continue
+		}
+		byName := make(map[string]*proto.NetworkStatus, len(onSlave))
+		for _, status := range onSlave {
+			byName[status.Name] = status
+		}
+		for j := range res {
+			res[j].Machines[machineNames[i]] = byName[res[j].Network.Name]
+		}
+	}
+	return res, nil
+}
+
+// ensureNetworkOnSlave checks the vm network is in the catalog and defined on the slave that runs it
+func ensureNetworkOnSlave(conn *grpc.ClientConn, name string) error {
+	if name == "" {
+		return fmt.Errorf("network is required")
+	}
+	if name == defaultNetworkName {
+		return nil
+	}
+
+	n, err := db.GetNetworkByName(name)
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("network %s not found, create it under /networks first", name)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get network: %v", err)
+	}
+	if err := network.DefineNetwork(conn, networkToGRPC(*n)); err != nil {
+		return fmt.Errorf("failed to define network %s on slave: %v", name, err)
+	}
+	return nil
+}
@@ -123,7 +123,7 @@ func (s *NFSService) DeleteSharePoint() error {
 		for _, vm := range vms {
 			vmsNames = append(vmsNames, vm.Name)
 		}
-		return fmt.Errorf("cannot delete NFS share, there are VMs using it: %v", vmsNames)
+		return fmt.Errorf("cannot delete NFS share, there are VMs using it (move their storage first): %v", vmsNames)
 	}
 
 	//check if any iso is on this nfs share
@@ -137,6 +137,40 @@ func (s *NFSService) DeleteSharePoint() error {
 		}
 	}
 
+	//templates are backing files of vms, they have to go first too
+	templates, err := db.GetAllVmTemplates()
+	if err != nil {
+		return fmt.Errorf("failed to get templates: %v", err)
+	}
+	for _, template := range templates {
+		if strings.HasPrefix(template.DiskPath, mount.Target) {
+			return fmt.Errorf("cannot delete NFS share, there are templates using it: %v", template.Name)
+		}
+	}
+
+	//the backup catalog would point to files that are gone
+	backups, err := db.GetVmBackups("")
+	if err != nil {
+		return fmt.Errorf("failed to get backups: %v", err)
+	}
+	for _, backup := range backups {
+		if strings.HasPrefix(backup.XmlPath, mount.Target) {
+			return fmt.Errorf("cannot delete NFS share, there are backups of VM %s on it", backup.VmName)
+		}
+	}
+
+	trashed, err := db.GetAllTrashedVms()
+	if err != nil {
+		return fmt.Errorf("failed to get trashed VMs: %v", err)
+	}
+	for _, vm := range trashed {
+		for _, file := range vm.Files {
+			if strings.HasPrefix(file.Trashed, mount.Target) {
+				return fmt.Errorf("cannot delete NFS share, trashed VM %s has files on it (purge it first)", vm.VmName)
+			}
+		}
+	}
+
 	if err := nfs.RemoveSharedFolder(conn.Connection, mount); err != nil {
 		return fmt.Errorf("failed to remove shared folder: %v", err)
 	}
@@ -320,10 +354,13 @@ func (s *NFSService) DownloadISO(ctx context.Context, url, isoName string, nfsSh
 		},
 	}
 
+	isoDownloadStarted()
 	if err := nfs.DownloadISO(conn.Connection, ctx, isoRequest); err != nil {
+		isoDownloadFinished(false)
 		logger.Error("DownloadISO failed: %v", err)
 		return "", err
 	}
+	isoDownloadFinished(true)
 	return isoPath, nil
 }
 
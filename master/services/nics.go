@@ -0,0 +1,118 @@
+package services
+
+import (
+	"512SvMan/virsh"
+	"fmt"
+	"net"
+	"strings"
+
+	grpcVirsh "github.com/Maruqes/512SvMan/api/proto/virsh"
+	"google.golang.org/grpc"
+)
+
+type NicConfig struct {
+	Network string `json:"network"`
+	Model   string `json:"model"` // virtio when empty
+	MAC     string `json:"mac"`   // random when empty
+}
+
+func normalizeMAC(mac string) (string, error) {
+	hw, err := net.ParseMAC(strings.TrimSpace(mac))
+	if err != nil || len(hw) != 6 {
+		return "", fmt.Errorf("invalid mac address %q", mac)
+	}
+	return hw.String(), nil
+}
+
+// ensureMacsUnused refuses macs that some nic of the cluster already has, the slaves only see their own vms
+func (v *VirshService) ensureMacsUnused(macs []string) error {
+	if len(macs) == 0 {
+		return nil
+	}
+	vms, err := v.GetAllVms()
+	if err != nil {
+		return fmt.Errorf("failed to get VMs: %v", err)
+	}
+	for _, vm := range vms {
+		for _, nic := range vm.Nics {
+			for _, mac := range macs {
+				if strings.EqualFold(nic.Mac, mac) {
+					return fmt.Errorf("mac address %s is already used by VM %s", mac, vm.Name)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// resolveNics checks the nic networks against the catalog and defines them on the slave,
+// fixed macs must be unique in the cluster
+func (v *VirshService) resolveNics(conn *grpc.ClientConn, network string, nics []NicConfig) ([]*grpcVirsh.VmNic, error) {
+	if len(nics) == 0 {
+		return nil, ensureNetworkOnSlave(conn, network)
+	}
+
+	res := make([]*grpcVirsh.VmNic, 0, len(nics))
+	var macs []string
+	for _, nic := range nics {
+		if err := ensureNetworkOnSlave(conn, nic.Network); err != nil {
+			return nil, err
+		}
+		if strings.TrimSpace(nic.MAC) != "" {
+			mac, err := normalizeMAC(nic.MAC)
+			if err != nil {
+				return nil, err
+			}
+			for _, seen := range macs {
+				if seen == mac {
+					return nil, fmt.Errorf("mac address %s used twice", mac)
+				}
+			}
+			macs = append(macs, mac)
+			nic.MAC = mac
+		}
+		res = append(res, &grpcVirsh.VmNic{Mac: nic.MAC, Network: nic.Network, Model: nic.Model})
+	}
+
+	if err := v.ensureMacsUnused(macs); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// AttachNic adds a nic to the vm, hot plugged when it is running
+func (v *VirshService) AttachNic(vmName string, nic NicConfig) (*grpcVirsh.VmNic, error) {
+	conn, _, err := findVmConnection(vmName)
+	if err != nil {
+		return nil, err
+	}
+
+	grpcNics, err := v.resolveNics(conn, "", []NicConfig{nic})
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := virsh.AttachNic(conn, &grpcVirsh.VmNicRequest{VmName: vmName, Nic: grpcNics[0]})
+	if err != nil {
+		return nil, fmt.Errorf("failed to attach nic to VM %s: %v", vmName, err)
+	}
+	return res, nil
+}
+
+func (v *VirshService) DetachNic(vmName string, mac string) error {
+	conn, _, err := findVmConnection(vmName)
+	if err != nil {
+		return err
+	}
+
+	mac, err = normalizeMAC(mac)
+	if err != nil {
+		return err
+	}
+
+	err = virsh.DetachNic(conn, &grpcVirsh.VmNicRequest{VmName: vmName, Nic: &grpcVirsh.VmNic{Mac: mac}})
+	if err != nil {
+		return fmt.Errorf("failed to detach nic %s from VM %s: %v", mac, vmName, err)
+	}
+	return nil
+}
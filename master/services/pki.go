@@ -0,0 +1,81 @@
+package services
+
+import (
+	"512SvMan/db"
+	"512SvMan/pki"
+	"512SvMan/protocol"
+	"fmt"
+	"time"
+
+	"github.com/Maruqes/512SvMan/logger"
+)
+
+const (
+	enrollmentTokenDefaultTTL = time.Hour
+	enrollmentTokenMaxTTL     = 7 * 24 * time.Hour
+)
+
+type PKIService struct{}
+
+// CreateEnrollmentToken returns the ENROLL_TOKEN for a new slave, bound to machineName unless it is empty
+func (p *PKIService) CreateEnrollmentToken(machineName string, ttl time.Duration) (string, *db.EnrollmentToken, error) {
+	if ttl <= 0 {
+		ttl = enrollmentTokenDefaultTTL
+	}
+	if ttl > enrollmentTokenMaxTTL {
+		return "", nil, fmt.Errorf("ttl can not be longer than %s", enrollmentTokenMaxTTL)
+	}
+	token, tok, err := pki.NewEnrollmentToken(machineName, ttl)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to create enrollment token: %v", err)
+	}
+	return token, tok, nil
+}
+
+func (p *PKIService) GetEnrollmentTokens() ([]db.EnrollmentToken, error) {
+	tokens, err := db.GetEnrollmentTokens()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get enrollment tokens: %v", err)
+	}
+	if tokens == nil {
+		tokens = []db.EnrollmentToken{}
+	}
+	return tokens, nil
+}
+
+func (p *PKIService) RemoveEnrollmentToken(id int) error {
+	if err := db.RemoveEnrollmentToken(id); err != nil {
+		return fmt.Errorf("failed to remove enrollment token: %v", err)
+	}
+	return nil
+}
+
+func (p *PKIService) GetSlaveCerts() ([]db.SlaveCert, error) {
+	certs, err := db.GetSlaveCerts(time.Now().Unix())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get slave certificates: %v", err)
+	}
+	if certs == nil {
+		certs = []db.SlaveCert{}
+	}
+	return certs, nil
+}
+
+// RevokeSlave refuses every certificate of the slave from now on and drops its connection,
+// its vms keep running but it has to enroll again before the master talks to it
+func (p *PKIService) RevokeSlave(machineName string) error {
+	n, err := db.RevokeSlaveCerts(machineName)
+	if err != nil {
+		return fmt.Errorf("failed to revoke certificates of %s: %v", machineName, err)
+	}
+	if n == 0 {
+		return fmt.Errorf("no certificates to revoke for %s", machineName)
+	}
+	protocol.DisconnectSlave(machineName)
+	logger.Warn("revoked", n, "certificates of", machineName)
+	return nil
+}
+
+func (p *PKIService) GetCA() ([]byte, string) {
+	return pki.CAPEM(), pki.CAFingerprint()
+}
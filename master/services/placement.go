@@ -0,0 +1,478 @@
+package services
+
+import (
+	"512SvMan/db"
+	"512SvMan/nfs"
+	"512SvMan/protocol"
+	"512SvMan/virsh"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	nfsproto "github.com/Maruqes/512SvMan/api/proto/nfs"
+	protocolGrpc "github.com/Maruqes/512SvMan/api/proto/protocol"
+	grpcVirsh "github.com/Maruqes/512SvMan/api/proto/virsh"
+)
+
+// machine name that lets the scheduler pick the slave, an empty machine name does the same
+const PlacementAuto = "auto"
+
+const (
+	maxVcpuOvercommit = 4.0 // vcpus of running vms per host cpu
+	minHostFreeMB     = 512 // memory left for the host after the vm starts
+)
+
+// PlacementConfig are the rules a new vm is created with, they are saved and hold both ways and are
+// checked again whenever the vm moves (migration, maintenance, ha restart)
+type PlacementConfig struct {
+	Affinity     []string `json:"affinity"`      // vms the new vm has to run with
+	AntiAffinity []string `json:"anti_affinity"` // vms the new vm must not run with
+}
+
+type PlacementRequest struct {
+	VmName       string
+	MemoryMB     int32
+	VCPUs        int32
+	DiskSizeGB   int32
+	NfsShare     *db.NFSShare
+	Affinity     []string
+	AntiAffinity []string
+	VmHosts      map[string]string // every vm of the cluster -> slave it is defined on
+	FromMachine  string            // slave the vm leaves when it is moved, empty for a new vm
+	OldestQemu   string            // lowest qemu version of the slaves being scored
+}
+
+// PlacementHost is what the scorers know about one slave
+type PlacementHost struct {
+	MachineName string
+	Resources   *grpcVirsh.HostResources
+	Vms         []*grpcVirsh.Vm
+	Share       *nfsproto.SharedFolderStatusResponse // nil when the share could not be checked
+	// what the slave sent when it connected, nil for a slave that sent nothing
+	Capabilities *protocolGrpc.SlaveCapabilities
+}
+
+type PlacementCandidate struct {
+	MachineName string   `json:"machine_name"`
+	Score       float64  `json:"score"`
+	Rejected    string   `json:"rejected,omitempty"`
+	Reasons     []string `json:"reasons"`
+}
+
+type PlacementDecision struct {
+	MachineName string               `json:"machine_name"`
+	Candidates  []PlacementCandidate `json:"candidates"` // best first, rejected slaves last
+}
+
+// PlacementScorer looks at one slave for a vm, it returns the points the slave gets and why.
+// an error rejects the slave
+type PlacementScorer interface {
+	Name() string
+	Score(req PlacementRequest, host PlacementHost) (float64, string, error)
+}
+
+var (
+	placementScorersMu sync.RWMutex
+	placementScorers   = []PlacementScorer{
+		maintenanceScorer{},
+		shareScorer{},
+		affinityScorer{},
+		memoryScorer{},
+		vcpuScorer{},
+		cpuLoadScorer{},
+		qemuScorer{},
+	}
+)
+
+// RegisterPlacementScorer adds a scorer after the built in ones
+func RegisterPlacementScorer(s PlacementScorer) {
+	placementScorersMu.Lock()
+	defer placementScorersMu.Unlock()
+	placementScorers = append(placementScorers, s)
+}
+
+type maintenanceScorer struct{}
+
+func (maintenanceScorer) Name() string { return "maintenance" }
+
+func (maintenanceScorer) Score(req PlacementRequest, host PlacementHost) (float64, string, error) {
+	if err := ensureNotInMaintenance(host.MachineName); err != nil {
+		return 0, "", err
+	}
+	return 0, "not in maintenance", nil
+}
+
+type shareScorer struct{}
+
+func (shareScorer) Name() string { return "nfs" }
+
+func (shareScorer) Score(req PlacementRequest, host PlacementHost) (float64, string, error) {
+	if req.NfsShare == nil {
+		return 0, "no share needed", nil
+	}
+	if host.Share == nil || !host.Share.Working {
+		return 0, "", fmt.Errorf("share %s is not mounted", req.NfsShare.Target)
+	}
+	if int64(req.DiskSizeGB) > host.Share.SpaceFreeGB {
+		return 0, "", fmt.Errorf("share %s has %d GB free, %d GB needed", req.NfsShare.Target, host.Share.SpaceFreeGB, req.DiskSizeGB)
+	}
+	return 0, fmt.Sprintf("share %s mounted, %d GB free", req.NfsShare.Target, host.Share.SpaceFreeGB), nil
+}
+
+type memoryScorer struct{}
+
+func (memoryScorer) Name() string { return "memory" }
+
+// up to 40 points, the more memory left after the vm the better
+func (memoryScorer) Score(req PlacementRequest, host PlacementHost) (float64, string, error) {
+	res := host.Resources
+	left := res.MemoryFreeMB - int64(req.MemoryMB)
+	if left < minHostFreeMB {
+		return 0, "", fmt.Errorf("%d MB free, %d MB needed", res.MemoryFreeMB, int64(req.MemoryMB)+minHostFreeMB)
+	}
+	if res.MemoryTotalMB <= 0 {
+		return 0, fmt.Sprintf("%d MB free after placement", left), nil
+	}
+	return 40 * float64(left) / float64(res.MemoryTotalMB), fmt.Sprintf("%d of %d MB free after placement", left, res.MemoryTotalMB), nil
+}
+
+type vcpuScorer struct{}
+
+func (vcpuScorer) Name() string { return "vcpu" }
+
+// up to 30 points, the lower the vcpu overcommit after the vm the better
+func (vcpuScorer) Score(req PlacementRequest, host PlacementHost) (float64, string, error) {
+	res := host.Resources
+	if res.Cpus <= 0 {
+		return 0, "", fmt.Errorf("host reported no cpus")
+	}
+	ratio := float64(res.AllocatedVcpus+req.VCPUs) / float64(res.Cpus)
+	if ratio > maxVcpuOvercommit {
+		return 0, "", fmt.Errorf("overcommit would be %.2f, max %.2f", ratio, maxVcpuOvercommit)
+	}
+	return 30 * (1 - ratio/maxVcpuOvercommit), fmt.Sprintf("%d vcpus on %d cpus after placement (%.2fx)", res.AllocatedVcpus+req.VCPUs, res.Cpus, ratio), nil
+}
+
+type cpuLoadScorer struct{}
+
+func (cpuLoadScorer) Name() string { return "cpu load" }
+
+// up to 30 points, the idler the host the better
+func (cpuLoadScorer) Score(req PlacementRequest, host PlacementHost) (float64, string, error) {
+	load := host.Resources.CpuLoad
+	return 30 * (1 - load), fmt.Sprintf("%.0f%% busy", load*100), nil
+}
+
+type qemuScorer struct{}
+
+func (qemuScorer) Name() string { return "qemu" }
+
+// 5 points on the slaves with the oldest qemu, a vm started on a newer qemu can not live migrate back to them
+func (qemuScorer) Score(req PlacementRequest, host PlacementHost) (float64, string, error) {
+	if host.Capabilities == nil {
+		return 0, "version unknown", nil
+	}
+	version := host.Capabilities.QemuVersion
+	if req.OldestQemu == "" || protocol.CompareVersions(version, req.OldestQemu) <= 0 {
+		return 5, fmt.Sprintf("qemu %s, the vm can live migrate to every slave", version), nil
+	}
+	return 0, fmt.Sprintf("qemu %s, the vm could not live migrate to slaves on %s", version, req.OldestQemu), nil
+}
+
+type affinityScorer struct{}
+
+func (affinityScorer) Name() string { return "affinity" }
+
+func (affinityScorer) Score(req PlacementRequest, host PlacementHost) (float64, string, error) {
+	if len(req.Affinity) == 0 && len(req.AntiAffinity) == 0 {
+		return 0, "no rules", nil
+	}
+	for _, other := range req.AntiAffinity {
+		if machine, ok := req.VmHosts[other]; ok && machine == host.MachineName {
+			return 0, "", fmt.Errorf("VM %s runs here (anti-affinity)", other)
+		}
+	}
+	var with []string
+	for _, other := range req.Affinity {
+		machine, ok := req.VmHosts[other]
+		if !ok {
+			// a vm that does not exist yet does not pin anything
+			continue
+		}
+		if req.FromMachine != "" && machine == req.FromMachine {
+			// still where the vm comes from, a group moves one vm at a time and the others follow
+			continue
+		}
+		if machine != host.MachineName {
+			return 0, "", fmt.Errorf("VM %s runs on %s (affinity)", other, machine)
+		}
+		with = append(with, other)
+	}
+	if len(with) > 0 {
+		return 0, "with " + strings.Join(with, ", "), nil
+	}
+	return 0, "rules respected", nil
+}
+
+// placementRules merges the rules given for the vm with the saved ones other vms have with it
+func placementRules(vmName string, cfg *PlacementConfig) ([]string, []string, error) {
+	var affinity, antiAffinity []string
+	if cfg != nil {
+		affinity = append(affinity, cfg.Affinity...)
+		antiAffinity = append(antiAffinity, cfg.AntiAffinity...)
+	}
+	saved, err := db.GetVmPlacementRules(vmName)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get placement rules: %v", err)
+	}
+	for _, rule := range saved {
+		switch rule.Rule {
+		case db.PlacementAffinity:
+			affinity = append(affinity, rule.OtherVm)
+		case db.PlacementAntiAffinity:
+			antiAffinity = append(antiAffinity, rule.OtherVm)
+		}
+	}
+	return uniqueSorted(affinity), uniqueSorted(antiAffinity), nil
+}
+
+// vmShare is the nfs share a disk lives on, nil when it is on none
+func vmShare(diskPath string) (*db.NFSShare, error) {
+	if diskPath == "" {
+		return nil, nil
+	}
+	shares, err := db.GetAllNFShares()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get NFS shares: %v", err)
+	}
+	var best *db.NFSShare
+	bestLen := 0
+	for i := range shares {
+		target := strings.TrimSuffix(shares[i].Target, "/")
+		if target == "" || !strings.HasPrefix(diskPath, target+"/") {
+			continue
+		}
+		if len(target) > bestLen {
+			best, bestLen = &shares[i], len(target)
+		}
+	}
+	return best, nil
+}
+
+// slaveVmHosts maps every vm of the slaves to the slave it is defined on
+func slaveVmHosts(slaves []slaveVms) map[string]string {
+	vmHosts := map[string]string{}
+	for _, s := range slaves {
+		for _, vm := range s.vms {
+			vmHosts[vm.Name] = s.machineName
+		}
+	}
+	return vmHosts
+}
+
+// checkMovePlacement refuses to move a vm from one slave to another against its saved rules, the
+// slaves are only asked for their vms when the vm has rules and vmHosts is nil
+func checkMovePlacement(vmName, fromMachine, toMachine string, vmHosts map[string]string) error {
+	affinity, antiAffinity, err := placementRules(vmName, nil)
+	if err != nil {
+		return err
+	}
+	if len(affinity) == 0 && len(antiAffinity) == 0 {
+		return nil
+	}
+	if vmHosts == nil {
+		vmHosts = slaveVmHosts(listSlaveVms())
+	}
+	req := PlacementRequest{
+		VmName:       vmName,
+		Affinity:     affinity,
+		AntiAffinity: antiAffinity,
+		VmHosts:      vmHosts,
+		FromMachine:  fromMachine,
+	}
+	if _, _, err := (affinityScorer{}).Score(req, PlacementHost{MachineName: toMachine}); err != nil {
+		return fmt.Errorf("VM %s can not go to %s: %v", vmName, toMachine, err)
+	}
+	return nil
+}
+
+func savePlacementRules(vmName string, cfg *PlacementConfig) error {
+	if cfg == nil {
+		return nil
+	}
+	add := func(rule string, others []string) error {
+		for _, other := range others {
+			other = strings.TrimSpace(other)
+			if other == "" || other == vmName {
+				continue
+			}
+			if err := db.AddVmPlacementRule(db.VmPlacementRule{VmName: vmName, Rule: rule, OtherVm: other}); err != nil {
+				return fmt.Errorf("failed to save placement rule: %v", err)
+			}
+		}
+		return nil
+	}
+	if err := add(db.PlacementAffinity, cfg.Affinity); err != nil {
+		return err
+	}
+	return add(db.PlacementAntiAffinity, cfg.AntiAffinity)
+}
+
+func gatherPlacementHosts(share *db.NFSShare) ([]PlacementHost, []PlacementCandidate) {
+	conns := protocol.GetConnectionsSnapshot()
+	hosts := make([]*PlacementHost, len(conns))
+	failed := make([]string, len(conns))
+
+	var wg sync.WaitGroup
+	for i, c := range conns {
+		if c.Connection == nil {
+			failed[i] = "not connected"
+			continue
+		}
+		wg.Add(1)
+		go func(i int, c protocol.ConnectionsStruct) {
+			defer wg.Done()
+			res, err := virsh.GetHostResources(c.Connection)
+			if err != nil {
+				failed[i] = fmt.Sprintf("failed to get resources: %v", err)
+				return
+			}
+			vms, err := virsh.GetAllVms(c.Connection, &grpcVirsh.Empty{})
+			if err != nil {
+				failed[i] = fmt.Sprintf("failed to get VMs: %v", err)
+				return
+			}
+			host := &PlacementHost{MachineName: c.MachineName, Resources: res, Vms: vms.Vms, Capabilities: c.Capabilities}
+			if share != nil {
+				// statfs on the mount point gives the space of the share as this slave sees it
+				status, err := nfs.GetSharedFolderStatus(c.Connection, &nfsproto.FolderMount{
+					MachineName: share.MachineName,
+					FolderPath:  share.Target,
+					Source:      share.Source,
+					Target:      share.Target,
+				})
+				if err == nil {
+					host.Share = status
+				}
+			}
+			hosts[i] = host
+		}(i, c)
+	}
+	wg.Wait()
+
+	var ok []PlacementHost
+	var rejected []PlacementCandidate
+	for i, c := range conns {
+		if hosts[i] == nil {
+			rejected = append(rejected, PlacementCandidate{MachineName: c.MachineName, Rejected: failed[i]})
+			continue
+		}
+		ok = append(ok, *hosts[i])
+	}
+	return ok, rejected
+}
+
+func scorePlacementHost(req PlacementRequest, host PlacementHost) PlacementCandidate {
+	placementScorersMu.RLock()
+	scorers := append([]PlacementScorer(nil), placementScorers...)
+	placementScorersMu.RUnlock()
+
+	candidate := PlacementCandidate{MachineName: host.MachineName}
+	for _, scorer := range scorers {
+		points, reason, err := scorer.Score(req, host)
+		if err != nil {
+			candidate.Rejected = fmt.Sprintf("%s: %v", scorer.Name(), err)
+			candidate.Score = 0
+			break
+		}
+		candidate.Score += points
+		candidate.Reasons = append(candidate.Reasons, fmt.Sprintf("%s: %s", scorer.Name(), reason))
+	}
+	return candidate
+}
+
+// PlaceVM runs every scorer on every connected slave and picks the one with most points
+func (v *VirshService) PlaceVM(req PlacementRequest) (*PlacementDecision, error) {
+	hosts, candidates := gatherPlacementHosts(req.NfsShare)
+
+	if req.VmHosts == nil {
+		req.VmHosts = map[string]string{}
+		for _, host := range hosts {
+			for _, vm := range host.Vms {
+				req.VmHosts[vm.Name] = host.MachineName
+			}
+		}
+	}
+
+	if req.OldestQemu == "" {
+		for _, host := range hosts {
+			if host.Capabilities == nil {
+				continue
+			}
+			if req.OldestQemu == "" || protocol.CompareVersions(host.Capabilities.QemuVersion, req.OldestQemu) < 0 {
+				req.OldestQemu = host.Capabilities.QemuVersion
+			}
+		}
+	}
+
+	for _, host := range hosts {
+		candidates = append(candidates, scorePlacementHost(req, host))
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if (a.Rejected == "") != (b.Rejected == "") {
+			return a.Rejected == ""
+		}
+		return a.Score > b.Score
+	})
+
+	decision := &PlacementDecision{Candidates: candidates}
+	if len(candidates) == 0 || candidates[0].Rejected != "" {
+		var why []string
+		for _, c := range candidates {
+			why = append(why, c.MachineName+" ("+c.Rejected+")")
+		}
+		if len(why) == 0 {
+			return decision, fmt.Errorf("no slave can take VM %s, no slave connected", req.VmName)
+		}
+		return decision, fmt.Errorf("no slave can take VM %s: %s", req.VmName, strings.Join(why, "; "))
+	}
+	decision.MachineName = candidates[0].MachineName
+	return decision, nil
+}
+
+// placeNewVm returns the slave a new vm goes to, the scheduler decides when machineName is auto or empty
+func (v *VirshService) placeNewVm(machineName, vmName string, memory, vcpu int32, nfsShareId int, diskSizeGB int32, cfg *PlacementConfig) (string, *PlacementDecision, error) {
+	machineName = strings.TrimSpace(machineName)
+	if machineName != "" && machineName != PlacementAuto {
+		return machineName, nil, nil
+	}
+
+	share, err := db.GetNFSShareByID(nfsShareId)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to get NFS share by ID: %v", err)
+	}
+	if share == nil {
+		return "", nil, fmt.Errorf("NFS share with ID %d not found", nfsShareId)
+	}
+
+	affinity, antiAffinity, err := placementRules(vmName, cfg)
+	if err != nil {
+		return "", nil, err
+	}
+	decision, err := v.PlaceVM(PlacementRequest{
+		VmName:       vmName,
+		MemoryMB:     memory,
+		VCPUs:        vcpu,
+		DiskSizeGB:   diskSizeGB,
+		NfsShare:     share,
+		Affinity:     affinity,
+		AntiAffinity: antiAffinity,
+	})
+	if err != nil {
+		return "", decision, err
+	}
+	return decision.MachineName, decision, nil
+}
@@ -0,0 +1,241 @@
+package services
+
+import (
+	"512SvMan/db"
+	"database/sql"
+	"fmt"
+	"math"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	nfsproto "github.com/Maruqes/512SvMan/api/proto/nfs"
+	grpcVirsh "github.com/Maruqes/512SvMan/api/proto/virsh"
+	"github.com/Maruqes/512SvMan/logger"
+)
+
+// setupServiceDB points the db package at a fresh sqlite file with only the given tables, the
+// logger is set up too since main is not there to do it
+func setupServiceDB(t *testing.T, tables ...func() error) {
+	t.Helper()
+	logger.SetType("dev")
+	conn, err := sql.Open("sqlite3", filepath.Join(t.TempDir(), "services.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	old := db.DB
+	db.DB = conn
+	t.Cleanup(func() {
+		conn.Close()
+		db.DB = old
+	})
+	for _, create := range tables {
+		if err := create(); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// testHost has 16 GB with 10 GB free, 8 cpus with 6 vcpus given out and half its cpu busy
+func testHost(name string) PlacementHost {
+	return PlacementHost{
+		MachineName: name,
+		Resources: &grpcVirsh.HostResources{
+			MemoryTotalMB:  16384,
+			MemoryFreeMB:   10240,
+			Cpus:           8,
+			AllocatedVcpus: 6,
+			CpuLoad:        0.5,
+		},
+	}
+}
+
+func TestScorePlacementHost(t *testing.T) {
+	setupServiceDB(t, db.CreateSlaveMaintenanceTable, db.CreateVmPlacementRulesTable)
+	if err := db.AddSlaveMaintenance("down"); err != nil {
+		t.Fatal(err)
+	}
+	share := &db.NFSShare{Target: "/mnt/share"}
+
+	tests := []struct {
+		name     string
+		req      PlacementRequest
+		host     func() PlacementHost
+		score    float64 // checked when rejected is empty
+		rejected string  // prefix of the rejection, the scorer name
+	}{
+		{
+			name: "fits",
+			req:  PlacementRequest{VmName: "vm", MemoryMB: 2048, VCPUs: 2},
+			host: func() PlacementHost { return testHost("s1") },
+			// memory 40*8192/16384 + vcpu 30*(1-1/4) + cpu load 30*0.5
+			score: 20 + 22.5 + 15,
+		},
+		{
+			name:     "in maintenance",
+			req:      PlacementRequest{VmName: "vm", MemoryMB: 2048, VCPUs: 2},
+			host:     func() PlacementHost { return testHost("down") },
+			rejected: "maintenance:",
+		},
+		{
+			name:     "memory left for the host",
+			req:      PlacementRequest{VmName: "vm", MemoryMB: 10240 - minHostFreeMB + 1, VCPUs: 1},
+			host:     func() PlacementHost { return testHost("s1") },
+			rejected: "memory:",
+		},
+		{
+			name:     "vcpu overcommit",
+			req:      PlacementRequest{VmName: "vm", MemoryMB: 1024, VCPUs: 27},
+			host:     func() PlacementHost { return testHost("s1") },
+			rejected: "vcpu:",
+		},
+		{
+			name:     "share not mounted",
+			req:      PlacementRequest{VmName: "vm", MemoryMB: 1024, VCPUs: 1, NfsShare: share},
+			host:     func() PlacementHost { return testHost("s1") },
+			rejected: "nfs:",
+		},
+		{
+			name: "share too small",
+			req:  PlacementRequest{VmName: "vm", MemoryMB: 1024, VCPUs: 1, NfsShare: share, DiskSizeGB: 100},
+			host: func() PlacementHost {
+				h := testHost("s1")
+				h.Share = &nfsproto.SharedFolderStatusResponse{Working: true, SpaceFreeGB: 50}
+				return h
+			},
+			rejected: "nfs:",
+		},
+		{
+			name: "anti-affinity partner here",
+			req: PlacementRequest{
+				VmName: "vm", MemoryMB: 1024, VCPUs: 1,
+				AntiAffinity: []string{"db"},
+				VmHosts:      map[string]string{"db": "s1"},
+			},
+			host:     func() PlacementHost { return testHost("s1") },
+			rejected: "affinity:",
+		},
+		{
+			name: "affinity partner elsewhere",
+			req: PlacementRequest{
+				VmName: "vm", MemoryMB: 1024, VCPUs: 1,
+				Affinity: []string{"web"},
+				VmHosts:  map[string]string{"web": "s2"},
+			},
+			host:     func() PlacementHost { return testHost("s1") },
+			rejected: "affinity:",
+		},
+		{
+			name: "affinity partner still on the slave the vm leaves",
+			req: PlacementRequest{
+				VmName: "vm", MemoryMB: 2048, VCPUs: 2,
+				Affinity:    []string{"web"},
+				VmHosts:     map[string]string{"web": "s2"},
+				FromMachine: "s2",
+			},
+			host:  func() PlacementHost { return testHost("s1") },
+			score: 20 + 22.5 + 15,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := scorePlacementHost(tt.req, tt.host())
+			if tt.rejected != "" {
+				if !strings.HasPrefix(c.Rejected, tt.rejected) {
+					t.Fatalf("want rejected by %q, got %q", tt.rejected, c.Rejected)
+				}
+				return
+			}
+			if c.Rejected != "" {
+				t.Fatalf("want accepted, rejected: %s", c.Rejected)
+			}
+			if math.Abs(c.Score-tt.score) > 1e-9 {
+				t.Fatalf("want score %.2f, got %.2f (%v)", tt.score, c.Score, c.Reasons)
+			}
+		})
+	}
+}
+
+func TestScorePlacementHostPrefersIdleHost(t *testing.T) {
+	setupServiceDB(t, db.CreateSlaveMaintenanceTable)
+	req := PlacementRequest{VmName: "vm", MemoryMB: 2048, VCPUs: 2}
+
+	busy := testHost("busy")
+	busy.Resources.CpuLoad = 0.9
+	idle := testHost("idle")
+	idle.Resources.CpuLoad = 0.1
+
+	if b, i := scorePlacementHost(req, busy), scorePlacementHost(req, idle); b.Score >= i.Score {
+		t.Fatalf("idle host scored %.2f, busy one %.2f", i.Score, b.Score)
+	}
+}
+
+func TestPlacementRulesHoldBothWays(t *testing.T) {
+	setupServiceDB(t, db.CreateVmPlacementRulesTable)
+	err := savePlacementRules("app", &PlacementConfig{
+		Affinity:     []string{"cache", " ", "app"},
+		AntiAffinity: []string{"app-replica"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		vm           string
+		affinity     []string
+		antiAffinity []string
+	}{
+		{vm: "app", affinity: []string{"cache"}, antiAffinity: []string{"app-replica"}},
+		{vm: "cache", affinity: []string{"app"}},
+		{vm: "app-replica", antiAffinity: []string{"app"}},
+		{vm: "unrelated"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.vm, func(t *testing.T) {
+			affinity, antiAffinity, err := placementRules(tt.vm, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			// nil and empty print the same
+			if fmt.Sprint(affinity) != fmt.Sprint(tt.affinity) {
+				t.Errorf("affinity: want %v, got %v", tt.affinity, affinity)
+			}
+			if fmt.Sprint(antiAffinity) != fmt.Sprint(tt.antiAffinity) {
+				t.Errorf("anti-affinity: want %v, got %v", tt.antiAffinity, antiAffinity)
+			}
+		})
+	}
+}
+
+func TestCheckMovePlacement(t *testing.T) {
+	setupServiceDB(t, db.CreateVmPlacementRulesTable)
+	err := savePlacementRules("app", &PlacementConfig{Affinity: []string{"cache"}, AntiAffinity: []string{"app-replica"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	vmHosts := map[string]string{"app": "s1", "cache": "s1", "app-replica": "s2"}
+
+	tests := []struct {
+		name    string
+		vm      string
+		from    string
+		to      string
+		wantErr bool
+	}{
+		{name: "replica can not join app", vm: "app-replica", from: "s2", to: "s1", wantErr: true},
+		{name: "replica moves elsewhere", vm: "app-replica", from: "s2", to: "s3"},
+		{name: "app can not join its replica", vm: "app", from: "s1", to: "s2", wantErr: true},
+		// cache is still on s1, the slave app leaves, so it follows later
+		{name: "app leads its group", vm: "app", from: "s1", to: "s3"},
+		{name: "no rules", vm: "other", from: "s1", to: "s2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkMovePlacement(tt.vm, tt.from, tt.to, vmHosts)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("want error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
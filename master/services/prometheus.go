@@ -0,0 +1,281 @@
+package services
+
+import (
+	"512SvMan/db"
+	"512SvMan/nfs"
+	"512SvMan/protocol"
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	nfsproto "github.com/Maruqes/512SvMan/api/proto/nfs"
+	"github.com/Maruqes/512SvMan/logger"
+)
+
+const (
+	gib = 1024 * 1024 * 1024
+	// a share that does not answer in time is reported down, a hung mount must not hang the scrape
+	promShareTimeout = 5 * time.Second
+)
+
+type PrometheusService struct{}
+
+var (
+	isoDownloadsActive int
+	isoDownloadsOK     uint64
+	isoDownloadsFailed uint64
+	isoDownloadsMu     sync.Mutex
+)
+
+func isoDownloadStarted() {
+	isoDownloadsMu.Lock()
+	isoDownloadsActive++
+	isoDownloadsMu.Unlock()
+}
+
+func isoDownloadFinished(ok bool) {
+	isoDownloadsMu.Lock()
+	isoDownloadsActive--
+	if ok {
+		isoDownloadsOK++
+	} else {
+		isoDownloadsFailed++
+	}
+	isoDownloadsMu.Unlock()
+}
+
+// promWriter writes the prometheus text format, one HELP/TYPE header per family
+type promWriter struct {
+	b strings.Builder
+}
+
+func (p *promWriter) family(name, typ, help string) {
+	fmt.Fprintf(&p.b, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, typ)
+}
+
+var promLabelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// sample takes the labels as name, value pairs
+func (p *promWriter) sample(name string, value float64, labels ...string) {
+	p.b.WriteString(name)
+	if len(labels) > 0 {
+		p.b.WriteByte('{')
+		for i := 0; i+1 < len(labels); i += 2 {
+			if i > 0 {
+				p.b.WriteByte(',')
+			}
+			fmt.Fprintf(&p.b, `%s="%s"`, labels[i], promLabelEscaper.Replace(labels[i+1]))
+		}
+		p.b.WriteByte('}')
+	}
+	p.b.WriteByte(' ')
+	p.b.WriteString(strconv.FormatFloat(value, 'g', -1, 64))
+	p.b.WriteByte('\n')
+}
+
+func (p *promWriter) slaves() {
+	conns := protocol.GetConnectionsSnapshot()
+	p.family("svman_slaves_connected", "gauge", "Slaves connected to the master.")
+	p.sample("svman_slaves_connected", float64(len(conns)))
+
+	p.family("svman_slave_last_seen_seconds", "gauge", "Seconds since the slave last answered a ping.")
+	for _, c := range conns {
+		p.sample("svman_slave_last_seen_seconds", time.Since(c.LastSeen).Seconds(), "machine", c.MachineName)
+	}
+}
+
+// vms come from the last poll of the history service, svman_vm_poll_timestamp_seconds says how old it is
+func (p *promWriter) vms() {
+	lastVmPollMu.RLock()
+	polled := lastVmPoll
+	polledAt := lastVmPollAt
+	lastVmPollMu.RUnlock()
+
+	machines := make([]string, 0, len(polled))
+	for m := range polled {
+		machines = append(machines, m)
+	}
+	sort.Strings(machines)
+
+	p.family("svman_vm_poll_timestamp_seconds", "gauge", "When the vm metrics below were read from the slaves.")
+	if !polledAt.IsZero() {
+		p.sample("svman_vm_poll_timestamp_seconds", float64(polledAt.Unix()))
+	}
+
+	p.family("svman_vms", "gauge", "VMs per slave and state.")
+	for _, m := range machines {
+		states := map[string]int{}
+		for _, vm := range polled[m] {
+			states[strings.ToLower(vm.State.String())]++
+		}
+		names := make([]string, 0, len(states))
+		for s := range states {
+			names = append(names, s)
+		}
+		sort.Strings(names)
+		for _, s := range names {
+			p.sample("svman_vms", float64(states[s]), "machine", m, "state", s)
+		}
+	}
+
+	p.family("svman_vm_cpu_percent", "gauge", "CPU usage of the VM, percent of its vcpus.")
+	for _, m := range machines {
+		for _, vm := range polled[m] {
+			p.sample("svman_vm_cpu_percent", float64(vm.CurrentCpuUsage), "machine", m, "vm", vm.Name)
+		}
+	}
+	p.family("svman_vm_vcpus", "gauge", "vCPUs of the running VM.")
+	for _, m := range machines {
+		for _, vm := range polled[m] {
+			p.sample("svman_vm_vcpus", float64(vm.CpuCount), "machine", m, "vm", vm.Name)
+		}
+	}
+	p.family("svman_vm_memory_bytes", "gauge", "Memory given to the running VM.")
+	for _, m := range machines {
+		for _, vm := range polled[m] {
+			p.sample("svman_vm_memory_bytes", float64(vm.MemoryMB)*1024*1024, "machine", m, "vm", vm.Name)
+		}
+	}
+	p.family("svman_vm_memory_used_bytes", "gauge", "Memory the guest is using.")
+	for _, m := range machines {
+		for _, vm := range polled[m] {
+			p.sample("svman_vm_memory_used_bytes", float64(vm.CurrentMemoryUsageMB)*1024*1024, "machine", m, "vm", vm.Name)
+		}
+	}
+	p.family("svman_vm_disk_size_bytes", "gauge", "Provisioned size of each VM disk.")
+	for _, m := range machines {
+		for _, vm := range polled[m] {
+			for _, d := range vm.Disks {
+				p.sample("svman_vm_disk_size_bytes", float64(d.SizeGB)*gib, "machine", m, "vm", vm.Name, "disk", d.Target)
+			}
+		}
+	}
+}
+
+// shares asks the slaves that export the shares all at once, a share whose slave is gone or does
+// not answer within promShareTimeout is reported down
+func (p *promWriter) shares() {
+	shares, err := db.GetAllNFShares()
+	if err != nil {
+		logger.Error("metrics: failed to get NFS shares:", err)
+		return
+	}
+
+	type shareStatus struct {
+		share  db.NFSShare
+		status *nfsproto.SharedFolderStatusResponse
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), promShareTimeout)
+	defer cancel()
+	statuses := make([]shareStatus, len(shares))
+	var wg sync.WaitGroup
+	for i, share := range shares {
+		statuses[i] = shareStatus{share: share}
+		conn := protocol.GetConnectionByMachineName(share.MachineName)
+		if conn == nil || conn.Connection == nil {
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			status, err := nfs.GetSharedFolderStatusCtx(conn.Connection, ctx, &nfsproto.FolderMount{
+				MachineName: share.MachineName,
+				FolderPath:  share.FolderPath,
+				Source:      share.Source,
+				Target:      share.Target,
+			})
+			if err == nil {
+				statuses[i].status = status
+			}
+		}()
+	}
+	wg.Wait()
+
+	labels := func(s db.NFSShare) []string {
+		return []string{"machine", s.MachineName, "share", s.Target, "name", s.Name}
+	}
+	p.family("svman_nfs_share_up", "gauge", "1 when the share is exported and readable.")
+	for _, st := range statuses {
+		up := 0.0
+		if st.status != nil && st.status.Working {
+			up = 1
+		}
+		p.sample("svman_nfs_share_up", up, labels(st.share)...)
+	}
+	for _, f := range []struct {
+		name, help string
+		value      func(*nfsproto.SharedFolderStatusResponse) int64
+	}{
+		{"svman_nfs_share_size_bytes", "Size of the filesystem behind the share.", func(s *nfsproto.SharedFolderStatusResponse) int64 { return s.SpaceTotalGB }},
+		{"svman_nfs_share_used_bytes", "Space used on the share.", func(s *nfsproto.SharedFolderStatusResponse) int64 { return s.SpaceOccupiedGB }},
+		{"svman_nfs_share_free_bytes", "Space left on the share.", func(s *nfsproto.SharedFolderStatusResponse) int64 { return s.SpaceFreeGB }},
+	} {
+		p.family(f.name, "gauge", f.help)
+		for _, st := range statuses {
+			if st.status != nil {
+				p.sample(f.name, float64(f.value(st.status))*gib, labels(st.share)...)
+			}
+		}
+	}
+}
+
+func (p *promWriter) isoDownloads() {
+	isoDownloadsMu.Lock()
+	active, ok, failed := isoDownloadsActive, isoDownloadsOK, isoDownloadsFailed
+	isoDownloadsMu.Unlock()
+
+	p.family("svman_iso_downloads_active", "gauge", "ISO downloads in progress.")
+	p.sample("svman_iso_downloads_active", float64(active))
+	p.family("svman_iso_downloads_total", "counter", "ISO downloads finished since the master started.")
+	p.sample("svman_iso_downloads_total", float64(ok), "result", "ok")
+	p.sample("svman_iso_downloads_total", float64(failed), "result", "failed")
+}
+
+func (p *promWriter) grpc() {
+	stats := protocol.GetGRPCStats()
+
+	p.family("svman_grpc_client_calls_total", "counter", "Unary calls from the master to the slaves.")
+	for _, s := range stats {
+		p.sample("svman_grpc_client_calls_total", float64(s.Calls), "method", s.Method)
+	}
+	p.family("svman_grpc_client_errors_total", "counter", "Unary calls to the slaves that returned an error.")
+	for _, s := range stats {
+		p.sample("svman_grpc_client_errors_total", float64(s.Errors), "method", s.Method)
+	}
+	p.family("svman_grpc_client_streams_total", "counter", "Streams opened to the slaves.")
+	for _, s := range stats {
+		p.sample("svman_grpc_client_streams_total", float64(s.StreamsOpen), "method", s.Method)
+	}
+	p.family("svman_grpc_client_stream_errors_total", "counter", "Streams to the slaves that failed to open.")
+	for _, s := range stats {
+		p.sample("svman_grpc_client_stream_errors_total", float64(s.StreamErrors), "method", s.Method)
+	}
+
+	p.family("svman_grpc_client_latency_seconds", "histogram", "Latency of the unary calls to the slaves.")
+	for _, s := range stats {
+		if s.Calls == 0 {
+			continue
+		}
+		for i, bound := range protocol.GRPCLatencyBuckets {
+			p.sample("svman_grpc_client_latency_seconds_bucket", float64(s.Buckets[i]), "method", s.Method, "le", strconv.FormatFloat(bound, 'g', -1, 64))
+		}
+		p.sample("svman_grpc_client_latency_seconds_bucket", float64(s.Calls), "method", s.Method, "le", "+Inf")
+		p.sample("svman_grpc_client_latency_seconds_sum", s.LatencySum, "method", s.Method)
+		p.sample("svman_grpc_client_latency_seconds_count", float64(s.Calls), "method", s.Method)
+	}
+}
+
+// Render returns every metric of the cluster in the prometheus text format
+func (s *PrometheusService) Render() string {
+	var p promWriter
+	p.slaves()
+	p.vms()
+	p.shares()
+	p.isoDownloads()
+	p.grpc()
+	return p.b.String()
+}
@@ -0,0 +1,23 @@
+package services
+
+import (
+	"512SvMan/virsh"
+	"context"
+	"fmt"
+
+	grpcVirsh "github.com/Maruqes/512SvMan/api/proto/virsh"
+)
+
+// OpenSerialConsole attaches to the serial console of the vm, whoever had it open is kicked out
+func (v *VirshService) OpenSerialConsole(ctx context.Context, vmName string) (grpcVirsh.SlaveVirshService_SerialConsoleClient, error) {
+	conn, _, err := findVmConnection(vmName)
+	if err != nil {
+		return nil, err
+	}
+
+	stream, err := virsh.SerialConsole(ctx, conn, vmName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open serial console of VM %s: %v", vmName, err)
+	}
+	return stream, nil
+}
@@ -0,0 +1,186 @@
+package services
+
+import (
+	"512SvMan/db"
+	"512SvMan/virsh"
+	"fmt"
+
+	grpcVirsh "github.com/Maruqes/512SvMan/api/proto/virsh"
+	"github.com/Maruqes/512SvMan/logger"
+	"google.golang.org/grpc"
+)
+
+func snapshotToDB(snap *grpcVirsh.Snapshot) db.VmSnapshot {
+	return db.VmSnapshot{
+		VmName:      snap.VmName,
+		Name:        snap.Name,
+		Description: snap.Description,
+		External:    snap.External,
+		State:       snap.State,
+		Parent:      snap.Parent,
+		CreatedAt:   snap.CreatedAt,
+		Current:     snap.Current,
+		Xml:         snap.Xml,
+	}
+}
+
+// saves what the slave reports into the db so the metadata survives a migration
+func syncSnapshotsToDB(conn *grpc.ClientConn, vmName string) error {
+	snaps, err := virsh.ListSnapshots(conn, vmName)
+	if err != nil {
+		return fmt.Errorf("failed to list snapshots of VM %s: %v", vmName, err)
+	}
+	dbSnaps := make([]db.VmSnapshot, 0, len(snaps))
+	for _, snap := range snaps {
+		dbSnaps = append(dbSnaps, snapshotToDB(snap))
+	}
+	if err := db.ReplaceVmSnapshots(vmName, dbSnaps); err != nil {
+		return fmt.Errorf("failed to save snapshots of VM %s: %v", vmName, err)
+	}
+	return nil
+}
+
+// redefines the snapshots saved in the db on conn if that slave does not know about them
+func restoreSnapshotMetadata(conn *grpc.ClientConn, vmName string) error {
+	saved, err := db.GetVmSnapshots(vmName)
+	if err != nil {
+		return fmt.Errorf("failed to get snapshots of VM %s from database: %v", vmName, err)
+	}
+	if len(saved) == 0 {
+		return nil
+	}
+
+	onSlave, err := virsh.ListSnapshots(conn, vmName)
+	if err != nil {
+		return fmt.Errorf("failed to list snapshots of VM %s: %v", vmName, err)
+	}
+	known := make(map[string]struct{}, len(onSlave))
+	for _, snap := range onSlave {
+		known[snap.Name] = struct{}{}
+	}
+
+	//saved is ordered by creation time so parents are defined before their children
+	for _, snap := range saved {
+		if _, ok := known[snap.Name]; ok {
+			continue
+		}
+		err := virsh.DefineSnapshot(conn, &grpcVirsh.DefineSnapshotRequest{
+			VmName:  vmName,
+			Xml:     snap.Xml,
+			Current: snap.Current,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to redefine snapshot %s of VM %s: %v", snap.Name, vmName, err)
+		}
+	}
+	return nil
+}
+
+// libvirt refuses to migrate a domain that has snapshots, so the metadata is dropped on the origin
+// (the data stays inside the qcow2 files) and redefined from the db once the vm lands
+func dropSnapshotMetadata(conn *grpc.ClientConn, vmName string) (bool, error) {
+	if err := syncSnapshotsToDB(conn, vmName); err != nil {
+		return false, err
+	}
+	saved, err := db.GetVmSnapshots(vmName)
+	if err != nil {
+		return false, fmt.Errorf("failed to get snapshots of VM %s from database: %v", vmName, err)
+	}
+	for i := len(saved) - 1; i >= 0; i-- {
+		err := virsh.DeleteSnapshot(conn, &grpcVirsh.SnapshotRequest{
+			VmName:       vmName,
+			Name:         saved[i].Name,
+			MetadataOnly: true,
+		})
+		if err != nil {
+			return len(saved) > 0, fmt.Errorf("failed to drop snapshot metadata %s of VM %s: %v", saved[i].Name, vmName, err)
+		}
+	}
+	return len(saved) > 0, nil
+}
+
+func (v *VirshService) CreateSnapshot(vmName, name, description string, external bool) (*db.VmSnapshot, error) {
+	conn, _, err := findVmConnection(vmName)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := restoreSnapshotMetadata(conn, vmName); err != nil {
+		return nil, err
+	}
+
+	snap, err := virsh.CreateSnapshot(conn, &grpcVirsh.CreateSnapshotRequest{
+		VmName:      vmName,
+		Name:        name,
+		Description: description,
+		External:    external,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create snapshot %s of VM %s: %v", name, vmName, err)
+	}
+
+	if err := syncSnapshotsToDB(conn, vmName); err != nil {
+		logger.Error("snapshot created but not saved in database:", err)
+	}
+
+	res := snapshotToDB(snap)
+	return &res, nil
+}
+
+func (v *VirshService) ListSnapshots(vmName string) ([]db.VmSnapshot, error) {
+	conn, _, err := findVmConnection(vmName)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := restoreSnapshotMetadata(conn, vmName); err != nil {
+		return nil, err
+	}
+	if err := syncSnapshotsToDB(conn, vmName); err != nil {
+		return nil, err
+	}
+	return db.GetVmSnapshots(vmName)
+}
+
+func (v *VirshService) RevertSnapshot(vmName, name string) error {
+	if err := ensureNoLinkedClones(vmName, "revert"); err != nil {
+		return err
+	}
+
+	conn, _, err := findVmConnection(vmName)
+	if err != nil {
+		return err
+	}
+
+	if err := restoreSnapshotMetadata(conn, vmName); err != nil {
+		return err
+	}
+
+	err = virsh.RevertSnapshot(conn, &grpcVirsh.SnapshotRequest{VmName: vmName, Name: name})
+	if err != nil {
+		return fmt.Errorf("failed to revert VM %s to snapshot %s: %v", vmName, name, err)
+	}
+
+	if err := syncSnapshotsToDB(conn, vmName); err != nil {
+		logger.Error("snapshot reverted but database not updated:", err)
+	}
+	return nil
+}
+
+func (v *VirshService) DeleteSnapshot(vmName, name string) error {
+	conn, _, err := findVmConnection(vmName)
+	if err != nil {
+		return err
+	}
+
+	if err := restoreSnapshotMetadata(conn, vmName); err != nil {
+		return err
+	}
+
+	err = virsh.DeleteSnapshot(conn, &grpcVirsh.SnapshotRequest{VmName: vmName, Name: name})
+	if err != nil {
+		return fmt.Errorf("failed to delete snapshot %s of VM %s: %v", name, vmName, err)
+	}
+
+	return syncSnapshotsToDB(conn, vmName)
+}
@@ -0,0 +1,169 @@
+package services
+
+import (
+	"512SvMan/db"
+	"512SvMan/protocol"
+	"512SvMan/virsh"
+	"database/sql"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	grpcVirsh "github.com/Maruqes/512SvMan/api/proto/virsh"
+)
+
+type TemplateService struct {
+}
+
+type CloudInitConfig struct {
+	Hostname string   `json:"hostname"`
+	UserData string   `json:"user_data"`
+	SSHKeys  []string `json:"ssh_keys"`
+}
+
+func (c *CloudInitConfig) toGRPC() *grpcVirsh.CloudInit {
+	if c == nil {
+		return nil
+	}
+	return &grpcVirsh.CloudInit{
+		Hostname: c.Hostname,
+		UserData: c.UserData,
+		SshKeys:  c.SSHKeys,
+	}
+}
+
+// AddTemplate registers fileName (relative to the nfs share) as a golden image
+func (t *TemplateService) AddTemplate(name string, nfsShareId int, fileName string, description string) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return fmt.Errorf("template name is required")
+	}
+
+	nfsShare, err := db.GetNFSShareByID(nfsShareId)
+	if err != nil {
+		return fmt.Errorf("failed to get NFS share by ID: %v", err)
+	}
+	if nfsShare == nil {
+		return fmt.Errorf("NFS share with ID %d not found", nfsShareId)
+	}
+
+	fileName = path.Clean("/" + strings.TrimSpace(fileName))
+	if fileName == "/" {
+		return fmt.Errorf("template file is required")
+	}
+	diskPath := strings.TrimSuffix(nfsShare.Target, "/") + fileName
+
+	nfsService := NFSService{}
+	found, err := nfsService.CanFindFileOrDirOnAllSlaves(diskPath)
+	if err != nil {
+		return fmt.Errorf("failed to look for template file: %v", err)
+	}
+	available := false
+	for _, ok := range found {
+		available = available || ok
+	}
+	if !available {
+		return fmt.Errorf("template file %s not found on any slave", diskPath)
+	}
+
+	if err := db.AddVmTemplate(name, nfsShareId, diskPath, description); err != nil {
+		return fmt.Errorf("failed to add template to database: %v", err)
+	}
+	return nil
+}
+
+// RemoveTemplate only drops the catalog entry, the image stays on the share. It is refused while a vm,
+// trashed ones included, still has the image in its backing chain, the catalog entry is what keeps the
+// share from being deleted
+func (t *TemplateService) RemoveTemplate(id int) error {
+	template, err := getTemplate(id)
+	if err != nil {
+		return err
+	}
+
+	trashed, err := db.GetAllTrashedVms()
+	if err != nil {
+		return fmt.Errorf("failed to get trashed VMs: %v", err)
+	}
+	// the slave a vm was trashed on checks its files, trashed file -> vm name
+	trashedFiles := map[string]map[string]string{}
+	for _, vm := range trashed {
+		if trashedFiles[vm.MachineName] == nil {
+			trashedFiles[vm.MachineName] = map[string]string{}
+		}
+		for _, file := range vm.Files {
+			trashedFiles[vm.MachineName][file.Trashed] = vm.VmName
+		}
+	}
+
+	var users []string
+	for _, c := range protocol.GetConnectionsSnapshot() {
+		if c.Connection == nil {
+			continue
+		}
+		files := make([]string, 0, len(trashedFiles[c.MachineName]))
+		for file := range trashedFiles[c.MachineName] {
+			files = append(files, file)
+		}
+		res, err := virsh.GetBackingFileUsers(c.Connection, template.DiskPath, files)
+		if err != nil {
+			return fmt.Errorf("failed to check VMs using template %s on %s: %v", template.Name, c.MachineName, err)
+		}
+		users = append(users, res.VmNames...)
+		seen := map[string]bool{}
+		for _, file := range res.Files {
+			name := trashedFiles[c.MachineName][file]
+			if !seen[name] {
+				seen[name] = true
+				users = append(users, name+" (trashed)")
+			}
+		}
+		delete(trashedFiles, c.MachineName)
+	}
+	// without its slave nobody can tell if a trashed vm still reads from the template
+	for machine, files := range trashedFiles {
+		if len(files) > 0 {
+			return fmt.Errorf("cannot check trashed VMs of slave %s for template %s, the slave is not connected", machine, template.Name)
+		}
+	}
+	if len(users) > 0 {
+		return fmt.Errorf("template %s is the backing file of VMs %v, remove them first", template.Name, users)
+	}
+	if err := db.RemoveVmTemplateByID(id); err != nil {
+		return fmt.Errorf("failed to remove template: %v", err)
+	}
+	return nil
+}
+
+func getTemplate(id int) (*db.VmTemplate, error) {
+	template, err := db.GetVmTemplateByID(id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("template with ID %d not found", id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get template by ID: %v", err)
+	}
+	return template, nil
+}
+
+// resolveInstallMedia returns the iso or template the vm is created from, a template wins over an iso
+func resolveInstallMedia(isoID, templateID int) (isoPath string, templatePath string, err error) {
+	if templateID > 0 {
+		template, err := getTemplate(templateID)
+		if err != nil {
+			return "", "", err
+		}
+		return "", template.DiskPath, nil
+	}
+
+	//get iso path from isoID
+	iso, err := db.GetIsoByID(isoID)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to get ISO by ID: %v", err)
+	}
+	if iso == nil {
+		return "", "", fmt.Errorf("ISO with ID %d not found", isoID)
+	}
+	return iso.FilePath, "", nil
+}
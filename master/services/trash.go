@@ -0,0 +1,234 @@
+package services
+
+import (
+	"512SvMan/db"
+	"512SvMan/protocol"
+	"512SvMan/virsh"
+	"fmt"
+	"time"
+
+	grpcVirsh "github.com/Maruqes/512SvMan/api/proto/virsh"
+	"github.com/Maruqes/512SvMan/logger"
+	"google.golang.org/grpc"
+)
+
+const trashPurgeInterval = 10 * time.Minute
+
+type TrashService struct{}
+
+type TrashStatus struct {
+	RetentionHours int            `json:"retention_hours"`
+	Vms            []db.TrashedVm `json:"vms"`
+}
+
+func trashedVmToGRPC(vm *db.TrashedVm) *grpcVirsh.TrashedVm {
+	res := &grpcVirsh.TrashedVm{Name: vm.VmName, Xml: vm.Xml}
+	for _, file := range vm.Files {
+		res.Files = append(res.Files, &grpcVirsh.TrashFile{Original: file.Original, Trashed: file.Trashed})
+	}
+	return res
+}
+
+// trashVM moves the vm files to the trash of their share and keeps what is needed to bring it back
+func trashVM(conn *grpc.ClientConn, vm *grpcVirsh.Vm) error {
+	//libvirt drops the snapshot metadata with the domain, the db copy is what redefines it later
+	if err := syncSnapshotsToDB(conn, vm.Name); err != nil {
+		return err
+	}
+	snaps, err := db.GetVmSnapshots(vm.Name)
+	if err != nil {
+		return fmt.Errorf("failed to get snapshots of VM %s from database: %v", vm.Name, err)
+	}
+	live, err := db.DoesVmLiveExist(vm.Name)
+	if err != nil {
+		return fmt.Errorf("failed to check if live VM exists in database: %v", err)
+	}
+
+	now := time.Now()
+	trashed, err := virsh.TrashVM(conn, &grpcVirsh.TrashVmRequest{
+		Name:      vm.Name,
+		TrashName: fmt.Sprintf("%s-%d", vm.Name, now.Unix()),
+	})
+	if err != nil {
+		return err
+	}
+
+	entry := &db.TrashedVm{
+		VmName:      vm.Name,
+		MachineName: vm.MachineName,
+		Live:        live,
+		Xml:         trashed.Xml,
+		DeletedAt:   now.Unix(),
+		Snapshots:   snaps,
+	}
+	for _, file := range trashed.Files {
+		entry.Files = append(entry.Files, db.TrashedFile{Original: file.Original, Trashed: file.Trashed})
+	}
+	if err := db.AddTrashedVm(entry); err != nil {
+		return fmt.Errorf("VM trashed but not saved in database, its files are in .trash of its share: %v", err)
+	}
+	return nil
+}
+
+func (t *TrashService) GetStatus() (*TrashStatus, error) {
+	hours, err := db.GetTrashRetentionHours()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get trash retention: %v", err)
+	}
+	vms, err := db.GetAllTrashedVms()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get trashed VMs: %v", err)
+	}
+	if vms == nil {
+		vms = []db.TrashedVm{}
+	}
+	return &TrashStatus{RetentionHours: hours, Vms: vms}, nil
+}
+
+func (t *TrashService) SetRetention(hours int) error {
+	if hours <= 0 {
+		return fmt.Errorf("retention_hours must be greater than 0")
+	}
+	if err := db.SetTrashRetentionHours(hours); err != nil {
+		return fmt.Errorf("failed to save trash retention: %v", err)
+	}
+	return nil
+}
+
+// Restore defines the trashed vm again on machineName (the slave it was deleted on when empty), shut off
+func (t *TrashService) Restore(id int, machineName string) error {
+	vm, err := db.GetTrashedVm(id)
+	if err != nil {
+		return fmt.Errorf("failed to get trashed VM: %v", err)
+	}
+	if vm == nil {
+		return fmt.Errorf("trashed VM %d not found", id)
+	}
+
+	exists, err := virsh.DoesVMExist(vm.VmName)
+	if err != nil {
+		return fmt.Errorf("error checking if VM exists: %v", err)
+	}
+	if exists {
+		return fmt.Errorf("a VM with the name %s already exists", vm.VmName)
+	}
+
+	if machineName == "" {
+		machineName = vm.MachineName
+	}
+	if err := ensureNotInMaintenance(machineName); err != nil {
+		return err
+	}
+	conn := protocol.GetConnectionByMachineName(machineName)
+	if conn == nil || conn.Connection == nil {
+		return fmt.Errorf("slave %s not connected", machineName)
+	}
+
+	if err := virsh.RestoreTrashedVM(conn.Connection, trashedVmToGRPC(vm)); err != nil {
+		return fmt.Errorf("failed to restore VM %s: %v", vm.VmName, err)
+	}
+	if err := db.RemoveTrashedVm(id); err != nil {
+		return fmt.Errorf("VM restored but not removed from the trash: %v", err)
+	}
+
+	if vm.Live {
+		if err := db.AddVmLive(vm.VmName); err != nil {
+			return fmt.Errorf("VM restored but not added as live VM in database: %v", err)
+		}
+	}
+	//saved is ordered by creation time so parents are defined before their children
+	for _, snap := range vm.Snapshots {
+		err := virsh.DefineSnapshot(conn.Connection, &grpcVirsh.DefineSnapshotRequest{
+			VmName:  vm.VmName,
+			Xml:     snap.Xml,
+			Current: snap.Current,
+		})
+		if err != nil {
+			return fmt.Errorf("VM restored but snapshot %s was not: %v", snap.Name, err)
+		}
+	}
+	if len(vm.Snapshots) > 0 {
+		if err := syncSnapshotsToDB(conn.Connection, vm.VmName); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Purge deletes the files of the trashed vm for good
+func (t *TrashService) Purge(id int) error {
+	vm, err := db.GetTrashedVm(id)
+	if err != nil {
+		return fmt.Errorf("failed to get trashed VM: %v", err)
+	}
+	if vm == nil {
+		return fmt.Errorf("trashed VM %d not found", id)
+	}
+
+	//every slave mounts the shares, the one it was deleted on is only preferred
+	var conn *grpc.ClientConn
+	if c := protocol.GetConnectionByMachineName(vm.MachineName); c != nil && c.Connection != nil {
+		conn = c.Connection
+	} else {
+		for _, c := range protocol.GetAllGRPCConnections() {
+			if c != nil {
+				conn = c
+				break
+			}
+		}
+	}
+	if conn == nil {
+		return fmt.Errorf("no slave connected to purge the trashed VM")
+	}
+
+	if err := virsh.PurgeTrashedVM(conn, trashedVmToGRPC(vm)); err != nil {
+		return fmt.Errorf("failed to purge VM %s: %v", vm.VmName, err)
+	}
+	if err := db.RemoveTrashedVm(id); err != nil {
+		return fmt.Errorf("VM purged but not removed from the trash: %v", err)
+	}
+
+	//the link was kept while the vm could come back, unless a new vm took the name
+	exists, err := virsh.DoesVMExist(vm.VmName)
+	if err == nil && !exists {
+		if err := db.RemoveVmLinkedClone(vm.VmName); err != nil {
+			return fmt.Errorf("failed to remove linked clone from database: %v", err)
+		}
+	}
+	return nil
+}
+
+func (t *TrashService) purgeExpired() error {
+	hours, err := db.GetTrashRetentionHours()
+	if err != nil {
+		return err
+	}
+	vms, err := db.GetAllTrashedVms()
+	if err != nil {
+		return err
+	}
+	cutoff := time.Now().Add(-time.Duration(hours) * time.Hour).Unix()
+	for _, vm := range vms {
+		if vm.DeletedAt > cutoff {
+			continue
+		}
+		if err := t.Purge(vm.Id); err != nil {
+			logger.Error("trash: purge of", vm.VmName, "failed:", err)
+			continue
+		}
+		logger.Info("trash: purged VM", vm.VmName)
+	}
+	return nil
+}
+
+// Start purges the trashed vms older than the retention every few minutes
+func (t *TrashService) Start() {
+	go func() {
+		for {
+			if err := t.purgeExpired(); err != nil {
+				logger.Error("trash: failed to purge expired VMs:", err)
+			}
+			time.Sleep(trashPurgeInterval)
+		}
+	}()
+}
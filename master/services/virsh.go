@@ -9,6 +9,8 @@ import (
 	"strings"
 
 	grpcVirsh "github.com/Maruqes/512SvMan/api/proto/virsh"
+	"github.com/Maruqes/512SvMan/logger"
+	"google.golang.org/grpc"
 	"libvirt.org/go/libvirt"
 )
 
@@ -135,6 +137,11 @@ func (v *VirshService) GetCpuDisableFeatures(conns []string) (string, error) {
 		if conn == nil {
 			return "", fmt.Errorf("machine %s not found", machineName)
 		}
+		// sent with SetConnection, only slaves that did not send it are asked
+		if conn.Capabilities != nil && conn.Capabilities.CpuXML != "" {
+			xmls = append(xmls, conn.Capabilities.CpuXML)
+			continue
+		}
 		cpuXML, err := virsh.GetCPUXML(conn.Connection)
 		if err != nil {
 			return "", err
@@ -150,41 +157,53 @@ func (v *VirshService) GetCpuDisableFeatures(conns []string) (string, error) {
 }
 
 // vmReq.MachineName, vmReq.Name, vmReq.Memory, vmReq.Vcpu, vmReq.NfsShareId, vmReq.DiskSizeGB, vmReq.IsoID, vmReq.Network, vmReq.VNCPassword
-func (v *VirshService) CreateVM(machine_name string, name string, memory int32, vcpu int32, nfsShareId int, diskSizeGB int32, isoID int, network string, VNCPassword string) error {
+// templateID > 0 builds the disk on top of that template and seeds it with cloudInit instead of booting the iso
+// nics empty gives the vm a single virtio nic on network
+// an empty or "auto" machine_name lets the scheduler pick the slave, the decision is returned
+func (v *VirshService) CreateVM(machine_name string, name string, memory int32, vcpu int32, nfsShareId int, diskSizeGB int32, isoID int, network string, VNCPassword string, templateID int, cloudInit *CloudInitConfig, nics []NicConfig, placement *PlacementConfig) (*PlacementDecision, error) {
 
 	//get all vms cant have same name
 	//cant have two vms with the same name
 	exists, err := virsh.DoesVMExist(name)
 	if err != nil {
-		return fmt.Errorf("error checking if VM exists: %v", err)
+		return nil, fmt.Errorf("error checking if VM exists: %v", err)
 	}
 	if exists {
-		return fmt.Errorf("a VM with the name %s already exists", name)
+		return nil, fmt.Errorf("a VM with the name %s already exists", name)
+	}
+
+	machine_name, decision, err := v.placeNewVm(machine_name, name, memory, vcpu, nfsShareId, diskSizeGB, placement)
+	if err != nil {
+		return decision, err
 	}
 
 	slaveMachine := protocol.GetConnectionByMachineName(machine_name)
 	if slaveMachine == nil {
-		return fmt.Errorf("machine %s not found", machine_name)
+		return nil, fmt.Errorf("machine %s not found", machine_name)
+	}
+	if err := ensureNotInMaintenance(machine_name); err != nil {
+		return nil, err
+	}
+
+	grpcNics, err := v.resolveNics(slaveMachine.Connection, network, nics)
+	if err != nil {
+		return nil, err
 	}
 
 	//get disk path from nfsShareId
 	nfsShare, err := db.GetNFSShareByID(nfsShareId)
 	if err != nil {
-		return fmt.Errorf("failed to get NFS share by ID: %v", err)
+		return nil, fmt.Errorf("failed to get NFS share by ID: %v", err)
 	}
 	if nfsShare == nil {
-		return fmt.Errorf("NFS share with ID %d not found", nfsShareId)
+		return nil, fmt.Errorf("NFS share with ID %d not found", nfsShareId)
 	}
 
-	//get iso path from isoID
-	iso, err := db.GetIsoByID(isoID)
+	//get iso or template path
+	isoPath, templatePath, err := resolveInstallMedia(isoID, templateID)
 	if err != nil {
-		return fmt.Errorf("failed to get ISO by ID: %v", err)
-	}
-	if iso == nil {
-		return fmt.Errorf("ISO with ID %d not found", isoID)
+		return nil, err
 	}
-	isoPath := iso.FilePath
 
 	var qcowFile string
 	if nfsShare.Target[len(nfsShare.Target)-1] != '/' {
@@ -204,51 +223,68 @@ func (v *VirshService) CreateVM(machine_name string, name string, memory int32,
 		diskFolder = nfsShare.Target + name
 	}
 
-	return virsh.CreateVM(slaveMachine.Connection, name, memory, vcpu, diskFolder, qcowFile, diskSizeGB, isoPath, network, VNCPassword)
+	err = virsh.CreateVM(slaveMachine.Connection, name, memory, vcpu, diskFolder, qcowFile, diskSizeGB, isoPath, network, VNCPassword, templatePath, cloudInit.toGRPC(), grpcNics)
+	if err != nil {
+		return decision, err
+	}
+
+	if err := savePlacementRules(name, placement); err != nil {
+		return decision, err
+	}
+	return decision, nil
 }
 
-func (v *VirshService) CreateLiveVM(machine_name string, name string, memory int32, vcpu int32, nfsShareId int, diskSizeGB int32, isoID int, network string, VNCPassword string, cpuXml string) error {
+func (v *VirshService) CreateLiveVM(machine_name string, name string, memory int32, vcpu int32, nfsShareId int, diskSizeGB int32, isoID int, network string, VNCPassword string, cpuXml string, templateID int, cloudInit *CloudInitConfig, nics []NicConfig, placement *PlacementConfig) (*PlacementDecision, error) {
 	exists, err := db.DoesVmLiveExist(name)
 	if err != nil {
-		return fmt.Errorf("failed to check if live VM exists in database: %v", err)
+		return nil, fmt.Errorf("failed to check if live VM exists in database: %v", err)
 	}
 	if exists {
-		return fmt.Errorf("a live VM with the name %s already exists in the database", name)
+		return nil, fmt.Errorf("a live VM with the name %s already exists in the database", name)
 	}
 
 	//get all vms cant have same name
 	//cant have two vms with the same name
 	exists, err = virsh.DoesVMExist(name)
 	if err != nil {
-		return fmt.Errorf("error checking if VM exists: %v", err)
+		return nil, fmt.Errorf("error checking if VM exists: %v", err)
 	}
 	if exists {
-		return fmt.Errorf("a VM with the name %s already exists", name)
+		return nil, fmt.Errorf("a VM with the name %s already exists", name)
+	}
+
+	machine_name, decision, err := v.placeNewVm(machine_name, name, memory, vcpu, nfsShareId, diskSizeGB, placement)
+	if err != nil {
+		return decision, err
 	}
 
 	slaveMachine := protocol.GetConnectionByMachineName(machine_name)
 	if slaveMachine == nil {
-		return fmt.Errorf("machine %s not found", machine_name)
+		return nil, fmt.Errorf("machine %s not found", machine_name)
+	}
+	if err := ensureNotInMaintenance(machine_name); err != nil {
+		return nil, err
+	}
+
+	grpcNics, err := v.resolveNics(slaveMachine.Connection, network, nics)
+	if err != nil {
+		return nil, err
 	}
 
 	//get disk path from nfsShareId
 	nfsShare, err := db.GetNFSShareByID(nfsShareId)
 	if err != nil {
-		return fmt.Errorf("failed to get NFS share by ID: %v", err)
+		return nil, fmt.Errorf("failed to get NFS share by ID: %v", err)
 	}
 	if nfsShare == nil {
-		return fmt.Errorf("NFS share with ID %d not found", nfsShareId)
+		return nil, fmt.Errorf("NFS share with ID %d not found", nfsShareId)
 	}
 
-	//get iso path from isoID
-	iso, err := db.GetIsoByID(isoID)
+	//get iso or template path
+	isoPath, templatePath, err := resolveInstallMedia(isoID, templateID)
 	if err != nil {
-		return fmt.Errorf("failed to get ISO by ID: %v", err)
-	}
-	if iso == nil {
-		return fmt.Errorf("ISO with ID %d not found", isoID)
+		return nil, err
 	}
-	isoPath := iso.FilePath
 
 	var qcowFile string
 	if nfsShare.Target[len(nfsShare.Target)-1] != '/' {
@@ -268,20 +304,24 @@ func (v *VirshService) CreateLiveVM(machine_name string, name string, memory int
 		diskFolder = nfsShare.Target + name
 	}
 
-	err = virsh.CreateLiveVM(slaveMachine.Connection, name, memory, vcpu, diskFolder, qcowFile, diskSizeGB, isoPath, network, VNCPassword, cpuXml)
+	err = virsh.CreateLiveVM(slaveMachine.Connection, name, memory, vcpu, diskFolder, qcowFile, diskSizeGB, isoPath, network, VNCPassword, cpuXml, templatePath, cloudInit.toGRPC(), grpcNics)
 	if err != nil {
-		return err
+		return decision, err
 	}
 
 	//add to db
 	err = db.AddVmLive(name)
 	if err != nil {
-		return fmt.Errorf("failed to add live VM to database: %v", err)
+		return decision, fmt.Errorf("failed to add live VM to database: %v", err)
 	}
-	return nil
+
+	if err := savePlacementRules(name, placement); err != nil {
+		return decision, err
+	}
+	return decision, nil
 }
 
-func (v *VirshService) MigrateVm(originMachine string, destMachine string, vmName string, live bool) error {
+func (v *VirshService) MigrateVm(originMachine string, destMachine string, vmName string, live bool, limits MigrationLimits) error {
 	exists, err := db.DoesVmLiveExist(vmName)
 	if err != nil {
 		return fmt.Errorf("failed to check if live VM exists in database: %v", err)
@@ -289,7 +329,12 @@ func (v *VirshService) MigrateVm(originMachine string, destMachine string, vmNam
 	if !exists {
 		return fmt.Errorf("a live VM with the name %s does not exist in the database", vmName)
 	}
+	return v.migrateVm(originMachine, destMachine, vmName, live, limits)
+}
 
+// migrateVm moves the vm between slaves, a shut off vm only has its definition moved
+// so it does not need to be live capable
+func (v *VirshService) migrateVm(originMachine string, destMachine string, vmName string, live bool, limits MigrationLimits) error {
 	if originMachine == destMachine {
 		return fmt.Errorf("origin and destination machines cannot be the same")
 	}
@@ -304,9 +349,15 @@ func (v *VirshService) MigrateVm(originMachine string, destMachine string, vmNam
 	if destConn == nil {
 		return fmt.Errorf("destination machine %s not found", destMachine)
 	}
+	if err := ensureNotInMaintenance(destMachine); err != nil {
+		return err
+	}
+	if err := checkMovePlacement(vmName, originMachine, destMachine, nil); err != nil {
+		return err
+	}
 
 	//Check Vms existance and Get vm
-	exists, err = virsh.DoesVMExist(vmName)
+	exists, err := virsh.DoesVMExist(vmName)
 	if err != nil {
 		return fmt.Errorf("error checking if VM exists: %v", err)
 	}
@@ -324,11 +375,67 @@ func (v *VirshService) MigrateVm(originMachine string, destMachine string, vmNam
 	if vm.MachineName != originMachine {
 		return fmt.Errorf("VM %s is not running on origin machine %s", vmName, originMachine)
 	}
+	if err := checkMigrationCompat(originConn, destConn, live && isVmActive(vm)); err != nil {
+		return err
+	}
+
+	//the vm nics point to catalog networks, the destination must have them all
+	networkService := NetworkService{}
+	if err := networkService.SyncNetworksOn(destConn.Connection); err != nil {
+		logger.Error("SyncNetworks on destination failed:", err)
+	}
+
+	endMigration, err := startMigration(vmName, originMachine)
+	if err != nil {
+		return err
+	}
+	defer endMigration()
 
-	return virsh.MigrateVm(originConn.Connection, vmName, destConn.Addr, live)
+	hadSnapshots, err := dropSnapshotMetadata(originConn.Connection, vmName)
+	if err != nil {
+		if hadSnapshots {
+			if restoreErr := restoreSnapshotMetadata(originConn.Connection, vmName); restoreErr != nil {
+				logger.Error("failed to restore snapshot metadata on origin:", restoreErr)
+			}
+		}
+		return err
+	}
+
+	err = virsh.MigrateVm(originConn.Connection, &grpcVirsh.MigrateVmRequest{
+		Name:          vmName,
+		SlaveIp:       destConn.Addr,
+		Live:          live,
+		BandwidthMiB:  limits.BandwidthMiB,
+		MaxDowntimeMs: limits.MaxDowntimeMs,
+	})
+	if err != nil {
+		if hadSnapshots {
+			if restoreErr := restoreSnapshotMetadata(originConn.Connection, vmName); restoreErr != nil {
+				logger.Error("failed to restore snapshot metadata on origin:", restoreErr)
+			}
+		}
+		return err
+	}
+
+	recordVmMigration(vmName)
+
+	//the HA monitor would notice it too, but a slave dying right now must restart it from here
+	if ha, err := db.GetVmHA(vmName); err == nil {
+		if err := db.UpdateVmHAPlacement(vmName, destMachine, ha.DiskPath); err != nil {
+			logger.Error("failed to update HA placement:", err)
+		}
+	}
+
+	if hadSnapshots {
+		if err := restoreSnapshotMetadata(destConn.Connection, vmName); err != nil {
+			return fmt.Errorf("VM %s migrated but snapshots were not restored: %v", vmName, err)
+		}
+	}
+	return nil
 }
 
-func (v *VirshService) DeleteVM(name string) error {
+// DeleteVM moves the vm to the trash, purge deletes its files right away like before the trash existed
+func (v *VirshService) DeleteVM(name string, purge bool) error {
 	//find vm by name
 	exists, err := virsh.DoesVMExist(name)
 	if err != nil {
@@ -338,12 +445,20 @@ func (v *VirshService) DeleteVM(name string) error {
 		return fmt.Errorf("a VM with the name %s does not exist", name)
 	}
 
+	if err := ensureNoLinkedClones(name, "delete"); err != nil {
+		return err
+	}
+
 	con := protocol.GetAllGRPCConnections()
 	for _, conn := range con {
 		vm, err := virsh.GetVmByName(conn, &grpcVirsh.GetVmByNameRequest{Name: name})
 		if err == nil && vm != nil {
 			//found the vm
-			err = virsh.RemoveVM(conn, vm)
+			if purge {
+				err = virsh.RemoveVM(conn, vm)
+			} else {
+				err = trashVM(conn, vm)
+			}
 			if err != nil {
 				return fmt.Errorf("failed to delete VM %s: %v", name, err)
 			}
@@ -360,6 +475,35 @@ func (v *VirshService) DeleteVM(name string) error {
 				}
 			}
 
+			err = db.RemoveVmSnapshots(name)
+			if err != nil {
+				return fmt.Errorf("failed to remove VM snapshots from database: %v", err)
+			}
+
+			//a trashed linked clone still needs its source, the link goes with the purge
+			if purge {
+				err = db.RemoveVmLinkedClone(name)
+				if err != nil {
+					return fmt.Errorf("failed to remove linked clone from database: %v", err)
+				}
+			}
+
+			err = db.RemoveVmHA(name)
+			if err != nil {
+				return fmt.Errorf("failed to remove HA policy from database: %v", err)
+			}
+
+			err = db.RemoveVmPlacementRules(name)
+			if err != nil {
+				return fmt.Errorf("failed to remove placement rules from database: %v", err)
+			}
+
+			//the backups stay in the catalog, they are the only way back after a delete
+			err = db.RemoveVmBackupPolicy(name)
+			if err != nil {
+				return fmt.Errorf("failed to remove backup policy from database: %v", err)
+			}
+
 			return nil
 		}
 	}
@@ -376,6 +520,10 @@ func (v *VirshService) StartVM(name string) error {
 		return fmt.Errorf("a VM with the name %s does not exist", name)
 	}
 
+	if err := ensureNoLinkedClones(name, "start"); err != nil {
+		return err
+	}
+
 	con := protocol.GetAllGRPCConnections()
 	for _, conn := range con {
 		vm, err := virsh.GetVmByName(conn, &grpcVirsh.GetVmByNameRequest{Name: name})
@@ -487,6 +635,18 @@ func (v *VirshService) GetVmByName(name string) (*grpcVirsh.Vm, error) {
 	return nil, fmt.Errorf("failed to find VM %s on any machine", name)
 }
 
+// findVmConnection returns the slave connection that currently holds the vm
+func findVmConnection(name string) (*grpc.ClientConn, *grpcVirsh.Vm, error) {
+	con := protocol.GetAllGRPCConnections()
+	for _, conn := range con {
+		vm, err := virsh.GetVmByName(conn, &grpcVirsh.GetVmByNameRequest{Name: name})
+		if err == nil && vm != nil {
+			return conn, vm, nil
+		}
+	}
+	return nil, nil, fmt.Errorf("failed to find VM %s on any machine", name)
+}
+
 type VmType struct {
 	*grpcVirsh.Vm
 	IsLive bool
@@ -530,9 +690,13 @@ func (v *VirshService) GetAllVmsByOnNfsShare(nfsSharePathTarget string) ([]VmTyp
 		return nil, err
 	}
 	var vmsOnShare []VmType
-	//if vm include in nfsShareId
+	//if any disk of the vm is in nfsShareId
 	for _, vm := range allVms {
-		if strings.Contains(vm.DiskPath, nfsSharePathTarget) {
+		onShare := strings.Contains(vm.DiskPath, nfsSharePathTarget)
+		for _, disk := range vm.Disks {
+			onShare = onShare || strings.Contains(disk.Path, nfsSharePathTarget)
+		}
+		if onShare {
 			vmsOnShare = append(vmsOnShare, vm)
 		}
 	}
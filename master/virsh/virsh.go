@@ -27,18 +27,21 @@ func GetCPUXML(conn *grpc.ClientConn) (string, error) {
 	return resp.CpuXML, nil
 }
 
-func CreateVM(conn *grpc.ClientConn, name string, memory, vcpu int32, diskFolder, diskPath string, diskSizeGB int32, isoPath, network, VNCPassword string) error {
+func CreateVM(conn *grpc.ClientConn, name string, memory, vcpu int32, diskFolder, diskPath string, diskSizeGB int32, isoPath, network, VNCPassword string, templatePath string, cloudInit *grpcVirsh.CloudInit, nics []*grpcVirsh.VmNic) error {
 	client := grpcVirsh.NewSlaveVirshServiceClient(conn)
 	_, err := client.CreateVm(context.Background(), &grpcVirsh.CreateVmRequest{
-		Name:        name,
-		Memory:      memory,
-		Vcpu:        vcpu,
-		DiskFolder:  diskFolder,
-		DiskPath:    diskPath,
-		DiskSizeGB:  diskSizeGB,
-		IsoPath:     isoPath,
-		Network:     network,
-		VncPassword: VNCPassword,
+		Name:         name,
+		Memory:       memory,
+		Vcpu:         vcpu,
+		DiskFolder:   diskFolder,
+		DiskPath:     diskPath,
+		DiskSizeGB:   diskSizeGB,
+		IsoPath:      isoPath,
+		Network:      network,
+		VncPassword:  VNCPassword,
+		TemplatePath: templatePath,
+		CloudInit:    cloudInit,
+		Nics:         nics,
 	})
 	if err != nil {
 		return err
@@ -46,20 +49,23 @@ func CreateVM(conn *grpc.ClientConn, name string, memory, vcpu int32, diskFolder
 	return nil
 }
 
-func CreateLiveVM(conn *grpc.ClientConn, name string, memory, vcpu int32, diskFolder, diskPath string, diskSizeGB int32, isoPath, network, VNCPassword string, cpuXml string) error {
+func CreateLiveVM(conn *grpc.ClientConn, name string, memory, vcpu int32, diskFolder, diskPath string, diskSizeGB int32, isoPath, network, VNCPassword string, cpuXml string, templatePath string, cloudInit *grpcVirsh.CloudInit, nics []*grpcVirsh.VmNic) error {
 	client := grpcVirsh.NewSlaveVirshServiceClient(conn)
 	fmt.Println("Creating live VM with CPU XML:", cpuXml)
 	_, err := client.CreateLiveVM(context.Background(), &grpcVirsh.CreateVmLiveRequest{
 		Vm: &grpcVirsh.CreateVmRequest{
-			Name:        name,
-			Memory:      memory,
-			Vcpu:        vcpu,
-			DiskFolder:  diskFolder,
-			DiskPath:    diskPath,
-			DiskSizeGB:  diskSizeGB,
-			IsoPath:     isoPath,
-			Network:     network,
-			VncPassword: VNCPassword,
+			Name:         name,
+			Memory:       memory,
+			Vcpu:         vcpu,
+			DiskFolder:   diskFolder,
+			DiskPath:     diskPath,
+			DiskSizeGB:   diskSizeGB,
+			IsoPath:      isoPath,
+			Network:      network,
+			VncPassword:  VNCPassword,
+			TemplatePath: templatePath,
+			CloudInit:    cloudInit,
+			Nics:         nics,
 		},
 		CpuXml: cpuXml,
 	})
@@ -69,14 +75,37 @@ func CreateLiveVM(conn *grpc.ClientConn, name string, memory, vcpu int32, diskFo
 	return nil
 }
 
+func CloneVM(conn *grpc.ClientConn, req *grpcVirsh.CloneVmRequest) error {
+	client := grpcVirsh.NewSlaveVirshServiceClient(conn)
+	_, err := client.CloneVM(context.Background(), req)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
 // conn machine will migrate do slaveIp machine
-func MigrateVm(conn *grpc.ClientConn, name, slaveIp string, live bool) error {
+func MigrateVm(conn *grpc.ClientConn, req *grpcVirsh.MigrateVmRequest) error {
 	client := grpcVirsh.NewSlaveVirshServiceClient(conn)
-	_, err := client.MigrateVM(context.Background(), &grpcVirsh.MigrateVmRequest{
-		Name:    name,
-		SlaveIp: slaveIp,
-		Live:    live,
-	})
+	_, err := client.MigrateVM(context.Background(), req)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func CancelMigration(conn *grpc.ClientConn, name string) error {
+	client := grpcVirsh.NewSlaveVirshServiceClient(conn)
+	_, err := client.CancelMigration(context.Background(), &grpcVirsh.GetVmByNameRequest{Name: name})
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func SetMigrationLimits(conn *grpc.ClientConn, req *grpcVirsh.MigrateVmRequest) error {
+	client := grpcVirsh.NewSlaveVirshServiceClient(conn)
+	_, err := client.SetMigrationLimits(context.Background(), req)
 	if err != nil {
 		return err
 	}
@@ -148,6 +177,33 @@ func RemoveVM(conn *grpc.ClientConn, req *grpcVirsh.Vm) error {
 	return nil
 }
 
+func TrashVM(conn *grpc.ClientConn, req *grpcVirsh.TrashVmRequest) (*grpcVirsh.TrashedVm, error) {
+	client := grpcVirsh.NewSlaveVirshServiceClient(conn)
+	resp, err := client.TrashVM(context.Background(), req)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func RestoreTrashedVM(conn *grpc.ClientConn, req *grpcVirsh.TrashedVm) error {
+	client := grpcVirsh.NewSlaveVirshServiceClient(conn)
+	_, err := client.RestoreTrashedVM(context.Background(), req)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func PurgeTrashedVM(conn *grpc.ClientConn, req *grpcVirsh.TrashedVm) error {
+	client := grpcVirsh.NewSlaveVirshServiceClient(conn)
+	_, err := client.PurgeTrashedVM(context.Background(), req)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
 func RestartVM(conn *grpc.ClientConn, req *grpcVirsh.Vm) error {
 	client := grpcVirsh.NewSlaveVirshServiceClient(conn)
 	_, err := client.RestartVM(context.Background(), req)
@@ -192,3 +248,242 @@ func ResumeVM(conn *grpc.ClientConn, req *grpcVirsh.Vm) error {
 	}
 	return nil
 }
+
+func CreateSnapshot(conn *grpc.ClientConn, req *grpcVirsh.CreateSnapshotRequest) (*grpcVirsh.Snapshot, error) {
+	client := grpcVirsh.NewSlaveVirshServiceClient(conn)
+	resp, err := client.CreateSnapshot(context.Background(), req)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func ListSnapshots(conn *grpc.ClientConn, vmName string) ([]*grpcVirsh.Snapshot, error) {
+	client := grpcVirsh.NewSlaveVirshServiceClient(conn)
+	resp, err := client.ListSnapshots(context.Background(), &grpcVirsh.GetVmByNameRequest{Name: vmName})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Snapshots, nil
+}
+
+func RevertSnapshot(conn *grpc.ClientConn, req *grpcVirsh.SnapshotRequest) error {
+	client := grpcVirsh.NewSlaveVirshServiceClient(conn)
+	_, err := client.RevertSnapshot(context.Background(), req)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func DeleteSnapshot(conn *grpc.ClientConn, req *grpcVirsh.SnapshotRequest) error {
+	client := grpcVirsh.NewSlaveVirshServiceClient(conn)
+	_, err := client.DeleteSnapshot(context.Background(), req)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func DefineSnapshot(conn *grpc.ClientConn, req *grpcVirsh.DefineSnapshotRequest) error {
+	client := grpcVirsh.NewSlaveVirshServiceClient(conn)
+	_, err := client.DefineSnapshot(context.Background(), req)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func AddDisk(conn *grpc.ClientConn, req *grpcVirsh.VmDiskRequest) (*grpcVirsh.VmDisk, error) {
+	client := grpcVirsh.NewSlaveVirshServiceClient(conn)
+	resp, err := client.AddDisk(context.Background(), req)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func AttachDisk(conn *grpc.ClientConn, req *grpcVirsh.VmDiskRequest) (*grpcVirsh.VmDisk, error) {
+	client := grpcVirsh.NewSlaveVirshServiceClient(conn)
+	resp, err := client.AttachDisk(context.Background(), req)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func DetachDisk(conn *grpc.ClientConn, req *grpcVirsh.VmDiskRequest) error {
+	client := grpcVirsh.NewSlaveVirshServiceClient(conn)
+	_, err := client.DetachDisk(context.Background(), req)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func ResizeDisk(conn *grpc.ClientConn, req *grpcVirsh.VmDiskRequest) (*grpcVirsh.VmDisk, error) {
+	client := grpcVirsh.NewSlaveVirshServiceClient(conn)
+	resp, err := client.ResizeDisk(context.Background(), req)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func MoveVmStorage(conn *grpc.ClientConn, req *grpcVirsh.VmDiskRequest) (*grpcVirsh.MoveVmStorageResponse, error) {
+	client := grpcVirsh.NewSlaveVirshServiceClient(conn)
+	resp, err := client.MoveVmStorage(context.Background(), req)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func BackupVM(conn *grpc.ClientConn, req *grpcVirsh.BackupVmRequest) (*grpcVirsh.BackupVmResponse, error) {
+	client := grpcVirsh.NewSlaveVirshServiceClient(conn)
+	resp, err := client.BackupVM(context.Background(), req)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func RestoreBackup(conn *grpc.ClientConn, req *grpcVirsh.RestoreBackupRequest) error {
+	client := grpcVirsh.NewSlaveVirshServiceClient(conn)
+	_, err := client.RestoreBackup(context.Background(), req)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func DeleteBackup(conn *grpc.ClientConn, req *grpcVirsh.DeleteBackupRequest) error {
+	client := grpcVirsh.NewSlaveVirshServiceClient(conn)
+	_, err := client.DeleteBackup(context.Background(), req)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func AttachNic(conn *grpc.ClientConn, req *grpcVirsh.VmNicRequest) (*grpcVirsh.VmNic, error) {
+	client := grpcVirsh.NewSlaveVirshServiceClient(conn)
+	resp, err := client.AttachNic(context.Background(), req)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func DetachNic(conn *grpc.ClientConn, req *grpcVirsh.VmNicRequest) error {
+	client := grpcVirsh.NewSlaveVirshServiceClient(conn)
+	_, err := client.DetachNic(context.Background(), req)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func GetGuestInfo(conn *grpc.ClientConn, req *grpcVirsh.GetVmByNameRequest) (*grpcVirsh.GuestInfo, error) {
+	client := grpcVirsh.NewSlaveVirshServiceClient(conn)
+	resp, err := client.GetGuestInfo(context.Background(), req)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func GuestExec(conn *grpc.ClientConn, req *grpcVirsh.GuestExecRequest) (*grpcVirsh.GuestExecResponse, error) {
+	client := grpcVirsh.NewSlaveVirshServiceClient(conn)
+	resp, err := client.GuestExec(context.Background(), req)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func EnableGuestAgent(conn *grpc.ClientConn, req *grpcVirsh.GetVmByNameRequest) error {
+	client := grpcVirsh.NewSlaveVirshServiceClient(conn)
+	_, err := client.EnableGuestAgent(context.Background(), req)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// SerialConsole attaches to the vm console, cancel ctx to drop the session
+func SerialConsole(ctx context.Context, conn *grpc.ClientConn, vmName string) (grpcVirsh.SlaveVirshService_SerialConsoleClient, error) {
+	client := grpcVirsh.NewSlaveVirshServiceClient(conn)
+	stream, err := client.SerialConsole(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := stream.Send(&grpcVirsh.ConsoleData{VmName: vmName}); err != nil {
+		return nil, err
+	}
+	return stream, nil
+}
+
+func SetVmHA(conn *grpc.ClientConn, req *grpcVirsh.HaVmRequest) error {
+	client := grpcVirsh.NewSlaveVirshServiceClient(conn)
+	_, err := client.SetVmHA(context.Background(), req)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func RecoverVM(conn *grpc.ClientConn, req *grpcVirsh.HaVmRequest) error {
+	client := grpcVirsh.NewSlaveVirshServiceClient(conn)
+	_, err := client.RecoverVM(context.Background(), req)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func ReleaseVM(conn *grpc.ClientConn, req *grpcVirsh.HaVmRequest) error {
+	client := grpcVirsh.NewSlaveVirshServiceClient(conn)
+	_, err := client.ReleaseVM(context.Background(), req)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func GetHostResources(conn *grpc.ClientConn) (*grpcVirsh.HostResources, error) {
+	client := grpcVirsh.NewSlaveVirshServiceClient(conn)
+	resp, err := client.GetHostResources(context.Background(), &grpcVirsh.Empty{})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetBackingFileUsers returns the vms and the trashed files among files that read from path
+func GetBackingFileUsers(conn *grpc.ClientConn, path string, files []string) (*grpcVirsh.BackingFileUsers, error) {
+	client := grpcVirsh.NewSlaveVirshServiceClient(conn)
+	resp, err := client.GetBackingFileUsers(context.Background(), &grpcVirsh.BackingFileRequest{Path: path, Files: files})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func GetVmCPUXML(conn *grpc.ClientConn, vmName string) (string, error) {
+	client := grpcVirsh.NewSlaveVirshServiceClient(conn)
+	resp, err := client.GetVmCPUXML(context.Background(), &grpcVirsh.GetVmByNameRequest{Name: vmName})
+	if err != nil {
+		return "", err
+	}
+	return resp.CpuXML, nil
+}
+
+func CompareCPU(conn *grpc.ClientConn, cpuXML string) (*grpcVirsh.OkResponse, error) {
+	client := grpcVirsh.NewSlaveVirshServiceClient(conn)
+	resp, err := client.CompareCPU(context.Background(), &grpcVirsh.CPUXMLResponse{CpuXML: cpuXML})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
@@ -17,6 +17,7 @@ var (
 	VNC_MIN_PORT int
 	VNC_MAX_PORT int
 	OTHER_SLAVES []string
+	EnrollToken  string // only needed the first time, before ./pki has a certificate
 	Conn         *grpc.ClientConn
 )
 
@@ -30,6 +31,7 @@ func Setup() error {
 	SlaveIP = os.Getenv("SLAVE_IP")
 	Mode = os.Getenv("MODE")
 	MachineName = os.Getenv("MACHINE_NAME")
+	EnrollToken = os.Getenv("ENROLL_TOKEN")
 	PingInterval, _ = strconv.Atoi(os.Getenv("PING_INTERVAL"))
 	VNC_MIN_PORT, _ = strconv.Atoi(os.Getenv("VNC_MIN_PORT"))
 	VNC_MAX_PORT, _ = strconv.Atoi(os.Getenv("VNC_MAX_PORT"))
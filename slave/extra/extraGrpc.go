@@ -40,17 +40,40 @@ func (s *ExtraService) PerformUpdate(ctx context.Context, req *extraGrpc.UpdateR
 }
 
 func SendWebsocketMessage(message string) error {
+	return SendWebsocketMessageType(extraGrpc.WebSocketsMessageType_DownloadIso, message)
+}
+
+func SendWebsocketMessageType(msgType extraGrpc.WebSocketsMessageType, message string) error {
 	if env512.Conn == nil {
 		return fmt.Errorf("gRPC connection not set")
 	}
 	h := extraGrpc.NewExtraServiceClient(env512.Conn)
-	_, err := h.SendWebsocketMessage(context.Background(), &extraGrpc.WebsocketMessage{Message: message})
+	_, err := h.SendWebsocketMessage(context.Background(), &extraGrpc.WebsocketMessage{Type: msgType, Message: message})
 	if err != nil {
 		logger.Error("SendWebsocketMessage: %v", err)
 	}
 	return err
 }
 
+// ReportEvent tells the master about a problem found here (or that it went away), the master's alert rules decide who hears of it
+func ReportEvent(kind, subject, message string, resolved bool) error {
+	if env512.Conn == nil {
+		return fmt.Errorf("gRPC connection not set")
+	}
+	h := extraGrpc.NewExtraServiceClient(env512.Conn)
+	_, err := h.ReportEvent(context.Background(), &extraGrpc.SlaveEvent{
+		MachineName: env512.MachineName,
+		Kind:        kind,
+		Subject:     subject,
+		Message:     message,
+		Resolved:    resolved,
+	})
+	if err != nil {
+		logger.Error("ReportEvent:", err)
+	}
+	return err
+}
+
 func ExecWithOutToSocket(ctx context.Context, msgType extraGrpc.WebSocketsMessageType, command string, args ...string) error {
 	cmd := exec.CommandContext(ctx, command, args...)
 	ptmx, err := pty.Start(cmd)
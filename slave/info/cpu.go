@@ -1,12 +1,14 @@
 package info
 
 import (
+	"errors"
 	"flag"
 	"fmt"
 	"time"
 
 	"github.com/klauspost/cpuid/v2"
 	"github.com/shirou/gopsutil/v4/cpu"
+	"github.com/shirou/gopsutil/v4/sensors"
 )
 
 type CPUInfoStruct struct{}
@@ -29,11 +31,28 @@ func (c *CPUInfoStruct) GetCPUModel() (map[string]int, error) {
 	return modelCores, nil
 }
 
-//sudo dnf install lm_sensors lm_sensors-devel gcc
+type Temperature struct {
+	Sensor  string
+	Celsius float64
+}
 
-func (c *CPUInfoStruct) GetCpuTemps() ([]float64, error) {
-	panic("unimplemented")
-	return nil, nil
+// every hwmon/thermal sensor the kernel exposes (coretemp, k10temp, acpitz...), lm_sensors only has to load the modules
+// sudo dnf install lm_sensors && sudo sensors-detect
+func (c *CPUInfoStruct) GetCpuTemps() ([]Temperature, error) {
+	stats, err := sensors.SensorsTemperatures()
+	// a sensor that fails to read comes back as a warning, the rest are still good
+	var warn *sensors.Warnings
+	if err != nil && !errors.As(err, &warn) {
+		return nil, err
+	}
+	temps := make([]Temperature, 0, len(stats))
+	for _, st := range stats {
+		if st.Temperature <= 0 {
+			continue
+		}
+		temps = append(temps, Temperature{Sensor: st.SensorKey, Celsius: st.Temperature})
+	}
+	return temps, nil
 }
 
 // per core
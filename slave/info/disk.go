@@ -1,18 +1,79 @@
 package info
 
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/shirou/gopsutil/v4/disk"
+)
+
 type DiskInfoStruct struct{}
 
 var DiskInfo DiskInfoStruct
 
+type DiskUsage struct {
+	Device      string
+	Mountpoint  string
+	Fstype      string
+	TotalBytes  uint64
+	UsedBytes   uint64
+	UsedPercent float64
+}
+
+// mountpoints of the local block devices, nfs shares and pseudo filesystems are left out
 func (d *DiskInfoStruct) GetDisks() ([]string, error) {
-	return []string{}, nil
+	parts, err := disk.Partitions(false)
+	if err != nil {
+		return nil, err
+	}
+	var res []string
+	for _, p := range parts {
+		if strings.HasPrefix(p.Device, "/dev/") {
+			res = append(res, p.Mountpoint)
+		}
+	}
+	return res, nil
+}
+
+// same order as GetDisks
+func (d *DiskInfoStruct) GetDiskUsage() ([]DiskUsage, error) {
+	parts, err := disk.Partitions(false)
+	if err != nil {
+		return nil, err
+	}
+	var res []DiskUsage
+	seen := map[string]bool{}
+	for _, p := range parts {
+		// bind mounts show the same device more than once
+		if !strings.HasPrefix(p.Device, "/dev/") || seen[p.Device] {
+			continue
+		}
+		usage, err := disk.Usage(p.Mountpoint)
+		if err != nil {
+			continue
+		}
+		seen[p.Device] = true
+		res = append(res, DiskUsage{
+			Device:      p.Device,
+			Mountpoint:  p.Mountpoint,
+			Fstype:      p.Fstype,
+			TotalBytes:  usage.Total,
+			UsedBytes:   usage.Used,
+			UsedPercent: usage.UsedPercent,
+		})
+	}
+	return res, nil
 }
 
-// % of used/free
-func (d *DiskInfoStruct) GetDiskUsage() ([]float64, error) {
-	return []float64{}, nil
+// bytes read and written since boot per device name (sda, nvme0n1p1, dm-0...)
+func (d *DiskInfoStruct) GetDiskIOUsage() (map[string]disk.IOCountersStat, error) {
+	return disk.IOCounters()
 }
 
-func (d *DiskInfoStruct) GetDiskIOUsage() ([]float64, error) {
-	return []float64{}, nil
+// ioCounterName maps /dev/mapper/x to the dm-N name the io counters use
+func ioCounterName(device string) string {
+	if resolved, err := filepath.EvalSymlinks(device); err == nil {
+		device = resolved
+	}
+	return filepath.Base(device)
 }
@@ -1,23 +1,124 @@
 package info
 
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/shirou/gopsutil/v4/net"
+	"github.com/shirou/gopsutil/v4/process"
+)
+
 type NetworkInfoStruct struct{}
 
 var NetworkInfo NetworkInfoStruct
 
+type InterfaceStats struct {
+	Name    string
+	MAC     string
+	Addrs   []string
+	Up      bool
+	RxBytes uint64 // since boot
+	TxBytes uint64
+}
+
 func (n *NetworkInfoStruct) GetInterfaces() ([]string, error) {
-	return []string{}, nil
+	ifaces, err := net.Interfaces()
+	if err != nil {
+		return nil, err
+	}
+	res := make([]string, 0, len(ifaces))
+	for _, iface := range ifaces {
+		res = append(res, iface.Name)
+	}
+	return res, nil
 }
 
-func (n *NetworkInfoStruct) GetInterfaceStats() (map[any]any, error) {
-	return nil, nil
+// vnet/tap devices of the vms are listed too, they show the traffic of each vm
+func (n *NetworkInfoStruct) GetInterfaceStats() ([]InterfaceStats, error) {
+	ifaces, err := net.Interfaces()
+	if err != nil {
+		return nil, err
+	}
+	counters, err := net.IOCounters(true)
+	if err != nil {
+		return nil, err
+	}
+	byName := make(map[string]net.IOCountersStat, len(counters))
+	for _, c := range counters {
+		byName[c.Name] = c
+	}
+
+	res := make([]InterfaceStats, 0, len(ifaces))
+	for _, iface := range ifaces {
+		stats := InterfaceStats{
+			Name:    iface.Name,
+			MAC:     iface.HardwareAddr,
+			RxBytes: byName[iface.Name].BytesRecv,
+			TxBytes: byName[iface.Name].BytesSent,
+		}
+		for _, flag := range iface.Flags {
+			if flag == "up" {
+				stats.Up = true
+			}
+		}
+		for _, addr := range iface.Addrs {
+			stats.Addrs = append(stats.Addrs, addr.Addr)
+		}
+		res = append(res, stats)
+	}
+	return res, nil
 }
 
 type ProcessInfoStruct struct{}
 
 var ProcessInfo ProcessInfoStruct
 
-func (p *ProcessInfoStruct) GetProcesses() ([]map[string]any, error) {
-	return []map[string]any{}, nil
+type Process struct {
+	Pid         int32
+	Name        string
+	User        string
+	CPUPercent  float64 // of one core over the sample, like htop
+	MemoryBytes uint64  // rss
+	Cmdline     string
 }
 
+// GetProcesses samples the cpu of every process over interval, sorted by cpu or by "memory"
+func (p *ProcessInfoStruct) GetProcesses(interval time.Duration, sortBy string) ([]Process, error) {
+	procs, err := process.Processes()
+	if err != nil {
+		return nil, err
+	}
+	// the first Percent call only records the starting point
+	for _, proc := range procs {
+		_, _ = proc.Percent(0)
+	}
+	time.Sleep(interval)
+
+	res := make([]Process, 0, len(procs))
+	for _, proc := range procs {
+		name, err := proc.Name()
+		if err != nil {
+			// exited during the sample
+			continue
+		}
+		info := Process{Pid: proc.Pid, Name: name}
+		info.CPUPercent, _ = proc.Percent(0)
+		info.User, _ = proc.Username()
+		if mem, err := proc.MemoryInfo(); err == nil {
+			info.MemoryBytes = mem.RSS
+		}
+		if cmd, err := proc.Cmdline(); err == nil {
+			info.Cmdline = strings.TrimSpace(cmd)
+		}
+		res = append(res, info)
+	}
 
+	sort.Slice(res, func(i, j int) bool {
+		if sortBy == "memory" {
+			return res[i].MemoryBytes > res[j].MemoryBytes
+		}
+		return res[i].CPUPercent > res[j].CPUPercent
+	})
+	return res, nil
+}
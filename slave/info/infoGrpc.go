@@ -0,0 +1,356 @@
+package info
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	infoGrpc "github.com/Maruqes/512SvMan/api/proto/info"
+	"github.com/Maruqes/512SvMan/logger"
+	"github.com/shirou/gopsutil/v4/disk"
+)
+
+const (
+	defaultMetricsInterval = 5 * time.Second
+	processSampleInterval  = time.Second
+	rateSampleInterval     = time.Second // between the counters of a disk or network only sample
+)
+
+type InfoService struct {
+	infoGrpc.UnimplementedSlaveInfoServiceServer
+}
+
+func cpuModelsToGRPC() ([]*infoGrpc.CpuModel, error) {
+	models, err := CPUInfo.GetCPUModel()
+	if err != nil {
+		return nil, fmt.Errorf("cpu model: %w", err)
+	}
+	res := make([]*infoGrpc.CpuModel, 0, len(models))
+	for key, cores := range models {
+		// key is model|physical id, one entry per socket
+		model, _, _ := strings.Cut(key, "|")
+		res = append(res, &infoGrpc.CpuModel{Model: model, Cores: int32(cores)})
+	}
+	return res, nil
+}
+
+// cpuInfo takes the per core usage sampled by the caller, the sample is what takes the time
+func cpuInfo(usage []float64) (*infoGrpc.CpuInfo, error) {
+	models, err := cpuModelsToGRPC()
+	if err != nil {
+		return nil, err
+	}
+	res := &infoGrpc.CpuInfo{Models: models, Usage: usage}
+	if len(usage) > 0 {
+		var total float64
+		for _, u := range usage {
+			total += u
+		}
+		res.UsageTotal = total / float64(len(usage))
+	}
+	// no sensors (vms, some servers) is not an error
+	if temps, err := CPUInfo.GetCpuTemps(); err == nil {
+		for _, t := range temps {
+			res.Temps = append(res.Temps, &infoGrpc.Temperature{Sensor: t.Sensor, Celsius: t.Celsius})
+		}
+	}
+	return res, nil
+}
+
+func memInfo() (*infoGrpc.MemInfo, error) {
+	v, err := MemInfo.GetMemStats()
+	if err != nil {
+		return nil, fmt.Errorf("memory: %w", err)
+	}
+	return &infoGrpc.MemInfo{
+		TotalMB:     int64(v.Total / 1024 / 1024),
+		UsedMB:      int64(v.Used / 1024 / 1024),
+		FreeMB:      int64(v.Free / 1024 / 1024),
+		UsedPercent: v.UsedPercent,
+	}, nil
+}
+
+// perSec is the rate between two counters taken elapsed apart, 0 if the counter went back (reboot, device readded)
+func perSec(before, after uint64, elapsed time.Duration) uint64 {
+	if after < before || elapsed <= 0 {
+		return 0
+	}
+	return uint64(float64(after-before) / elapsed.Seconds())
+}
+
+// disksInfo reads the usage of every disk, the rates come from io counters taken before and after the sample
+func disksInfo(ioBefore, ioAfter map[string]disk.IOCountersStat, elapsed time.Duration) (*infoGrpc.DisksInfo, error) {
+	usages, err := DiskInfo.GetDiskUsage()
+	if err != nil {
+		return nil, fmt.Errorf("disks: %w", err)
+	}
+	res := &infoGrpc.DisksInfo{}
+	for _, u := range usages {
+		d := &infoGrpc.DiskInfo{
+			Device:      u.Device,
+			Mountpoint:  u.Mountpoint,
+			Fstype:      u.Fstype,
+			TotalBytes:  u.TotalBytes,
+			UsedBytes:   u.UsedBytes,
+			UsedPercent: u.UsedPercent,
+		}
+		name := ioCounterName(u.Device)
+		if before, ok := ioBefore[name]; ok {
+			after := ioAfter[name]
+			d.ReadBytesPerSec = perSec(before.ReadBytes, after.ReadBytes, elapsed)
+			d.WriteBytesPerSec = perSec(before.WriteBytes, after.WriteBytes, elapsed)
+		}
+		res.Disks = append(res.Disks, d)
+	}
+	return res, nil
+}
+
+func networkInfo(before, after []InterfaceStats, elapsed time.Duration) *infoGrpc.NetworkInfo {
+	prev := make(map[string]InterfaceStats, len(before))
+	for _, s := range before {
+		prev[s.Name] = s
+	}
+	res := &infoGrpc.NetworkInfo{}
+	for _, s := range after {
+		iface := &infoGrpc.NetworkInterface{
+			Name:    s.Name,
+			Mac:     s.MAC,
+			Addrs:   s.Addrs,
+			Up:      s.Up,
+			RxBytes: s.RxBytes,
+			TxBytes: s.TxBytes,
+		}
+		if p, ok := prev[s.Name]; ok {
+			iface.RxBytesPerSec = perSec(p.RxBytes, s.RxBytes, elapsed)
+			iface.TxBytesPerSec = perSec(p.TxBytes, s.TxBytes, elapsed)
+		}
+		res.Interfaces = append(res.Interfaces, iface)
+	}
+	return res
+}
+
+// sampleMetrics takes about a second, cpu usage and the disk and network rates all cover that same second
+func sampleMetrics() (*infoGrpc.HostMetrics, error) {
+	ioBefore, err := DiskInfo.GetDiskIOUsage()
+	if err != nil {
+		return nil, fmt.Errorf("disk io: %w", err)
+	}
+	netBefore, err := NetworkInfo.GetInterfaceStats()
+	if err != nil {
+		return nil, fmt.Errorf("network: %w", err)
+	}
+	start := time.Now()
+
+	usage, err := CPUInfo.GetCPUUsage()
+	if err != nil {
+		return nil, fmt.Errorf("cpu usage: %w", err)
+	}
+
+	ioAfter, err := DiskInfo.GetDiskIOUsage()
+	if err != nil {
+		return nil, fmt.Errorf("disk io: %w", err)
+	}
+	netAfter, err := NetworkInfo.GetInterfaceStats()
+	if err != nil {
+		return nil, fmt.Errorf("network: %w", err)
+	}
+	elapsed := time.Since(start)
+
+	cpu, err := cpuInfo(usage)
+	if err != nil {
+		return nil, err
+	}
+	mem, err := memInfo()
+	if err != nil {
+		return nil, err
+	}
+	disks, err := disksInfo(ioBefore, ioAfter, elapsed)
+	if err != nil {
+		return nil, err
+	}
+	return &infoGrpc.HostMetrics{
+		Timestamp: time.Now().Unix(),
+		Cpu:       cpu,
+		Mem:       mem,
+		Disks:     disks,
+		Network:   networkInfo(netBefore, netAfter, elapsed),
+	}, nil
+}
+
+func (s *InfoService) GetCPUInfo(ctx context.Context, req *infoGrpc.Empty) (*infoGrpc.CpuInfo, error) {
+	usage, err := CPUInfo.GetCPUUsage()
+	if err != nil {
+		return nil, fmt.Errorf("cpu usage: %w", err)
+	}
+	return cpuInfo(usage)
+}
+
+func (s *InfoService) GetMemInfo(ctx context.Context, req *infoGrpc.Empty) (*infoGrpc.MemInfo, error) {
+	return memInfo()
+}
+
+// GetDiskInfo only takes the disk counters, the cpu and network are left alone
+func (s *InfoService) GetDiskInfo(ctx context.Context, req *infoGrpc.Empty) (*infoGrpc.DisksInfo, error) {
+	before, err := DiskInfo.GetDiskIOUsage()
+	if err != nil {
+		return nil, fmt.Errorf("disk io: %w", err)
+	}
+	start := time.Now()
+	time.Sleep(rateSampleInterval)
+	after, err := DiskInfo.GetDiskIOUsage()
+	if err != nil {
+		return nil, fmt.Errorf("disk io: %w", err)
+	}
+	return disksInfo(before, after, time.Since(start))
+}
+
+func (s *InfoService) GetNetworkInfo(ctx context.Context, req *infoGrpc.Empty) (*infoGrpc.NetworkInfo, error) {
+	before, err := NetworkInfo.GetInterfaceStats()
+	if err != nil {
+		return nil, fmt.Errorf("network: %w", err)
+	}
+	start := time.Now()
+	time.Sleep(rateSampleInterval)
+	after, err := NetworkInfo.GetInterfaceStats()
+	if err != nil {
+		return nil, fmt.Errorf("network: %w", err)
+	}
+	return networkInfo(before, after, time.Since(start)), nil
+}
+
+func (s *InfoService) GetProcesses(ctx context.Context, req *infoGrpc.ProcessesRequest) (*infoGrpc.ProcessList, error) {
+	sortBy := strings.ToLower(strings.TrimSpace(req.SortBy))
+	if sortBy != "" && sortBy != "cpu" && sortBy != "memory" {
+		return nil, fmt.Errorf("sortBy must be cpu or memory")
+	}
+	procs, err := ProcessInfo.GetProcesses(processSampleInterval, sortBy)
+	if err != nil {
+		return nil, fmt.Errorf("processes: %w", err)
+	}
+	if req.Limit > 0 && int(req.Limit) < len(procs) {
+		procs = procs[:req.Limit]
+	}
+	res := &infoGrpc.ProcessList{}
+	for _, p := range procs {
+		res.Processes = append(res.Processes, &infoGrpc.Process{
+			Pid:         p.Pid,
+			Name:        p.Name,
+			User:        p.User,
+			CpuPercent:  p.CPUPercent,
+			MemoryBytes: p.MemoryBytes,
+			Cmdline:     p.Cmdline,
+		})
+	}
+	return res, nil
+}
+
+func (s *InfoService) GetMetrics(ctx context.Context, req *infoGrpc.Empty) (*infoGrpc.HostMetrics, error) {
+	return sampleMetrics()
+}
+
+func (s *InfoService) StreamMetrics(req *infoGrpc.StreamMetricsRequest, stream infoGrpc.SlaveInfoService_StreamMetricsServer) error {
+	interval := defaultMetricsInterval
+	if req.IntervalSeconds > 0 {
+		interval = time.Duration(req.IntervalSeconds) * time.Second
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		metrics, err := sampleMetrics()
+		if err != nil {
+			return err
+		}
+		if err := stream.Send(metrics); err != nil {
+			return err
+		}
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+const defaultServiceLogLines = 100
+
+func serviceToGRPC(s Service) *infoGrpc.Service {
+	return &infoGrpc.Service{
+		Name:        s.Name,
+		Description: s.Description,
+		LoadState:   s.LoadState,
+		ActiveState: s.ActiveState,
+		SubState:    s.SubState,
+		Manageable:  IsManageableService(s.Name),
+	}
+}
+
+func (s *InfoService) GetServices(ctx context.Context, req *infoGrpc.Empty) (*infoGrpc.ServiceList, error) {
+	services, err := ServicesInfo.GetServices()
+	if err != nil {
+		return nil, fmt.Errorf("list units: %w", err)
+	}
+	res := &infoGrpc.ServiceList{}
+	for _, svc := range services {
+		res.Services = append(res.Services, serviceToGRPC(svc))
+	}
+	return res, nil
+}
+
+func (s *InfoService) GetServiceStatus(ctx context.Context, req *infoGrpc.ServiceRequest) (*infoGrpc.Service, error) {
+	name := NormalizeServiceName(req.Name)
+	if name == "" {
+		return nil, fmt.Errorf("service name is empty")
+	}
+	svc, err := ServicesInfo.GetServiceStatus(name)
+	if err != nil {
+		return nil, fmt.Errorf("status of %s: %w", name, err)
+	}
+	return serviceToGRPC(svc), nil
+}
+
+func (s *InfoService) GetServiceLogs(ctx context.Context, req *infoGrpc.ServiceLogsRequest) (*infoGrpc.ServiceLogs, error) {
+	name := NormalizeServiceName(req.Name)
+	if name == "" {
+		return nil, fmt.Errorf("service name is empty")
+	}
+	lines := int(req.Lines)
+	if lines <= 0 {
+		lines = defaultServiceLogLines
+	}
+	logs, err := ServicesInfo.GetLogs(name, lines)
+	if err != nil {
+		return nil, fmt.Errorf("logs of %s: %w", name, err)
+	}
+	return &infoGrpc.ServiceLogs{Logs: logs}, nil
+}
+
+// ServiceAction is checked against the allow-list here and not only on the master, the slave is the one that would lose sshd
+func (s *InfoService) ServiceAction(ctx context.Context, req *infoGrpc.ServiceActionRequest) (*infoGrpc.Empty, error) {
+	name := NormalizeServiceName(req.Name)
+	if !IsManageableService(name) {
+		return nil, fmt.Errorf("%s is not in the list of manageable services", name)
+	}
+
+	var err error
+	switch req.Action {
+	case "start":
+		err = ServicesInfo.StartService(name)
+	case "stop":
+		err = ServicesInfo.StopService(name)
+	case "restart":
+		err = ServicesInfo.RestartService(name)
+	case "enable":
+		err = ServicesInfo.EnableService(name)
+	case "disable":
+		err = ServicesInfo.DisableService(name)
+	default:
+		return nil, fmt.Errorf("unknown action %q, must be start, stop, restart, enable or disable", req.Action)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s %s: %w", req.Action, name, err)
+	}
+	logger.Info("service action from master", "action", req.Action, "service", name)
+	return &infoGrpc.Empty{}, nil
+}
@@ -0,0 +1,54 @@
+//go:build !nojournal
+
+package info
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/coreos/go-systemd/v22/sdjournal"
+)
+
+// sdjournal is cgo and needs the libsystemd headers to build, -tags nojournal builds the slave
+// without them and without service logs
+
+// GetLogs returns the last lines of the journal of the unit, oldest first
+func (s *ServicesInfoStruct) GetLogs(name string, lines int) (string, error) {
+	j, err := sdjournal.NewJournal()
+	if err != nil {
+		return "", err
+	}
+	defer j.Close()
+	// what the service itself logged, UNIT= only has systemd's messages about it
+	err = j.AddMatch("_SYSTEMD_UNIT=" + name)
+	if err != nil {
+		return "", err
+	}
+	if err := j.SeekTail(); err != nil {
+		return "", err
+	}
+
+	var entries []string
+	for len(entries) < lines {
+		n, err := j.Previous()
+		if err != nil {
+			return "", err
+		}
+		if n == 0 {
+			break
+		}
+		entry, err := j.GetEntry()
+		if err != nil {
+			return "", err
+		}
+		ts := time.Unix(0, int64(entry.RealtimeTimestamp)*int64(time.Microsecond)).Format(time.RFC3339)
+		entries = append(entries, fmt.Sprintf("%s %s\n", ts, entry.Fields["MESSAGE"]))
+	}
+
+	var logs strings.Builder
+	for i := len(entries) - 1; i >= 0; i-- {
+		logs.WriteString(entries[i])
+	}
+	return logs.String(), nil
+}
@@ -0,0 +1,10 @@
+//go:build nojournal
+
+package info
+
+import "fmt"
+
+// GetLogs is not available in a build without the journal (-tags nojournal)
+func (s *ServicesInfoStruct) GetLogs(name string, lines int) (string, error) {
+	return "", fmt.Errorf("slave built without journal support (nojournal), no logs for %s", name)
+}
@@ -39,3 +39,8 @@ func (m *MemInfoStruct) GetMemFreeMB() (int, error) {
 	}
 	return int(v.Free / 1024 / 1024), nil
 }
+
+// everything in one read, the getters above each read /proc/meminfo again
+func (m *MemInfoStruct) GetMemStats() (*mem.VirtualMemoryStat, error) {
+	return mem.VirtualMemory()
+}
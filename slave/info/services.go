@@ -3,16 +3,51 @@ package info
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/coreos/go-systemd/v22/dbus"
-	"github.com/coreos/go-systemd/v22/sdjournal"
 )
 
 type ServicesInfoStruct struct{}
 
 var ServicesInfo ServicesInfoStruct
 
+// units the master is allowed to start/stop/restart/enable/disable, anything else (sshd, NetworkManager...) is
+// read only so a call from the dashboard can not lock us out of the node
+var ManageableServices = []string{
+	"libvirtd.service",
+	"virtqemud.service",
+	"virtlogd.service",
+	"virtlockd.service",
+	"virtnetworkd.service",
+	"virtstoraged.service",
+	"nfs-server.service",
+	"nfs-mountd.service",
+	"rpcbind.service",
+	"firewalld.service",
+	"chronyd.service",
+}
+
+// NormalizeServiceName turns libvirtd into libvirtd.service, other unit types keep their suffix
+func NormalizeServiceName(name string) string {
+	name = strings.TrimSpace(name)
+	if name != "" && !strings.Contains(name, ".") {
+		name += ".service"
+	}
+	return name
+}
+
+func IsManageableService(name string) bool {
+	name = NormalizeServiceName(name)
+	for _, s := range ManageableServices {
+		if s == name {
+			return true
+		}
+	}
+	return false
+}
+
 type Service struct {
 	Name        string
 	Description string
@@ -65,52 +100,22 @@ func (s *ServicesInfoStruct) GetServiceStatus(name string) (Service, error) {
 		return Service{}, err
 	}
 
+	// a missing property would panic the whole slave with a plain type assertion
+	prop := func(key string) string {
+		v, _ := unitStatus[key].(string)
+		return v
+	}
 	service := Service{
 		Name:        name,
-		Description: unitStatus["Description"].(string),
-		LoadState:   unitStatus["LoadState"].(string),
-		ActiveState: unitStatus["ActiveState"].(string),
-		SubState:    unitStatus["SubState"].(string),
+		Description: prop("Description"),
+		LoadState:   prop("LoadState"),
+		ActiveState: prop("ActiveState"),
+		SubState:    prop("SubState"),
 	}
 
 	return service, nil
 }
 
-func (s *ServicesInfoStruct) GetLogs(name string, lines int) (string, error) {
-	j, err := sdjournal.NewJournal()
-	if err != nil {
-		return "", err
-	}
-	defer j.Close()
-	err = j.AddMatch("UNIT=" + name)
-	if err != nil {
-		return "", err
-	}
-
-	var logs string
-	count := 0
-	for {
-		if count >= lines {
-			break
-		}
-		n, err := j.Next()
-		if err != nil {
-			return "", err
-		}
-		if n == 0 {
-			break
-		}
-		entry, err := j.GetEntry()
-		if err != nil {
-			return "", err
-		}
-		ts := time.Unix(0, int64(entry.RealtimeTimestamp)*int64(time.Microsecond)).Format(time.RFC3339)
-		logs += fmt.Sprintf("%s %s\n", ts, entry.Fields["MESSAGE"])
-		count++
-	}
-	return logs, nil
-}
-
 func (s *ServicesInfoStruct) StartService(name string) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
@@ -5,14 +5,28 @@ import (
 	"fmt"
 	stdlog "log"
 	"slave/env512"
+	"sync"
 
 	logsGrpc "github.com/Maruqes/512SvMan/api/proto/logsserve"
 	"github.com/Maruqes/512SvMan/logger"
 	"google.golang.org/grpc"
 )
 
-var stream logsGrpc.LogsServe_RecordLogClient
+// how many lines may wait for the master, lines logged before the first stream wait here too. the ones
+// that do not fit go to the local log only
+const logQueueSize = 1024
 
+var (
+	// replaced every time the slave registers with the master again, only the writer sends on it
+	stream   logsGrpc.LogsServe_RecordLogClient
+	streamMu sync.Mutex
+
+	logQueue    = make(chan *logsGrpc.Log, logQueueSize)
+	startWriter sync.Once
+)
+
+// LogMessage queues the line for the master and returns at once, a slow or stuck stream never blocks
+// the caller
 func LogMessage(urgency int, msg string, fields ...interface{}) {
 	content := msg
 	if len(fields) > 0 {
@@ -21,18 +35,35 @@ func LogMessage(urgency int, msg string, fields ...interface{}) {
 		}
 	}
 
-	if stream == nil {
-		stdlog.Printf("[logs512] stream not ready (urgency %d): %s", urgency, content)
-		return
-	}
-
 	var entry logsGrpc.Log
 	entry.MachineName = env512.MachineName
 	entry.LogType = int32(urgency)
 	entry.Content = content
 
-	if err := stream.Send(&entry); err != nil {
-		stdlog.Printf("[logs512] failed to send log (urgency %d): %v -- %s", urgency, err, content)
+	select {
+	case logQueue <- &entry:
+	default:
+		stdlog.Printf("[logs512] queue full, dropped log (urgency %d): %s", urgency, content)
+	}
+}
+
+// writeLogs is the single goroutine that sends, it also closes a stream StartLogs replaced since grpc
+// does not allow CloseSend next to a Send
+func writeLogs() {
+	var cur logsGrpc.LogsServe_RecordLogClient
+	for entry := range logQueue {
+		streamMu.Lock()
+		latest := stream
+		streamMu.Unlock()
+		if latest != cur {
+			if cur != nil {
+				_ = cur.CloseSend()
+			}
+			cur = latest
+		}
+		if err := cur.Send(entry); err != nil {
+			stdlog.Printf("[logs512] failed to send log (urgency %d): %v -- %s", entry.LogType, err, entry.Content)
+		}
 	}
 }
 
@@ -44,5 +75,8 @@ func StartLogs(conn *grpc.ClientConn) {
 		logger.Error("Error starting log stream: %v", err)
 		return
 	}
+	streamMu.Lock()
 	stream = streamC
+	streamMu.Unlock()
+	startWriter.Do(func() { go writeLogs() })
 }
@@ -371,6 +371,8 @@ func main() {
 		log.Fatalf("setup all: %v", err)
 	}
 
+	virsh.StartHALeaseLoop()
+
 	conn := protocol.ConnectGRPC()
 	env512.SetConn(conn)
 	defer conn.Close()
@@ -0,0 +1,538 @@
+package network
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/Maruqes/512SvMan/logger"
+	libvirt "libvirt.org/go/libvirt"
+)
+
+const (
+	// networks defined by the master and the xml they were defined with,
+	// sync only ever removes networks listed here so hand made ones are left alone
+	managedNetworksFile = "/var/lib/512svman/networks.json"
+
+	ModeNAT      = "nat"
+	ModeRoute    = "route"
+	ModeIsolated = "isolated"
+	ModeBridge   = "bridge"
+)
+
+var (
+	networkNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]{0,63}$`)
+	deviceNamePattern  = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.:-]{0,14}$`)
+
+	// one network change at a time, sync and define both rewrite the managed file
+	networksMu sync.Mutex
+)
+
+type Network struct {
+	Name          string
+	Mode          string
+	Bridge        string
+	HostInterface string
+	IPAddress     string
+	Netmask       string
+	DHCPStart     string
+	DHCPEnd       string
+	Autostart     bool
+}
+
+type NetworkStatus struct {
+	Name      string
+	Active    bool
+	Autostart bool
+	Bridge    string
+	Managed   bool
+}
+
+type networkXML struct {
+	XMLName xml.Name    `xml:"network"`
+	Name    string      `xml:"name"`
+	Forward *forwardXML `xml:"forward,omitempty"`
+	Bridge  *bridgeXML  `xml:"bridge,omitempty"`
+	IP      *ipXML      `xml:"ip,omitempty"`
+}
+
+type forwardXML struct {
+	Mode       string             `xml:"mode,attr"`
+	Dev        string             `xml:"dev,attr,omitempty"`
+	Interfaces []forwardInterface `xml:"interface,omitempty"`
+}
+
+type forwardInterface struct {
+	Dev string `xml:"dev,attr"`
+}
+
+type bridgeXML struct {
+	Name  string `xml:"name,attr,omitempty"`
+	STP   string `xml:"stp,attr,omitempty"`
+	Delay string `xml:"delay,attr,omitempty"`
+}
+
+type ipXML struct {
+	Address string   `xml:"address,attr"`
+	Netmask string   `xml:"netmask,attr"`
+	DHCP    *dhcpXML `xml:"dhcp,omitempty"`
+}
+
+type dhcpXML struct {
+	Range dhcpRange `xml:"range"`
+}
+
+type dhcpRange struct {
+	Start string `xml:"start,attr"`
+	End   string `xml:"end,attr"`
+}
+
+func parseIPv4(value, field string) (net.IP, error) {
+	ip := net.ParseIP(strings.TrimSpace(value)).To4()
+	if ip == nil {
+		return nil, fmt.Errorf("invalid %s %q", field, value)
+	}
+	return ip, nil
+}
+
+func sameSubnet(a, b net.IP, mask net.IPMask) bool {
+	return a.Mask(mask).Equal(b.Mask(mask))
+}
+
+// ValidateNetwork checks the fields that make sense for the mode
+func ValidateNetwork(n Network) error {
+	if !networkNamePattern.MatchString(n.Name) {
+		return fmt.Errorf("invalid network name %q", n.Name)
+	}
+	if n.Name == "default" {
+		return fmt.Errorf("network name default is reserved for the libvirt default network")
+	}
+	if n.Bridge != "" && !deviceNamePattern.MatchString(n.Bridge) {
+		return fmt.Errorf("invalid bridge name %q", n.Bridge)
+	}
+	if n.HostInterface != "" && !deviceNamePattern.MatchString(n.HostInterface) {
+		return fmt.Errorf("invalid host interface %q", n.HostInterface)
+	}
+
+	switch n.Mode {
+	case ModeBridge:
+		if n.Bridge == "" && n.HostInterface == "" {
+			return fmt.Errorf("bridge network needs a host bridge or a host interface")
+		}
+		if n.Bridge != "" && n.HostInterface != "" {
+			return fmt.Errorf("bridge network takes a host bridge or a host interface, not both")
+		}
+		if n.IPAddress != "" || n.DHCPStart != "" || n.DHCPEnd != "" {
+			return fmt.Errorf("bridge network gets its addresses from the physical network, ip and dhcp must be empty")
+		}
+		return nil
+	case ModeNAT, ModeRoute:
+		if n.IPAddress == "" {
+			return fmt.Errorf("%s network needs an ip address", n.Mode)
+		}
+	case ModeIsolated:
+		if n.HostInterface != "" {
+			return fmt.Errorf("isolated network cannot have a host interface")
+		}
+		if n.IPAddress == "" {
+			// plain l2 segment between the vms
+			if n.DHCPStart != "" || n.DHCPEnd != "" {
+				return fmt.Errorf("dhcp needs an ip address")
+			}
+			return nil
+		}
+	default:
+		return fmt.Errorf("invalid network mode %q (nat, route, isolated or bridge)", n.Mode)
+	}
+	if n.Mode == ModeNAT && n.HostInterface != "" {
+		return fmt.Errorf("nat network goes out through the host routing table, host interface must be empty")
+	}
+
+	ip, err := parseIPv4(n.IPAddress, "ip address")
+	if err != nil {
+		return err
+	}
+	maskIP, err := parseIPv4(n.Netmask, "netmask")
+	if err != nil {
+		return err
+	}
+	mask := net.IPMask(maskIP)
+	if ones, bits := mask.Size(); bits == 0 || ones == 0 {
+		return fmt.Errorf("invalid netmask %q", n.Netmask)
+	}
+
+	if (n.DHCPStart == "") != (n.DHCPEnd == "") {
+		return fmt.Errorf("dhcp needs both start and end")
+	}
+	if n.DHCPStart == "" {
+		return nil
+	}
+	start, err := parseIPv4(n.DHCPStart, "dhcp start")
+	if err != nil {
+		return err
+	}
+	end, err := parseIPv4(n.DHCPEnd, "dhcp end")
+	if err != nil {
+		return err
+	}
+	if !sameSubnet(ip, start, mask) || !sameSubnet(ip, end, mask) {
+		return fmt.Errorf("dhcp range must be inside %s/%s", n.IPAddress, n.Netmask)
+	}
+	if bytes.Compare(start, end) > 0 {
+		return fmt.Errorf("dhcp start must come before dhcp end")
+	}
+	return nil
+}
+
+// BuildNetworkXML renders the libvirt definition, the same input gives the same xml on every slave
+func BuildNetworkXML(n Network) (string, error) {
+	if err := ValidateNetwork(n); err != nil {
+		return "", err
+	}
+
+	def := networkXML{Name: n.Name}
+	switch n.Mode {
+	case ModeBridge:
+		def.Forward = &forwardXML{Mode: "bridge"}
+		if n.Bridge != "" {
+			def.Bridge = &bridgeXML{Name: n.Bridge}
+		} else {
+			// macvtap on top of the nic, no bridge needed on the host
+			def.Forward.Interfaces = []forwardInterface{{Dev: n.HostInterface}}
+		}
+	case ModeNAT:
+		def.Forward = &forwardXML{Mode: "nat"}
+	case ModeRoute:
+		def.Forward = &forwardXML{Mode: "route", Dev: n.HostInterface}
+	}
+
+	if n.Mode != ModeBridge {
+		// empty name lets libvirt pick the next free virbrN
+		def.Bridge = &bridgeXML{Name: n.Bridge, STP: "on", Delay: "0"}
+		if n.IPAddress != "" {
+			def.IP = &ipXML{Address: n.IPAddress, Netmask: n.Netmask}
+			if n.DHCPStart != "" {
+				def.IP.DHCP = &dhcpXML{Range: dhcpRange{Start: n.DHCPStart, End: n.DHCPEnd}}
+			}
+		}
+	}
+
+	out, err := xml.MarshalIndent(def, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("marshal network xml: %w", err)
+	}
+	return string(out), nil
+}
+
+// the host side devices must exist on this slave, the master cannot know that
+func checkHostDevices(n Network) error {
+	var devices []string
+	if n.Mode == ModeBridge && n.Bridge != "" {
+		devices = append(devices, n.Bridge)
+	}
+	if n.HostInterface != "" {
+		devices = append(devices, n.HostInterface)
+	}
+	for _, dev := range devices {
+		if _, err := os.Stat(filepath.Join("/sys/class/net", dev)); err != nil {
+			return fmt.Errorf("network %s: host device %s not found on this slave", n.Name, dev)
+		}
+	}
+	return nil
+}
+
+func loadManagedNetworks() (map[string]string, error) {
+	managed := make(map[string]string)
+	data, err := os.ReadFile(managedNetworksFile)
+	if errors.Is(err, os.ErrNotExist) {
+		return managed, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read managed networks: %w", err)
+	}
+	if len(strings.TrimSpace(string(data))) == 0 {
+		return managed, nil
+	}
+	if err := json.Unmarshal(data, &managed); err != nil {
+		return nil, fmt.Errorf("parse managed networks: %w", err)
+	}
+	return managed, nil
+}
+
+func saveManagedNetworks(managed map[string]string) error {
+	if err := os.MkdirAll(filepath.Dir(managedNetworksFile), 0o755); err != nil {
+		return fmt.Errorf("managed networks dir: %w", err)
+	}
+	data, err := json.MarshalIndent(managed, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal managed networks: %w", err)
+	}
+	tmp := managedNetworksFile + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("write managed networks: %w", err)
+	}
+	return os.Rename(tmp, managedNetworksFile)
+}
+
+// networkUsers returns the domains with an interface on the network, running or not
+func networkUsers(conn *libvirt.Connect, name string) ([]string, error) {
+	doms, err := conn.ListAllDomains(0)
+	if err != nil {
+		return nil, fmt.Errorf("list domains: %w", err)
+	}
+
+	var users []string
+	for _, dom := range doms {
+		xmlDesc, err := dom.GetXMLDesc(libvirt.DOMAIN_XML_INACTIVE)
+		domName, nameErr := dom.GetName()
+		dom.Free()
+		if err != nil || nameErr != nil {
+			continue
+		}
+		var def struct {
+			Interfaces []struct {
+				Type   string `xml:"type,attr"`
+				Source struct {
+					Network string `xml:"network,attr"`
+				} `xml:"source"`
+			} `xml:"devices>interface"`
+		}
+		if err := xml.Unmarshal([]byte(xmlDesc), &def); err != nil {
+			continue
+		}
+		for _, iface := range def.Interfaces {
+			if iface.Type == "network" && iface.Source.Network == name {
+				users = append(users, domName)
+				break
+			}
+		}
+	}
+	sort.Strings(users)
+	return users, nil
+}
+
+// applyNetwork defines (or redefines when the xml changed) and starts the network
+func applyNetwork(conn *libvirt.Connect, n Network, xmlDesc, previous string) error {
+	if err := checkHostDevices(n); err != nil {
+		return err
+	}
+
+	existing, err := conn.LookupNetworkByName(n.Name)
+	if err == nil {
+		defer existing.Free()
+		if previous != xmlDesc {
+			logger.Info("redefining network", "name", n.Name)
+			if active, _ := existing.IsActive(); active {
+				// the new config only applies after a restart, vms on it lose the link for a moment
+				if err := existing.Destroy(); err != nil {
+					return fmt.Errorf("stop network %s: %w", n.Name, err)
+				}
+			}
+		}
+	}
+
+	var netw *libvirt.Network
+	if err != nil || previous != xmlDesc {
+		netw, err = conn.NetworkDefineXML(xmlDesc)
+		if err != nil {
+			return fmt.Errorf("define network %s: %w", n.Name, err)
+		}
+		defer netw.Free()
+	} else {
+		netw = existing
+	}
+
+	if err := netw.SetAutostart(n.Autostart); err != nil {
+		return fmt.Errorf("autostart network %s: %w", n.Name, err)
+	}
+	active, err := netw.IsActive()
+	if err != nil {
+		return fmt.Errorf("network %s state: %w", n.Name, err)
+	}
+	if !active {
+		if err := netw.Create(); err != nil {
+			return fmt.Errorf("start network %s: %w", n.Name, err)
+		}
+	}
+	return nil
+}
+
+func removeNetwork(conn *libvirt.Connect, name string) error {
+	users, err := networkUsers(conn, name)
+	if err != nil {
+		return err
+	}
+	if len(users) > 0 {
+		return fmt.Errorf("network %s is used by vms %v", name, users)
+	}
+
+	netw, err := conn.LookupNetworkByName(name)
+	if err != nil {
+		var lvErr libvirt.Error
+		if errors.As(err, &lvErr) && lvErr.Code == libvirt.ERR_NO_NETWORK {
+			return nil
+		}
+		return fmt.Errorf("lookup network %s: %w", name, err)
+	}
+	defer netw.Free()
+
+	if active, _ := netw.IsActive(); active {
+		if err := netw.Destroy(); err != nil {
+			return fmt.Errorf("stop network %s: %w", name, err)
+		}
+	}
+	if err := netw.Undefine(); err != nil {
+		return fmt.Errorf("undefine network %s: %w", name, err)
+	}
+	return nil
+}
+
+func DefineNetwork(n Network) error {
+	xmlDesc, err := BuildNetworkXML(n)
+	if err != nil {
+		return err
+	}
+
+	networksMu.Lock()
+	defer networksMu.Unlock()
+
+	managed, err := loadManagedNetworks()
+	if err != nil {
+		return err
+	}
+
+	conn, err := libvirt.NewConnect("qemu:///system")
+	if err != nil {
+		return fmt.Errorf("connect: %w", err)
+	}
+	defer conn.Close()
+
+	if err := applyNetwork(conn, n, xmlDesc, managed[n.Name]); err != nil {
+		return err
+	}
+	managed[n.Name] = xmlDesc
+	return saveManagedNetworks(managed)
+}
+
+func RemoveNetwork(name string) error {
+	networksMu.Lock()
+	defer networksMu.Unlock()
+
+	managed, err := loadManagedNetworks()
+	if err != nil {
+		return err
+	}
+	if _, ok := managed[name]; !ok {
+		return fmt.Errorf("network %s is not managed by 512SvMan", name)
+	}
+
+	conn, err := libvirt.NewConnect("qemu:///system")
+	if err != nil {
+		return fmt.Errorf("connect: %w", err)
+	}
+	defer conn.Close()
+
+	if err := removeNetwork(conn, name); err != nil {
+		return err
+	}
+	delete(managed, name)
+	return saveManagedNetworks(managed)
+}
+
+// SyncNetworks makes the managed networks on this slave match the list from the master
+func SyncNetworks(networks []Network) error {
+	networksMu.Lock()
+	defer networksMu.Unlock()
+
+	managed, err := loadManagedNetworks()
+	if err != nil {
+		return err
+	}
+
+	conn, err := libvirt.NewConnect("qemu:///system")
+	if err != nil {
+		return fmt.Errorf("connect: %w", err)
+	}
+	defer conn.Close()
+
+	var errs []error
+	wanted := make(map[string]struct{}, len(networks))
+	for _, n := range networks {
+		wanted[n.Name] = struct{}{}
+		xmlDesc, err := BuildNetworkXML(n)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if err := applyNetwork(conn, n, xmlDesc, managed[n.Name]); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		managed[n.Name] = xmlDesc
+	}
+
+	for name := range managed {
+		if _, ok := wanted[name]; ok {
+			continue
+		}
+		if err := removeNetwork(conn, name); err != nil {
+			// stays managed so the next sync tries again
+			errs = append(errs, err)
+			continue
+		}
+		delete(managed, name)
+	}
+
+	if err := saveManagedNetworks(managed); err != nil {
+		errs = append(errs, err)
+	}
+	logger.Info("networks synchronized", "count", len(networks))
+	return errors.Join(errs...)
+}
+
+func ListNetworks() ([]NetworkStatus, error) {
+	networksMu.Lock()
+	managed, err := loadManagedNetworks()
+	networksMu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := libvirt.NewConnect("qemu:///system")
+	if err != nil {
+		return nil, fmt.Errorf("connect: %w", err)
+	}
+	defer conn.Close()
+
+	nets, err := conn.ListAllNetworks(0)
+	if err != nil {
+		return nil, fmt.Errorf("list networks: %w", err)
+	}
+
+	res := make([]NetworkStatus, 0, len(nets))
+	for _, netw := range nets {
+		name, err := netw.GetName()
+		if err != nil {
+			netw.Free()
+			continue
+		}
+		status := NetworkStatus{Name: name}
+		status.Active, _ = netw.IsActive()
+		status.Autostart, _ = netw.GetAutostart()
+		status.Bridge, _ = netw.GetBridgeName()
+		_, status.Managed = managed[name]
+		netw.Free()
+		res = append(res, status)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Name < res[j].Name })
+	return res, nil
+}
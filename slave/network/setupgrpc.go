@@ -0,0 +1,76 @@
+package network
+
+import (
+	"context"
+
+	pb "github.com/Maruqes/512SvMan/api/proto/network"
+	"github.com/Maruqes/512SvMan/logger"
+)
+
+type NetworkService struct {
+	pb.UnimplementedNetworkServiceServer
+}
+
+func networkFromGRPC(n *pb.Network) Network {
+	return Network{
+		Name:          n.Name,
+		Mode:          n.Mode,
+		Bridge:        n.Bridge,
+		HostInterface: n.HostInterface,
+		IPAddress:     n.IpAddress,
+		Netmask:       n.Netmask,
+		DHCPStart:     n.DhcpStart,
+		DHCPEnd:       n.DhcpEnd,
+		Autostart:     n.Autostart,
+	}
+}
+
+func (s *NetworkService) DefineNetwork(ctx context.Context, req *pb.Network) (*pb.NetworkResponse, error) {
+	if err := DefineNetwork(networkFromGRPC(req)); err != nil {
+		logger.Error("DefineNetwork failed", "error", err)
+		return &pb.NetworkResponse{Ok: false}, err
+	}
+	logger.Info("DefineNetwork succeeded", "name", req.Name, "mode", req.Mode)
+	return &pb.NetworkResponse{Ok: true}, nil
+}
+
+func (s *NetworkService) RemoveNetwork(ctx context.Context, req *pb.NetworkName) (*pb.NetworkResponse, error) {
+	if err := RemoveNetwork(req.Name); err != nil {
+		logger.Error("RemoveNetwork failed", "error", err)
+		return &pb.NetworkResponse{Ok: false}, err
+	}
+	logger.Info("RemoveNetwork succeeded", "name", req.Name)
+	return &pb.NetworkResponse{Ok: true}, nil
+}
+
+func (s *NetworkService) SyncNetworks(ctx context.Context, req *pb.NetworkList) (*pb.NetworkResponse, error) {
+	networks := make([]Network, 0, len(req.Networks))
+	for _, n := range req.Networks {
+		networks = append(networks, networkFromGRPC(n))
+	}
+	if err := SyncNetworks(networks); err != nil {
+		logger.Error("SyncNetworks failed", "error", err)
+		return &pb.NetworkResponse{Ok: false}, err
+	}
+	logger.Info("SyncNetworks succeeded", "count", len(req.Networks))
+	return &pb.NetworkResponse{Ok: true}, nil
+}
+
+func (s *NetworkService) ListNetworks(ctx context.Context, req *pb.Empty) (*pb.NetworkStatusList, error) {
+	networks, err := ListNetworks()
+	if err != nil {
+		logger.Error("ListNetworks failed", "error", err)
+		return &pb.NetworkStatusList{}, err
+	}
+	res := &pb.NetworkStatusList{}
+	for _, n := range networks {
+		res.Networks = append(res.Networks, &pb.NetworkStatus{
+			Name:      n.Name,
+			Active:    n.Active,
+			Autostart: n.Autostart,
+			Bridge:    n.Bridge,
+			Managed:   n.Managed,
+		})
+	}
+	return res, nil
+}
@@ -84,6 +84,8 @@ func isMounted(target string) bool {
 }
 
 func MonitorMounts() {
+	// targets the master was told failed, it hears again once they are back
+	remountFailed := map[string]bool{}
 	for {
 		CurrentMountsLock.RLock()
 		mounts := append([]FolderMount(nil), CurrentMounts...)
@@ -106,10 +108,17 @@ func MonitorMounts() {
 				}
 				if !success {
 					logger.Error("Failed to remount NFS share after multiple attempts:", mount.Target)
+					_ = extra.ReportEvent("nfs_remount_failed", mount.Target,
+						fmt.Sprintf("failed to remount %s from %s after %d attempts", mount.Target, mount.Source, monitorFailureThreshold), false)
+					remountFailed[mount.Target] = true
 				} else {
 					logger.Info("Successfully remounted NFS share:", mount.Target)
 				}
 			}
+			if remountFailed[mount.Target] && isMounted(mount.Target) {
+				_ = extra.ReportEvent("nfs_remount_failed", mount.Target, "remounted "+mount.Target, true)
+				delete(remountFailed, mount.Target)
+			}
 		}
 		time.Sleep(monitorInterval)
 	}
@@ -0,0 +1,299 @@
+package pki
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	pb "github.com/Maruqes/512SvMan/api/proto/protocol"
+	"github.com/Maruqes/512SvMan/logger"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+const (
+	// must match the name in the master certificate
+	MasterServerName = "512svman-master"
+
+	pkiDir = "pki"
+	// the master issues 90 day certificates, a new one is asked for when less than this is left
+	renewBefore   = 30 * 24 * time.Hour
+	renewInterval = 12 * time.Hour
+)
+
+var (
+	// swapped on renewal, the tls configs read them on every handshake
+	caPool    atomic.Pointer[x509.CertPool]
+	slaveCert atomic.Pointer[tls.Certificate]
+)
+
+func caPath() string   { return filepath.Join(pkiDir, "ca.crt") }
+func certPath() string { return filepath.Join(pkiDir, "slave.crt") }
+func keyPath() string  { return filepath.Join(pkiDir, "slave.key") }
+
+// newCSR makes a key and a request for machineName, the key pem is only written once the certificate comes back
+func newCSR(machineName string) (csrPEM, keyPEM []byte, err error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	der, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject: pkix.Name{CommonName: machineName},
+	}, key)
+	if err != nil {
+		return nil, nil, err
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), nil
+}
+
+// writeFile goes through a temp file so a crash never leaves half a key behind
+func writeFile(path string, data []byte, mode os.FileMode) error {
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, mode); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// store saves what the master sent and starts using it
+func store(certPEM, keyPEM, ca []byte) error {
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return fmt.Errorf("certificate from master: %w", err)
+	}
+	if cert.Leaf, err = x509.ParseCertificate(cert.Certificate[0]); err != nil {
+		return err
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(ca) {
+		return fmt.Errorf("ca from master is not pem")
+	}
+	if err := writeFile(caPath(), ca, 0o644); err != nil {
+		return err
+	}
+	if err := writeFile(keyPath(), keyPEM, 0o600); err != nil {
+		return err
+	}
+	if err := writeFile(certPath(), certPEM, 0o644); err != nil {
+		return err
+	}
+	caPool.Store(pool)
+	slaveCert.Store(&cert)
+	return nil
+}
+
+func load() error {
+	ca, err := os.ReadFile(caPath())
+	if err != nil {
+		return err
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(ca) {
+		return fmt.Errorf("%s is not pem", caPath())
+	}
+	cert, err := tls.LoadX509KeyPair(certPath(), keyPath())
+	if err != nil {
+		return err
+	}
+	if cert.Leaf, err = x509.ParseCertificate(cert.Certificate[0]); err != nil {
+		return err
+	}
+	caPool.Store(pool)
+	slaveCert.Store(&cert)
+	return nil
+}
+
+// enrollTLSConfig trusts the master only if the CA it sends has the fingerprint from the token
+func enrollTLSConfig(fingerprint string) *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS13,
+		// verified below against the pinned ca, there is no ca to give the normal check yet
+		InsecureSkipVerify: true,
+		VerifyPeerCertificate: func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+			pool := x509.NewCertPool()
+			for _, raw := range rawCerts {
+				sum := sha256.Sum256(raw)
+				if hex.EncodeToString(sum[:]) == fingerprint {
+					ca, err := x509.ParseCertificate(raw)
+					if err != nil {
+						return err
+					}
+					pool.AddCert(ca)
+				}
+			}
+			if len(rawCerts) == 0 {
+				return fmt.Errorf("master sent no certificate")
+			}
+			leaf, err := x509.ParseCertificate(rawCerts[0])
+			if err != nil {
+				return err
+			}
+			_, err = leaf.Verify(x509.VerifyOptions{DNSName: MasterServerName, Roots: pool})
+			if err != nil {
+				return fmt.Errorf("master certificate does not match the enrollment token: %w", err)
+			}
+			return nil
+		},
+	}
+}
+
+func enroll(masterTarget, machineName, token string) error {
+	secret, fingerprint, ok := strings.Cut(strings.TrimSpace(token), ".")
+	if !ok || secret == "" || len(fingerprint) != sha256.Size*2 {
+		return fmt.Errorf("ENROLL_TOKEN must be <secret>.<ca fingerprint> as given by the master")
+	}
+
+	csr, keyPEM, err := newCSR(machineName)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	conn, err := grpc.DialContext(ctx, masterTarget, grpc.WithTransportCredentials(credentials.NewTLS(enrollTLSConfig(strings.ToLower(fingerprint)))), grpc.WithBlock())
+	if err != nil {
+		return fmt.Errorf("dial master: %w", err)
+	}
+	defer conn.Close()
+
+	res, err := pb.NewProtocolServiceClient(conn).Enroll(ctx, &pb.EnrollRequest{Token: secret, MachineName: machineName, Csr: csr})
+	if err != nil {
+		return fmt.Errorf("enroll: %w", err)
+	}
+	return store(res.Certificate, keyPEM, res.Ca)
+}
+
+// Setup loads the certificate from ./pki, or enrolls with the token the first time
+func Setup(masterTarget, machineName, token string) error {
+	if err := os.MkdirAll(pkiDir, 0o700); err != nil {
+		return err
+	}
+	err := load()
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	if token == "" {
+		return fmt.Errorf("no certificate in %s, set ENROLL_TOKEN to a token from the master", pkiDir)
+	}
+	if err := enroll(masterTarget, machineName, token); err != nil {
+		return err
+	}
+	logger.Info("enrolled with master", "machine", machineName)
+	return nil
+}
+
+func currentCert() (*tls.Certificate, error) {
+	cert := slaveCert.Load()
+	if cert == nil {
+		return nil, fmt.Errorf("slave certificate not loaded")
+	}
+	return cert, nil
+}
+
+// ClientTLSConfig is for the connection to the master on :50051
+func ClientTLSConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS13,
+		ServerName: MasterServerName,
+		// RootCAs is fixed when the config is made, the master is verified below against the current ca
+		InsecureSkipVerify: true,
+		VerifyConnection: func(cs tls.ConnectionState) error {
+			pool := caPool.Load()
+			if pool == nil {
+				return fmt.Errorf("ca not loaded")
+			}
+			if len(cs.PeerCertificates) == 0 {
+				return fmt.Errorf("master sent no certificate")
+			}
+			opts := x509.VerifyOptions{
+				DNSName:       MasterServerName,
+				Roots:         pool,
+				Intermediates: x509.NewCertPool(),
+			}
+			for _, cert := range cs.PeerCertificates[1:] {
+				opts.Intermediates.AddCert(cert)
+			}
+			_, err := cs.PeerCertificates[0].Verify(opts)
+			return err
+		},
+		GetClientCertificate: func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			return currentCert()
+		},
+	}
+}
+
+// ServerTLSConfig is for :50052, only the master may call, another slave's certificate is refused
+func ServerTLSConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS13,
+		// a new config per handshake so a ca stored on renewal is the one checked
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			return &tls.Config{
+				MinVersion: tls.VersionTLS13,
+				GetCertificate: func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+					return currentCert()
+				},
+				ClientAuth: tls.RequireAndVerifyClientCert,
+				ClientCAs:  caPool.Load(),
+				VerifyConnection: func(cs tls.ConnectionState) error {
+					if len(cs.PeerCertificates) == 0 || cs.PeerCertificates[0].Subject.CommonName != MasterServerName {
+						return fmt.Errorf("only the master may connect")
+					}
+					return nil
+				},
+			}, nil
+		},
+	}
+}
+
+func renew(conn *grpc.ClientConn, machineName string) error {
+	csr, keyPEM, err := newCSR(machineName)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	res, err := pb.NewProtocolServiceClient(conn).RenewCertificate(ctx, &pb.RenewRequest{Csr: csr})
+	if err != nil {
+		return err
+	}
+	return store(res.Certificate, keyPEM, res.Ca)
+}
+
+// StartRenewal asks the master for a new certificate before the current one expires, connections already
+// open keep working and the vms are never touched
+func StartRenewal(conn *grpc.ClientConn, machineName string) {
+	go func() {
+		for {
+			if cert, err := currentCert(); err == nil && time.Until(cert.Leaf.NotAfter) < renewBefore {
+				if err := renew(conn, machineName); err != nil {
+					logger.Error("certificate renewal failed", "err", err)
+				} else {
+					logger.Info("certificate renewed")
+				}
+			}
+			time.Sleep(renewInterval)
+		}
+	}()
+}
@@ -4,38 +4,82 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"math/rand"
 	"net"
-	"os"
 	"slave/env512"
 	"slave/extra"
+	"slave/info"
 	"slave/logs512"
+	networkservice "slave/network"
 	nfsservice "slave/nfs"
+	"slave/pki"
 	"slave/virsh"
-	"syscall"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	extraGrpc "github.com/Maruqes/512SvMan/api/proto/extra"
+	infoGrpc "github.com/Maruqes/512SvMan/api/proto/info"
+	networkproto "github.com/Maruqes/512SvMan/api/proto/network"
 	nfsproto "github.com/Maruqes/512SvMan/api/proto/nfs"
 	pb "github.com/Maruqes/512SvMan/api/proto/protocol"
 	grpcVirsh "github.com/Maruqes/512SvMan/api/proto/virsh"
 
 	"github.com/Maruqes/512SvMan/logger"
 	"google.golang.org/grpc"
+	grpcbackoff "google.golang.org/grpc/backoff"
 	"google.golang.org/grpc/connectivity"
-	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/credentials"
 )
 
-func restartSelf() error {
-	exe, err := os.Executable()
-	if err != nil {
-		return err
+const (
+	reconnectMinBackoff = time.Second
+	reconnectMaxBackoff = time.Minute
+	// first master protocol whose Notify says if it knows the slave, older ones always answer false
+	masterKnownProto = 2
+)
+
+var (
+	// the monitor and the pings may both decide to register, one at a time
+	registerMu sync.Mutex
+	// protocol of the master as of the last registration
+	masterProto atomic.Uint32
+)
+
+// nextBackoff doubles up to reconnectMaxBackoff, the jitter keeps a rack of slaves from hitting a master
+// that just came back all in the same second
+func nextBackoff(cur time.Duration) time.Duration {
+	next := cur * 2
+	if next > reconnectMaxBackoff {
+		next = reconnectMaxBackoff
 	}
+	return next/2 + time.Duration(rand.Int63n(int64(next/2)+1))
+}
 
-	args := os.Args
-	env := os.Environ()
+// register tells the master where we listen and what we are, the master dials back, syncs nfs and networks, then the logs
+// stream is opened on top
+func register(conn *grpc.ClientConn) error {
+	registerMu.Lock()
+	defer registerMu.Unlock()
 
-	logger.Info("Restarting process...")
-	return syscall.Exec(exe, args, env)
+	caps, err := capabilities()
+	if err != nil {
+		return fmt.Errorf("capabilities: %w", err)
+	}
+	h := pb.NewProtocolServiceClient(conn)
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	outR, err := h.SetConnection(ctx, &pb.SetConnectionRequest{Addr: env512.SlaveIP, MachineName: env512.MachineName, Capabilities: caps})
+	cancel()
+	if err != nil {
+		return fmt.Errorf("SetConnection: %w", err)
+	}
+	logger.Info("registered with master", "reply", outR.GetOk(), "master_version", outR.GetBuildVersion(), "master_proto", outR.GetProtoVersion())
+	masterProto.Store(outR.GetProtoVersion())
+	for _, warning := range outR.GetWarnings() {
+		logger.Warn("master: " + warning)
+	}
+	logs512.StartLogs(conn)
+	return nil
 }
 
 // === Servidor do CLIENTE (ClientService) ===
@@ -53,38 +97,60 @@ func listenGRPC() {
 	if err != nil {
 		log.Fatalf("listen: %v", err)
 	}
-	s := grpc.NewServer()
+	// only the master, with a certificate from our ca, gets in
+	s := grpc.NewServer(grpc.Creds(credentials.NewTLS(pki.ServerTLSConfig())))
 
 	//registar services
 	pb.RegisterClientServiceServer(s, &clientServer{})
 	nfsproto.RegisterNFSServiceServer(s, &nfsservice.NFSService{})
 	grpcVirsh.RegisterSlaveVirshServiceServer(s, &virsh.SlaveVirshService{})
+	networkproto.RegisterNetworkServiceServer(s, &networkservice.NetworkService{})
 	extraGrpc.RegisterExtraServiceServer(s, &extra.ExtraService{})
+	infoGrpc.RegisterSlaveInfoServiceServer(s, &info.InfoService{})
 	logger.Info("Cliente a ouvir em :50052")
 	if err := s.Serve(lis); err != nil {
 		logger.Error("serve: %v", err)
 	}
 }
 
+// monitorConnection keeps the link with the master up, grpc redials the transport on its own backoff and
+// every time it comes back Ready the slave registers again, the process, its server on :50052 and whatever
+// it was doing (downloads, migrations, mount monitor) carry on untouched
 func monitorConnection(conn *grpc.ClientConn) {
 	ctx := context.Background()
+	// the master only knows about us while the transport we registered on is alive
+	registered := true
+	backoff := reconnectMinBackoff
 	for {
 		state := conn.GetState()
 		switch state {
 		case connectivity.Ready:
-			// healthy, nothing to do
+			if !registered {
+				if err := register(conn); err != nil {
+					logger.Error("register with master failed, retrying in %s: %v", backoff, err)
+					time.Sleep(backoff)
+					backoff = nextBackoff(backoff)
+					// still Ready, WaitForStateChange would block forever
+					continue
+				}
+				registered = true
+				backoff = reconnectMinBackoff
+				logger.Info("reconnected to master")
+			}
 		case connectivity.Idle:
+			registered = false
 			logger.Info("connection to master idle, forcing reconnect")
 			conn.Connect()
 		case connectivity.Connecting:
+			registered = false
 			logger.Info("connection to master reconnecting...")
-		case connectivity.Shutdown, connectivity.TransientFailure:
-			logger.Info("connection to master lost (state: %s), restarting", state)
-			_ = conn.Close()
-			if err := restartSelf(); err != nil {
-				logger.Error("failed to restart slave process: %v", err)
+		case connectivity.TransientFailure:
+			if registered {
+				logger.Warn("connection to master lost, reconnecting")
 			}
-			os.Exit(1)
+			registered = false
+		case connectivity.Shutdown:
+			logger.Info("connection to master closed, stopping monitor")
 			return
 		default:
 			logger.Info("connection state changed: %s", state)
@@ -96,14 +162,21 @@ func monitorConnection(conn *grpc.ClientConn) {
 	}
 }
 
+// PingMaster also catches a master that forgot us while the transport stayed up (it dropped us after
+// missed pings, or restarted behind a proxy), monitorConnection never sees a state change for those
 func PingMaster(conn *grpc.ClientConn) {
 	for {
 		h := pb.NewProtocolServiceClient(conn)
 		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-		_, err := h.Notify(ctx, &pb.NotifyRequest{Text: "Ping do Slave"})
+		resp, err := h.Notify(ctx, &pb.NotifyRequest{Text: "Ping do Slave"})
 		cancel()
 		if err != nil {
 			logger.Error("PingMaster: %v", err)
+		} else if !resp.GetKnown() && masterProto.Load() >= masterKnownProto {
+			logger.Warn("master does not know this slave anymore, registering again")
+			if err := register(conn); err != nil {
+				logger.Error("register with master failed: %v", err)
+			}
 		}
 		//ping every 30 seconds
 		time.Sleep(time.Duration(env512.PingInterval) * time.Second)
@@ -113,33 +186,53 @@ func PingMaster(conn *grpc.ClientConn) {
 func ConnectGRPC() *grpc.ClientConn {
 
 	target := fmt.Sprintf("%s:50051", env512.MasterIP)
+
+	// the first run enrolls with ENROLL_TOKEN, the master may not be up yet
+	for {
+		err := pki.Setup(target, env512.MachineName, env512.EnrollToken)
+		if err == nil {
+			break
+		}
+		logger.Error("certificate setup failed: %v", err)
+		time.Sleep(3 * time.Second)
+	}
 	go listenGRPC()
 
+	backoff := reconnectMinBackoff
 	for {
 		logger.Info("Connecting to master at", target)
 		ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
-		conn, err := grpc.DialContext(ctx, target, grpc.WithTransportCredentials(insecure.NewCredentials()), grpc.WithBlock())
+		conn, err := grpc.DialContext(ctx, target,
+			grpc.WithTransportCredentials(credentials.NewTLS(pki.ClientTLSConfig())),
+			grpc.WithConnectParams(grpc.ConnectParams{
+				Backoff: grpcbackoff.Config{
+					BaseDelay:  reconnectMinBackoff,
+					Multiplier: 1.6,
+					Jitter:     0.2,
+					MaxDelay:   reconnectMaxBackoff,
+				},
+				MinConnectTimeout: 10 * time.Second,
+			}),
+			grpc.WithBlock(),
+		)
 		cancel()
 		if err != nil {
 			logger.Error("dial master failed: %v", err)
-			time.Sleep(3 * time.Second)
+			time.Sleep(backoff)
+			backoff = nextBackoff(backoff)
 			continue
 		}
-		logs512.StartLogs(conn)
-		h := pb.NewProtocolServiceClient(conn)
-		reqCtx, reqCancel := context.WithTimeout(context.Background(), 60*time.Second)
-		outR, err := h.SetConnection(reqCtx, &pb.SetConnectionRequest{Addr: env512.SlaveIP, MachineName: env512.MachineName})
-		reqCancel()
-		if err != nil {
-			logger.Error("SetConnection failed: %v", err)
+		if err := register(conn); err != nil {
+			logger.Error("%v", err)
 			conn.Close()
-			time.Sleep(3 * time.Second)
+			time.Sleep(backoff)
+			backoff = nextBackoff(backoff)
 			continue
 		}
 
-		logger.Info("Resposta do master: %s", outR.GetOk())
 		go monitorConnection(conn)
 		go PingMaster(conn)
+		pki.StartRenewal(conn, env512.MachineName)
 		return conn
 	}
 }
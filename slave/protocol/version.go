@@ -0,0 +1,34 @@
+package protocol
+
+import (
+	"slave/virsh"
+
+	pb "github.com/Maruqes/512SvMan/api/proto/protocol"
+	"github.com/Maruqes/512SvMan/api/version"
+)
+
+// what this build of the slave can do
+var Features = []string{
+	version.FeatureLiveMigration,
+	version.FeatureMigrationLimits,
+	version.FeatureCompareCPU,
+	version.FeatureSerialConsole,
+	version.FeatureGuestAgent,
+	version.FeatureCloudInit,
+	version.FeatureHostMetrics,
+	version.FeatureSystemdServices,
+	version.FeatureCertRenewal,
+	version.FeatureSlaveEvents,
+}
+
+// capabilities is what SetConnection carries, read again on every register since libvirt may have been upgraded
+func capabilities() (*pb.SlaveCapabilities, error) {
+	caps, err := virsh.GetHostCapabilities()
+	if err != nil {
+		return nil, err
+	}
+	caps.BuildVersion = version.GetBuild()
+	caps.ProtoVersion = version.ProtoVersion
+	caps.Features = Features
+	return caps, nil
+}
@@ -0,0 +1,481 @@
+package virsh
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	grpcVirsh "github.com/Maruqes/512SvMan/api/proto/virsh"
+	"github.com/Maruqes/512SvMan/logger"
+	libvirt "libvirt.org/go/libvirt"
+)
+
+const backupPollInterval = time.Second
+
+var (
+	cdromDevicePattern = regexp.MustCompile(`device=['"]cdrom['"]`)
+	diskSourcePattern  = regexp.MustCompile(`<source\s+file=['"]([^'"]*)['"][^>]*/>\s*`)
+)
+
+// push mode backup, qemu writes the files itself while the guest keeps running
+type domainBackupXML struct {
+	XMLName     xml.Name `xml:"domainbackup"`
+	Mode        string   `xml:"mode,attr"`
+	Incremental string   `xml:"incremental,omitempty"`
+	Disks       struct {
+		Disks []backupDiskXML `xml:"disk"`
+	} `xml:"disks"`
+}
+
+type backupDiskXML struct {
+	Name   string `xml:"name,attr"`
+	Backup string `xml:"backup,attr"`
+	Type   string `xml:"type,attr,omitempty"`
+	Driver *struct {
+		Type string `xml:"type,attr"`
+	} `xml:"driver,omitempty"`
+	Target *struct {
+		File string `xml:"file,attr"`
+	} `xml:"target,omitempty"`
+}
+
+// the checkpoint starts a dirty bitmap on every disk, the next incremental copies only what it marked
+type domainCheckpointXML struct {
+	XMLName xml.Name `xml:"domaincheckpoint"`
+	Name    string   `xml:"name"`
+	Disks   struct {
+		Disks []checkpointDiskXML `xml:"disk"`
+	} `xml:"disks"`
+}
+
+type checkpointDiskXML struct {
+	Name       string `xml:"name,attr"`
+	Checkpoint string `xml:"checkpoint,attr"`
+}
+
+func backupDiskPath(folder, name, target string) string {
+	return filepath.Join(folder, fmt.Sprintf("%s-%s.qcow2", name, target))
+}
+
+func buildBackupXML(folder, name, parent string, disks []domainDiskTarget) (string, string, error) {
+	backup := domainBackupXML{Mode: "push", Incremental: parent}
+	checkpoint := domainCheckpointXML{Name: name}
+	for _, disk := range disks {
+		if disk.Target == "" {
+			continue
+		}
+		if disk.Device != "disk" || disk.Source == "" {
+			backup.Disks.Disks = append(backup.Disks.Disks, backupDiskXML{Name: disk.Target, Backup: "no"})
+			checkpoint.Disks.Disks = append(checkpoint.Disks.Disks, checkpointDiskXML{Name: disk.Target, Checkpoint: "no"})
+			continue
+		}
+		backup.Disks.Disks = append(backup.Disks.Disks, backupDiskXML{
+			Name:   disk.Target,
+			Backup: "yes",
+			Type:   "file",
+			Driver: &struct {
+				Type string `xml:"type,attr"`
+			}{Type: "qcow2"},
+			Target: &struct {
+				File string `xml:"file,attr"`
+			}{File: backupDiskPath(folder, name, disk.Target)},
+		})
+		checkpoint.Disks.Disks = append(checkpoint.Disks.Disks, checkpointDiskXML{Name: disk.Target, Checkpoint: "bitmap"})
+	}
+
+	backupOut, err := xml.MarshalIndent(backup, "", "  ")
+	if err != nil {
+		return "", "", fmt.Errorf("marshal backup xml: %w", err)
+	}
+	checkpointOut, err := xml.MarshalIndent(checkpoint, "", "  ")
+	if err != nil {
+		return "", "", fmt.Errorf("marshal checkpoint xml: %w", err)
+	}
+	return string(backupOut), string(checkpointOut), nil
+}
+
+// waitBackupJob blocks until the backup job of the domain is gone and tells if it finished well
+func waitBackupJob(dom *libvirt.Domain) error {
+	for {
+		stats, err := dom.GetJobStats(0)
+		if err != nil {
+			return fmt.Errorf("backup job: %w", err)
+		}
+		if stats.Type == libvirt.DOMAIN_JOB_NONE {
+			break
+		}
+		time.Sleep(backupPollInterval)
+	}
+
+	stats, err := dom.GetJobStats(libvirt.DOMAIN_JOB_STATS_COMPLETED)
+	if err != nil {
+		// the stats of the finished job are best effort, the files are checked after this
+		return nil
+	}
+	if stats.Type == libvirt.DOMAIN_JOB_FAILED {
+		return fmt.Errorf("backup job failed")
+	}
+	return nil
+}
+
+// rebaseBackupDisk makes an incremental file an overlay of the previous backup, unchanged clusters
+// read through to it so the newest file of a chain is the whole disk at that point
+func rebaseBackupDisk(path, parent string) error {
+	cmd := exec.Command("qemu-img", "rebase", "-u", "-f", "qcow2", "-F", "qcow2", "-b", parent, path)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		msg := strings.TrimSpace(string(out))
+		if msg != "" {
+			return fmt.Errorf("qemu-img rebase %s: %s", path, msg)
+		}
+		return fmt.Errorf("qemu-img rebase %s: %w", path, err)
+	}
+	return nil
+}
+
+// dropBackupCheckpoints deletes every checkpoint of the vm and its bitmaps, they do not follow the
+// disks to another slave or another share, the next backup of the vm is a full one
+func dropBackupCheckpoints(dom *libvirt.Domain) error {
+	roots, err := dom.ListAllCheckpoints(libvirt.DOMAIN_CHECKPOINT_LIST_ROOTS)
+	if err != nil {
+		return fmt.Errorf("list checkpoints: %w", err)
+	}
+	var firstErr error
+	for i := range roots {
+		if err := roots[i].Delete(libvirt.DOMAIN_CHECKPOINT_DELETE_CHILDREN); err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("delete checkpoint: %w", err)
+		}
+		roots[i].Free()
+	}
+	return firstErr
+}
+
+// BackupVM copies the disks of the vm to req.Folder. a running vm goes through the libvirt backup api and
+// gets a checkpoint so the next backup only copies the blocks written since, a shut off vm gets a full copy
+func BackupVM(req *grpcVirsh.BackupVmRequest) (*grpcVirsh.BackupVmResponse, error) {
+	vmName := strings.TrimSpace(req.VmName)
+	name := strings.TrimSpace(req.Name)
+	folder := strings.TrimSpace(req.Folder)
+	if vmName == "" || folder == "" {
+		return nil, fmt.Errorf("vm name and backup folder are required")
+	}
+	if !snapshotNamePattern.MatchString(name) {
+		return nil, fmt.Errorf("invalid backup name %q", name)
+	}
+
+	conn, err := libvirt.NewConnect("qemu:///system")
+	if err != nil {
+		return nil, fmt.Errorf("connect: %w", err)
+	}
+	defer conn.Close()
+
+	dom, err := conn.LookupDomainByName(vmName)
+	if err != nil {
+		return nil, fmt.Errorf("lookup: %w", err)
+	}
+	defer dom.Free()
+
+	state, _, err := dom.GetState()
+	if err != nil {
+		return nil, fmt.Errorf("state: %w", err)
+	}
+	running := state == libvirt.DOMAIN_RUNNING || state == libvirt.DOMAIN_PAUSED
+
+	xmlDesc, err := dom.GetXMLDesc(libvirt.DOMAIN_XML_INACTIVE)
+	if err != nil {
+		return nil, fmt.Errorf("xml: %w", err)
+	}
+	disks, err := domainDiskTargets(xmlDesc)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &grpcVirsh.BackupVmResponse{}
+	for _, disk := range disks {
+		if disk.Device == "disk" && disk.Source != "" {
+			res.Disks = append(res.Disks, &grpcVirsh.BackupDisk{Target: disk.Target, Path: backupDiskPath(folder, name, disk.Target)})
+		}
+	}
+	if len(res.Disks) == 0 {
+		return nil, fmt.Errorf("vm %s has no file backed disk to back up", vmName)
+	}
+	for _, disk := range res.Disks {
+		if _, err := os.Stat(disk.Path); err == nil {
+			return nil, fmt.Errorf("backup file %s already exists", disk.Path)
+		}
+	}
+
+	if err := os.MkdirAll(folder, 0o777); err != nil {
+		return nil, fmt.Errorf("creating backup folder: %w", err)
+	}
+	if err := os.Chmod(folder, 0o777); err != nil {
+		return nil, fmt.Errorf("chmod backup folder: %w", err)
+	}
+
+	cleanup := func() {
+		for _, disk := range res.Disks {
+			_ = os.Remove(disk.Path)
+		}
+	}
+
+	if !running {
+		for _, disk := range disks {
+			if disk.Device != "disk" || disk.Source == "" {
+				continue
+			}
+			if err := copyDiskForClone(disk.Source, backupDiskPath(folder, name, disk.Target), false); err != nil {
+				cleanup()
+				return nil, err
+			}
+		}
+	} else {
+		// the increment is relative to the parent checkpoint, without it (or with a disk it does not
+		// cover) the backup has to be a full one
+		parentDisks := make(map[string]string, len(req.ParentDisks))
+		for _, disk := range req.ParentDisks {
+			parentDisks[disk.Target] = disk.Path
+		}
+		parent := strings.TrimSpace(req.Parent)
+		if parent != "" {
+			if cp, err := dom.CheckpointLookupByName(parent, 0); err != nil {
+				logger.Warn("parent checkpoint not found, doing a full backup", "vm", vmName, "checkpoint", parent)
+				parent = ""
+			} else {
+				cp.Free()
+			}
+		}
+		for _, disk := range res.Disks {
+			if parent != "" && parentDisks[disk.Target] == "" {
+				logger.Warn("disk not in the parent backup, doing a full backup", "vm", vmName, "disk", disk.Target)
+				parent = ""
+			}
+		}
+
+		// a full backup starts a new chain, the old bitmaps would only slow every write down
+		if parent == "" {
+			if err := dropBackupCheckpoints(dom); err != nil {
+				logger.Warn("dropping old backup checkpoints failed", "vm", vmName, "error", err)
+			}
+		}
+
+		backupXML, checkpointXML, err := buildBackupXML(folder, name, parent, disks)
+		if err != nil {
+			return nil, err
+		}
+		// the push backup copies the disks as they were at begin, the guest only needs to be frozen that long
+		thaw := freezeGuest(dom)
+		err = dom.BackupBegin(backupXML, checkpointXML, 0)
+		thaw()
+		if err != nil {
+			return nil, fmt.Errorf("backup begin: %w", err)
+		}
+		jobErr := waitBackupJob(dom)
+		if jobErr == nil {
+			for _, disk := range res.Disks {
+				if _, err := os.Stat(disk.Path); err != nil {
+					jobErr = fmt.Errorf("backup file %s missing: %w", disk.Path, err)
+					break
+				}
+			}
+		}
+		if jobErr == nil && parent != "" {
+			for _, disk := range res.Disks {
+				if jobErr = rebaseBackupDisk(disk.Path, parentDisks[disk.Target]); jobErr != nil {
+					break
+				}
+			}
+		}
+		if jobErr != nil {
+			// a checkpoint without its backup would make the next increment skip blocks
+			if cp, err := dom.CheckpointLookupByName(name, 0); err == nil {
+				_ = cp.Delete(0)
+				cp.Free()
+			}
+			cleanup()
+			return nil, jobErr
+		}
+		res.Incremental = parent != ""
+		res.Checkpoint = true
+	}
+
+	for _, disk := range res.Disks {
+		if err := ensureDiskPermissions(disk.Path); err != nil {
+			return nil, err
+		}
+		if info, err := os.Stat(disk.Path); err == nil {
+			res.SizeBytes += info.Size()
+		}
+	}
+
+	res.XmlPath = filepath.Join(folder, name+".xml")
+	if err := os.WriteFile(res.XmlPath, []byte(strings.TrimSpace(xmlDesc)+"\n"), 0o644); err != nil {
+		return nil, fmt.Errorf("write xml %s: %w", res.XmlPath, err)
+	}
+	return res, nil
+}
+
+// dropMissingMedia ejects the cdroms whose file is gone, a restored vm would not start otherwise
+func dropMissingMedia(xmlDesc string) string {
+	return cloneDiskPattern.ReplaceAllStringFunc(xmlDesc, func(block string) string {
+		if !cdromDevicePattern.MatchString(block) {
+			return block
+		}
+		sub := diskSourcePattern.FindStringSubmatch(block)
+		if sub == nil {
+			return block
+		}
+		if _, err := os.Stat(sub[1]); err == nil {
+			return block
+		}
+		return strings.Replace(block, sub[0], "", 1)
+	})
+}
+
+// RestoreBackup defines a new vm from a backup, every disk is flattened from its backup chain into a
+// standalone qcow2 and the definition gets a new name, uuid and macs like a clone
+func RestoreBackup(req *grpcVirsh.RestoreBackupRequest) error {
+	name := strings.TrimSpace(req.Name)
+	disk := strings.TrimSpace(req.DiskPath)
+	if name == "" || disk == "" {
+		return fmt.Errorf("name and disk path are required")
+	}
+
+	data, err := os.ReadFile(req.XmlPath)
+	if err != nil {
+		return fmt.Errorf("read backup xml: %w", err)
+	}
+	xmlDesc := string(data)
+
+	conn, err := libvirt.NewConnect("qemu:///system")
+	if err != nil {
+		return fmt.Errorf("connect: %w", err)
+	}
+	defer conn.Close()
+
+	if existing, err := conn.LookupDomainByName(name); err == nil {
+		existing.Free()
+		return fmt.Errorf("vm %s already exists", name)
+	}
+
+	backupFiles := make(map[string]string, len(req.Disks))
+	for _, d := range req.Disks {
+		backupFiles[d.Target] = d.Path
+	}
+	disks, err := domainDiskTargets(xmlDesc)
+	if err != nil {
+		return err
+	}
+	diskPaths := cloneDiskPaths(disks, name, disk)
+	copies := make(map[string]string, len(diskPaths))
+	for _, d := range disks {
+		dst, ok := diskPaths[d.Source]
+		if !ok {
+			continue
+		}
+		src := backupFiles[d.Target]
+		if src == "" {
+			return fmt.Errorf("backup has no file for disk %s", d.Target)
+		}
+		copies[src] = dst
+	}
+
+	folder := strings.TrimSpace(req.DiskFolder)
+	if folder == "" {
+		folder = filepath.Dir(disk)
+	}
+	if err := os.MkdirAll(folder, 0o777); err != nil {
+		return fmt.Errorf("creating disk folder: %w", err)
+	}
+	if err := os.Chmod(folder, 0o777); err != nil {
+		return fmt.Errorf("chmod disk folder: %w", err)
+	}
+
+	newXML, err := mutateDomainXMLForClone(xmlDesc, name, diskPaths)
+	if err != nil {
+		return err
+	}
+	newXML = dropMissingMedia(newXML)
+
+	var created []string
+	cleanup := func() {
+		for _, file := range created {
+			_ = os.Remove(file)
+		}
+	}
+	for src, dst := range copies {
+		if err := copyDiskForClone(src, dst, false); err != nil {
+			cleanup()
+			return err
+		}
+		created = append(created, dst)
+	}
+
+	dom, err := conn.DomainDefineXML(newXML)
+	if err != nil {
+		cleanup()
+		return fmt.Errorf("define: %w", err)
+	}
+	defer dom.Free()
+
+	var owned []string
+	for _, dst := range diskPaths {
+		if dst != disk {
+			owned = append(owned, dst)
+		}
+	}
+	if err := setOwnedDisks(dom, owned); err != nil {
+		return err
+	}
+
+	if defined, err := dom.GetXMLDesc(libvirt.DOMAIN_XML_INACTIVE); err == nil {
+		newXML = defined
+	}
+	if _, err := WriteDomainXMLToDisk(name, newXML, disk); err != nil {
+		return fmt.Errorf("write domain xml: %w", err)
+	}
+	return nil
+}
+
+// DeleteBackup removes the files of a backup and its checkpoint, the vm may live on another slave
+// (or be gone) and then only the files go
+func DeleteBackup(req *grpcVirsh.DeleteBackupRequest) error {
+	if checkpoint := strings.TrimSpace(req.Checkpoint); checkpoint != "" && strings.TrimSpace(req.VmName) != "" {
+		conn, err := libvirt.NewConnect("qemu:///system")
+		if err != nil {
+			return fmt.Errorf("connect: %w", err)
+		}
+		defer conn.Close()
+
+		if dom, err := conn.LookupDomainByName(req.VmName); err == nil {
+			defer dom.Free()
+			cp, err := dom.CheckpointLookupByName(checkpoint, 0)
+			if err == nil {
+				defer cp.Free()
+				if err := cp.Delete(0); err != nil {
+					return fmt.Errorf("delete checkpoint: %w", err)
+				}
+			} else {
+				var lvErr libvirt.Error
+				if !errors.As(err, &lvErr) || lvErr.Code != libvirt.ERR_NO_DOMAIN_CHECKPOINT {
+					return fmt.Errorf("lookup checkpoint: %w", err)
+				}
+			}
+		}
+	}
+
+	for _, file := range req.Files {
+		if strings.TrimSpace(file) == "" {
+			continue
+		}
+		if err := os.Remove(file); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("remove backup file %s: %w", file, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package virsh
+
+import (
+	"encoding/xml"
+	"fmt"
+	"sort"
+
+	pb "github.com/Maruqes/512SvMan/api/proto/protocol"
+	libvirt "libvirt.org/go/libvirt"
+)
+
+// only the parts of virsh capabilities the master cares about
+type hostCapabilities struct {
+	Host struct {
+		CPU struct {
+			Arch     string `xml:"arch"`
+			Model    string `xml:"model"`
+			Vendor   string `xml:"vendor"`
+			Features []struct {
+				Name string `xml:"name,attr"`
+			} `xml:"feature"`
+		} `xml:"cpu"`
+	} `xml:"host"`
+}
+
+// libvirt packs versions as major*1000000 + minor*1000 + release
+func formatLibvirtVersion(v uint32) string {
+	return fmt.Sprintf("%d.%d.%d", v/1000000, (v/1000)%1000, v%1000)
+}
+
+// GetHostCapabilities fills the host part of what the slave sends with SetConnection,
+// versions and features of the build are set by the caller
+func GetHostCapabilities() (*pb.SlaveCapabilities, error) {
+	conn, err := libvirt.NewConnect("qemu:///system")
+	if err != nil {
+		return nil, fmt.Errorf("connect: %w", err)
+	}
+	defer conn.Close()
+
+	libVersion, err := conn.GetLibVersion()
+	if err != nil {
+		return nil, fmt.Errorf("libvirt version: %w", err)
+	}
+	qemuVersion, err := conn.GetVersion()
+	if err != nil {
+		return nil, fmt.Errorf("qemu version: %w", err)
+	}
+	node, err := conn.GetNodeInfo()
+	if err != nil {
+		return nil, fmt.Errorf("node info: %w", err)
+	}
+	capsXML, err := conn.GetCapabilities()
+	if err != nil {
+		return nil, fmt.Errorf("capabilities: %w", err)
+	}
+	var hostCaps hostCapabilities
+	if err := xml.Unmarshal([]byte(capsXML), &hostCaps); err != nil {
+		return nil, fmt.Errorf("parse capabilities: %w", err)
+	}
+	cpuXML, err := GetHostCPUXML()
+	if err != nil {
+		return nil, err
+	}
+
+	caps := &pb.SlaveCapabilities{
+		LibvirtVersion: formatLibvirtVersion(libVersion),
+		QemuVersion:    formatLibvirtVersion(qemuVersion),
+		CpuArch:        hostCaps.Host.CPU.Arch,
+		CpuVendor:      hostCaps.Host.CPU.Vendor,
+		CpuModel:       hostCaps.Host.CPU.Model,
+		CpuXML:         cpuXML,
+		MemoryTotalMB:  int64(node.Memory / 1024),
+		Cpus:           int32(node.Cpus),
+	}
+	for _, f := range hostCaps.Host.CPU.Features {
+		caps.CpuFeatures = append(caps.CpuFeatures, f.Name)
+	}
+	sort.Strings(caps.CpuFeatures)
+	return caps, nil
+}
@@ -0,0 +1,282 @@
+package virsh
+
+import (
+	"crypto/rand"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	libvirt "libvirt.org/go/libvirt"
+)
+
+var (
+	cloneNamePattern     = regexp.MustCompile(`<name>[^<]*</name>`)
+	cloneUUIDPattern     = regexp.MustCompile(`(?m)^[ \t]*<uuid>[^<]*</uuid>[ \t]*\n?`)
+	cloneMACPattern      = regexp.MustCompile(`<mac\s+address=['"][^'"]*['"]\s*/>`)
+	cloneGraphicsPattern = regexp.MustCompile(`<graphics\b([^>]*?)(/?)>`)
+	cloneDiskPattern     = regexp.MustCompile(`(?s)<disk\b[^>]*>.*?</disk>`)
+	cloneDriverPattern   = regexp.MustCompile(`<driver\b([^>]*?)/>`)
+	cloneOwnedPattern    = regexp.MustCompile(`(?s)<(\w+):disks\b[^>]*` + regexp.QuoteMeta(disksMetadataURI) + `.*?</(\w+):disks>`)
+	cloneHAPattern       = regexp.MustCompile(`(?s)<(\w+):ha\b[^>]*` + regexp.QuoteMeta(haMetadataURI) + `[^>]*?(/>|>\s*</\w+:ha>)`)
+)
+
+type CloneVMOptions struct {
+	SourceName string
+	Name       string
+	DiskFolder string
+	DiskPath   string // new primary disk, extra disks go next to it
+	Linked     bool   // qcow2 overlay with the source disk as backing file
+	Start      bool
+}
+
+// randomMAC returns a locally administered address in the qemu/kvm range (52:54:00)
+func randomMAC() (string, error) {
+	buf := make([]byte, 3)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("random mac: %w", err)
+	}
+	return fmt.Sprintf("52:54:00:%02x:%02x:%02x", buf[0], buf[1], buf[2]), nil
+}
+
+// cloneDiskPaths maps every file backed disk of the source to the path it gets in the clone
+func cloneDiskPaths(disks []domainDiskTarget, name, primary string) map[string]string {
+	paths := make(map[string]string)
+	dir := filepath.Dir(primary)
+	for _, disk := range disks {
+		if disk.Device != "disk" || disk.Source == "" {
+			continue
+		}
+		if len(paths) == 0 {
+			paths[disk.Source] = primary
+			continue
+		}
+		paths[disk.Source] = filepath.Join(dir, fmt.Sprintf("%s-%s.qcow2", name, disk.Target))
+	}
+	return paths
+}
+
+// copyDiskForClone creates dst from src, a full standalone copy or a thin overlay on top of src
+func copyDiskForClone(src, dst string, linked bool) error {
+	if _, err := os.Stat(dst); err == nil {
+		return fmt.Errorf("disk %s already exists", dst)
+	}
+	srcFormat, err := DetectDiskFormat(src)
+	if err != nil {
+		return err
+	}
+
+	var args []string
+	if linked {
+		args = []string{"create", "-f", "qcow2", "-F", srcFormat, "-b", src, dst}
+	} else {
+		// convert also flattens any external snapshot overlays into a single image
+		args = []string{"convert", "-p", "-f", srcFormat, "-O", "qcow2", src, dst}
+	}
+	cmd := exec.Command("qemu-img", args...)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		msg := strings.TrimSpace(string(out))
+		if msg != "" {
+			return fmt.Errorf("qemu-img %s %s: %s", args[0], dst, msg)
+		}
+		return fmt.Errorf("qemu-img %s %s: %w", args[0], dst, err)
+	}
+	return ensureDiskPermissions(dst)
+}
+
+// repointDomainDisks swaps the source of every disk found in diskPaths for its new file and returns
+// how many disks it changed, the new files are always qcow2 even when the old ones were raw
+func repointDomainDisks(xmlDesc string, diskPaths map[string]string) (string, int) {
+	replaced := 0
+	xmlDesc = cloneDiskPattern.ReplaceAllStringFunc(xmlDesc, func(block string) string {
+		for src, dst := range diskPaths {
+			if !strings.Contains(block, "'"+src+"'") && !strings.Contains(block, `"`+src+`"`) {
+				continue
+			}
+			replaced++
+			block = strings.ReplaceAll(block, "'"+src+"'", "'"+dst+"'")
+			block = strings.ReplaceAll(block, `"`+src+`"`, "'"+dst+"'")
+			return cloneDriverPattern.ReplaceAllStringFunc(block, func(driver string) string {
+				attrs := cloneDriverPattern.FindStringSubmatch(driver)[1]
+				return "<driver" + setAttributeString(attrs, "type", "qcow2") + "/>"
+			})
+		}
+		return block
+	})
+	return xmlDesc, replaced
+}
+
+// mutateDomainXMLForClone renames the domain, drops the uuid so libvirt makes a new one,
+// points the disks to the new files, gives every nic a new mac and lets libvirt pick a new vnc port
+func mutateDomainXMLForClone(xmlDesc, name string, diskPaths map[string]string) (string, error) {
+	loc := cloneNamePattern.FindStringIndex(xmlDesc)
+	if loc == nil {
+		return "", fmt.Errorf("name element not found in domain xml")
+	}
+	var escaped strings.Builder
+	if err := xml.EscapeText(&escaped, []byte(name)); err != nil {
+		return "", fmt.Errorf("escape name: %w", err)
+	}
+	xmlDesc = xmlDesc[:loc[0]] + "<name>" + escaped.String() + "</name>" + xmlDesc[loc[1]:]
+
+	xmlDesc = cloneUUIDPattern.ReplaceAllString(xmlDesc, "")
+	// the owned disks list points to the source files, CloneVM writes a new one
+	xmlDesc = cloneOwnedPattern.ReplaceAllString(xmlDesc, "")
+	// a clone starts unprotected, it would otherwise fight the source over its lease
+	xmlDesc = cloneHAPattern.ReplaceAllString(xmlDesc, "")
+
+	var macErr error
+	xmlDesc = cloneMACPattern.ReplaceAllStringFunc(xmlDesc, func(match string) string {
+		mac, err := randomMAC()
+		if err != nil {
+			macErr = err
+			return match
+		}
+		return fmt.Sprintf("<mac address='%s'/>", mac)
+	})
+	if macErr != nil {
+		return "", macErr
+	}
+
+	xmlDesc = cloneGraphicsPattern.ReplaceAllStringFunc(xmlDesc, func(match string) string {
+		sub := cloneGraphicsPattern.FindStringSubmatch(match)
+		attrs := sub[1]
+		if !strings.Contains(attrs, "'vnc'") && !strings.Contains(attrs, `"vnc"`) {
+			return match
+		}
+		attrs = setAttributeString(attrs, "port", "-1")
+		attrs = setAttributeString(attrs, "autoport", "yes")
+		if strings.Contains(attrs, "websocket=") {
+			attrs = setAttributeString(attrs, "websocket", "-1")
+		}
+		return "<graphics" + attrs + sub[2] + ">"
+	})
+
+	xmlDesc, replaced := repointDomainDisks(xmlDesc, diskPaths)
+	if replaced != len(diskPaths) {
+		return "", fmt.Errorf("could not repoint all disks of the clone (%d of %d)", replaced, len(diskPaths))
+	}
+
+	return xmlDesc, nil
+}
+
+// CloneVM copies a shut off vm to a new name, the disks land on opts.DiskPath (any nfs share mounted here)
+func CloneVM(opts CloneVMOptions) error {
+	opts.SourceName = strings.TrimSpace(opts.SourceName)
+	opts.Name = strings.TrimSpace(opts.Name)
+	if opts.SourceName == "" || opts.Name == "" {
+		return fmt.Errorf("source and clone names are required")
+	}
+	disk := strings.TrimSpace(opts.DiskPath)
+	if disk == "" {
+		return fmt.Errorf("disk path is required")
+	}
+
+	conn, err := libvirt.NewConnect("qemu:///system")
+	if err != nil {
+		return fmt.Errorf("connect: %w", err)
+	}
+	defer conn.Close()
+
+	if existing, err := conn.LookupDomainByName(opts.Name); err == nil {
+		existing.Free()
+		return fmt.Errorf("vm %s already exists", opts.Name)
+	}
+
+	src, err := conn.LookupDomainByName(opts.SourceName)
+	if err != nil {
+		return fmt.Errorf("lookup: %w", err)
+	}
+	defer src.Free()
+
+	state, _, err := src.GetState()
+	if err != nil {
+		return fmt.Errorf("state: %w", err)
+	}
+	if state != libvirt.DOMAIN_SHUTOFF {
+		stateLabel := domainStateToString(state).String()
+		return fmt.Errorf("vm %s must be shut off before cloning (state %s)", opts.SourceName, stateLabel)
+	}
+
+	xmlDesc, err := src.GetXMLDesc(libvirt.DOMAIN_XML_INACTIVE)
+	if err != nil {
+		return fmt.Errorf("xml: %w", err)
+	}
+	disks, err := domainDiskTargets(xmlDesc)
+	if err != nil {
+		return err
+	}
+	diskPaths := cloneDiskPaths(disks, opts.Name, disk)
+	if len(diskPaths) == 0 {
+		return fmt.Errorf("vm %s has no file backed disk to clone", opts.SourceName)
+	}
+
+	folder := strings.TrimSpace(opts.DiskFolder)
+	if folder == "" {
+		folder = filepath.Dir(disk)
+	}
+	if err := os.MkdirAll(folder, 0o777); err != nil {
+		return fmt.Errorf("creating disk folder: %w", err)
+	}
+	if err := os.Chmod(folder, 0o777); err != nil {
+		return fmt.Errorf("chmod disk folder: %w", err)
+	}
+
+	newXML, err := mutateDomainXMLForClone(xmlDesc, opts.Name, diskPaths)
+	if err != nil {
+		return err
+	}
+
+	var created []string
+	cleanup := func() {
+		for _, file := range created {
+			_ = os.Remove(file)
+		}
+	}
+	for srcDisk, dstDisk := range diskPaths {
+		if err := copyDiskForClone(srcDisk, dstDisk, opts.Linked); err != nil {
+			cleanup()
+			return err
+		}
+		created = append(created, dstDisk)
+	}
+
+	dom, err := conn.DomainDefineXML(newXML)
+	if err != nil {
+		cleanup()
+		return fmt.Errorf("define: %w", err)
+	}
+	defer dom.Free()
+
+	// every extra disk was copied for the clone, so the clone owns all of them
+	var owned []string
+	for _, dstDisk := range diskPaths {
+		if dstDisk != disk {
+			owned = append(owned, dstDisk)
+		}
+	}
+	sort.Strings(owned)
+	if err := setOwnedDisks(dom, owned); err != nil {
+		return err
+	}
+
+	// save the xml libvirt generated (uuid and macs included) next to the disk
+	if defined, err := dom.GetXMLDesc(libvirt.DOMAIN_XML_INACTIVE); err == nil {
+		newXML = defined
+	}
+	if _, err := WriteDomainXMLToDisk(opts.Name, newXML, disk); err != nil {
+		return fmt.Errorf("write domain xml: %w", err)
+	}
+
+	if opts.Start {
+		if err := dom.Create(); err != nil {
+			return fmt.Errorf("start: %w", err)
+		}
+	}
+	return nil
+}
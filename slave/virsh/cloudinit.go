@@ -0,0 +1,156 @@
+package virsh
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	grpcVirsh "github.com/Maruqes/512SvMan/api/proto/virsh"
+)
+
+var cloudInitHostnamePattern = regexp.MustCompile(`^[A-Za-z0-9]([A-Za-z0-9-]{0,61}[A-Za-z0-9])?$`)
+
+type CloudInitOptions struct {
+	Hostname string
+	UserData string
+	SSHKeys  []string
+}
+
+// seed iso sits next to the disk so RemoveVM takes it with the vm
+func cloudInitSeedPath(diskPath, vmName string) string {
+	return filepath.Join(filepath.Dir(diskPath), vmName+"-seed.iso")
+}
+
+func buildCloudInitMetaData(vmName string, ci CloudInitOptions) (string, error) {
+	hostname := strings.TrimSpace(ci.Hostname)
+	if hostname != "" && !cloudInitHostnamePattern.MatchString(hostname) {
+		return "", fmt.Errorf("invalid hostname %q", hostname)
+	}
+	if hostname == "" && cloudInitHostnamePattern.MatchString(vmName) {
+		hostname = vmName
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "instance-id: %s\n", strconv.Quote(vmName))
+	if hostname != "" {
+		fmt.Fprintf(&b, "local-hostname: %s\n", hostname)
+	}
+
+	// NoCloud takes the keys from meta-data so the user-data stays exactly as given
+	var keys []string
+	for _, key := range ci.SSHKeys {
+		key = strings.TrimSpace(key)
+		if key == "" {
+			continue
+		}
+		if strings.ContainsAny(key, "\r\n") {
+			return "", fmt.Errorf("ssh key must be a single line")
+		}
+		keys = append(keys, key)
+	}
+	if len(keys) > 0 {
+		b.WriteString("public-keys:\n")
+		for _, key := range keys {
+			fmt.Fprintf(&b, "  - %s\n", strconv.Quote(key))
+		}
+	}
+	return b.String(), nil
+}
+
+// runs the first iso tool found, they all take the mkisofs arguments
+func makeSeedISO(out, dir string, files ...string) error {
+	tools := [][]string{
+		{"genisoimage"},
+		{"mkisofs"},
+		{"xorriso", "-as", "mkisofs"},
+	}
+	for _, tool := range tools {
+		if _, err := exec.LookPath(tool[0]); err != nil {
+			continue
+		}
+		args := append(tool[1:], "-output", out, "-volid", "cidata", "-joliet", "-rock")
+		args = append(args, files...)
+		cmd := exec.Command(tool[0], args...)
+		cmd.Dir = dir
+		res, err := cmd.CombinedOutput()
+		if err != nil {
+			msg := strings.TrimSpace(string(res))
+			if msg != "" {
+				return fmt.Errorf("%s %s: %s", tool[0], out, msg)
+			}
+			return fmt.Errorf("%s %s: %w", tool[0], out, err)
+		}
+		return nil
+	}
+	return fmt.Errorf("no iso tool found, install genisoimage or xorriso")
+}
+
+// BuildCloudInitSeed writes a NoCloud seed iso (volume cidata with user-data and meta-data) to out
+func BuildCloudInitSeed(out, vmName string, ci CloudInitOptions) error {
+	metaData, err := buildCloudInitMetaData(vmName, ci)
+	if err != nil {
+		return err
+	}
+	userData := ci.UserData
+	if strings.TrimSpace(userData) == "" {
+		userData = "#cloud-config\n"
+	}
+
+	tmp, err := os.MkdirTemp("", "512svman-seed-")
+	if err != nil {
+		return fmt.Errorf("seed temp dir: %w", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	if err := os.WriteFile(filepath.Join(tmp, "meta-data"), []byte(metaData), 0o644); err != nil {
+		return fmt.Errorf("write meta-data: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(tmp, "user-data"), []byte(userData), 0o644); err != nil {
+		return fmt.Errorf("write user-data: %w", err)
+	}
+
+	if err := ensureParentDirExists(out); err != nil {
+		return err
+	}
+	if err := makeSeedISO(out, tmp, "user-data", "meta-data"); err != nil {
+		return err
+	}
+	return ensureDiskPermissions(out)
+}
+
+// prepareTemplateDisk builds the vm disk as an overlay of the template and its seed iso, returns the seed path
+func prepareTemplateDisk(templatePath, disk, vmName string, sizeGB int, ci CloudInitOptions) (string, error) {
+	templatePath = strings.TrimSpace(templatePath)
+	if err := ensureFileExists(templatePath); err != nil {
+		return "", fmt.Errorf("template: %w", err)
+	}
+	if err := copyDiskForClone(templatePath, disk, true); err != nil {
+		return "", err
+	}
+	if err := ensureDiskSizeAtLeast(disk, sizeGB); err != nil {
+		os.Remove(disk)
+		return "", err
+	}
+
+	seed := cloudInitSeedPath(disk, vmName)
+	if err := BuildCloudInitSeed(seed, vmName, ci); err != nil {
+		os.Remove(disk)
+		return "", fmt.Errorf("cloud-init seed: %w", err)
+	}
+	return seed, nil
+}
+
+func cloudInitFromGRPC(ci *grpcVirsh.CloudInit) CloudInitOptions {
+	if ci == nil {
+		return CloudInitOptions{}
+	}
+	return CloudInitOptions{
+		Hostname: ci.Hostname,
+		UserData: ci.UserData,
+		SSHKeys:  ci.SshKeys,
+	}
+}
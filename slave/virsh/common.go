@@ -154,6 +154,34 @@ func readQemuImgInfo(path string) (*qiInfo, error) {
 	return &info, nil
 }
 
+// diskBackingChain returns the image followed by all of its backing files (external snapshot overlays)
+func diskBackingChain(path string) ([]string, error) {
+	cmd := exec.Command("qemu-img", "info", "--backing-chain", "--output=json", "-U", path)
+	out, err := cmd.Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return nil, fmt.Errorf("qemu-img info %s: %s", path, strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return nil, fmt.Errorf("qemu-img info %s: %w", path, err)
+	}
+
+	var chain []struct {
+		Filename string `json:"filename"`
+	}
+	if err := json.Unmarshal(out, &chain); err != nil {
+		return nil, fmt.Errorf("parse qemu-img info json: %w", err)
+	}
+
+	files := make([]string, 0, len(chain))
+	for _, img := range chain {
+		if strings.TrimSpace(img.Filename) != "" {
+			files = append(files, img.Filename)
+		}
+	}
+	return files, nil
+}
+
 func ensureDiskPermissions(path string) error {
 	info, err := os.Stat(path)
 	if err != nil {
@@ -553,6 +581,8 @@ func GetVMByName(name string) (*grpcVirsh.Vm, error) {
 	var cpuPct int32
 	var vcpuCount int32
 	var totalMemMB int32
+	var cpuTimeNs uint64
+	var cpuTimeAt int64
 	// Only do live sampling when actually running
 	if state == libvirt.DOMAIN_RUNNING {
 		if totalKiB, usedKiB, err := getMemStats(dom); err == nil {
@@ -563,6 +593,9 @@ func GetVMByName(name string) (*grpcVirsh.Vm, error) {
 			cpuPct = int32(pct + 0.5)
 			vcpuCount = int32(vcpus)
 		}
+		if ns, _, err := sampleCPUTime(dom); err == nil {
+			cpuTimeNs, cpuTimeAt = ns, time.Now().UnixMilli()
+		}
 	}
 
 	//get diskSize and DiskPath
@@ -572,6 +605,16 @@ func GetVMByName(name string) (*grpcVirsh.Vm, error) {
 		return nil, fmt.Errorf("get disk info: %w", err)
 	}
 
+	disks, err := listVmDisks(dom, xmlDesc)
+	if err != nil {
+		return nil, fmt.Errorf("list disks: %w", err)
+	}
+
+	nics, ips, err := listVmNics(dom, xmlDesc, state == libvirt.DOMAIN_RUNNING)
+	if err != nil {
+		return nil, fmt.Errorf("list nics: %w", err)
+	}
+
 	info := &grpcVirsh.Vm{
 		MachineName:          env512.MachineName,
 		Name:                 name,
@@ -583,6 +626,11 @@ func GetVMByName(name string) (*grpcVirsh.Vm, error) {
 		CurrentMemoryUsageMB: usedMemMB,
 		DiskSizeGB:           int32(diskInfo.SizeGB),
 		DiskPath:             diskInfo.Path,
+		Ip:                   ips,
+		Disks:                disks,
+		Nics:                 nics,
+		CpuTimeNs:            cpuTimeNs,
+		CpuTimeAt:            cpuTimeAt,
 	}
 	return info, nil
 }
@@ -636,6 +684,8 @@ func GetAllVMs() ([]*grpcVirsh.Vm, []error) {
 		var cpuPct int32
 		var vcpuCount int32
 		var totalMemMB int32
+		var cpuTimeNs uint64
+		var cpuTimeAt int64
 		// Only do live sampling when actually running
 		if state == libvirt.DOMAIN_RUNNING {
 			if totalKiB, usedKiB, err := getMemStats(&dom); err == nil {
@@ -646,6 +696,9 @@ func GetAllVMs() ([]*grpcVirsh.Vm, []error) {
 				cpuPct = int32(pct + 0.5)
 				vcpuCount = int32(vcpus)
 			}
+			if ns, _, err := sampleCPUTime(&dom); err == nil {
+				cpuTimeNs, cpuTimeAt = ns, time.Now().UnixMilli()
+			}
 		}
 
 		//get diskSize and DiskPath
@@ -656,21 +709,18 @@ func GetAllVMs() ([]*grpcVirsh.Vm, []error) {
 			continue
 		}
 
-		networkIP := []string{}
-
-		if state == libvirt.DOMAIN_RUNNING {
-			ifAddrs, err := dom.ListAllInterfaceAddresses(libvirt.DOMAIN_INTERFACE_ADDRESSES_SRC_LEASE)
-			if err != nil {
-				dom.Free()
-				errs = append(errs, fmt.Errorf("get interface addresses: %w", err))
-				continue
-			}
+		disks, err := listVmDisks(&dom, xmlDesc)
+		if err != nil {
+			dom.Free()
+			errs = append(errs, fmt.Errorf("list disks: %w", err))
+			continue
+		}
 
-			for _, ifAddr := range ifAddrs {
-				for _, addr := range ifAddr.Addrs {
-					networkIP = append(networkIP, addr.Addr)
-				}
-			}
+		nics, networkIP, err := listVmNics(&dom, xmlDesc, state == libvirt.DOMAIN_RUNNING)
+		if err != nil {
+			dom.Free()
+			errs = append(errs, fmt.Errorf("list nics: %w", err))
+			continue
 		}
 
 		info := &grpcVirsh.Vm{
@@ -685,6 +735,10 @@ func GetAllVMs() ([]*grpcVirsh.Vm, []error) {
 			DiskSizeGB:           int32(diskInfo.SizeGB),
 			DiskPath:             diskInfo.Path,
 			Ip:                   networkIP,
+			Disks:                disks,
+			Nics:                 nics,
+			CpuTimeNs:            cpuTimeNs,
+			CpuTimeAt:            cpuTimeAt,
 		}
 		vms = append(vms, info)
 		dom.Free()
@@ -798,6 +852,18 @@ func RemoveVM(name string) error {
 		return fmt.Errorf("detect disk path: %w", err)
 	}
 
+	// extra disks created for this vm (AddDisk), attached files are not ours to delete
+	ownedDisks, err := getOwnedDisks(dom)
+	if err != nil {
+		return err
+	}
+	disks, err := domainDiskTargets(xmlDesc)
+	if err != nil {
+		return err
+	}
+	// after an external snapshot the active file is an overlay, the chain is removed from the top
+	ownedDisks = append(ownedDiskSources(disks, ownedDisks), ownedDisks...)
+
 	// Get state
 	state, _, err := dom.GetState()
 	if err != nil {
@@ -811,23 +877,41 @@ func RemoveVM(name string) error {
 	}
 
 	//force remove
-	if err := dom.UndefineFlags(libvirt.DOMAIN_UNDEFINE_MANAGED_SAVE | libvirt.DOMAIN_UNDEFINE_SNAPSHOTS_METADATA | libvirt.DOMAIN_UNDEFINE_NVRAM); err != nil {
+	if err := dom.UndefineFlags(libvirt.DOMAIN_UNDEFINE_MANAGED_SAVE | libvirt.DOMAIN_UNDEFINE_SNAPSHOTS_METADATA | libvirt.DOMAIN_UNDEFINE_CHECKPOINTS_METADATA | libvirt.DOMAIN_UNDEFINE_NVRAM); err != nil {
 		return fmt.Errorf("undefine: %w", err)
 	}
 
 	if diskPath != "" {
-		if err := os.Remove(diskPath); err != nil && !os.IsNotExist(err) {
-			return fmt.Errorf("remove disk %s: %w", diskPath, err)
-		}
-
 		xmlDir := filepath.Dir(diskPath)
 		if xmlDir == "" {
 			xmlDir = "."
 		}
+
+		// external snapshots leave the original image (and older overlays) under the active disk
+		if err := removeDiskChain(diskPath); err != nil {
+			return err
+		}
+
+		seedPath := cloudInitSeedPath(diskPath, name)
+		if err := os.Remove(seedPath); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("remove cloud-init seed %s: %w", seedPath, err)
+		}
+
 		xmlPath := filepath.Join(xmlDir, name+".xml")
 		if err := os.Remove(xmlPath); err != nil && !os.IsNotExist(err) {
 			return fmt.Errorf("remove xml %s: %w", xmlPath, err)
 		}
+
+		leasePath := haLeasePath(name, diskPath)
+		if err := os.Remove(leasePath); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("remove lease %s: %w", leasePath, err)
+		}
+	}
+
+	for _, path := range ownedDisks {
+		if err := removeDiskChain(path); err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -4,7 +4,6 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
-	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -63,12 +62,20 @@ type CreateVMCustomCPUOptions struct {
 	ISOPath        string
 	Machine        string
 	Network        string
+	Nics           []NicOptions // every nic, a single virtio one on Network when empty
 	GraphicsListen string
 	VNCPassword    string // fazer
 	CPUXml         string
+	TemplatePath   string // golden qcow2 used as backing file instead of the iso
+	CloudInit      CloudInitOptions
 }
 
 func CreateVMCustomCPU(opts CreateVMCustomCPUOptions) (string, error) {
+	// checked before any disk is created
+	nicsXML, err := nicsDomainXML(opts.Network, opts.Nics, "\t")
+	if err != nil {
+		return "", err
+	}
 
 	//make sure DiskFolder exists
 	if opts.DiskFolder != "" {
@@ -98,19 +105,30 @@ func CreateVMCustomCPU(opts CreateVMCustomCPUOptions) (string, error) {
 		return "", fmt.Errorf("disk directory: %w", err)
 	}
 
-	// detect/create disk & get its format
-	if _, err := EnsureDiskAndDetectFormat(disk, opts.DiskSizeGB); err != nil {
-		return "", fmt.Errorf("disk: %w", err)
-	}
-
 	// ISO optional
 	hasISO := false
+	bootISO := false
 	isoTrim := strings.TrimSpace(opts.ISOPath)
-	if isoTrim != "" {
-		if err := ensureFileExists(isoTrim); err != nil {
-			return "", fmt.Errorf("iso path: %w", err)
+	if strings.TrimSpace(opts.TemplatePath) != "" {
+		// template vms boot the overlay, the cdrom only carries the cloud-init seed
+		seed, err := prepareTemplateDisk(opts.TemplatePath, disk, opts.Name, opts.DiskSizeGB, opts.CloudInit)
+		if err != nil {
+			return "", fmt.Errorf("disk: %w", err)
 		}
+		isoTrim = seed
 		hasISO = true
+	} else {
+		// detect/create disk & get its format
+		if _, err := EnsureDiskAndDetectFormat(disk, opts.DiskSizeGB); err != nil {
+			return "", fmt.Errorf("disk: %w", err)
+		}
+		if isoTrim != "" {
+			if err := ensureFileExists(isoTrim); err != nil {
+				return "", fmt.Errorf("iso path: %w", err)
+			}
+			hasISO = true
+			bootISO = true
+		}
 	}
 
 	connURI := strings.TrimSpace(opts.ConnURI)
@@ -161,7 +179,7 @@ func CreateVMCustomCPU(opts CreateVMCustomCPUOptions) (string, error) {
 	}
 
 	bootDev := "hd"
-	if hasISO {
+	if bootISO {
 		bootDev = "cdrom"
 	}
 
@@ -187,20 +205,19 @@ func CreateVMCustomCPU(opts CreateVMCustomCPUOptions) (string, error) {
 	  <driver name='qemu' type='qcow2' cache='none' io='native'/>
 	  <source file='%s'/>
 	  <target dev='vda' bus='virtio'/>
-	</disk>%s
-	<interface type='network'>
-	  <source network='%s'/>
-	  <model type='virtio'/>
-	</interface>
+	</disk>%s%s
 	<graphics type='vnc' autoport='yes' port='-1'%s/>
 	<video><model type='virtio'/></video>
+	%s
+	%s
   </devices>
 </domain>`,
 		opts.Name, opts.MemoryMB, opts.VCPUs,
 		cputuneXML,
 		machineAttr,
 		bootDev,
-		cpuXML, disk, cdromXML, opts.Network, graphicsAttrs,
+		cpuXML, disk, cdromXML, nicsXML, graphicsAttrs,
+		serialConsoleXML, guestAgentChannelXML,
 	)
 
 	xmlPath, err := WriteDomainXMLToDisk(opts.Name, domainXML, disk)
@@ -220,110 +237,6 @@ func CreateVMCustomCPU(opts CreateVMCustomCPUOptions) (string, error) {
 	return xmlPath, nil
 }
 
-type SSHOptions struct {
-	Password             string
-	IdentityFile         string
-	SkipHostKeyCheck     bool
-	UserKnownHostsFile   string
-	AdditionalSSHOptions []string
-}
-
-type MigrateOptions struct {
-	ConnURI string
-	Name    string
-	DestURI string
-
-	Live bool
-
-	SSH SSHOptions
-}
-
-// MigrateVM shells out to virsh, allowing ssh configuration via sshpass or LIBVIRT_SSH_OPTS.
-func MigrateVM(opts MigrateOptions) error {
-	connURI := strings.TrimSpace(opts.ConnURI)
-	if connURI == "" {
-		return fmt.Errorf("conn uri is required")
-	}
-	name := strings.TrimSpace(opts.Name)
-	if name == "" {
-		return fmt.Errorf("domain name is required")
-	}
-	destURI := strings.TrimSpace(opts.DestURI)
-	if destURI == "" {
-		return fmt.Errorf("destination URI is required")
-	}
-
-	baseArgs := []string{
-		"-c", connURI,
-		"migrate",
-		"--persistent",
-		"--verbose",
-		"--undefinesource",
-		"--p2p",
-		"--tunnelled",
-	}
-
-	if opts.Live {
-		baseArgs = append(baseArgs, "--live")
-	}
-
-	baseArgs = append(baseArgs, name, destURI)
-
-	var sshOpts []string
-	if opts.SSH.SkipHostKeyCheck {
-		sshOpts = append(sshOpts, "-o", "StrictHostKeyChecking=no")
-		if opts.SSH.UserKnownHostsFile == "" {
-			sshOpts = append(sshOpts, "-o", "UserKnownHostsFile=/dev/null")
-		}
-	}
-	if file := strings.TrimSpace(opts.SSH.UserKnownHostsFile); file != "" {
-		sshOpts = append(sshOpts, "-o", "UserKnownHostsFile="+file)
-	}
-	if key := strings.TrimSpace(opts.SSH.IdentityFile); key != "" {
-		sshOpts = append(sshOpts, "-i", key)
-	}
-	if len(opts.SSH.AdditionalSSHOptions) > 0 {
-		sshOpts = append(sshOpts, opts.SSH.AdditionalSSHOptions...)
-	}
-
-	env := os.Environ()
-	if len(sshOpts) > 0 {
-		env = append(env, fmt.Sprintf("LIBVIRT_SSH_OPTS=%s", strings.Join(sshOpts, " ")))
-	}
-
-	// //testar esta merda melhor
-	// err := extra.ExecWithOutToSocket(context.Background(), extraGrpc.WebSocketsMessageType_DownloadIso, "virsh", baseArgs...)
-	// if err != nil {
-	// 	return fmt.Errorf("virsh migrate: %w", err)
-	// }
-
-	cmd := exec.Command("virsh", baseArgs...)
-	cmd.Env = env
-
-	var stdoutBuf, stderrBuf bytes.Buffer
-	cmd.Stdout = io.MultiWriter(os.Stdout, &stdoutBuf)
-	cmd.Stderr = io.MultiWriter(os.Stderr, &stderrBuf)
-
-	err := cmd.Run()
-	output := strings.TrimSpace(stdoutBuf.String() + "\n" + stderrBuf.String())
-	if err != nil {
-		fullCmd := strings.Join(cmd.Args, " ")
-		var exitErr *exec.ExitError
-		if errors.As(err, &exitErr) {
-			if output == "" {
-				output = "no output captured"
-			}
-			return fmt.Errorf("virsh migrate failed (%s): exit code %d: %s", fullCmd, exitErr.ExitCode(), output)
-		}
-		if output != "" {
-			return fmt.Errorf("virsh migrate failed (%s): %s: %w", fullCmd, output, err)
-		}
-		return fmt.Errorf("virsh migrate failed (%s): %w", fullCmd, err)
-	}
-
-	return nil
-}
-
 func GetCpuFeatures() ([]string, error) {
 	//call "sudo virsh -c qemu:///system capabilities | xmlstarlet sel -t -m '/capabilities/host/cpu/feature' -v '@name' -n | sort -u"
 	cmd := exec.Command("bash", "-c", "sudo virsh -c qemu:///system capabilities | xmlstarlet sel -t -m '/capabilities/host/cpu/feature' -v '@name' -n | sort -u")
@@ -353,3 +266,57 @@ func GetHostCPUXML() (string, error) {
 	}
 	return m[1], nil
 }
+
+var domainCPUPattern = regexp.MustCompile(`(?s)<cpu\b[^>]*/>|<cpu\b.*?</cpu>`)
+
+// GetVmCPUXML returns the <cpu> element of the vm definition, the baseline it was created with
+func GetVmCPUXML(name string) (string, error) {
+	conn, err := libvirt.NewConnect("qemu:///system")
+	if err != nil {
+		return "", fmt.Errorf("connect: %w", err)
+	}
+	defer conn.Close()
+
+	dom, err := conn.LookupDomainByName(name)
+	if err != nil {
+		return "", fmt.Errorf("lookup: %w", err)
+	}
+	defer dom.Free()
+
+	xmlDesc, err := dom.GetXMLDesc(libvirt.DOMAIN_XML_INACTIVE)
+	if err != nil {
+		return "", fmt.Errorf("xml: %w", err)
+	}
+	cpuXML := domainCPUPattern.FindString(xmlDesc)
+	if cpuXML == "" {
+		return "", fmt.Errorf("vm %s has no cpu definition", name)
+	}
+	return cpuXML, nil
+}
+
+// CompareCPU tells if a vm defined with cpuXML can run on this host, the reason is set when it cannot
+func CompareCPU(cpuXML string) (bool, string, error) {
+	if strings.Contains(cpuXML, "host-passthrough") {
+		return false, "host-passthrough cpu only runs on the host it was made for", nil
+	}
+
+	conn, err := libvirt.NewConnect("qemu:///system")
+	if err != nil {
+		return false, "", fmt.Errorf("connect: %w", err)
+	}
+	defer conn.Close()
+
+	res, err := conn.CompareHypervisorCPU("", "", "", "kvm", cpuXML, 0)
+	if err != nil {
+		var lvErr libvirt.Error
+		if errors.As(err, &lvErr) && lvErr.Code == libvirt.ERR_CPU_INCOMPATIBLE {
+			return false, lvErr.Message, nil
+		}
+		return false, "", fmt.Errorf("compare cpu: %w", err)
+	}
+	switch res {
+	case libvirt.CPU_COMPARE_IDENTICAL, libvirt.CPU_COMPARE_SUPERSET:
+		return true, "", nil
+	}
+	return false, "host cpu lacks features the vm needs", nil
+}
@@ -0,0 +1,573 @@
+package virsh
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	grpcVirsh "github.com/Maruqes/512SvMan/api/proto/virsh"
+	libvirt "libvirt.org/go/libvirt"
+)
+
+// disks created for the vm are listed in the domain metadata so RemoveVM knows what it can delete,
+// disks attached from an existing file are left alone
+const (
+	disksMetadataURI = "https://github.com/Maruqes/512SvMan/disks"
+	disksMetadataKey = "svman"
+)
+
+type ownedDisksXML struct {
+	XMLName xml.Name `xml:"disks"`
+	Disks   []struct {
+		Path string `xml:"path,attr"`
+	} `xml:"disk"`
+}
+
+func getOwnedDisks(dom *libvirt.Domain) ([]string, error) {
+	meta, err := dom.GetMetadata(libvirt.DOMAIN_METADATA_ELEMENT, disksMetadataURI, libvirt.DOMAIN_AFFECT_CONFIG)
+	if err != nil {
+		var lvErr libvirt.Error
+		if errors.As(err, &lvErr) && lvErr.Code == libvirt.ERR_NO_DOMAIN_METADATA {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("get disks metadata: %w", err)
+	}
+
+	var owned ownedDisksXML
+	if err := xml.Unmarshal([]byte(meta), &owned); err != nil {
+		return nil, fmt.Errorf("parse disks metadata: %w", err)
+	}
+	paths := make([]string, 0, len(owned.Disks))
+	for _, disk := range owned.Disks {
+		if strings.TrimSpace(disk.Path) != "" {
+			paths = append(paths, disk.Path)
+		}
+	}
+	return paths, nil
+}
+
+func setOwnedDisks(dom *libvirt.Domain, paths []string) error {
+	flags := libvirt.DOMAIN_AFFECT_CONFIG
+	if active, err := dom.IsActive(); err == nil && active {
+		flags |= libvirt.DOMAIN_AFFECT_LIVE
+	}
+
+	if len(paths) == 0 {
+		if err := dom.SetMetadata(libvirt.DOMAIN_METADATA_ELEMENT, "", disksMetadataKey, disksMetadataURI, flags); err != nil {
+			return fmt.Errorf("clear disks metadata: %w", err)
+		}
+		return nil
+	}
+
+	var owned ownedDisksXML
+	for _, path := range paths {
+		owned.Disks = append(owned.Disks, struct {
+			Path string `xml:"path,attr"`
+		}{Path: path})
+	}
+	out, err := xml.Marshal(owned)
+	if err != nil {
+		return fmt.Errorf("marshal disks metadata: %w", err)
+	}
+	if err := dom.SetMetadata(libvirt.DOMAIN_METADATA_ELEMENT, string(out), disksMetadataKey, disksMetadataURI, flags); err != nil {
+		return fmt.Errorf("set disks metadata: %w", err)
+	}
+	return nil
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+// diskIsOwned tells if the vm owns the disk whose active file is source, an external snapshot puts an
+// overlay on top of the recorded file so every file of the backing chain counts
+func diskIsOwned(owned []string, source string) bool {
+	if containsString(owned, source) {
+		return true
+	}
+	if len(owned) == 0 {
+		return false
+	}
+	files, err := diskBackingChain(source)
+	if err != nil {
+		return false
+	}
+	for _, file := range files {
+		if containsString(owned, file) {
+			return true
+		}
+	}
+	return false
+}
+
+// withoutDiskChain drops source and the files under it from owned
+func withoutDiskChain(owned []string, source string) []string {
+	chain := []string{source}
+	if files, err := diskBackingChain(source); err == nil && len(files) > 0 {
+		chain = files
+	}
+	var keep []string
+	for _, path := range owned {
+		if !containsString(chain, path) {
+			keep = append(keep, path)
+		}
+	}
+	return keep
+}
+
+// ownedDiskSources returns the active file of every extra disk the vm owns, the boot disk is not in it
+func ownedDiskSources(disks []domainDiskTarget, owned []string) []string {
+	var res []string
+	first := true
+	for _, disk := range disks {
+		if disk.Device != "disk" || disk.Source == "" {
+			continue
+		}
+		if !first && diskIsOwned(owned, disk.Source) {
+			res = append(res, disk.Source)
+		}
+		first = false
+	}
+	return res
+}
+
+// nextDiskTarget returns the first free virtio name after the ones in use (vdb, vdc...)
+func nextDiskTarget(disks []domainDiskTarget) (string, error) {
+	used := make(map[string]struct{}, len(disks))
+	for _, disk := range disks {
+		used[disk.Target] = struct{}{}
+	}
+	for c := 'a'; c <= 'z'; c++ {
+		target := "vd" + string(c)
+		if _, ok := used[target]; !ok {
+			return target, nil
+		}
+	}
+	return "", fmt.Errorf("no free virtio disk target left")
+}
+
+func diskDeviceXML(path, format, target string) string {
+	return fmt.Sprintf(`<disk type='file' device='disk'>
+  <driver name='qemu' type='%s' cache='none' io='native'/>
+  <source file='%s'/>
+  <target dev='%s' bus='virtio'/>
+</disk>`, format, path, target)
+}
+
+func deviceModifyFlags(dom *libvirt.Domain) libvirt.DomainDeviceModifyFlags {
+	flags := libvirt.DOMAIN_DEVICE_MODIFY_CONFIG
+	if active, err := dom.IsActive(); err == nil && active {
+		flags |= libvirt.DOMAIN_DEVICE_MODIFY_LIVE
+	}
+	return flags
+}
+
+// findDomainDisk looks a disk up by target or by file, the first disk is the boot disk
+func findDomainDisk(disks []domainDiskTarget, target, path string) (domainDiskTarget, bool, error) {
+	first := true
+	for _, disk := range disks {
+		if disk.Device != "disk" {
+			continue
+		}
+		if (target != "" && disk.Target == target) || (target == "" && path != "" && disk.Source == path) {
+			return disk, first, nil
+		}
+		first = false
+	}
+	if target != "" {
+		return domainDiskTarget{}, false, fmt.Errorf("disk %s not found", target)
+	}
+	return domainDiskTarget{}, false, fmt.Errorf("disk %s not found", path)
+}
+
+func diskSizeGB(dom *libvirt.Domain, disk domainDiskTarget) int32 {
+	const gib = uint64(1 << 30)
+	if info, err := dom.GetBlockInfo(disk.Target, 0); err == nil && info.Capacity > 0 {
+		return int32((info.Capacity + gib - 1) / gib)
+	}
+	if info, err := readQemuImgInfo(disk.Source); err == nil && info.VirtualSize > 0 {
+		return int32((info.VirtualSize + gib - 1) / gib)
+	}
+	return 0
+}
+
+// listVmDisks reports every file backed disk of the domain
+func listVmDisks(dom *libvirt.Domain, xmlDesc string) ([]*grpcVirsh.VmDisk, error) {
+	disks, err := domainDiskTargets(xmlDesc)
+	if err != nil {
+		return nil, err
+	}
+	owned, err := getOwnedDisks(dom)
+	if err != nil {
+		return nil, err
+	}
+
+	var res []*grpcVirsh.VmDisk
+	for _, disk := range disks {
+		if disk.Device != "disk" || disk.Source == "" {
+			continue
+		}
+		// the boot disk always goes with the vm
+		res = append(res, vmDiskToGRPC(dom, disk, len(res) == 0 || diskIsOwned(owned, disk.Source)))
+	}
+	return res, nil
+}
+
+func vmDiskToGRPC(dom *libvirt.Domain, disk domainDiskTarget, owned bool) *grpcVirsh.VmDisk {
+	return &grpcVirsh.VmDisk{
+		Target: disk.Target,
+		Path:   disk.Source,
+		SizeGB: diskSizeGB(dom, disk),
+		Bus:    disk.Bus,
+		Owned:  owned,
+	}
+}
+
+// BackingFileUsers lists the vms defined here that have path in the backing chain of one of their disks,
+// a template image can not go while an overlay still reads from it
+func BackingFileUsers(path string) ([]string, error) {
+	path = filepath.Clean(strings.TrimSpace(path))
+	conn, err := libvirt.NewConnect("qemu:///system")
+	if err != nil {
+		return nil, fmt.Errorf("connect: %w", err)
+	}
+	defer conn.Close()
+
+	doms, err := conn.ListAllDomains(0)
+	if err != nil {
+		return nil, fmt.Errorf("list domains: %w", err)
+	}
+	var users []string
+	for i := range doms {
+		dom := &doms[i]
+		name, err := dom.GetName()
+		if err != nil {
+			dom.Free()
+			continue
+		}
+		xmlDesc, err := dom.GetXMLDesc(0)
+		dom.Free()
+		if err != nil {
+			return nil, fmt.Errorf("xml of %s: %w", name, err)
+		}
+		disks, err := domainDiskTargets(xmlDesc)
+		if err != nil {
+			return nil, err
+		}
+		for _, disk := range disks {
+			if disk.Device != "disk" || disk.Source == "" {
+				continue
+			}
+			chain, err := diskBackingChain(disk.Source)
+			if err != nil {
+				// an unreadable chain can not prove the file is free
+				return nil, fmt.Errorf("backing chain of %s on vm %s: %w", disk.Source, name, err)
+			}
+			if containsString(chain, path) {
+				users = append(users, name)
+				break
+			}
+		}
+	}
+	return users, nil
+}
+
+// TrashedBackingFileUsers returns the files that have path in their backing chain, the overlays of a
+// trashed vm are no longer in any domain but still need the template until they are purged
+func TrashedBackingFileUsers(path string, files []string) ([]string, error) {
+	path = filepath.Clean(strings.TrimSpace(path))
+	var users []string
+	for _, file := range files {
+		if _, err := os.Stat(file); os.IsNotExist(err) {
+			continue
+		}
+		chain, err := diskBackingChain(file)
+		if err != nil {
+			return nil, fmt.Errorf("backing chain of trashed file %s: %w", file, err)
+		}
+		// the first entry is the file itself
+		if len(chain) > 1 && containsString(chain[1:], path) {
+			users = append(users, file)
+		}
+	}
+	return users, nil
+}
+
+// removeDiskChain deletes a disk and the overlays/backing files that live in the same folder
+func removeDiskChain(path string) error {
+	dir := filepath.Dir(path)
+	chain := []string{path}
+	if files, err := diskBackingChain(path); err == nil && len(files) > 0 {
+		chain = files
+	}
+	for _, file := range chain {
+		if filepath.Dir(file) != dir {
+			continue
+		}
+		if err := os.Remove(file); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("remove disk %s: %w", file, err)
+		}
+	}
+	return nil
+}
+
+func attachDiskFile(dom *libvirt.Domain, path, target string) error {
+	format, err := DetectDiskFormat(path)
+	if err != nil {
+		return err
+	}
+	if err := dom.AttachDeviceFlags(diskDeviceXML(path, format, target), deviceModifyFlags(dom)); err != nil {
+		return fmt.Errorf("attach disk: %w", err)
+	}
+	return nil
+}
+
+// AddDisk creates a new disk for the vm and attaches it (live when the vm is running)
+func AddDisk(vmName, diskPath, diskFolder, target string, sizeGB int) (*grpcVirsh.VmDisk, error) {
+	if sizeGB <= 0 {
+		return nil, fmt.Errorf("sizeGB must be greater than zero")
+	}
+
+	conn, err := libvirt.NewConnect("qemu:///system")
+	if err != nil {
+		return nil, fmt.Errorf("connect: %w", err)
+	}
+	defer conn.Close()
+
+	dom, err := conn.LookupDomainByName(vmName)
+	if err != nil {
+		return nil, fmt.Errorf("lookup: %w", err)
+	}
+	defer dom.Free()
+
+	xmlDesc, err := dom.GetXMLDesc(libvirt.DOMAIN_XML_INACTIVE)
+	if err != nil {
+		return nil, fmt.Errorf("xml: %w", err)
+	}
+	disks, err := domainDiskTargets(xmlDesc)
+	if err != nil {
+		return nil, err
+	}
+	if target == "" {
+		if target, err = nextDiskTarget(disks); err != nil {
+			return nil, err
+		}
+	} else if _, _, err := findDomainDisk(disks, target, ""); err == nil {
+		return nil, fmt.Errorf("disk %s already exists on vm %s", target, vmName)
+	}
+
+	path := strings.TrimSpace(diskPath)
+	if path == "" {
+		if strings.TrimSpace(diskFolder) == "" {
+			return nil, fmt.Errorf("disk path or disk folder is required")
+		}
+		path = filepath.Join(diskFolder, fmt.Sprintf("%s-%s.qcow2", vmName, target))
+	}
+	if _, err := os.Stat(path); err == nil {
+		return nil, fmt.Errorf("disk %s already exists, attach it instead", path)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o777); err != nil {
+		return nil, fmt.Errorf("create disk directory: %w", err)
+	}
+	if _, err := EnsureDiskAndDetectFormat(path, sizeGB); err != nil {
+		return nil, fmt.Errorf("disk: %w", err)
+	}
+
+	if err := attachDiskFile(dom, path, target); err != nil {
+		os.Remove(path)
+		return nil, err
+	}
+
+	owned, err := getOwnedDisks(dom)
+	if err != nil {
+		return nil, err
+	}
+	if err := setOwnedDisks(dom, append(owned, path)); err != nil {
+		return nil, err
+	}
+	if err := refreshDomainXMLOnDisk(dom); err != nil {
+		return nil, fmt.Errorf("write domain xml: %w", err)
+	}
+
+	return vmDiskToGRPC(dom, domainDiskTarget{Device: "disk", Target: target, Source: path}, true), nil
+}
+
+// AttachDisk attaches an existing disk file, the vm does not own it so RemoveVM keeps it
+func AttachDisk(vmName, diskPath, target string) (*grpcVirsh.VmDisk, error) {
+	path := strings.TrimSpace(diskPath)
+	if err := ensureFileExists(path); err != nil {
+		return nil, fmt.Errorf("disk: %w", err)
+	}
+
+	conn, err := libvirt.NewConnect("qemu:///system")
+	if err != nil {
+		return nil, fmt.Errorf("connect: %w", err)
+	}
+	defer conn.Close()
+
+	dom, err := conn.LookupDomainByName(vmName)
+	if err != nil {
+		return nil, fmt.Errorf("lookup: %w", err)
+	}
+	defer dom.Free()
+
+	xmlDesc, err := dom.GetXMLDesc(libvirt.DOMAIN_XML_INACTIVE)
+	if err != nil {
+		return nil, fmt.Errorf("xml: %w", err)
+	}
+	disks, err := domainDiskTargets(xmlDesc)
+	if err != nil {
+		return nil, err
+	}
+	if _, _, err := findDomainDisk(disks, "", path); err == nil {
+		return nil, fmt.Errorf("disk %s is already attached to vm %s", path, vmName)
+	}
+	if target == "" {
+		if target, err = nextDiskTarget(disks); err != nil {
+			return nil, err
+		}
+	} else if _, _, err := findDomainDisk(disks, target, ""); err == nil {
+		return nil, fmt.Errorf("disk %s already exists on vm %s", target, vmName)
+	}
+
+	if err := ensureDiskPermissions(path); err != nil {
+		return nil, err
+	}
+	if err := attachDiskFile(dom, path, target); err != nil {
+		return nil, err
+	}
+	if err := refreshDomainXMLOnDisk(dom); err != nil {
+		return nil, fmt.Errorf("write domain xml: %w", err)
+	}
+
+	return vmDiskToGRPC(dom, domainDiskTarget{Device: "disk", Target: target, Source: path}, false), nil
+}
+
+// DetachDisk removes a disk from the vm, the file is only deleted when asked and the vm owns it
+func DetachDisk(vmName, target, diskPath string, deleteFile bool) error {
+	conn, err := libvirt.NewConnect("qemu:///system")
+	if err != nil {
+		return fmt.Errorf("connect: %w", err)
+	}
+	defer conn.Close()
+
+	dom, err := conn.LookupDomainByName(vmName)
+	if err != nil {
+		return fmt.Errorf("lookup: %w", err)
+	}
+	defer dom.Free()
+
+	xmlDesc, err := dom.GetXMLDesc(libvirt.DOMAIN_XML_INACTIVE)
+	if err != nil {
+		return fmt.Errorf("xml: %w", err)
+	}
+	disks, err := domainDiskTargets(xmlDesc)
+	if err != nil {
+		return err
+	}
+	disk, primary, err := findDomainDisk(disks, strings.TrimSpace(target), strings.TrimSpace(diskPath))
+	if err != nil {
+		return err
+	}
+	if primary {
+		return fmt.Errorf("disk %s is the boot disk of vm %s", disk.Target, vmName)
+	}
+
+	owned, err := getOwnedDisks(dom)
+	if err != nil {
+		return err
+	}
+	isOwned := diskIsOwned(owned, disk.Source)
+	// worked out before the detach, the chain is read from the file that is still in use
+	keep := withoutDiskChain(owned, disk.Source)
+	if deleteFile && !isOwned {
+		return fmt.Errorf("disk %s was not created for vm %s, refusing to delete it", disk.Source, vmName)
+	}
+
+	format, err := DetectDiskFormat(disk.Source)
+	if err != nil {
+		return err
+	}
+	if err := dom.DetachDeviceFlags(diskDeviceXML(disk.Source, format, disk.Target), deviceModifyFlags(dom)); err != nil {
+		return fmt.Errorf("detach disk: %w", err)
+	}
+
+	if isOwned {
+		// once detached the file is on its own, it can be attached somewhere else
+		if err := setOwnedDisks(dom, keep); err != nil {
+			return err
+		}
+	}
+	if err := refreshDomainXMLOnDisk(dom); err != nil {
+		return fmt.Errorf("write domain xml: %w", err)
+	}
+
+	if deleteFile {
+		return removeDiskChain(disk.Source)
+	}
+	return nil
+}
+
+// ResizeDisk grows a disk, through qemu when the vm is running so the guest sees the new size
+func ResizeDisk(vmName, target string, sizeGB int) (*grpcVirsh.VmDisk, error) {
+	if sizeGB <= 0 {
+		return nil, fmt.Errorf("sizeGB must be greater than zero")
+	}
+
+	conn, err := libvirt.NewConnect("qemu:///system")
+	if err != nil {
+		return nil, fmt.Errorf("connect: %w", err)
+	}
+	defer conn.Close()
+
+	dom, err := conn.LookupDomainByName(vmName)
+	if err != nil {
+		return nil, fmt.Errorf("lookup: %w", err)
+	}
+	defer dom.Free()
+
+	xmlDesc, err := dom.GetXMLDesc(0)
+	if err != nil {
+		return nil, fmt.Errorf("xml: %w", err)
+	}
+	disks, err := domainDiskTargets(xmlDesc)
+	if err != nil {
+		return nil, err
+	}
+	disk, primary, err := findDomainDisk(disks, strings.TrimSpace(target), "")
+	if err != nil {
+		return nil, err
+	}
+
+	current := diskSizeGB(dom, disk)
+	if int32(sizeGB) < current {
+		return nil, fmt.Errorf("disk %s is %dGB, shrinking is not supported", disk.Target, current)
+	}
+
+	active, err := dom.IsActive()
+	if err != nil {
+		return nil, fmt.Errorf("state: %w", err)
+	}
+	if active {
+		const gib = uint64(1 << 30)
+		if err := dom.BlockResize(disk.Target, uint64(sizeGB)*gib, libvirt.DOMAIN_BLOCK_RESIZE_BYTES); err != nil {
+			return nil, fmt.Errorf("block resize: %w", err)
+		}
+	} else if err := ensureDiskSizeAtLeast(disk.Source, sizeGB); err != nil {
+		return nil, err
+	}
+
+	owned, err := getOwnedDisks(dom)
+	if err != nil {
+		return nil, err
+	}
+	return vmDiskToGRPC(dom, disk, primary || diskIsOwned(owned, disk.Source)), nil
+}
@@ -0,0 +1,287 @@
+package virsh
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	grpcVirsh "github.com/Maruqes/512SvMan/api/proto/virsh"
+	"github.com/Maruqes/512SvMan/logger"
+	libvirt "libvirt.org/go/libvirt"
+)
+
+const guestAgentChannelName = "org.qemu.guest_agent.0"
+
+// libvirt picks the socket path, the guest needs qemu-guest-agent installed for anything to answer
+const guestAgentChannelXML = `<channel type='unix'><target type='virtio' name='` + guestAgentChannelName + `'/></channel>`
+
+const (
+	guestExecDefaultTimeout = 30 * time.Second
+	guestExecMaxTimeout     = 5 * time.Minute
+)
+
+// guestAgentCommand runs a raw qga command and returns what is inside "return"
+func guestAgentCommand(dom *libvirt.Domain, command string, args any) (json.RawMessage, error) {
+	req := map[string]any{"execute": command}
+	if args != nil {
+		req["arguments"] = args
+	}
+	data, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	out, err := dom.QemuAgentCommand(string(data), libvirt.DOMAIN_QEMU_AGENT_COMMAND_DEFAULT, 0)
+	if err != nil {
+		return nil, fmt.Errorf("guest agent %s: %w", command, err)
+	}
+	var resp struct {
+		Return json.RawMessage `json:"return"`
+	}
+	if err := json.Unmarshal([]byte(out), &resp); err != nil {
+		return nil, fmt.Errorf("guest agent %s: parse reply: %w", command, err)
+	}
+	return resp.Return, nil
+}
+
+// guestUptime reads /proc/uptime through the agent file api, guest-exec may be disabled in the guest
+func guestUptime(dom *libvirt.Domain) (int64, error) {
+	raw, err := guestAgentCommand(dom, "guest-file-open", map[string]any{"path": "/proc/uptime", "mode": "r"})
+	if err != nil {
+		return 0, err
+	}
+	var handle int64
+	if err := json.Unmarshal(raw, &handle); err != nil {
+		return 0, fmt.Errorf("guest-file-open: %w", err)
+	}
+	defer guestAgentCommand(dom, "guest-file-close", map[string]any{"handle": handle})
+
+	raw, err = guestAgentCommand(dom, "guest-file-read", map[string]any{"handle": handle, "count": 128})
+	if err != nil {
+		return 0, err
+	}
+	var read struct {
+		Buf string `json:"buf-b64"`
+	}
+	if err := json.Unmarshal(raw, &read); err != nil {
+		return 0, fmt.Errorf("guest-file-read: %w", err)
+	}
+	buf, err := base64.StdEncoding.DecodeString(read.Buf)
+	if err != nil {
+		return 0, fmt.Errorf("guest-file-read: %w", err)
+	}
+	fields := strings.Fields(string(buf))
+	if len(fields) == 0 {
+		return 0, fmt.Errorf("empty /proc/uptime")
+	}
+	secs, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return 0, fmt.Errorf("parse /proc/uptime: %w", err)
+	}
+	return int64(secs), nil
+}
+
+func GetGuestInfo(vmName string) (*grpcVirsh.GuestInfo, error) {
+	conn, err := libvirt.NewConnect("qemu:///system")
+	if err != nil {
+		return nil, fmt.Errorf("connect: %w", err)
+	}
+	defer conn.Close()
+
+	dom, err := conn.LookupDomainByName(vmName)
+	if err != nil {
+		return nil, fmt.Errorf("lookup: %w", err)
+	}
+	defer dom.Free()
+
+	if state, _, err := dom.GetState(); err != nil {
+		return nil, fmt.Errorf("state: %w", err)
+	} else if state != libvirt.DOMAIN_RUNNING {
+		return nil, fmt.Errorf("vm %s is not running", vmName)
+	}
+
+	types := libvirt.DOMAIN_GUEST_INFO_OS | libvirt.DOMAIN_GUEST_INFO_TIMEZONE | libvirt.DOMAIN_GUEST_INFO_HOSTNAME |
+		libvirt.DOMAIN_GUEST_INFO_FILESYSTEM | libvirt.DOMAIN_GUEST_INFO_INTERFACES | libvirt.DOMAIN_GUEST_INFO_USERS
+	guest, err := dom.GetGuestInfo(types, 0)
+	if err != nil {
+		return nil, fmt.Errorf("guest info: %w", err)
+	}
+
+	res := &grpcVirsh.GuestInfo{VmName: vmName, Hostname: guest.Hostname}
+	if guest.OS != nil {
+		res.OsName = guest.OS.PrettyName
+		if res.OsName == "" {
+			res.OsName = guest.OS.Name
+		}
+		res.OsVersion = guest.OS.Version
+		res.Kernel = guest.OS.KernelRelease
+	}
+	if guest.TimeZone != nil {
+		res.Timezone = guest.TimeZone.Name
+	}
+	for _, user := range guest.Users {
+		res.Users = append(res.Users, user.Name)
+	}
+	for _, iface := range guest.Interfaces {
+		info := &grpcVirsh.GuestInterface{Name: iface.Name, Mac: strings.ToLower(iface.Hwaddr)}
+		for _, addr := range iface.Addrs {
+			info.Ips = append(info.Ips, fmt.Sprintf("%s/%d", addr.Addr, addr.Prefix))
+		}
+		res.Interfaces = append(res.Interfaces, info)
+	}
+	for _, fs := range guest.FileSystems {
+		res.Filesystems = append(res.Filesystems, &grpcVirsh.GuestFilesystem{
+			Mountpoint: fs.MountPoint,
+			Name:       fs.Name,
+			Type:       fs.FSType,
+			TotalBytes: fs.TotalBytes,
+			UsedBytes:  fs.UsedBytes,
+		})
+	}
+
+	// windows guests have no /proc/uptime, the rest of the info is still good
+	if uptime, err := guestUptime(dom); err == nil {
+		res.UptimeSeconds = uptime
+	}
+	return res, nil
+}
+
+// GuestExec runs a program inside the guest and waits for it, the program can not be killed from here
+// so a timeout only stops the waiting
+func GuestExec(req *grpcVirsh.GuestExecRequest) (*grpcVirsh.GuestExecResponse, error) {
+	program := strings.TrimSpace(req.Path)
+	if !path.IsAbs(program) && !strings.Contains(program, `:\`) {
+		return nil, fmt.Errorf("path must be absolute")
+	}
+	timeout := time.Duration(req.TimeoutSeconds) * time.Second
+	if timeout <= 0 {
+		timeout = guestExecDefaultTimeout
+	}
+	if timeout > guestExecMaxTimeout {
+		timeout = guestExecMaxTimeout
+	}
+
+	conn, err := libvirt.NewConnect("qemu:///system")
+	if err != nil {
+		return nil, fmt.Errorf("connect: %w", err)
+	}
+	defer conn.Close()
+
+	dom, err := conn.LookupDomainByName(req.VmName)
+	if err != nil {
+		return nil, fmt.Errorf("lookup: %w", err)
+	}
+	defer dom.Free()
+
+	args := map[string]any{
+		"path":           program,
+		"capture-output": true,
+	}
+	// the agent rejects "arg": null, leave it out when there is nothing to pass
+	if len(req.Args) > 0 {
+		args["arg"] = req.Args
+	}
+	if req.Input != "" {
+		args["input-data"] = base64.StdEncoding.EncodeToString([]byte(req.Input))
+	}
+	raw, err := guestAgentCommand(dom, "guest-exec", args)
+	if err != nil {
+		return nil, err
+	}
+	var started struct {
+		Pid int64 `json:"pid"`
+	}
+	if err := json.Unmarshal(raw, &started); err != nil {
+		return nil, fmt.Errorf("guest-exec: %w", err)
+	}
+
+	deadline := time.Now().Add(timeout)
+	for {
+		raw, err := guestAgentCommand(dom, "guest-exec-status", map[string]any{"pid": started.Pid})
+		if err != nil {
+			return nil, err
+		}
+		var status struct {
+			Exited       bool   `json:"exited"`
+			ExitCode     int32  `json:"exitcode"`
+			Signal       int32  `json:"signal"`
+			OutData      string `json:"out-data"`
+			ErrData      string `json:"err-data"`
+			OutTruncated bool   `json:"out-truncated"`
+			ErrTruncated bool   `json:"err-truncated"`
+		}
+		if err := json.Unmarshal(raw, &status); err != nil {
+			return nil, fmt.Errorf("guest-exec-status: %w", err)
+		}
+		if status.Exited {
+			stdout, _ := base64.StdEncoding.DecodeString(status.OutData)
+			stderr, _ := base64.StdEncoding.DecodeString(status.ErrData)
+			exitCode := status.ExitCode
+			if status.Signal != 0 {
+				exitCode = 128 + status.Signal
+			}
+			return &grpcVirsh.GuestExecResponse{
+				ExitCode:  exitCode,
+				Stdout:    string(stdout),
+				Stderr:    string(stderr),
+				Truncated: status.OutTruncated || status.ErrTruncated,
+			}, nil
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("guest exec timed out after %s (pid %d is still running in the guest)", timeout, started.Pid)
+		}
+		time.Sleep(200 * time.Millisecond)
+	}
+}
+
+// EnableGuestAgent adds the agent channel, live too when running, to a vm defined without it
+func EnableGuestAgent(vmName string) error {
+	conn, err := libvirt.NewConnect("qemu:///system")
+	if err != nil {
+		return fmt.Errorf("connect: %w", err)
+	}
+	defer conn.Close()
+
+	dom, err := conn.LookupDomainByName(vmName)
+	if err != nil {
+		return fmt.Errorf("lookup: %w", err)
+	}
+	defer dom.Free()
+
+	xmlDesc, err := dom.GetXMLDesc(libvirt.DOMAIN_XML_INACTIVE)
+	if err != nil {
+		return fmt.Errorf("xml: %w", err)
+	}
+	if strings.Contains(xmlDesc, guestAgentChannelName) {
+		return nil
+	}
+
+	if err := dom.AttachDeviceFlags(guestAgentChannelXML, deviceModifyFlags(dom)); err != nil {
+		return fmt.Errorf("attach guest agent channel: %w", err)
+	}
+	if err := refreshDomainXMLOnDisk(dom); err != nil {
+		return fmt.Errorf("write domain xml: %w", err)
+	}
+	return nil
+}
+
+// freezeGuest flushes and freezes the guest filesystems so a disk-only copy taken now is consistent,
+// the returned func thaws them and must always run, without an agent it just logs and does nothing
+func freezeGuest(dom *libvirt.Domain) func() {
+	if state, _, err := dom.GetState(); err != nil || state != libvirt.DOMAIN_RUNNING {
+		return func() {}
+	}
+	name, _ := dom.GetName()
+	if err := dom.FSFreeze(nil, 0); err != nil {
+		logger.Warn("fs-freeze failed, the copy is only crash consistent", "vm", name, "error", err)
+		return func() {}
+	}
+	return func() {
+		if err := dom.FSThaw(nil, 0); err != nil {
+			logger.Error("fs-thaw failed", "vm", name, "error", err)
+		}
+	}
+}
@@ -0,0 +1,491 @@
+package virsh
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slave/env512"
+	"sync"
+	"time"
+
+	"github.com/Maruqes/512SvMan/logger"
+	libvirt "libvirt.org/go/libvirt"
+)
+
+// protected vms carry <ha epoch='N'/> in their metadata and keep a lease file next to the disk.
+// the epoch goes up every time the master restarts the vm on another slave, a slave that still
+// runs an older epoch lost the vm and kills its copy so two qemus never write the same qcow2
+const (
+	haMetadataURI = "https://github.com/Maruqes/512SvMan/ha"
+	haMetadataKey = "svmanha"
+
+	HALeaseInterval = 10 * time.Second
+	// a lease not renewed for this long belongs to a dead slave
+	HALeaseTimeout = 45 * time.Second
+)
+
+type haMetadataXML struct {
+	XMLName xml.Name `xml:"ha"`
+	Epoch   int64    `xml:"epoch,attr"`
+}
+
+type haLease struct {
+	Holder  string `json:"holder"`
+	VmName  string `json:"vm_name"`
+	Epoch   int64  `json:"epoch"`
+	Renewed int64  `json:"renewed"` // unix seconds on the holder, only compared for changes
+}
+
+// one lease read or write may take this long before the share counts as gone,
+// a hard nfs mount blocks forever instead of failing
+const haLeaseIOTimeout = 5 * time.Second
+
+var errHALeaseIOTimeout = errors.New("lease i/o timed out")
+
+var (
+	// last successful lease write per vm, used to fence ourselves when the share goes away
+	haRenewedMu sync.Mutex
+	haRenewed   = map[string]time.Time{}
+
+	// lease paths with an i/o still hanging on the share, no second one is started behind it
+	haLeaseBusyMu sync.Mutex
+	haLeaseBusy   = map[string]bool{}
+
+	// what this slave last read from a foreign lease and when, on the local monotonic clock.
+	// the holder clock is never trusted, a lease is dead once it stopped changing for HALeaseTimeout
+	haLeaseSeenMu sync.Mutex
+	haLeaseSeen   = map[string]haLeaseSighting{}
+)
+
+type haLeaseSighting struct {
+	lease  haLease
+	seenAt time.Time
+}
+
+func haLeasePath(vmName, diskPath string) string {
+	return filepath.Join(filepath.Dir(diskPath), vmName+".lease")
+}
+
+// withLeaseDeadline runs io in its own goroutine and gives up at deadline, a call stuck on the
+// share is left behind and keeps the path busy until it returns
+func withLeaseDeadline(path string, deadline time.Time, io func() error) error {
+	haLeaseBusyMu.Lock()
+	if haLeaseBusy[path] {
+		haLeaseBusyMu.Unlock()
+		return fmt.Errorf("%s: %w", path, errHALeaseIOTimeout)
+	}
+	haLeaseBusy[path] = true
+	haLeaseBusyMu.Unlock()
+
+	done := make(chan error, 1)
+	go func() {
+		err := io()
+		haLeaseBusyMu.Lock()
+		delete(haLeaseBusy, path)
+		haLeaseBusyMu.Unlock()
+		done <- err
+	}()
+
+	timer := time.NewTimer(time.Until(deadline))
+	defer timer.Stop()
+	select {
+	case err := <-done:
+		return err
+	case <-timer.C:
+		return fmt.Errorf("%s: %w", path, errHALeaseIOTimeout)
+	}
+}
+
+func readHALease(path string, deadline time.Time) (*haLease, error) {
+	var lease *haLease
+	err := withLeaseDeadline(path, deadline, func() error {
+		data, err := os.ReadFile(path)
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("read lease: %w", err)
+		}
+		var l haLease
+		if err := json.Unmarshal(data, &l); err != nil {
+			return fmt.Errorf("parse lease %s: %w", path, err)
+		}
+		lease = &l
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return lease, nil
+}
+
+func writeHALease(path, vmName string, epoch int64, deadline time.Time) error {
+	data, err := json.Marshal(haLease{
+		Holder:  env512.MachineName,
+		VmName:  vmName,
+		Epoch:   epoch,
+		Renewed: time.Now().Unix(),
+	})
+	if err != nil {
+		return fmt.Errorf("marshal lease: %w", err)
+	}
+	return withLeaseDeadline(path, deadline, func() error {
+		tmp := path + ".tmp"
+		if err := os.WriteFile(tmp, data, 0o644); err != nil {
+			return fmt.Errorf("write lease: %w", err)
+		}
+		if err := os.Rename(tmp, path); err != nil {
+			return fmt.Errorf("write lease: %w", err)
+		}
+		return nil
+	})
+}
+
+func removeHALease(path string, deadline time.Time) error {
+	return withLeaseDeadline(path, deadline, func() error {
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("remove lease: %w", err)
+		}
+		return nil
+	})
+}
+
+// haLeaseUnchangedFor records a foreign lease as read now and returns how long it has looked
+// exactly like this, zero on the first sighting or after any change
+func haLeaseUnchangedFor(path string, lease haLease) time.Duration {
+	haLeaseSeenMu.Lock()
+	defer haLeaseSeenMu.Unlock()
+	prev, ok := haLeaseSeen[path]
+	if !ok || prev.lease != lease {
+		haLeaseSeen[path] = haLeaseSighting{lease: lease, seenAt: time.Now()}
+		return 0
+	}
+	return time.Since(prev.seenAt)
+}
+
+func forgetHALeaseSighting(path string) {
+	haLeaseSeenMu.Lock()
+	delete(haLeaseSeen, path)
+	haLeaseSeenMu.Unlock()
+}
+
+// getHAEpoch returns the epoch of a protected vm, ok is false when the vm is not protected
+func getHAEpoch(dom *libvirt.Domain, flags libvirt.DomainModificationImpact) (int64, bool, error) {
+	meta, err := dom.GetMetadata(libvirt.DOMAIN_METADATA_ELEMENT, haMetadataURI, flags)
+	if err != nil {
+		var lvErr libvirt.Error
+		if errors.As(err, &lvErr) && lvErr.Code == libvirt.ERR_NO_DOMAIN_METADATA {
+			return 0, false, nil
+		}
+		return 0, false, fmt.Errorf("get ha metadata: %w", err)
+	}
+	var ha haMetadataXML
+	if err := xml.Unmarshal([]byte(meta), &ha); err != nil {
+		return 0, false, fmt.Errorf("parse ha metadata: %w", err)
+	}
+	return ha.Epoch, true, nil
+}
+
+func setHAEpoch(dom *libvirt.Domain, epoch int64, enabled bool) error {
+	flags := libvirt.DOMAIN_AFFECT_CONFIG
+	if active, err := dom.IsActive(); err == nil && active {
+		flags |= libvirt.DOMAIN_AFFECT_LIVE
+	}
+
+	if !enabled {
+		if err := dom.SetMetadata(libvirt.DOMAIN_METADATA_ELEMENT, "", haMetadataKey, haMetadataURI, flags); err != nil {
+			return fmt.Errorf("clear ha metadata: %w", err)
+		}
+		return nil
+	}
+
+	out, err := xml.Marshal(haMetadataXML{Epoch: epoch})
+	if err != nil {
+		return fmt.Errorf("marshal ha metadata: %w", err)
+	}
+	if err := dom.SetMetadata(libvirt.DOMAIN_METADATA_ELEMENT, string(out), haMetadataKey, haMetadataURI, flags); err != nil {
+		return fmt.Errorf("set ha metadata: %w", err)
+	}
+	return nil
+}
+
+func domainPrimaryDisk(dom *libvirt.Domain) (string, error) {
+	xmlDesc, err := dom.GetXMLDesc(libvirt.DOMAIN_XML_INACTIVE)
+	if err != nil {
+		return "", fmt.Errorf("xml: %w", err)
+	}
+	disk, err := diskPathFromDomainXML(xmlDesc)
+	if err != nil {
+		return "", fmt.Errorf("detect disk path: %w", err)
+	}
+	if disk == "" {
+		return "", fmt.Errorf("vm has no file backed disk")
+	}
+	return disk, nil
+}
+
+// SetVmHA turns the protection of a vm on or off, turning it on takes the lease for this slave
+func SetVmHA(vmName string, enabled bool) error {
+	conn, err := libvirt.NewConnect("qemu:///system")
+	if err != nil {
+		return fmt.Errorf("connect: %w", err)
+	}
+	defer conn.Close()
+
+	dom, err := conn.LookupDomainByName(vmName)
+	if err != nil {
+		return fmt.Errorf("lookup: %w", err)
+	}
+	defer dom.Free()
+
+	disk, err := domainPrimaryDisk(dom)
+	if err != nil {
+		return err
+	}
+	leasePath := haLeasePath(vmName, disk)
+
+	epoch, protected, err := getHAEpoch(dom, libvirt.DOMAIN_AFFECT_CONFIG)
+	if err != nil {
+		return err
+	}
+	if enabled && !protected {
+		// a lease left from an older protection keeps counting up
+		if lease, err := readHALease(leasePath, time.Now().Add(haLeaseIOTimeout)); err == nil && lease != nil {
+			epoch = lease.Epoch
+		}
+	}
+
+	if err := setHAEpoch(dom, epoch, enabled); err != nil {
+		return err
+	}
+	if err := refreshDomainXMLOnDisk(dom); err != nil {
+		return fmt.Errorf("write domain xml: %w", err)
+	}
+
+	if !enabled {
+		return removeHALease(leasePath, time.Now().Add(haLeaseIOTimeout))
+	}
+	if active, _ := dom.IsActive(); active {
+		return writeHALease(leasePath, vmName, epoch, time.Now().Add(haLeaseIOTimeout))
+	}
+	return nil
+}
+
+// RecoverVM starts a protected vm of a dead slave here, from the domain xml saved next to its disk.
+// it refuses while another slave keeps the lease alive, the lease has to stay unchanged for
+// HALeaseTimeout between calls, so the first call for a dead holder only starts the clock
+func RecoverVM(vmName, diskPath string) error {
+	if diskPath == "" {
+		return fmt.Errorf("disk path is required")
+	}
+	leasePath := haLeasePath(vmName, diskPath)
+
+	lease, err := readHALease(leasePath, time.Now().Add(haLeaseIOTimeout))
+	if err != nil {
+		return err
+	}
+	if lease != nil && lease.Holder != env512.MachineName {
+		if unchanged := haLeaseUnchangedFor(leasePath, *lease); unchanged < HALeaseTimeout {
+			return fmt.Errorf("vm %s lease is held by %s (unchanged for %s here)", vmName, lease.Holder, unchanged.Round(time.Second))
+		}
+	}
+
+	conn, err := libvirt.NewConnect("qemu:///system")
+	if err != nil {
+		return fmt.Errorf("connect: %w", err)
+	}
+	defer conn.Close()
+
+	dom, err := conn.LookupDomainByName(vmName)
+	if err == nil {
+		if active, _ := dom.IsActive(); active {
+			dom.Free()
+			return nil
+		}
+	} else {
+		xmlPath := filepath.Join(filepath.Dir(diskPath), vmName+".xml")
+		xmlDesc, readErr := os.ReadFile(xmlPath)
+		if readErr != nil {
+			return fmt.Errorf("read domain xml: %w", readErr)
+		}
+		dom, err = conn.DomainDefineXML(string(xmlDesc))
+		if err != nil {
+			return fmt.Errorf("define: %w", err)
+		}
+	}
+	defer dom.Free()
+
+	epoch, _, err := getHAEpoch(dom, libvirt.DOMAIN_AFFECT_CONFIG)
+	if err != nil {
+		return err
+	}
+	if lease != nil && lease.Epoch > epoch {
+		epoch = lease.Epoch
+	}
+	epoch++
+
+	// lease first, the old holder sees the new epoch and kills its copy if it ever comes back
+	if err := writeHALease(leasePath, vmName, epoch, time.Now().Add(haLeaseIOTimeout)); err != nil {
+		return err
+	}
+	forgetHALeaseSighting(leasePath)
+	if err := setHAEpoch(dom, epoch, true); err != nil {
+		return err
+	}
+	if err := refreshDomainXMLOnDisk(dom); err != nil {
+		logger.Error("RecoverVM write domain xml failed", "vm", vmName, "error", err)
+	}
+	if err := dom.Create(); err != nil {
+		return fmt.Errorf("start: %w", err)
+	}
+	logger.Info("vm recovered", "vm", vmName, "epoch", epoch)
+	return nil
+}
+
+// ReleaseVM drops the definition a dead slave still has of a vm that now runs somewhere else,
+// disks are kept. nothing happens while this slave holds the lease
+func ReleaseVM(vmName string) error {
+	conn, err := libvirt.NewConnect("qemu:///system")
+	if err != nil {
+		return fmt.Errorf("connect: %w", err)
+	}
+	defer conn.Close()
+
+	dom, err := conn.LookupDomainByName(vmName)
+	if err != nil {
+		var lvErr libvirt.Error
+		if errors.As(err, &lvErr) && lvErr.Code == libvirt.ERR_NO_DOMAIN {
+			return nil
+		}
+		return fmt.Errorf("lookup: %w", err)
+	}
+	defer dom.Free()
+
+	disk, err := domainPrimaryDisk(dom)
+	if err != nil {
+		return err
+	}
+	lease, err := readHALease(haLeasePath(vmName, disk), time.Now().Add(haLeaseIOTimeout))
+	if err != nil {
+		return err
+	}
+	if lease == nil || lease.Holder == env512.MachineName {
+		return fmt.Errorf("vm %s is not held by another slave", vmName)
+	}
+
+	if active, _ := dom.IsActive(); active {
+		logger.Warn("fencing vm held by another slave", "vm", vmName, "holder", lease.Holder)
+		if err := dom.Destroy(); err != nil {
+			return fmt.Errorf("destroy: %w", err)
+		}
+	}
+	if err := dom.UndefineFlags(libvirt.DOMAIN_UNDEFINE_MANAGED_SAVE | libvirt.DOMAIN_UNDEFINE_SNAPSHOTS_METADATA | libvirt.DOMAIN_UNDEFINE_CHECKPOINTS_METADATA); err != nil {
+		return fmt.Errorf("undefine: %w", err)
+	}
+	return nil
+}
+
+// renewHALease keeps the lease of one running protected vm, or kills the vm when it lost it
+func renewHALease(dom *libvirt.Domain) error {
+	name, err := dom.GetName()
+	if err != nil {
+		return fmt.Errorf("name: %w", err)
+	}
+	epoch, protected, err := getHAEpoch(dom, libvirt.DOMAIN_AFFECT_LIVE)
+	if err != nil || !protected {
+		return err
+	}
+	disk, err := domainPrimaryDisk(dom)
+	if err != nil {
+		return err
+	}
+	leasePath := haLeasePath(name, disk)
+
+	haRenewedMu.Lock()
+	last, seen := haRenewed[name]
+	if !seen {
+		last = time.Now()
+		haRenewed[name] = last
+	}
+	haRenewedMu.Unlock()
+
+	// the master may restart the vm elsewhere once the lease looks dead, stop well before it does
+	// even when the share hangs instead of failing
+	fenceAt := last.Add(HALeaseTimeout / 2)
+
+	lease, err := readHALease(leasePath, fenceAt)
+	if err == nil && lease != nil && lease.Epoch > epoch {
+		logger.Warn("vm was recovered on another slave, fencing local copy", "vm", name, "holder", lease.Holder)
+		return dom.Destroy()
+	}
+
+	if err == nil {
+		err = writeHALease(leasePath, name, epoch, fenceAt)
+	}
+	if err != nil {
+		if !time.Now().Before(fenceAt) {
+			logger.Error("lease lost, fencing vm", "vm", name, "error", err)
+			return dom.Destroy()
+		}
+		return err
+	}
+
+	haRenewedMu.Lock()
+	haRenewed[name] = time.Now()
+	haRenewedMu.Unlock()
+	return nil
+}
+
+func renewHALeases() {
+	conn, err := libvirt.NewConnect("qemu:///system")
+	if err != nil {
+		logger.Error("ha lease connect failed", "error", err)
+		return
+	}
+	defer conn.Close()
+
+	doms, err := conn.ListAllDomains(libvirt.CONNECT_LIST_DOMAINS_ACTIVE)
+	if err != nil {
+		logger.Error("ha lease list domains failed", "error", err)
+		return
+	}
+	// every vm in parallel, one lease stuck on a dead share must not hold back the others
+	running := make(map[string]struct{}, len(doms))
+	var wg sync.WaitGroup
+	for i := range doms {
+		dom := &doms[i]
+		if name, err := dom.GetName(); err == nil {
+			running[name] = struct{}{}
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer dom.Free()
+			if err := renewHALease(dom); err != nil {
+				logger.Error("ha lease renew failed", "error", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	haRenewedMu.Lock()
+	for name := range haRenewed {
+		if _, ok := running[name]; !ok {
+			delete(haRenewed, name)
+		}
+	}
+	haRenewedMu.Unlock()
+}
+
+// StartHALeaseLoop renews the leases of the protected vms running on this slave
+func StartHALeaseLoop() {
+	go func() {
+		for {
+			renewHALeases()
+			time.Sleep(HALeaseInterval)
+		}
+	}()
+}
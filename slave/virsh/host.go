@@ -0,0 +1,58 @@
+package virsh
+
+import (
+	"fmt"
+
+	grpcVirsh "github.com/Maruqes/512SvMan/api/proto/virsh"
+	"github.com/shirou/gopsutil/v4/cpu"
+	"github.com/shirou/gopsutil/v4/mem"
+	libvirt "libvirt.org/go/libvirt"
+)
+
+// GetHostResources reports the memory, cpus and load of this slave and what the running vms take of it
+func GetHostResources() (*grpcVirsh.HostResources, error) {
+	conn, err := libvirt.NewConnect("qemu:///system")
+	if err != nil {
+		return nil, fmt.Errorf("connect: %w", err)
+	}
+	defer conn.Close()
+
+	node, err := conn.GetNodeInfo()
+	if err != nil {
+		return nil, fmt.Errorf("node info: %w", err)
+	}
+	memStats, err := mem.VirtualMemory()
+	if err != nil {
+		return nil, fmt.Errorf("memory stats: %w", err)
+	}
+
+	res := &grpcVirsh.HostResources{
+		MemoryTotalMB: int64(memStats.Total / 1024 / 1024),
+		MemoryFreeMB:  int64(memStats.Available / 1024 / 1024),
+		Cpus:          int32(node.Cpus),
+	}
+
+	doms, err := conn.ListAllDomains(libvirt.CONNECT_LIST_DOMAINS_ACTIVE)
+	if err != nil {
+		return nil, fmt.Errorf("list domains: %w", err)
+	}
+	for _, dom := range doms {
+		if domInfo, err := dom.GetInfo(); err == nil {
+			res.AllocatedVcpus += int32(domInfo.NrVirtCpu)
+			res.AllocatedMemoryMB += int64(domInfo.MaxMem / 1024)
+			res.RunningVms++
+		}
+		dom.Free()
+	}
+
+	// usage since the previous call, the master polls this so it does not block for a sample.
+	// the first call after start is the average since boot
+	usage, err := cpu.Percent(0, false)
+	if err != nil {
+		return nil, fmt.Errorf("cpu usage: %w", err)
+	}
+	if len(usage) > 0 {
+		res.CpuLoad = min(usage[0]/100, 1)
+	}
+	return res, nil
+}
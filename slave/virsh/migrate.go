@@ -0,0 +1,310 @@
+package virsh
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"slave/env512"
+	"slave/extra"
+	"strings"
+	"sync"
+	"time"
+
+	extraGrpc "github.com/Maruqes/512SvMan/api/proto/extra"
+	"github.com/Maruqes/512SvMan/logger"
+	libvirt "libvirt.org/go/libvirt"
+)
+
+const migrationPollInterval = time.Second
+
+const (
+	MigrationRunning   = "running"
+	MigrationCompleted = "completed"
+	MigrationFailed    = "failed"
+	MigrationCancelled = "cancelled"
+)
+
+// MigrationProgress is sent to the master websocket as a MigrationProgress message while a migration runs
+type MigrationProgress struct {
+	VmName        string  `json:"vm_name"`
+	Source        string  `json:"source"`
+	Destination   string  `json:"destination"`
+	Status        string  `json:"status"`
+	Percent       float64 `json:"percent"`
+	DataTotal     uint64  `json:"data_total"` // bytes
+	DataRemaining uint64  `json:"data_remaining"`
+	MemRemaining  uint64  `json:"mem_remaining"`
+	DirtyRate     uint64  `json:"dirty_rate"` // pages/s
+	MemBps        uint64  `json:"mem_bps"`
+	DowntimeMs    uint64  `json:"downtime_ms"` // expected while running, real once completed
+	ElapsedMs     uint64  `json:"elapsed_ms"`
+	Error         string  `json:"error,omitempty"`
+}
+
+type SSHOptions struct {
+	IdentityFile     string
+	SkipHostKeyCheck bool
+}
+
+type MigrateOptions struct {
+	ConnURI string
+	Name    string
+	DestURI string // libvirt uri of the destination slave
+
+	Live          bool
+	BandwidthMiB  uint64 // MiB/s, 0 is unlimited
+	MaxDowntimeMs uint64 // 0 keeps the hypervisor default
+
+	SSH SSHOptions
+}
+
+var (
+	// running migrations of this slave, vm -> destination
+	migrationsMu sync.Mutex
+	migrations   = map[string]string{}
+)
+
+// destURIWithSSH passes the ssh options in the uri, with p2p it is libvirtd and not us that opens the connection
+func destURIWithSSH(destURI string, ssh SSHOptions) (string, error) {
+	u, err := url.Parse(destURI)
+	if err != nil {
+		return "", fmt.Errorf("destination URI: %w", err)
+	}
+	q := u.Query()
+	if key := strings.TrimSpace(ssh.IdentityFile); key != "" {
+		q.Set("keyfile", key)
+	}
+	if ssh.SkipHostKeyCheck {
+		q.Set("no_verify", "1")
+	}
+	u.RawQuery = q.Encode()
+	return u.String(), nil
+}
+
+func publishMigrationProgress(p MigrationProgress) {
+	data, err := json.Marshal(p)
+	if err != nil {
+		logger.Error("migration progress marshal failed", "error", err)
+		return
+	}
+	_ = extra.SendWebsocketMessageType(extraGrpc.WebSocketsMessageType_MigrationProgress, string(data))
+}
+
+func fillMigrationProgress(p *MigrationProgress, stats *libvirt.DomainJobInfo) {
+	p.DataTotal = stats.DataTotal
+	p.DataRemaining = stats.DataRemaining
+	p.MemRemaining = stats.MemRemaining
+	p.DirtyRate = stats.MemDirtyRate
+	p.MemBps = stats.MemBps
+	p.DowntimeMs = stats.Downtime
+	p.ElapsedMs = stats.TimeElapsed
+	if stats.DataTotal > 0 {
+		p.Percent = float64(stats.DataProcessed) / float64(stats.DataTotal) * 100
+	}
+}
+
+// pollMigration publishes the job stats until done is closed, the downtime limit is set once
+// the job exists because qemu only takes it for a running migration
+func pollMigration(dom *libvirt.Domain, progress MigrationProgress, maxDowntimeMs uint64, done <-chan struct{}) {
+	ticker := time.NewTicker(migrationPollInterval)
+	defer ticker.Stop()
+	downtimeSet := maxDowntimeMs == 0
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+		}
+
+		stats, err := dom.GetJobStats(0)
+		if err != nil || stats.Type == libvirt.DOMAIN_JOB_NONE {
+			continue
+		}
+		if !downtimeSet {
+			if err := dom.MigrateSetMaxDowntime(maxDowntimeMs, 0); err != nil {
+				logger.Error("set migration downtime failed", "vm", progress.VmName, "error", err)
+			}
+			downtimeSet = true
+		}
+		fillMigrationProgress(&progress, stats)
+		publishMigrationProgress(progress)
+	}
+}
+
+// MigrateVM moves the vm to opts.DestURI with libvirt peer to peer migration. the progress goes to
+// the master websocket, a shut off vm only has its definition moved
+func MigrateVM(opts MigrateOptions) error {
+	connURI := strings.TrimSpace(opts.ConnURI)
+	if connURI == "" {
+		return fmt.Errorf("conn uri is required")
+	}
+	name := strings.TrimSpace(opts.Name)
+	if name == "" {
+		return fmt.Errorf("domain name is required")
+	}
+	destURI := strings.TrimSpace(opts.DestURI)
+	if destURI == "" {
+		return fmt.Errorf("destination URI is required")
+	}
+	destURI, err := destURIWithSSH(destURI, opts.SSH)
+	if err != nil {
+		return err
+	}
+
+	conn, err := libvirt.NewConnect(connURI)
+	if err != nil {
+		return fmt.Errorf("connect: %w", err)
+	}
+	defer conn.Close()
+
+	dom, err := conn.LookupDomainByName(name)
+	if err != nil {
+		return fmt.Errorf("lookup: %w", err)
+	}
+	defer dom.Free()
+
+	state, _, err := dom.GetState()
+	if err != nil {
+		return fmt.Errorf("state: %w", err)
+	}
+	offline := state == libvirt.DOMAIN_SHUTOFF
+
+	migrationsMu.Lock()
+	if _, running := migrations[name]; running {
+		migrationsMu.Unlock()
+		return fmt.Errorf("vm %s is already being migrated", name)
+	}
+	migrations[name] = destURI
+	migrationsMu.Unlock()
+	defer func() {
+		migrationsMu.Lock()
+		delete(migrations, name)
+		migrationsMu.Unlock()
+	}()
+
+	if err := dropBackupCheckpoints(dom); err != nil {
+		logger.Warn("dropping backup checkpoints before migration failed", "vm", name, "error", err)
+	}
+
+	flags := libvirt.MIGRATE_PERSIST_DEST | libvirt.MIGRATE_UNDEFINE_SOURCE | libvirt.MIGRATE_PEER2PEER
+	// a shut off vm has no memory to stream, only its definition moves
+	if offline {
+		flags |= libvirt.MIGRATE_OFFLINE
+	} else {
+		flags |= libvirt.MIGRATE_TUNNELLED
+		if opts.Live {
+			flags |= libvirt.MIGRATE_LIVE
+		}
+	}
+	params := &libvirt.DomainMigrateParameters{}
+	if opts.BandwidthMiB > 0 {
+		params.BandwidthSet = true
+		params.Bandwidth = opts.BandwidthMiB
+	}
+
+	progress := MigrationProgress{
+		VmName:      name,
+		Source:      env512.MachineName,
+		Destination: opts.DestURI,
+		Status:      MigrationRunning,
+	}
+	done := make(chan struct{})
+	polled := make(chan struct{})
+	if !offline {
+		go func() {
+			defer close(polled)
+			pollMigration(dom, progress, opts.MaxDowntimeMs, done)
+		}()
+	} else {
+		close(polled)
+	}
+
+	start := time.Now()
+	err = dom.MigrateToURI3(destURI, params, flags)
+	close(done)
+	<-polled
+
+	progress.ElapsedMs = uint64(time.Since(start).Milliseconds())
+	if err != nil {
+		progress.Status = MigrationFailed
+		var lvErr libvirt.Error
+		if errors.As(err, &lvErr) && lvErr.Code == libvirt.ERR_OPERATION_ABORTED {
+			progress.Status = MigrationCancelled
+			err = fmt.Errorf("migration of %s cancelled", name)
+		} else {
+			err = fmt.Errorf("migrate: %w", err)
+		}
+		progress.Error = err.Error()
+		publishMigrationProgress(progress)
+		return err
+	}
+
+	// the source domain is gone, the completed job stats are kept by libvirt only on the destination
+	progress.Status = MigrationCompleted
+	progress.Percent = 100
+	progress.DataRemaining = 0
+	progress.MemRemaining = 0
+	publishMigrationProgress(progress)
+	return nil
+}
+
+func runningMigrationDomain(name string) (*libvirt.Connect, *libvirt.Domain, error) {
+	migrationsMu.Lock()
+	_, running := migrations[name]
+	migrationsMu.Unlock()
+	if !running {
+		return nil, nil, fmt.Errorf("vm %s is not being migrated from this slave", name)
+	}
+
+	conn, err := libvirt.NewConnect("qemu:///system")
+	if err != nil {
+		return nil, nil, fmt.Errorf("connect: %w", err)
+	}
+	dom, err := conn.LookupDomainByName(name)
+	if err != nil {
+		conn.Close()
+		return nil, nil, fmt.Errorf("lookup: %w", err)
+	}
+	return conn, dom, nil
+}
+
+// CancelMigration aborts a running migration, the vm keeps running here
+func CancelMigration(name string) error {
+	conn, dom, err := runningMigrationDomain(name)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	defer dom.Free()
+
+	if err := dom.AbortJob(); err != nil {
+		return fmt.Errorf("abort: %w", err)
+	}
+	return nil
+}
+
+// SetMigrationLimits changes the bandwidth (MiB/s) and max downtime (ms) of a running migration, 0 leaves a limit as is
+func SetMigrationLimits(name string, bandwidthMiB, maxDowntimeMs uint64) error {
+	if bandwidthMiB == 0 && maxDowntimeMs == 0 {
+		return fmt.Errorf("no limit given")
+	}
+	conn, dom, err := runningMigrationDomain(name)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	defer dom.Free()
+
+	if bandwidthMiB > 0 {
+		if err := dom.MigrateSetMaxSpeed(bandwidthMiB, 0); err != nil {
+			return fmt.Errorf("set bandwidth: %w", err)
+		}
+	}
+	if maxDowntimeMs > 0 {
+		if err := dom.MigrateSetMaxDowntime(maxDowntimeMs, 0); err != nil {
+			return fmt.Errorf("set downtime: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,304 @@
+package virsh
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net"
+	"strings"
+
+	grpcVirsh "github.com/Maruqes/512SvMan/api/proto/virsh"
+	libvirt "libvirt.org/go/libvirt"
+)
+
+var nicModels = []string{"virtio", "e1000", "e1000e", "rtl8139", "vmxnet3"}
+
+type NicOptions struct {
+	MAC     string
+	Network string
+	Model   string
+}
+
+type domainInterface struct {
+	Type string `xml:"type,attr"`
+	MAC  struct {
+		Address string `xml:"address,attr"`
+	} `xml:"mac"`
+	Source struct {
+		Network string `xml:"network,attr"`
+		Bridge  string `xml:"bridge,attr"`
+	} `xml:"source"`
+	Target struct {
+		Dev string `xml:"dev,attr"`
+	} `xml:"target"`
+	Model struct {
+		Type string `xml:"type,attr"`
+	} `xml:"model"`
+}
+
+func domainInterfaces(xmlDesc string) ([]domainInterface, error) {
+	var def struct {
+		Interfaces []domainInterface `xml:"devices>interface"`
+	}
+	if err := xml.Unmarshal([]byte(xmlDesc), &def); err != nil {
+		return nil, fmt.Errorf("parse interfaces: %w", err)
+	}
+	return def.Interfaces, nil
+}
+
+// normalizeNic fills the defaults and checks the mac and model, macs are compared lower case everywhere
+func normalizeNic(nic NicOptions) (NicOptions, error) {
+	nic.Network = strings.TrimSpace(nic.Network)
+	if nic.Network == "" {
+		return nic, fmt.Errorf("nic network is required")
+	}
+
+	nic.Model = strings.ToLower(strings.TrimSpace(nic.Model))
+	if nic.Model == "" {
+		nic.Model = "virtio"
+	}
+	if !containsString(nicModels, nic.Model) {
+		return nic, fmt.Errorf("invalid nic model %q (%s)", nic.Model, strings.Join(nicModels, ", "))
+	}
+
+	nic.MAC = strings.TrimSpace(nic.MAC)
+	if nic.MAC == "" {
+		mac, err := randomMAC()
+		if err != nil {
+			return nic, err
+		}
+		nic.MAC = mac
+		return nic, nil
+	}
+	hw, err := net.ParseMAC(nic.MAC)
+	if err != nil || len(hw) != 6 {
+		return nic, fmt.Errorf("invalid mac address %q", nic.MAC)
+	}
+	if hw[0]&1 == 1 {
+		return nic, fmt.Errorf("mac address %s is multicast", nic.MAC)
+	}
+	nic.MAC = hw.String()
+	return nic, nil
+}
+
+func nicDeviceXML(nic NicOptions) string {
+	return fmt.Sprintf(`<interface type='network'>
+  <mac address='%s'/>
+  <source network='%s'/>
+  <model type='%s'/>
+</interface>`, nic.MAC, nic.Network, nic.Model)
+}
+
+// nicsDomainXML renders the interfaces of a new vm, the old single network field is used when nics is empty
+func nicsDomainXML(network string, nics []NicOptions, indent string) (string, error) {
+	if len(nics) == 0 {
+		nics = []NicOptions{{Network: network}}
+	}
+
+	var b strings.Builder
+	seen := make(map[string]struct{}, len(nics))
+	for _, nic := range nics {
+		nic, err := normalizeNic(nic)
+		if err != nil {
+			return "", err
+		}
+		if _, ok := seen[nic.MAC]; ok {
+			return "", fmt.Errorf("mac address %s used twice", nic.MAC)
+		}
+		seen[nic.MAC] = struct{}{}
+		for _, line := range strings.Split(nicDeviceXML(nic), "\n") {
+			b.WriteString("\n" + indent + line)
+		}
+	}
+	return b.String(), nil
+}
+
+func nicsFromGRPC(nics []*grpcVirsh.VmNic) []NicOptions {
+	res := make([]NicOptions, 0, len(nics))
+	for _, nic := range nics {
+		if nic == nil {
+			continue
+		}
+		res = append(res, NicOptions{MAC: nic.Mac, Network: nic.Network, Model: nic.Model})
+	}
+	return res
+}
+
+// interfaceAddresses maps mac to the addresses of a running vm, what the guest agent reports first,
+// then dhcp leases and the host arp table for the nics it did not report
+func interfaceAddresses(dom *libvirt.Domain) (map[string][]string, error) {
+	res := make(map[string][]string)
+	add := func(ifaces []libvirt.DomainInterface, onlyMissing bool) {
+		for _, iface := range ifaces {
+			mac := strings.ToLower(iface.Hwaddr)
+			if onlyMissing && len(res[mac]) > 0 {
+				continue
+			}
+			for _, addr := range iface.Addrs {
+				// the agent also lists loopback and link local addresses, nobody reaches the vm on those
+				if ip := net.ParseIP(addr.Addr); ip == nil || ip.IsLoopback() || ip.IsLinkLocalUnicast() {
+					continue
+				}
+				if !containsString(res[mac], addr.Addr) {
+					res[mac] = append(res[mac], addr.Addr)
+				}
+			}
+		}
+	}
+
+	// the agent knows static addresses too, it fails fast when the guest has none running
+	if agent, err := dom.ListAllInterfaceAddresses(libvirt.DOMAIN_INTERFACE_ADDRESSES_SRC_AGENT); err == nil {
+		add(agent, false)
+	}
+
+	ifAddrs, err := dom.ListAllInterfaceAddresses(libvirt.DOMAIN_INTERFACE_ADDRESSES_SRC_LEASE)
+	if err != nil {
+		if len(res) > 0 {
+			return res, nil
+		}
+		return nil, err
+	}
+	add(ifAddrs, true)
+
+	if arp, err := dom.ListAllInterfaceAddresses(libvirt.DOMAIN_INTERFACE_ADDRESSES_SRC_ARP); err == nil {
+		add(arp, true)
+	}
+	return res, nil
+}
+
+// listVmNics returns the nics in the domain order and every address of them, in that same order
+func listVmNics(dom *libvirt.Domain, xmlDesc string, running bool) ([]*grpcVirsh.VmNic, []string, error) {
+	ifaces, err := domainInterfaces(xmlDesc)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// addresses are best effort, a vm without leases yet is still listed
+	addrs := map[string][]string{}
+	if running {
+		if found, err := interfaceAddresses(dom); err == nil {
+			addrs = found
+		}
+	}
+
+	nics := make([]*grpcVirsh.VmNic, 0, len(ifaces))
+	ips := []string{}
+	for _, iface := range ifaces {
+		mac := strings.ToLower(iface.MAC.Address)
+		network := iface.Source.Network
+		if network == "" {
+			network = iface.Source.Bridge
+		}
+		nic := &grpcVirsh.VmNic{
+			Mac:     mac,
+			Network: network,
+			Model:   iface.Model.Type,
+			Ip:      addrs[mac],
+		}
+		if running {
+			nic.Device = iface.Target.Dev
+		}
+		nics = append(nics, nic)
+		ips = append(ips, addrs[mac]...)
+	}
+	return nics, ips, nil
+}
+
+// AttachNic adds a nic to the vm, live too when it is running
+func AttachNic(vmName string, nic NicOptions) (*grpcVirsh.VmNic, error) {
+	nic, err := normalizeNic(nic)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := libvirt.NewConnect("qemu:///system")
+	if err != nil {
+		return nil, fmt.Errorf("connect: %w", err)
+	}
+	defer conn.Close()
+
+	netw, err := conn.LookupNetworkByName(nic.Network)
+	if err != nil {
+		return nil, fmt.Errorf("network %s is not defined on this slave", nic.Network)
+	}
+	netw.Free()
+
+	dom, err := conn.LookupDomainByName(vmName)
+	if err != nil {
+		return nil, fmt.Errorf("lookup: %w", err)
+	}
+	defer dom.Free()
+
+	xmlDesc, err := dom.GetXMLDesc(libvirt.DOMAIN_XML_INACTIVE)
+	if err != nil {
+		return nil, fmt.Errorf("xml: %w", err)
+	}
+	ifaces, err := domainInterfaces(xmlDesc)
+	if err != nil {
+		return nil, err
+	}
+	for _, iface := range ifaces {
+		if strings.EqualFold(iface.MAC.Address, nic.MAC) {
+			return nil, fmt.Errorf("vm %s already has a nic with mac %s", vmName, nic.MAC)
+		}
+	}
+
+	if err := dom.AttachDeviceFlags(nicDeviceXML(nic), deviceModifyFlags(dom)); err != nil {
+		return nil, fmt.Errorf("attach nic: %w", err)
+	}
+	if err := refreshDomainXMLOnDisk(dom); err != nil {
+		return nil, fmt.Errorf("write domain xml: %w", err)
+	}
+
+	return &grpcVirsh.VmNic{Mac: nic.MAC, Network: nic.Network, Model: nic.Model}, nil
+}
+
+// DetachNic removes the nic with that mac, live too when the vm is running
+func DetachNic(vmName, mac string) error {
+	hw, err := net.ParseMAC(strings.TrimSpace(mac))
+	if err != nil {
+		return fmt.Errorf("invalid mac address %q", mac)
+	}
+	mac = hw.String()
+
+	conn, err := libvirt.NewConnect("qemu:///system")
+	if err != nil {
+		return fmt.Errorf("connect: %w", err)
+	}
+	defer conn.Close()
+
+	dom, err := conn.LookupDomainByName(vmName)
+	if err != nil {
+		return fmt.Errorf("lookup: %w", err)
+	}
+	defer dom.Free()
+
+	xmlDesc, err := dom.GetXMLDesc(libvirt.DOMAIN_XML_INACTIVE)
+	if err != nil {
+		return fmt.Errorf("xml: %w", err)
+	}
+	ifaces, err := domainInterfaces(xmlDesc)
+	if err != nil {
+		return err
+	}
+
+	var found *domainInterface
+	for i := range ifaces {
+		if strings.EqualFold(ifaces[i].MAC.Address, mac) {
+			found = &ifaces[i]
+			break
+		}
+	}
+	if found == nil {
+		return fmt.Errorf("vm %s has no nic with mac %s", vmName, mac)
+	}
+
+	// libvirt matches the interface by mac, type has to agree with the defined one
+	device := fmt.Sprintf("<interface type='%s'>\n  <mac address='%s'/>\n</interface>", found.Type, mac)
+	if err := dom.DetachDeviceFlags(device, deviceModifyFlags(dom)); err != nil {
+		return fmt.Errorf("detach nic: %w", err)
+	}
+	if err := refreshDomainXMLOnDisk(dom); err != nil {
+		return fmt.Errorf("write domain xml: %w", err)
+	}
+	return nil
+}
@@ -23,12 +23,20 @@ type VMCreationParams struct {
 	ISOPath        string // caminho do arquivo ISO (opcional)
 	Machine        string // tipo de máquina (opcional)
 	Network        string // nome da rede libvirt
+	Nics           []NicOptions
 	GraphicsListen string // endereço para o VNC escutar
 	VNCPassword    string // senha para o VNC (opcional)
+	TemplatePath   string // golden qcow2 usado como backing file em vez do ISO (opcional)
+	CloudInit      CloudInitOptions
 }
 
 // sem migracao
 func CreateVMHostPassthrough(params VMCreationParams) (string, error) {
+	// placas de rede, se Nics vazio fica uma virtio na Network
+	nicsXML, err := nicsDomainXML(params.Network, params.Nics, "    ")
+	if err != nil {
+		return "", err
+	}
 
 	//make sure DiskFolder exists
 	if params.DiskFolder != "" {
@@ -60,19 +68,30 @@ func CreateVMHostPassthrough(params VMCreationParams) (string, error) {
 		return "", fmt.Errorf("disk directory: %w", err)
 	}
 
-	// Create/inspect disk and get its format (qcow2/raw/…)
-	if _, err := EnsureDiskAndDetectFormat(disk, params.DiskSizeGB); err != nil {
-		return "", fmt.Errorf("disk: %w", err)
-	}
-
 	// ISO is optional: only include CDROM if the file exists
 	hasISO := false
+	bootISO := false
 	isoPath := strings.TrimSpace(params.ISOPath)
-	if isoPath != "" {
-		if err := ensureFileExists(isoPath); err != nil {
-			return "", fmt.Errorf("iso path: %w", err)
+	if strings.TrimSpace(params.TemplatePath) != "" {
+		// template vms boot the overlay, the cdrom only carries the cloud-init seed
+		seed, err := prepareTemplateDisk(params.TemplatePath, disk, params.Name, params.DiskSizeGB, params.CloudInit)
+		if err != nil {
+			return "", fmt.Errorf("disk: %w", err)
 		}
+		isoPath = seed
 		hasISO = true
+	} else {
+		// Create/inspect disk and get its format (qcow2/raw/…)
+		if _, err := EnsureDiskAndDetectFormat(disk, params.DiskSizeGB); err != nil {
+			return "", fmt.Errorf("disk: %w", err)
+		}
+		if isoPath != "" {
+			if err := ensureFileExists(isoPath); err != nil {
+				return "", fmt.Errorf("iso path: %w", err)
+			}
+			hasISO = true
+			bootISO = true
+		}
 	}
 
 	connURI := params.ConnURI
@@ -113,7 +132,7 @@ func CreateVMHostPassthrough(params VMCreationParams) (string, error) {
 	}
 
 	bootDev := "hd"
-	if hasISO {
+	if bootISO {
 		bootDev = "cdrom"
 	}
 
@@ -146,20 +165,19 @@ func CreateVMHostPassthrough(params VMCreationParams) (string, error) {
       <driver name='qemu' type='qcow2' cache='none' io='native'/>
       <source file='%s'/>
       <target dev='vda' bus='virtio'/>
-    </disk>%s
-    <interface type='network'>
-      <source network='%s'/>
-      <model type='virtio'/>
-    </interface>
+    </disk>%s%s
     <graphics type='vnc' autoport='yes' port='-1'%s/>
     <video><model type='virtio'/></video>
+    %s
+    %s
   </devices>
 </domain>`,
 		params.Name, params.MemoryMB, params.VCPUs,
 		cputuneXML, // <- new
 		machineAttr,
 		bootDev,
-		disk, cdromXML, params.Network, graphicsAttrs,
+		disk, cdromXML, nicsXML, graphicsAttrs,
+		serialConsoleXML, guestAgentChannelXML,
 	)
 
 	xmlPath, err := WriteDomainXMLToDisk(params.Name, domainXML, disk)
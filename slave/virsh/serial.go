@@ -0,0 +1,139 @@
+package virsh
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	grpcVirsh "github.com/Maruqes/512SvMan/api/proto/virsh"
+	libvirt "libvirt.org/go/libvirt"
+)
+
+// ttyS0 of the guest, the console element makes it the one OpenConsole attaches to
+const serialConsoleXML = `<serial type='pty'><target port='0'/></serial><console type='pty'><target type='serial' port='0'/></console>`
+
+type consoleStream interface {
+	Send(*grpcVirsh.ConsoleData) error
+	Recv() (*grpcVirsh.ConsoleData, error)
+}
+
+// SerialConsole pipes the domain console to the grpc stream until either side closes, a new session
+// takes the console over from an old one that was left open
+func SerialConsole(vmName string, stream consoleStream) error {
+	vmName = strings.TrimSpace(vmName)
+	if vmName == "" {
+		return fmt.Errorf("vm name is empty")
+	}
+
+	conn, err := libvirt.NewConnect("qemu:///system")
+	if err != nil {
+		return fmt.Errorf("connect: %w", err)
+	}
+	defer conn.Close()
+
+	dom, err := conn.LookupDomainByName(vmName)
+	if err != nil {
+		return fmt.Errorf("lookup: %w", err)
+	}
+	defer dom.Free()
+
+	if state, _, err := dom.GetState(); err != nil {
+		return fmt.Errorf("state: %w", err)
+	} else if state != libvirt.DOMAIN_RUNNING && state != libvirt.DOMAIN_PAUSED {
+		return fmt.Errorf("vm %s is not running", vmName)
+	}
+
+	console, err := conn.NewStream(0)
+	if err != nil {
+		return fmt.Errorf("new stream: %w", err)
+	}
+	defer console.Free()
+
+	if err := dom.OpenConsole("", console, libvirt.DOMAIN_CONSOLE_FORCE); err != nil {
+		return fmt.Errorf("open console (does the vm have a serial device?): %w", err)
+	}
+
+	// every goroutine reports once, none of them may block on errc after we stopped reading it
+	errc := make(chan error, 3)
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		buf := make([]byte, 4096)
+		for {
+			n, err := console.Recv(buf)
+			if err != nil {
+				if errors.Is(err, io.EOF) {
+					err = nil
+				}
+				errc <- err
+				return
+			}
+			if n <= 0 {
+				continue
+			}
+			data := make([]byte, n)
+			copy(data, buf[:n])
+			if err := stream.Send(&grpcVirsh.ConsoleData{Data: data}); err != nil {
+				errc <- err
+				return
+			}
+		}
+	}()
+
+	// stream.Recv cannot be interrupted from here, it returns once the handler does. this pump only
+	// hands messages over and never touches the console, so it is the one goroutine not waited for
+	incoming := make(chan *grpcVirsh.ConsoleData)
+	go func() {
+		for {
+			msg, err := stream.Recv()
+			if err != nil {
+				if errors.Is(err, io.EOF) {
+					err = nil
+				}
+				errc <- err
+				return
+			}
+			select {
+			case incoming <- msg:
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			var msg *grpcVirsh.ConsoleData
+			select {
+			case msg = <-incoming:
+			case <-done:
+				return
+			}
+			for data := msg.Data; len(data) > 0; {
+				n, err := console.Send(data)
+				if err != nil {
+					errc <- fmt.Errorf("console send: %w", err)
+					return
+				}
+				if n > 0 {
+					data = data[n:]
+				}
+			}
+		}
+	}()
+
+	err = <-errc
+	close(done)
+	// unblocks console.Recv and console.Send, the stream is useless once one side is gone
+	_ = console.Abort()
+	// nothing may use the console or stream.Send after we return and the console is freed
+	wg.Wait()
+	return err
+}
@@ -38,8 +38,11 @@ func (s *SlaveVirshService) CreateVm(ctx context.Context, req *grpcVirsh.CreateV
 		DiskSizeGB:     int(req.DiskSizeGB),
 		ISOPath:        req.IsoPath,
 		Network:        req.Network,
+		Nics:           nicsFromGRPC(req.Nics),
 		GraphicsListen: "0.0.0.0",
 		VNCPassword:    req.VncPassword,
+		TemplatePath:   req.TemplatePath,
+		CloudInit:      cloudInitFromGRPC(req.CloudInit),
 	}
 	_, err := CreateVMHostPassthrough(params)
 	if err != nil {
@@ -59,9 +62,12 @@ func (s *SlaveVirshService) CreateLiveVM(ctx context.Context, req *grpcVirsh.Cre
 		DiskSizeGB:     int(req.Vm.DiskSizeGB),
 		ISOPath:        req.Vm.IsoPath,
 		Network:        req.Vm.Network,
+		Nics:           nicsFromGRPC(req.Vm.Nics),
 		GraphicsListen: "0.0.0.0",
 		VNCPassword:    req.Vm.VncPassword,
 		CPUXml:         req.CpuXml,
+		TemplatePath:   req.Vm.TemplatePath,
+		CloudInit:      cloudInitFromGRPC(req.Vm.CloudInit),
 	}
 	_, err := CreateVMCustomCPU(params)
 	if err != nil {
@@ -70,16 +76,33 @@ func (s *SlaveVirshService) CreateLiveVM(ctx context.Context, req *grpcVirsh.Cre
 	return &grpcVirsh.OkResponse{Ok: true}, nil
 }
 
+func (s *SlaveVirshService) CloneVM(ctx context.Context, req *grpcVirsh.CloneVmRequest) (*grpcVirsh.OkResponse, error) {
+	opts := CloneVMOptions{
+		SourceName: req.SourceName,
+		Name:       req.Name,
+		DiskFolder: req.DiskFolder,
+		DiskPath:   req.DiskPath,
+		Linked:     req.Linked,
+		Start:      req.Start,
+	}
+	if err := CloneVM(opts); err != nil {
+		return nil, err
+	}
+	return &grpcVirsh.OkResponse{Ok: true}, nil
+}
+
 func (s *SlaveVirshService) MigrateVM(ctx context.Context, e *grpcVirsh.MigrateVmRequest) (*grpcVirsh.OkResponse, error) {
 	opts := MigrateOptions{
 		ConnURI: "qemu:///system",
 		Name:    e.Name,
 		DestURI: "qemu+ssh://root@" + e.SlaveIp + ":22/system",
 		Live:    e.Live,
+
+		BandwidthMiB:  e.BandwidthMiB,
+		MaxDowntimeMs: e.MaxDowntimeMs,
 		SSH: SSHOptions{
-			IdentityFile:       "/root/.ssh/id_rsa_512svman",
-			SkipHostKeyCheck:   true,
-			UserKnownHostsFile: "/dev/null",
+			IdentityFile:     "/root/.ssh/id_rsa_512svman",
+			SkipHostKeyCheck: true,
 		},
 	}
 	err := MigrateVM(opts)
@@ -126,6 +149,24 @@ func (s *SlaveVirshService) RemoveVM(ctx context.Context, req *grpcVirsh.Vm) (*g
 	return &grpcVirsh.OkResponse{Ok: true}, nil
 }
 
+func (s *SlaveVirshService) TrashVM(ctx context.Context, req *grpcVirsh.TrashVmRequest) (*grpcVirsh.TrashedVm, error) {
+	return TrashVM(req.Name, req.TrashName)
+}
+
+func (s *SlaveVirshService) RestoreTrashedVM(ctx context.Context, req *grpcVirsh.TrashedVm) (*grpcVirsh.OkResponse, error) {
+	if err := RestoreTrashedVM(req); err != nil {
+		return nil, err
+	}
+	return &grpcVirsh.OkResponse{Ok: true}, nil
+}
+
+func (s *SlaveVirshService) PurgeTrashedVM(ctx context.Context, req *grpcVirsh.TrashedVm) (*grpcVirsh.OkResponse, error) {
+	if err := PurgeTrashedVM(req); err != nil {
+		return nil, err
+	}
+	return &grpcVirsh.OkResponse{Ok: true}, nil
+}
+
 func (s *SlaveVirshService) ForceShutdownVM(ctx context.Context, req *grpcVirsh.Vm) (*grpcVirsh.OkResponse, error) {
 	if err := ForceShutdownVM(req.Name); err != nil {
 		return nil, err
@@ -167,3 +208,196 @@ func (s *SlaveVirshService) ResumeVM(ctx context.Context, req *grpcVirsh.Vm) (*g
 	}
 	return &grpcVirsh.OkResponse{Ok: true}, nil
 }
+
+func (s *SlaveVirshService) CreateSnapshot(ctx context.Context, req *grpcVirsh.CreateSnapshotRequest) (*grpcVirsh.Snapshot, error) {
+	snap, err := CreateSnapshot(req.VmName, req.Name, req.Description, req.External)
+	if err != nil {
+		return nil, err
+	}
+	return snap, nil
+}
+
+func (s *SlaveVirshService) ListSnapshots(ctx context.Context, req *grpcVirsh.GetVmByNameRequest) (*grpcVirsh.ListSnapshotsResponse, error) {
+	snaps, err := ListSnapshots(req.Name)
+	if err != nil {
+		return nil, err
+	}
+	return &grpcVirsh.ListSnapshotsResponse{Snapshots: snaps}, nil
+}
+
+func (s *SlaveVirshService) RevertSnapshot(ctx context.Context, req *grpcVirsh.SnapshotRequest) (*grpcVirsh.OkResponse, error) {
+	if err := RevertSnapshot(req.VmName, req.Name); err != nil {
+		return nil, err
+	}
+	return &grpcVirsh.OkResponse{Ok: true}, nil
+}
+
+func (s *SlaveVirshService) DeleteSnapshot(ctx context.Context, req *grpcVirsh.SnapshotRequest) (*grpcVirsh.OkResponse, error) {
+	if err := DeleteSnapshot(req.VmName, req.Name, req.MetadataOnly); err != nil {
+		return nil, err
+	}
+	return &grpcVirsh.OkResponse{Ok: true}, nil
+}
+
+func (s *SlaveVirshService) DefineSnapshot(ctx context.Context, req *grpcVirsh.DefineSnapshotRequest) (*grpcVirsh.OkResponse, error) {
+	if err := DefineSnapshot(req.VmName, req.Xml, req.Current); err != nil {
+		return nil, err
+	}
+	return &grpcVirsh.OkResponse{Ok: true}, nil
+}
+
+func (s *SlaveVirshService) AddDisk(ctx context.Context, req *grpcVirsh.VmDiskRequest) (*grpcVirsh.VmDisk, error) {
+	return AddDisk(req.VmName, req.DiskPath, req.DiskFolder, req.Target, int(req.SizeGB))
+}
+
+func (s *SlaveVirshService) AttachDisk(ctx context.Context, req *grpcVirsh.VmDiskRequest) (*grpcVirsh.VmDisk, error) {
+	return AttachDisk(req.VmName, req.DiskPath, req.Target)
+}
+
+func (s *SlaveVirshService) DetachDisk(ctx context.Context, req *grpcVirsh.VmDiskRequest) (*grpcVirsh.OkResponse, error) {
+	if err := DetachDisk(req.VmName, req.Target, req.DiskPath, req.DeleteFile); err != nil {
+		return nil, err
+	}
+	return &grpcVirsh.OkResponse{Ok: true}, nil
+}
+
+func (s *SlaveVirshService) ResizeDisk(ctx context.Context, req *grpcVirsh.VmDiskRequest) (*grpcVirsh.VmDisk, error) {
+	return ResizeDisk(req.VmName, req.Target, int(req.SizeGB))
+}
+
+// a failure after some disk moved is not a grpc error, the master has to see what moved
+func (s *SlaveVirshService) MoveVmStorage(ctx context.Context, req *grpcVirsh.VmDiskRequest) (*grpcVirsh.MoveVmStorageResponse, error) {
+	res, err := MoveVmStorage(req.VmName, req.DiskFolder)
+	if res == nil {
+		return nil, err
+	}
+	if err != nil {
+		res.Error = err.Error()
+	}
+	return res, nil
+}
+
+func (s *SlaveVirshService) BackupVM(ctx context.Context, req *grpcVirsh.BackupVmRequest) (*grpcVirsh.BackupVmResponse, error) {
+	return BackupVM(req)
+}
+
+func (s *SlaveVirshService) RestoreBackup(ctx context.Context, req *grpcVirsh.RestoreBackupRequest) (*grpcVirsh.OkResponse, error) {
+	if err := RestoreBackup(req); err != nil {
+		return nil, err
+	}
+	return &grpcVirsh.OkResponse{Ok: true}, nil
+}
+
+func (s *SlaveVirshService) DeleteBackup(ctx context.Context, req *grpcVirsh.DeleteBackupRequest) (*grpcVirsh.OkResponse, error) {
+	if err := DeleteBackup(req); err != nil {
+		return nil, err
+	}
+	return &grpcVirsh.OkResponse{Ok: true}, nil
+}
+
+func (s *SlaveVirshService) AttachNic(ctx context.Context, req *grpcVirsh.VmNicRequest) (*grpcVirsh.VmNic, error) {
+	if req.Nic == nil {
+		return nil, fmt.Errorf("nic is required")
+	}
+	return AttachNic(req.VmName, NicOptions{MAC: req.Nic.Mac, Network: req.Nic.Network, Model: req.Nic.Model})
+}
+
+func (s *SlaveVirshService) DetachNic(ctx context.Context, req *grpcVirsh.VmNicRequest) (*grpcVirsh.OkResponse, error) {
+	if req.Nic == nil {
+		return nil, fmt.Errorf("nic is required")
+	}
+	if err := DetachNic(req.VmName, req.Nic.Mac); err != nil {
+		return nil, err
+	}
+	return &grpcVirsh.OkResponse{Ok: true}, nil
+}
+
+func (s *SlaveVirshService) GetGuestInfo(ctx context.Context, req *grpcVirsh.GetVmByNameRequest) (*grpcVirsh.GuestInfo, error) {
+	return GetGuestInfo(req.Name)
+}
+
+func (s *SlaveVirshService) GuestExec(ctx context.Context, req *grpcVirsh.GuestExecRequest) (*grpcVirsh.GuestExecResponse, error) {
+	return GuestExec(req)
+}
+
+func (s *SlaveVirshService) EnableGuestAgent(ctx context.Context, req *grpcVirsh.GetVmByNameRequest) (*grpcVirsh.OkResponse, error) {
+	if err := EnableGuestAgent(req.Name); err != nil {
+		return nil, err
+	}
+	return &grpcVirsh.OkResponse{Ok: true}, nil
+}
+
+func (s *SlaveVirshService) SerialConsole(stream grpcVirsh.SlaveVirshService_SerialConsoleServer) error {
+	first, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	return SerialConsole(first.VmName, stream)
+}
+
+func (s *SlaveVirshService) SetVmHA(ctx context.Context, req *grpcVirsh.HaVmRequest) (*grpcVirsh.OkResponse, error) {
+	if err := SetVmHA(req.VmName, req.Enabled); err != nil {
+		return nil, err
+	}
+	return &grpcVirsh.OkResponse{Ok: true}, nil
+}
+
+func (s *SlaveVirshService) RecoverVM(ctx context.Context, req *grpcVirsh.HaVmRequest) (*grpcVirsh.OkResponse, error) {
+	if err := RecoverVM(req.VmName, req.DiskPath); err != nil {
+		return nil, err
+	}
+	return &grpcVirsh.OkResponse{Ok: true}, nil
+}
+
+func (s *SlaveVirshService) ReleaseVM(ctx context.Context, req *grpcVirsh.HaVmRequest) (*grpcVirsh.OkResponse, error) {
+	if err := ReleaseVM(req.VmName); err != nil {
+		return nil, err
+	}
+	return &grpcVirsh.OkResponse{Ok: true}, nil
+}
+
+func (s *SlaveVirshService) GetHostResources(ctx context.Context, req *grpcVirsh.Empty) (*grpcVirsh.HostResources, error) {
+	return GetHostResources()
+}
+
+func (s *SlaveVirshService) GetBackingFileUsers(ctx context.Context, req *grpcVirsh.BackingFileRequest) (*grpcVirsh.BackingFileUsers, error) {
+	users, err := BackingFileUsers(req.Path)
+	if err != nil {
+		return nil, err
+	}
+	files, err := TrashedBackingFileUsers(req.Path, req.Files)
+	if err != nil {
+		return nil, err
+	}
+	return &grpcVirsh.BackingFileUsers{VmNames: users, Files: files}, nil
+}
+
+func (s *SlaveVirshService) GetVmCPUXML(ctx context.Context, req *grpcVirsh.GetVmByNameRequest) (*grpcVirsh.CPUXMLResponse, error) {
+	cpuXML, err := GetVmCPUXML(req.Name)
+	if err != nil {
+		return nil, err
+	}
+	return &grpcVirsh.CPUXMLResponse{CpuXML: cpuXML}, nil
+}
+
+func (s *SlaveVirshService) CompareCPU(ctx context.Context, req *grpcVirsh.CPUXMLResponse) (*grpcVirsh.OkResponse, error) {
+	ok, reason, err := CompareCPU(req.CpuXML)
+	if err != nil {
+		return nil, err
+	}
+	return &grpcVirsh.OkResponse{Ok: ok, Message: reason}, nil
+}
+
+func (s *SlaveVirshService) CancelMigration(ctx context.Context, req *grpcVirsh.GetVmByNameRequest) (*grpcVirsh.OkResponse, error) {
+	if err := CancelMigration(req.Name); err != nil {
+		return nil, err
+	}
+	return &grpcVirsh.OkResponse{Ok: true}, nil
+}
+
+func (s *SlaveVirshService) SetMigrationLimits(ctx context.Context, req *grpcVirsh.MigrateVmRequest) (*grpcVirsh.OkResponse, error) {
+	if err := SetMigrationLimits(req.Name, req.BandwidthMiB, req.MaxDowntimeMs); err != nil {
+		return nil, err
+	}
+	return &grpcVirsh.OkResponse{Ok: true}, nil
+}
@@ -0,0 +1,422 @@
+package virsh
+
+import (
+	"encoding/xml"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	grpcVirsh "github.com/Maruqes/512SvMan/api/proto/virsh"
+	libvirt "libvirt.org/go/libvirt"
+)
+
+var snapshotNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]*$`)
+
+type snapshotDiskXML struct {
+	Name     string `xml:"name,attr"`
+	Snapshot string `xml:"snapshot,attr"`
+	Driver   *struct {
+		Type string `xml:"type,attr"`
+	} `xml:"driver,omitempty"`
+	Source *struct {
+		File string `xml:"file,attr"`
+	} `xml:"source,omitempty"`
+}
+
+type domainSnapshotXML struct {
+	XMLName      xml.Name `xml:"domainsnapshot"`
+	Name         string   `xml:"name"`
+	Description  string   `xml:"description,omitempty"`
+	State        string   `xml:"state,omitempty"`
+	CreationTime int64    `xml:"creationTime,omitempty"`
+	Parent       *struct {
+		Name string `xml:"name"`
+	} `xml:"parent,omitempty"`
+	Memory *struct {
+		Snapshot string `xml:"snapshot,attr"`
+	} `xml:"memory,omitempty"`
+	Disks *struct {
+		Disks []snapshotDiskXML `xml:"disk"`
+	} `xml:"disks,omitempty"`
+}
+
+type domainDiskTarget struct {
+	Device string
+	Target string
+	Source string
+	Bus    string
+}
+
+func domainDiskTargets(xmlData string) ([]domainDiskTarget, error) {
+	type domain struct {
+		Devices struct {
+			Disks []struct {
+				Device string `xml:"device,attr"`
+				Source struct {
+					File string `xml:"file,attr"`
+				} `xml:"source"`
+				Target struct {
+					Dev string `xml:"dev,attr"`
+					Bus string `xml:"bus,attr"`
+				} `xml:"target"`
+			} `xml:"disk"`
+		} `xml:"devices"`
+	}
+
+	var d domain
+	if err := xml.Unmarshal([]byte(xmlData), &d); err != nil {
+		return nil, fmt.Errorf("parse domain xml: %w", err)
+	}
+
+	var targets []domainDiskTarget
+	for _, disk := range d.Devices.Disks {
+		device := strings.TrimSpace(disk.Device)
+		if device == "" {
+			device = "disk"
+		}
+		targets = append(targets, domainDiskTarget{
+			Device: device,
+			Target: strings.TrimSpace(disk.Target.Dev),
+			Source: strings.TrimSpace(disk.Source.File),
+			Bus:    strings.TrimSpace(disk.Target.Bus),
+		})
+	}
+	return targets, nil
+}
+
+// overlay files live next to the disk they cover: /mnt/.../vm/vm.qcow2 -> /mnt/.../vm/vm.<snapshot>.qcow2
+func externalOverlayPath(diskPath, snapName string) string {
+	dir := filepath.Dir(diskPath)
+	base := strings.TrimSuffix(filepath.Base(diskPath), filepath.Ext(diskPath))
+	return filepath.Join(dir, fmt.Sprintf("%s.%s.qcow2", base, snapName))
+}
+
+func buildSnapshotXML(name, description string, external bool, disks []domainDiskTarget) (string, error) {
+	snap := domainSnapshotXML{
+		Name:        name,
+		Description: description,
+		Disks: &struct {
+			Disks []snapshotDiskXML `xml:"disk"`
+		}{},
+	}
+
+	for _, disk := range disks {
+		if disk.Target == "" {
+			continue
+		}
+		entry := snapshotDiskXML{Name: disk.Target, Snapshot: "no"}
+		if disk.Device == "disk" {
+			entry.Snapshot = "internal"
+			if external {
+				if disk.Source == "" {
+					return "", fmt.Errorf("disk %s has no file source for an external snapshot", disk.Target)
+				}
+				entry.Snapshot = "external"
+				entry.Driver = &struct {
+					Type string `xml:"type,attr"`
+				}{Type: "qcow2"}
+				entry.Source = &struct {
+					File string `xml:"file,attr"`
+				}{File: externalOverlayPath(disk.Source, name)}
+			}
+		}
+		snap.Disks.Disks = append(snap.Disks.Disks, entry)
+	}
+
+	out, err := xml.MarshalIndent(snap, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("marshal snapshot xml: %w", err)
+	}
+	return string(out), nil
+}
+
+func snapshotToGRPC(vmName string, snap *libvirt.DomainSnapshot) (*grpcVirsh.Snapshot, error) {
+	xmlDesc, err := snap.GetXMLDesc(libvirt.DOMAIN_SNAPSHOT_XML_SECURE)
+	if err != nil {
+		return nil, fmt.Errorf("snapshot xml: %w", err)
+	}
+
+	var parsed domainSnapshotXML
+	if err := xml.Unmarshal([]byte(xmlDesc), &parsed); err != nil {
+		return nil, fmt.Errorf("parse snapshot xml: %w", err)
+	}
+
+	external := false
+	if parsed.Memory != nil && parsed.Memory.Snapshot == "external" {
+		external = true
+	}
+	if parsed.Disks != nil {
+		for _, disk := range parsed.Disks.Disks {
+			if disk.Snapshot == "external" {
+				external = true
+				break
+			}
+		}
+	}
+
+	current, err := snap.IsCurrent(0)
+	if err != nil {
+		return nil, fmt.Errorf("snapshot is current: %w", err)
+	}
+
+	res := &grpcVirsh.Snapshot{
+		VmName:      vmName,
+		Name:        parsed.Name,
+		Description: parsed.Description,
+		External:    external,
+		State:       parsed.State,
+		CreatedAt:   parsed.CreationTime,
+		Current:     current,
+		Xml:         xmlDesc,
+	}
+	if parsed.Parent != nil {
+		res.Parent = parsed.Parent.Name
+	}
+	return res, nil
+}
+
+// refreshDomainXMLOnDisk rewrites the xml saved next to the disk, snapshots can swap the disk source to an overlay
+func refreshDomainXMLOnDisk(dom *libvirt.Domain) error {
+	name, err := dom.GetName()
+	if err != nil {
+		return fmt.Errorf("name: %w", err)
+	}
+	xmlDesc, err := dom.GetXMLDesc(libvirt.DOMAIN_XML_INACTIVE)
+	if err != nil {
+		return fmt.Errorf("xml: %w", err)
+	}
+	diskPath, err := diskPathFromDomainXML(xmlDesc)
+	if err != nil {
+		return fmt.Errorf("detect disk path: %w", err)
+	}
+	if diskPath == "" {
+		return nil
+	}
+	_, err = WriteDomainXMLToDisk(name, xmlDesc, diskPath)
+	return err
+}
+
+func CreateSnapshot(vmName, snapName, description string, external bool) (*grpcVirsh.Snapshot, error) {
+	vmName = strings.TrimSpace(vmName)
+	snapName = strings.TrimSpace(snapName)
+	if vmName == "" {
+		return nil, fmt.Errorf("vm name is empty")
+	}
+	if !snapshotNamePattern.MatchString(snapName) {
+		return nil, fmt.Errorf("invalid snapshot name %q", snapName)
+	}
+
+	conn, err := libvirt.NewConnect("qemu:///system")
+	if err != nil {
+		return nil, fmt.Errorf("connect: %w", err)
+	}
+	defer conn.Close()
+
+	dom, err := conn.LookupDomainByName(vmName)
+	if err != nil {
+		return nil, fmt.Errorf("lookup: %w", err)
+	}
+	defer dom.Free()
+
+	if existing, err := dom.SnapshotLookupByName(snapName, 0); err == nil {
+		existing.Free()
+		return nil, fmt.Errorf("snapshot %s already exists on vm %s", snapName, vmName)
+	}
+
+	xmlDesc, err := dom.GetXMLDesc(0)
+	if err != nil {
+		return nil, fmt.Errorf("xml: %w", err)
+	}
+	disks, err := domainDiskTargets(xmlDesc)
+	if err != nil {
+		return nil, err
+	}
+
+	snapXML, err := buildSnapshotXML(snapName, description, external, disks)
+	if err != nil {
+		return nil, err
+	}
+
+	var flags libvirt.DomainSnapshotCreateFlags
+	if external {
+		flags = libvirt.DOMAIN_SNAPSHOT_CREATE_DISK_ONLY | libvirt.DOMAIN_SNAPSHOT_CREATE_ATOMIC
+	}
+
+	// an internal snapshot of a running vm saves its memory too, a frozen guest would come back frozen
+	thaw := func() {}
+	if external {
+		thaw = freezeGuest(dom)
+	}
+	snap, err := dom.CreateSnapshotXML(snapXML, flags)
+	thaw()
+	if err != nil {
+		return nil, fmt.Errorf("create snapshot: %w", err)
+	}
+	defer snap.Free()
+
+	if external {
+		for _, disk := range disks {
+			if disk.Device == "disk" && disk.Source != "" {
+				if err := ensureDiskPermissions(externalOverlayPath(disk.Source, snapName)); err != nil {
+					return nil, err
+				}
+			}
+		}
+		// the overlays now are the active files of the owned disks, RemoveVM and DetachDisk must see them as ours
+		owned, err := getOwnedDisks(dom)
+		if err != nil {
+			return nil, err
+		}
+		var overlays []string
+		for _, disk := range disks {
+			if disk.Device == "disk" && disk.Source != "" && diskIsOwned(owned, disk.Source) {
+				overlays = append(overlays, externalOverlayPath(disk.Source, snapName))
+			}
+		}
+		if len(overlays) > 0 {
+			if err := setOwnedDisks(dom, append(owned, overlays...)); err != nil {
+				return nil, err
+			}
+		}
+		if err := refreshDomainXMLOnDisk(dom); err != nil {
+			return nil, fmt.Errorf("write domain xml: %w", err)
+		}
+	}
+
+	return snapshotToGRPC(vmName, snap)
+}
+
+func ListSnapshots(vmName string) ([]*grpcVirsh.Snapshot, error) {
+	conn, err := libvirt.NewConnect("qemu:///system")
+	if err != nil {
+		return nil, fmt.Errorf("connect: %w", err)
+	}
+	defer conn.Close()
+
+	dom, err := conn.LookupDomainByName(vmName)
+	if err != nil {
+		return nil, fmt.Errorf("lookup: %w", err)
+	}
+	defer dom.Free()
+
+	snaps, err := dom.ListAllSnapshots(0)
+	if err != nil {
+		return nil, fmt.Errorf("list snapshots: %w", err)
+	}
+
+	var res []*grpcVirsh.Snapshot
+	var firstErr error
+	for i := range snaps {
+		info, err := snapshotToGRPC(vmName, &snaps[i])
+		snaps[i].Free()
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		res = append(res, info)
+	}
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return res, nil
+}
+
+func RevertSnapshot(vmName, snapName string) error {
+	conn, err := libvirt.NewConnect("qemu:///system")
+	if err != nil {
+		return fmt.Errorf("connect: %w", err)
+	}
+	defer conn.Close()
+
+	dom, err := conn.LookupDomainByName(vmName)
+	if err != nil {
+		return fmt.Errorf("lookup: %w", err)
+	}
+	defer dom.Free()
+
+	snap, err := dom.SnapshotLookupByName(snapName, 0)
+	if err != nil {
+		return fmt.Errorf("lookup snapshot: %w", err)
+	}
+	defer snap.Free()
+
+	if err := snap.RevertToSnapshot(0); err != nil {
+		return fmt.Errorf("revert snapshot: %w", err)
+	}
+
+	if err := refreshDomainXMLOnDisk(dom); err != nil {
+		return fmt.Errorf("write domain xml: %w", err)
+	}
+	return nil
+}
+
+// metadataOnly drops libvirt's record but keeps the data in the disks, used before migrating
+func DeleteSnapshot(vmName, snapName string, metadataOnly bool) error {
+	conn, err := libvirt.NewConnect("qemu:///system")
+	if err != nil {
+		return fmt.Errorf("connect: %w", err)
+	}
+	defer conn.Close()
+
+	dom, err := conn.LookupDomainByName(vmName)
+	if err != nil {
+		return fmt.Errorf("lookup: %w", err)
+	}
+	defer dom.Free()
+
+	snap, err := dom.SnapshotLookupByName(snapName, 0)
+	if err != nil {
+		return fmt.Errorf("lookup snapshot: %w", err)
+	}
+	defer snap.Free()
+
+	var flags libvirt.DomainSnapshotDeleteFlags
+	if metadataOnly {
+		flags = libvirt.DOMAIN_SNAPSHOT_DELETE_METADATA_ONLY
+	}
+	if err := snap.Delete(flags); err != nil {
+		return fmt.Errorf("delete snapshot: %w", err)
+	}
+	if metadataOnly {
+		return nil
+	}
+
+	if err := refreshDomainXMLOnDisk(dom); err != nil {
+		return fmt.Errorf("write domain xml: %w", err)
+	}
+	return nil
+}
+
+// DefineSnapshot recreates snapshot metadata from xml, the disks/overlays must already be on the share
+func DefineSnapshot(vmName, snapXML string, current bool) error {
+	if strings.TrimSpace(snapXML) == "" {
+		return fmt.Errorf("snapshot xml is empty")
+	}
+
+	conn, err := libvirt.NewConnect("qemu:///system")
+	if err != nil {
+		return fmt.Errorf("connect: %w", err)
+	}
+	defer conn.Close()
+
+	dom, err := conn.LookupDomainByName(vmName)
+	if err != nil {
+		return fmt.Errorf("lookup: %w", err)
+	}
+	defer dom.Free()
+
+	flags := libvirt.DOMAIN_SNAPSHOT_CREATE_REDEFINE
+	if current {
+		flags |= libvirt.DOMAIN_SNAPSHOT_CREATE_CURRENT
+	}
+
+	snap, err := dom.CreateSnapshotXML(snapXML, flags)
+	if err != nil {
+		return fmt.Errorf("redefine snapshot: %w", err)
+	}
+	snap.Free()
+	return nil
+}
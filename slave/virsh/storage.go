@@ -0,0 +1,278 @@
+package virsh
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	grpcVirsh "github.com/Maruqes/512SvMan/api/proto/virsh"
+	"github.com/Maruqes/512SvMan/logger"
+	libvirt "libvirt.org/go/libvirt"
+)
+
+// storageMoveTarget is where a disk of the vm lands in the new folder, always a qcow2
+func storageMoveTarget(folder, src string) string {
+	base := filepath.Base(src)
+	return filepath.Join(folder, strings.TrimSuffix(base, filepath.Ext(base))+".qcow2")
+}
+
+// blockCopyDisk mirrors a disk of a running vm to dst and pivots to it once both are in sync,
+// the copy flattens the backing chain so dst does not depend on anything on the old share
+func blockCopyDisk(dom *libvirt.Domain, target, dst string) error {
+	destXML := fmt.Sprintf("<disk type='file'>\n  <driver type='qcow2'/>\n  <source file='%s'/>\n</disk>", dst)
+	if err := dom.BlockCopy(target, destXML, nil, 0); err != nil {
+		return fmt.Errorf("blockcopy %s: %w", target, err)
+	}
+
+	for {
+		info, err := dom.GetBlockJobInfo(target, 0)
+		if err != nil {
+			_ = dom.BlockJobAbort(target, 0)
+			return fmt.Errorf("blockcopy %s: %w", target, err)
+		}
+		if info.Type == libvirt.DOMAIN_BLOCK_JOB_TYPE_UNKNOWN {
+			return fmt.Errorf("blockcopy %s stopped before the copy was ready", target)
+		}
+		// cur reaches end when the mirror is in sync, writes keep going to both files after that
+		if info.End > 0 && info.Cur == info.End {
+			break
+		}
+		time.Sleep(time.Second)
+	}
+
+	if err := dom.BlockJobAbort(target, libvirt.DOMAIN_BLOCK_JOB_ABORT_PIVOT); err != nil {
+		_ = dom.BlockJobAbort(target, 0)
+		return fmt.Errorf("pivot %s: %w", target, err)
+	}
+	return ensureDiskPermissions(dst)
+}
+
+// MoveVmStorage moves the disks the vm owns (boot disk, disks made by AddDisk and the cloud-init seed)
+// to folder, usually the vm folder on another nfs share. a running vm is moved with blockcopy and
+// never stops, a shut off one gets a plain qemu-img copy. files attached from the share stay where they are
+func MoveVmStorage(vmName, folder string) (*grpcVirsh.MoveVmStorageResponse, error) {
+	vmName = strings.TrimSpace(vmName)
+	folder = strings.TrimSpace(folder)
+	if vmName == "" || folder == "" {
+		return nil, fmt.Errorf("vm name and disk folder are required")
+	}
+	folder = filepath.Clean(folder)
+
+	conn, err := libvirt.NewConnect("qemu:///system")
+	if err != nil {
+		return nil, fmt.Errorf("connect: %w", err)
+	}
+	defer conn.Close()
+
+	dom, err := conn.LookupDomainByName(vmName)
+	if err != nil {
+		return nil, fmt.Errorf("lookup: %w", err)
+	}
+	defer dom.Free()
+
+	// internal snapshots live inside the qcow2 and external ones in the chain, neither survives the copy
+	if snaps, err := dom.ListAllSnapshots(0); err != nil {
+		return nil, fmt.Errorf("list snapshots: %w", err)
+	} else if len(snaps) > 0 {
+		for i := range snaps {
+			snaps[i].Free()
+		}
+		return nil, fmt.Errorf("vm %s has %d snapshots, delete them before moving its storage", vmName, len(snaps))
+	}
+
+	state, _, err := dom.GetState()
+	if err != nil {
+		return nil, fmt.Errorf("state: %w", err)
+	}
+	running := state != libvirt.DOMAIN_SHUTOFF && state != libvirt.DOMAIN_CRASHED
+	if running && state != libvirt.DOMAIN_RUNNING && state != libvirt.DOMAIN_PAUSED {
+		return nil, fmt.Errorf("vm %s must be running or shut off to move its storage (state %s)", vmName, domainStateToString(state).String())
+	}
+
+	xmlDesc, err := dom.GetXMLDesc(libvirt.DOMAIN_XML_INACTIVE)
+	if err != nil {
+		return nil, fmt.Errorf("xml: %w", err)
+	}
+	disks, err := domainDiskTargets(xmlDesc)
+	if err != nil {
+		return nil, err
+	}
+	owned, err := getOwnedDisks(dom)
+	if err != nil {
+		return nil, err
+	}
+
+	var moving []domainDiskTarget
+	diskPaths := make(map[string]string)
+	oldPrimary := ""
+	for _, disk := range disks {
+		if disk.Device != "disk" || disk.Source == "" {
+			continue
+		}
+		primary := oldPrimary == ""
+		if primary {
+			oldPrimary = disk.Source
+		}
+		if !primary && !diskIsOwned(owned, disk.Source) {
+			continue
+		}
+		if filepath.Dir(disk.Source) == folder {
+			return nil, fmt.Errorf("disk %s of vm %s is already in %s", disk.Target, vmName, folder)
+		}
+		dst := storageMoveTarget(folder, disk.Source)
+		if _, err := os.Stat(dst); err == nil {
+			return nil, fmt.Errorf("disk %s already exists", dst)
+		}
+		moving = append(moving, disk)
+		diskPaths[disk.Source] = dst
+	}
+	if len(moving) == 0 {
+		return nil, fmt.Errorf("vm %s has no file backed disk to move", vmName)
+	}
+
+	// the dirty bitmaps stay in the old files, the next backup of the vm is a full one
+	if err := dropBackupCheckpoints(dom); err != nil {
+		logger.Warn("dropping backup checkpoints before storage move failed", "vm", vmName, "error", err)
+	}
+
+	if err := os.MkdirAll(folder, 0o777); err != nil {
+		return nil, fmt.Errorf("creating disk folder: %w", err)
+	}
+	if err := os.Chmod(folder, 0o777); err != nil {
+		return nil, fmt.Errorf("chmod disk folder: %w", err)
+	}
+
+	// offline a failure leaves the vm untouched, live every disk already pivoted is kept on the new share
+	moved := make(map[string]string)
+	var moveErr error
+	for _, disk := range moving {
+		dst := diskPaths[disk.Source]
+		logger.Info("moving vm disk", "vm", vmName, "disk", disk.Target, "from", disk.Source, "to", dst, "live", running)
+		if running {
+			moveErr = blockCopyDisk(dom, disk.Target, dst)
+		} else {
+			moveErr = copyDiskForClone(disk.Source, dst, false)
+		}
+		if moveErr != nil {
+			break
+		}
+		moved[disk.Source] = dst
+	}
+	if moveErr != nil && (!running || len(moved) == 0) {
+		for _, dst := range moved {
+			_ = os.Remove(dst)
+		}
+		return nil, moveErr
+	}
+
+	// once a running vm pivoted or a shut off one is redefined the vm uses the moved files,
+	// the caller gets them even when a later step fails
+	res := &grpcVirsh.MoveVmStorageResponse{}
+	_, res.PrimaryMoved = moved[oldPrimary]
+	for _, disk := range moving {
+		if dst, ok := moved[disk.Source]; ok {
+			res.Moved = append(res.Moved, &grpcVirsh.VmDisk{Target: disk.Target, Path: dst, Owned: true})
+		}
+	}
+
+	// the pivot only touches the running definition on older libvirt, the persistent one is fixed here
+	inactive, err := dom.GetXMLDesc(libvirt.DOMAIN_XML_INACTIVE)
+	if err != nil {
+		if !running {
+			for _, dst := range moved {
+				_ = os.Remove(dst)
+			}
+			return nil, fmt.Errorf("xml: %w", err)
+		}
+		return res, fmt.Errorf("xml: %w", err)
+	}
+	if newXML, replaced := repointDomainDisks(inactive, moved); replaced > 0 {
+		defined, err := conn.DomainDefineXML(newXML)
+		if err != nil {
+			if !running {
+				for _, dst := range moved {
+					_ = os.Remove(dst)
+				}
+				return nil, fmt.Errorf("define: %w", err)
+			}
+			return res, fmt.Errorf("define: %w", err)
+		}
+		defined.Free()
+	}
+
+	// an owned disk may be recorded by a file under its active one, the copy replaces the whole chain
+	newOwned := owned
+	for src, dst := range moved {
+		if src != oldPrimary && diskIsOwned(newOwned, src) {
+			newOwned = append(withoutDiskChain(newOwned, src), dst)
+		}
+	}
+	if err := setOwnedDisks(dom, newOwned); err != nil {
+		return res, err
+	}
+
+	var leftovers []string
+	for src := range moved {
+		leftovers = append(leftovers, src)
+	}
+	oldDir := filepath.Dir(oldPrimary)
+	if newPrimary, ok := moved[oldPrimary]; ok {
+		if err := moveCloudInitSeed(dom, xmlDesc, vmName, oldPrimary, newPrimary); err != nil {
+			return res, err
+		}
+		// the lease follows the boot disk, write it at once so the vm never looks unprotected
+		if running {
+			if epoch, protected, err := getHAEpoch(dom, libvirt.DOMAIN_AFFECT_LIVE); err == nil && protected {
+				if err := writeHALease(haLeasePath(vmName, newPrimary), vmName, epoch, time.Now().Add(haLeaseIOTimeout)); err != nil {
+					logger.Error("write ha lease after storage move failed", "vm", vmName, "error", err)
+				}
+			}
+		}
+		leftovers = append(leftovers,
+			filepath.Join(oldDir, vmName+".xml"),
+			cloudInitSeedPath(oldPrimary, vmName),
+			haLeasePath(vmName, oldPrimary),
+		)
+	}
+
+	if err := refreshDomainXMLOnDisk(dom); err != nil {
+		return res, fmt.Errorf("write domain xml: %w", err)
+	}
+
+	// nothing uses the old files anymore
+	for _, file := range leftovers {
+		if err := os.Remove(file); err != nil && !os.IsNotExist(err) {
+			logger.Error("remove old vm file failed", "file", file, "error", err)
+		}
+	}
+	// only goes away when empty, other files of the user stay
+	_ = os.Remove(oldDir)
+	return res, moveErr
+}
+
+// moveCloudInitSeed copies the seed iso next to the new boot disk and swaps the cdrom to it
+func moveCloudInitSeed(dom *libvirt.Domain, xmlDesc, vmName, oldPrimary, newPrimary string) error {
+	oldSeed := cloudInitSeedPath(oldPrimary, vmName)
+	newSeed := cloudInitSeedPath(newPrimary, vmName)
+	for _, block := range cloneDiskPattern.FindAllString(xmlDesc, -1) {
+		if !strings.Contains(block, "'"+oldSeed+"'") && !strings.Contains(block, `"`+oldSeed+`"`) {
+			continue
+		}
+		data, err := os.ReadFile(oldSeed)
+		if err != nil {
+			return fmt.Errorf("read cloud-init seed: %w", err)
+		}
+		if err := os.WriteFile(newSeed, data, 0o644); err != nil {
+			return fmt.Errorf("write cloud-init seed: %w", err)
+		}
+		block = strings.ReplaceAll(block, "'"+oldSeed+"'", "'"+newSeed+"'")
+		block = strings.ReplaceAll(block, `"`+oldSeed+`"`, "'"+newSeed+"'")
+		if err := dom.UpdateDeviceFlags(block, deviceModifyFlags(dom)); err != nil {
+			return fmt.Errorf("repoint cloud-init seed: %w", err)
+		}
+		return nil
+	}
+	return nil
+}
@@ -0,0 +1,222 @@
+package virsh
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	grpcVirsh "github.com/Maruqes/512SvMan/api/proto/virsh"
+	"github.com/Maruqes/512SvMan/logger"
+	libvirt "libvirt.org/go/libvirt"
+)
+
+const trashFolderName = ".trash"
+
+// trashPath keeps the file on its own share, vm files live in share/vmname/file so the trash of a
+// file is share/.trash/trashName/file and the move is a rename on the same mount
+func trashPath(file, trashName string) string {
+	share := filepath.Dir(filepath.Dir(file))
+	return filepath.Join(share, trashFolderName, trashName, filepath.Base(file))
+}
+
+// sameFolderChain is the disk with the overlays/backing files next to it, what RemoveVM would delete
+func sameFolderChain(path string) []string {
+	dir := filepath.Dir(path)
+	chain := []string{path}
+	if files, err := diskBackingChain(path); err == nil && len(files) > 0 {
+		chain = files
+	}
+	var res []string
+	for _, file := range chain {
+		if filepath.Dir(file) == dir {
+			res = append(res, file)
+		}
+	}
+	return res
+}
+
+func containsTrashFile(files []*grpcVirsh.TrashFile, original string) bool {
+	for _, file := range files {
+		if file.Original == original {
+			return true
+		}
+	}
+	return false
+}
+
+func moveTrashFiles(files []*grpcVirsh.TrashFile, back bool) error {
+	for i, file := range files {
+		src, dst := file.Original, file.Trashed
+		if back {
+			src, dst = dst, src
+		}
+		err := os.MkdirAll(filepath.Dir(dst), 0o777)
+		if err == nil {
+			if _, statErr := os.Stat(dst); statErr == nil {
+				err = fmt.Errorf("%s already exists", dst)
+			} else {
+				err = os.Rename(src, dst)
+			}
+		}
+		if err != nil {
+			// put back what already moved so nothing is left half way
+			for j := i - 1; j >= 0; j-- {
+				undoSrc, undoDst := files[j].Trashed, files[j].Original
+				if back {
+					undoSrc, undoDst = undoDst, undoSrc
+				}
+				if undoErr := os.Rename(undoSrc, undoDst); undoErr != nil {
+					logger.Error("undo trash move failed", "file", undoSrc, "error", undoErr)
+				}
+			}
+			return fmt.Errorf("move %s: %w", src, err)
+		}
+	}
+	return nil
+}
+
+// TrashVM works like RemoveVM but moves the disks, seed and xml to share/.trash/trashName instead of
+// deleting them, the returned definition and file list are what RestoreTrashedVM needs
+func TrashVM(name, trashName string) (*grpcVirsh.TrashedVm, error) {
+	name = strings.TrimSpace(name)
+	trashName = strings.TrimSpace(trashName)
+	if name == "" {
+		return nil, fmt.Errorf("vm name is empty")
+	}
+	if !snapshotNamePattern.MatchString(trashName) {
+		return nil, fmt.Errorf("invalid trash name %q", trashName)
+	}
+
+	conn, err := libvirt.NewConnect("qemu:///system")
+	if err != nil {
+		return nil, fmt.Errorf("connect: %w", err)
+	}
+	defer conn.Close()
+
+	dom, err := conn.LookupDomainByName(name)
+	if err != nil {
+		return nil, fmt.Errorf("lookup: %w", err)
+	}
+	defer dom.Free()
+
+	xmlDesc, err := dom.GetXMLDesc(libvirt.DOMAIN_XML_INACTIVE)
+	if err != nil {
+		return nil, fmt.Errorf("xml: %w", err)
+	}
+	diskPath, err := diskPathFromDomainXML(xmlDesc)
+	if err != nil {
+		return nil, fmt.Errorf("detect disk path: %w", err)
+	}
+	ownedDisks, err := getOwnedDisks(dom)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &grpcVirsh.TrashedVm{Name: name, Xml: xmlDesc}
+	add := func(file string) {
+		if _, err := os.Stat(file); err == nil {
+			res.Files = append(res.Files, &grpcVirsh.TrashFile{Original: file, Trashed: trashPath(file, trashName)})
+		}
+	}
+	if diskPath != "" {
+		for _, file := range sameFolderChain(diskPath) {
+			add(file)
+		}
+		add(cloudInitSeedPath(diskPath, name))
+		add(filepath.Join(filepath.Dir(diskPath), name+".xml"))
+	}
+	disks, err := domainDiskTargets(xmlDesc)
+	if err != nil {
+		return nil, err
+	}
+	// the active file of a snapshotted disk is an overlay above the recorded one
+	for _, path := range append(ownedDiskSources(disks, ownedDisks), ownedDisks...) {
+		for _, file := range sameFolderChain(path) {
+			if !containsTrashFile(res.Files, file) {
+				add(file)
+			}
+		}
+	}
+
+	state, _, err := dom.GetState()
+	if err != nil {
+		return nil, fmt.Errorf("state: %w", err)
+	}
+	if state == libvirt.DOMAIN_RUNNING || state == libvirt.DOMAIN_PAUSED || state == libvirt.DOMAIN_BLOCKED {
+		if err := dom.Destroy(); err != nil {
+			return nil, fmt.Errorf("force shutdown: %w", err)
+		}
+	}
+
+	// the files move while the vm is still defined, a failed move leaves it as it was (shut off)
+	if err := moveTrashFiles(res.Files, false); err != nil {
+		return nil, err
+	}
+	if err := dom.UndefineFlags(libvirt.DOMAIN_UNDEFINE_MANAGED_SAVE | libvirt.DOMAIN_UNDEFINE_SNAPSHOTS_METADATA | libvirt.DOMAIN_UNDEFINE_CHECKPOINTS_METADATA | libvirt.DOMAIN_UNDEFINE_NVRAM); err != nil {
+		if undoErr := moveTrashFiles(res.Files, true); undoErr != nil {
+			logger.Error("restore trashed files failed", "vm", name, "error", undoErr)
+		}
+		return nil, fmt.Errorf("undefine: %w", err)
+	}
+
+	if diskPath != "" {
+		leasePath := haLeasePath(name, diskPath)
+		if err := os.Remove(leasePath); err != nil && !os.IsNotExist(err) {
+			logger.Error("remove lease failed", "file", leasePath, "error", err)
+		}
+		// the vm folder is empty now unless the user kept other files there
+		_ = os.Remove(filepath.Dir(diskPath))
+	}
+	return res, nil
+}
+
+// RestoreTrashedVM moves the files back where they were and defines the vm again, shut off
+func RestoreTrashedVM(vm *grpcVirsh.TrashedVm) error {
+	if strings.TrimSpace(vm.Name) == "" || strings.TrimSpace(vm.Xml) == "" {
+		return fmt.Errorf("vm name and xml are required")
+	}
+
+	conn, err := libvirt.NewConnect("qemu:///system")
+	if err != nil {
+		return fmt.Errorf("connect: %w", err)
+	}
+	defer conn.Close()
+
+	if existing, err := conn.LookupDomainByName(vm.Name); err == nil {
+		existing.Free()
+		return fmt.Errorf("vm %s already exists", vm.Name)
+	}
+
+	if err := moveTrashFiles(vm.Files, true); err != nil {
+		return err
+	}
+	dom, err := conn.DomainDefineXML(vm.Xml)
+	if err != nil {
+		if undoErr := moveTrashFiles(vm.Files, false); undoErr != nil {
+			logger.Error("move restored files back to trash failed", "vm", vm.Name, "error", undoErr)
+		}
+		return fmt.Errorf("define: %w", err)
+	}
+	defer dom.Free()
+
+	removeEmptyTrashFolders(vm.Files)
+	return nil
+}
+
+// PurgeTrashedVM deletes the trashed files for good
+func PurgeTrashedVM(vm *grpcVirsh.TrashedVm) error {
+	for _, file := range vm.Files {
+		if err := os.Remove(file.Trashed); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("remove %s: %w", file.Trashed, err)
+		}
+	}
+	removeEmptyTrashFolders(vm.Files)
+	return nil
+}
+
+func removeEmptyTrashFolders(files []*grpcVirsh.TrashFile) {
+	for _, file := range files {
+		_ = os.Remove(filepath.Dir(file.Trashed))
+	}
+}